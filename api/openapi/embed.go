@@ -0,0 +1,8 @@
+package openapi
+
+import _ "embed"
+
+// UserAPISpec is the user API OpenAPI document shipped with the binary
+//
+//go:embed user-api.yaml
+var UserAPISpec []byte
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/gieart87/gohexaclean/internal/adapter/inbound/gateway"
+	"github.com/gieart87/gohexaclean/internal/infra/config"
+	"github.com/gieart87/gohexaclean/internal/infra/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func main() {
+	// Load configuration, the gateway only needs the gRPC address so no container is built
+	cfg, err := config.Load(getConfigPath())
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	appLogger, err := logger.NewLogger(&cfg.Logger)
+	if err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer appLogger.Close()
+
+	// Dial the gRPC server, connections are established lazily on the first call
+	grpcAddr := cfg.Server.Gateway.GRPCAddr
+	if grpcAddr == "" {
+		grpcAddr = fmt.Sprintf("localhost:%d", cfg.Server.GRPC.Port)
+	}
+	conn, err := grpc.NewClient(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		appLogger.Fatal(fmt.Sprintf("Failed to create gRPC client: %v", err))
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	handler, err := gateway.NewHandler(ctx, conn)
+	if err != nil {
+		appLogger.Fatal(fmt.Sprintf("Failed to create gateway: %v", err))
+	}
+
+	srv := &http.Server{
+		Addr:         fmt.Sprintf(":%d", cfg.Server.Gateway.Port),
+		Handler:      handler,
+		ReadTimeout:  cfg.Server.HTTP.ReadTimeout,
+		WriteTimeout: cfg.Server.HTTP.WriteTimeout,
+		IdleTimeout:  cfg.Server.HTTP.IdleTimeout,
+	}
+
+	appLogger.Info(fmt.Sprintf("gRPC gateway starting on port %d, proxying to %s", cfg.Server.Gateway.Port, grpcAddr))
+
+	// Graceful shutdown
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			appLogger.Fatal(fmt.Sprintf("Failed to start gateway: %v", err))
+		}
+	}()
+
+	// Wait for interrupt signal
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	shutdownTimeout := cfg.Server.HTTP.GetShutdownTimeout()
+	appLogger.Info(fmt.Sprintf("Shutting down gateway (timeout %s)...", shutdownTimeout))
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer shutdownCancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		appLogger.Error(fmt.Sprintf("Gateway forced to shutdown: %v", err))
+	}
+
+	appLogger.Info("gRPC gateway exited")
+}
+
+// getConfigPath returns the configuration file path
+func getConfigPath() string {
+	if path := os.Getenv("CONFIG_PATH"); path != "" {
+		return path
+	}
+	return "config/app.yaml"
+}
@@ -1,19 +1,30 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	grpchealth "github.com/gieart87/gohexaclean/internal/adapter/inbound/grpc/health"
+	"github.com/gieart87/gohexaclean/internal/adapter/inbound/grpc/interceptor"
+	grpcserver "github.com/gieart87/gohexaclean/internal/adapter/inbound/grpc/server"
 	"github.com/gieart87/gohexaclean/internal/bootstrap"
 	pb "github.com/gieart87/gohexaclean/api/proto/user"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionpbalpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
 )
 
+// healthCheckInterval is how often dependencies are probed to update the gRPC health status
+const healthCheckInterval = 10 * time.Second
+
 func main() {
 	// Load configuration
 	configPath := getConfigPath()
@@ -25,15 +36,45 @@ func main() {
 	}
 	defer container.Close()
 
+	// Login, registration and health probes are public, every other call needs a valid bearer token
+	userService := "/" + pb.UserService_ServiceDesc.ServiceName + "/"
+	publicMethods := []string{
+		userService + "Login",
+		userService + "CreateUser",
+		healthpb.Health_Check_FullMethodName,
+		healthpb.Health_Watch_FullMethodName,
+		reflectionpb.ServerReflection_ServerReflectionInfo_FullMethodName,
+		reflectionpbalpha.ServerReflection_ServerReflectionInfo_FullMethodName,
+	}
+	authInterceptor := interceptor.AuthUnaryInterceptor(container.Config.JWT.TokenOptions(), container.UserService, publicMethods...)
+	authStreamInterceptor := interceptor.AuthStreamInterceptor(container.Config.JWT.TokenOptions(), container.UserService, publicMethods...)
+
+	// Listing, streaming and deleting users is for admins, users may update their own account
+	rolePolicy := interceptor.RolePolicy{
+		userService + "ListUsers":   interceptor.AdminOnly,
+		userService + "StreamUsers": interceptor.AdminOnly,
+		userService + "DeleteUser":  interceptor.AdminOnly,
+		userService + "UpdateUser":  interceptor.OwnerOrAdmin,
+	}
+	roleInterceptor := interceptor.RoleUnaryInterceptor(rolePolicy)
+	roleStreamInterceptor := interceptor.RoleStreamInterceptor(rolePolicy)
+
 	// Create gRPC server
-	grpcServer := grpc.NewServer(
-		grpc.MaxRecvMsgSize(1024 * 1024 * 10), // 10MB
-		grpc.MaxSendMsgSize(1024 * 1024 * 10), // 10MB
+	// Message limits and keepalive come from config, stale connections are recycled for rebalancing
+	serverOptions := append(grpcserver.Options(&container.Config.Server.GRPC),
+		// Errors are mapped outermost so authentication failures and handler errors share one path
+		grpc.ChainUnaryInterceptor(interceptor.ErrorUnaryInterceptor(), authInterceptor, roleInterceptor),
+		grpc.ChainStreamInterceptor(interceptor.ErrorStreamInterceptor(), authStreamInterceptor, roleStreamInterceptor),
 	)
+	grpcServer := grpc.NewServer(serverOptions...)
 
 	// Register services
 	pb.RegisterUserServiceServer(grpcServer, container.UserGRPCHandler)
 
+	// Register standard grpc.health.v1 service for orchestrator health checks
+	healthServer := grpchealth.NewServer(pb.UserService_ServiceDesc.ServiceName)
+	healthServer.Register(grpcServer)
+
 	// Register reflection service for gRPC tools (e.g., grpcurl)
 	reflection.Register(grpcServer)
 
@@ -53,12 +94,18 @@ func main() {
 		}
 	}()
 
+	// Listener is bound, report SERVING while the readiness dependency checks pass
+	healthCtx, stopHealthWatch := context.WithCancel(context.Background())
+	go healthServer.Watch(healthCtx, healthCheckInterval, container.CheckReadiness)
+
 	// Wait for interrupt signal
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
 	container.Logger.Info("Shutting down gRPC server...")
+	stopHealthWatch()
+	healthServer.Shutdown()
 	grpcServer.GracefulStop()
 	container.Logger.Info("gRPC Server exited")
 }
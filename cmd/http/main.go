@@ -7,10 +7,15 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/gieart87/gohexaclean/api/openapi"
 	"github.com/gieart87/gohexaclean/internal/adapter/inbound/http/middleware"
 	"github.com/gieart87/gohexaclean/internal/adapter/inbound/http/router"
+	"github.com/gieart87/gohexaclean/internal/adapter/inbound/http/server"
 	"github.com/gieart87/gohexaclean/internal/bootstrap"
+	"github.com/gieart87/gohexaclean/internal/infra/metrics"
+	"github.com/gieart87/gohexaclean/pkg/jsoncodec"
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 )
 
@@ -23,35 +28,61 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to initialize container: %v", err)
 	}
-	defer container.Close()
+
+	// Response encoding and BodyParser share the configured codec, encoding/json by default
+	codec := jsoncodec.Codec(container.Config.Server.HTTP.JSONCodec)
 
 	// Create Fiber app
 	app := fiber.New(fiber.Config{
 		AppName:      container.Config.App.Name,
 		ServerHeader: "GoHexaClean",
-		ErrorHandler: customErrorHandler,
+		ErrorHandler: middleware.ErrorHandler,
+		// Server-wide cap, the router enforces the tighter per-route limits with a clean 413
+		BodyLimit:   container.Config.Server.HTTP.BodyLimit(),
+		JSONEncoder: codec.Marshal(),
+		JSONDecoder: codec.Unmarshal(),
 	})
 
 	// Global middleware
 	app.Use(recover.New())
 	app.Use(middleware.RecoveryMiddleware(container.Logger))
+	app.Use(middleware.RequestIDMiddleware())
 	app.Use(middleware.LoggerMiddleware(container.Logger))
-	app.Use(middleware.CORSMiddleware(&container.Config.CORS))
+
+	corsMiddleware, err := middleware.CORSMiddleware(&container.Config.CORS)
+	if err != nil {
+		log.Fatalf("Invalid CORS configuration: %v", err)
+	}
+	app.Use(corsMiddleware)
 
 	// Telemetry middleware (metrics and tracing)
-	if container.MetricsService != nil || container.TracingService != nil {
-		app.Use(middleware.TelemetryMiddleware(container.MetricsService, container.TracingService))
-		container.Logger.Info("Telemetry middleware enabled")
+	app.Use(middleware.TelemetryMiddleware(container.MetricsService, container.TracingService))
+
+	// Expose scraped metrics when the Prometheus exporter is enabled
+	if metricsHandler := metrics.Handler(container.MetricsService); metricsHandler != nil {
+		app.Get("/metrics", adaptor.HTTPHandler(metricsHandler))
+		container.Logger.Info("Prometheus metrics exposed on /metrics")
+	}
+
+	// Validate API requests against the embedded OpenAPI spec
+	requestValidator, err := middleware.OpenAPIValidationMiddleware(openapi.UserAPISpec, "/api/v1")
+	if err != nil {
+		log.Fatalf("Invalid OpenAPI spec: %v", err)
 	}
 
 	// Setup routes
 	router.SetupRoutes(
 		app,
 		container.UserService,
-		container.Config.JWT.Secret,
+		container.PasswordPolicy,
+		container.PageBounds,
+		container.CacheService,
+		&container.Config.Server.HTTP,
+		container.Config.JWT.TokenOptions(),
 		container.Logger,
 		container.MetricsService,
 		container.TracingService,
+		requestValidator,
 	)
 
 	// Start server
@@ -70,13 +101,23 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	container.Logger.Info("Shutting down server...")
+	shutdownTimeout := container.Config.Server.HTTP.GetShutdownTimeout()
+	container.Logger.Info(fmt.Sprintf("Shutting down server (timeout %s)...", shutdownTimeout))
 
-	if err := app.Shutdown(); err != nil {
+	timedOut, err := server.Shutdown(app, shutdownTimeout)
+	if err != nil {
 		container.Logger.Error(fmt.Sprintf("Server forced to shutdown: %v", err))
 	}
+	if timedOut {
+		container.Logger.Warn(fmt.Sprintf("Shutdown timeout of %s elapsed with requests still in flight", shutdownTimeout))
+	}
+
+	// Close dependencies only once the server has stopped serving requests
+	if err := container.Close(); err != nil {
+		log.Printf("Failed to close container: %v", err)
+	}
 
-	container.Logger.Info("Server exited")
+	log.Println("Server exited")
 }
 
 // getConfigPath returns the configuration file path
@@ -86,17 +127,3 @@ func getConfigPath() string {
 	}
 	return "config/app.yaml"
 }
-
-// customErrorHandler handles errors
-func customErrorHandler(c *fiber.Ctx, err error) error {
-	code := fiber.StatusInternalServerError
-	if e, ok := err.(*fiber.Error); ok {
-		code = e.Code
-	}
-
-	return c.Status(code).JSON(fiber.Map{
-		"success": false,
-		"message": "An error occurred",
-		"error":   err.Error(),
-	})
-}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/gieart87/gohexaclean/internal/infra/config"
+	"github.com/gieart87/gohexaclean/internal/infra/db"
+	"github.com/gieart87/gohexaclean/internal/infra/db/migrate"
+	"github.com/gieart87/gohexaclean/internal/infra/logger"
+)
+
+const usage = "usage: migrate [up|down|reset|status|version|create <name>]"
+
+// migrationsDir is where create writes new migrations, relative to the repository root
+const migrationsDir = "internal/infra/db/migrations"
+
+func main() {
+	command := "up"
+	if len(os.Args) > 1 {
+		command = os.Args[1]
+	}
+
+	// Scaffolding a migration only touches the source tree, no database is needed
+	if command == "create" {
+		if len(os.Args) < 3 {
+			log.Fatal(usage)
+		}
+		path, err := migrate.Create(migrationsDir, os.Args[2])
+		if err != nil {
+			log.Fatalf("Failed to create migration: %v", err)
+		}
+		log.Printf("Created %s", path)
+		return
+	}
+
+	// Get config path from environment or use default
+	configPath := os.Getenv("CONFIG_PATH")
+	if configPath == "" {
+		configPath = "config/app.yaml"
+	}
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	database, err := db.ConnectWithRetry(context.Background(), &cfg.Database, logger.GetLogger().Logger, cfg.App.Env == "production")
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close(database)
+
+	migrator, err := migrate.NewFromEmbedded(database)
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
+	}
+
+	ctx := context.Background()
+
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			log.Fatalf("Migration failed after %d applied: %v", applied, err)
+		}
+		log.Printf("Applied %d migration(s)", applied)
+	case "down":
+		name, err := migrator.Down(ctx)
+		if err != nil {
+			log.Fatalf("Rollback failed: %v", err)
+		}
+		if name == "" {
+			log.Println("No migrations to roll back")
+			return
+		}
+		log.Printf("Rolled back %s", name)
+	case "reset":
+		for {
+			name, err := migrator.Down(ctx)
+			if err != nil {
+				log.Fatalf("Rollback failed: %v", err)
+			}
+			if name == "" {
+				break
+			}
+			log.Printf("Rolled back %s", name)
+		}
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			log.Fatalf("Migration failed after %d applied: %v", applied, err)
+		}
+		log.Printf("Applied %d migration(s)", applied)
+	case "status":
+		applied, err := migrator.Applied(ctx)
+		if err != nil {
+			log.Fatalf("Failed to read migration status: %v", err)
+		}
+		for _, m := range migrator.Migrations() {
+			state := "pending"
+			if applied[m.Version] {
+				state = "applied"
+			}
+			fmt.Printf("%-8s %s\n", state, m.Name)
+		}
+	case "version":
+		version, err := migrator.Version(ctx)
+		if err != nil {
+			log.Fatalf("Failed to read migration version: %v", err)
+		}
+		fmt.Println(version)
+	default:
+		log.Fatal(usage)
+	}
+}
@@ -1,13 +1,19 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 	"os/signal"
 	"syscall"
 
+	"github.com/gieart87/gohexaclean/internal/adapter/outbound/notifier"
 	"github.com/gieart87/gohexaclean/internal/infra/asynq"
 	"github.com/gieart87/gohexaclean/internal/infra/asynq/tasks"
+	"github.com/gieart87/gohexaclean/internal/infra/config"
+	"github.com/gieart87/gohexaclean/internal/infra/logger"
+	"github.com/gieart87/gohexaclean/internal/infra/metrics"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/telemetry"
 	asynqlib "github.com/hibiken/asynq"
 )
 
@@ -24,11 +30,20 @@ func main() {
 	// Create Asynq server
 	srv := asynq.NewServer(redisAddr, concurrency)
 
+	// Initialize metrics without the full container, the worker doesn't need DB or broker
+	metricsService := newMetricsService()
+	if metricsService != nil {
+		defer metricsService.Close()
+	}
+
 	// Create task mux (router)
 	mux := asynqlib.NewServeMux()
+	mux.Use(asynq.MetricsMiddleware(metricsService))
 
 	// Register task handlers
 	mux.HandleFunc(tasks.TypeEmailWelcome, tasks.HandleEmailWelcomeTask)
+	mux.HandleFunc(tasks.TypeLoginAlert, tasks.NewLoginAlertHandler(notifier.NewLogNotifier()))
+	mux.HandleFunc(tasks.TypeEmailVerification, tasks.NewEmailVerificationHandler(notifier.NewLogNotifier()))
 
 	// Setup graceful shutdown
 	go func() {
@@ -48,3 +63,24 @@ func main() {
 	srv.Shutdown()
 	log.Println("Worker stopped")
 }
+
+// newMetricsService initializes metrics from the application config, returning nil if unavailable
+func newMetricsService() telemetry.MetricsService {
+	configPath := os.Getenv("CONFIG_PATH")
+	if configPath == "" {
+		configPath = "config/app.yaml"
+	}
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		log.Printf("Failed to load config, task metrics disabled: %v", err)
+		return nil
+	}
+
+	appLogger, err := logger.NewLogger(&cfg.Logger)
+	if err != nil {
+		appLogger = logger.NewDefaultLogger()
+	}
+
+	return metrics.NewMetricsService(context.Background(), cfg, appLogger)
+}
@@ -0,0 +1,70 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/gieart87/gohexaclean/internal/port/outbound/broker"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/service"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/telemetry"
+)
+
+const (
+	defaultDedupTTL = 24 * time.Hour
+	// processedEventKeyPrefix marks events whose handler already succeeded
+	processedEventKeyPrefix = "event-processed:"
+)
+
+// Deduplicator skips events that are delivered again after their handler succeeded,
+// such as messages requeued after a lost ack or replayed from a dead-letter queue
+// Events are remembered by ID in the cache, so while it is unavailable every delivery is handled
+// Skipped events are counted as events.duplicates.skipped
+type Deduplicator struct {
+	cache   service.CacheService
+	ttl     time.Duration
+	metrics telemetry.MetricsService
+}
+
+// NewDeduplicator remembers handled events for ttl (24h when 0), it returns nil without a cache
+// metrics is optional and may be nil
+func NewDeduplicator(cache service.CacheService, ttl time.Duration, metrics telemetry.MetricsService) *Deduplicator {
+	if cache == nil {
+		return nil
+	}
+	if ttl <= 0 {
+		ttl = defaultDedupTTL
+	}
+
+	return &Deduplicator{cache: cache, ttl: ttl, metrics: metrics}
+}
+
+// wrap runs handler once per event ID of topic, messages without an ID are always handled
+func (d *Deduplicator) wrap(topic string, handler broker.MessageHandler) broker.MessageHandler {
+	return func(ctx context.Context, message []byte) error {
+		var event struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(message, &event); err != nil || event.ID == "" {
+			return handler(ctx, message)
+		}
+
+		key := processedEventKeyPrefix + topic + ":" + event.ID
+		if seen, err := d.cache.Exists(ctx, key); err == nil && seen {
+			log.Printf("[EVENT] Skipping duplicate %s event %s", topic, event.ID)
+			if d.metrics != nil {
+				d.metrics.IncrementCounter("events.duplicates.skipped", map[string]string{"topic": topic}, 1)
+			}
+			return nil
+		}
+
+		if err := handler(ctx, message); err != nil {
+			return err
+		}
+
+		// Failing to remember the event only lets a redelivery run the handler again
+		_ = d.cache.Set(ctx, key, 1, d.ttl)
+		return nil
+	}
+}
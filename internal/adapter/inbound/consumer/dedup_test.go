@@ -0,0 +1,80 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	cacheredis "github.com/gieart87/gohexaclean/internal/adapter/outbound/redis"
+	"github.com/gieart87/gohexaclean/internal/domain"
+	telemetrymock "github.com/gieart87/gohexaclean/internal/port/outbound/telemetry/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDeduplicator(t *testing.T, metrics *telemetrymock.MockMetricsService) *Deduplicator {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return NewDeduplicator(cacheredis.NewCacheServiceRedis(client), 0, metrics)
+}
+
+func TestDeduplicator_SkipsDuplicateEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	metrics := telemetrymock.NewMockMetricsService(ctrl)
+	metrics.EXPECT().IncrementCounter("events.duplicates.skipped", map[string]string{"topic": "user.created"}, float64(1)).Times(1)
+
+	calls := 0
+	handle := newTestDeduplicator(t, metrics).wrap("user.created", func(ctx context.Context, message []byte) error {
+		calls++
+		return nil
+	})
+
+	message, err := json.Marshal(domain.NewUserCreatedEvent(uuid.New(), "jane@example.com", "Jane"))
+	require.NoError(t, err)
+
+	require.NoError(t, handle(context.Background(), message))
+	require.NoError(t, handle(context.Background(), message))
+	assert.Equal(t, 1, calls, "the redelivered event should be skipped")
+
+	// Another event on the same topic is still handled
+	other, err := json.Marshal(domain.NewUserCreatedEvent(uuid.New(), "john@example.com", "John"))
+	require.NoError(t, err)
+	require.NoError(t, handle(context.Background(), other))
+	assert.Equal(t, 2, calls)
+}
+
+func TestDeduplicator_HandlesRedeliveryOfFailedEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Nothing is skipped, so the counter must not move
+	metrics := telemetrymock.NewMockMetricsService(ctrl)
+
+	handlerErr := errors.New("task queue unavailable")
+	calls := 0
+	handle := newTestDeduplicator(t, metrics).wrap("user.created", func(ctx context.Context, message []byte) error {
+		calls++
+		if calls == 1 {
+			return handlerErr
+		}
+		return nil
+	})
+
+	message, err := json.Marshal(domain.NewUserCreatedEvent(uuid.New(), "jane@example.com", "Jane"))
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, handle(context.Background(), message), handlerErr)
+	assert.NoError(t, handle(context.Background(), message))
+	assert.Equal(t, 2, calls, "a failed event is not remembered")
+}
@@ -0,0 +1,54 @@
+package consumer
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/gieart87/gohexaclean/internal/port/outbound/broker"
+)
+
+// RetryPolicy bounds how often a failing handler is retried before the message is given up on
+type RetryPolicy struct {
+	MaxRetries int           // 0 runs the handler once
+	BaseDelay  time.Duration // delay before the first retry, doubled after every failed attempt
+	// DeadLetter returns the last error once retries are exhausted so the broker can route the
+	// message to its dead-letter queue; otherwise the message is acked to avoid a poison-message loop
+	DeadLetter bool
+}
+
+// withRetry wraps handler so transient failures are retried with exponential backoff
+// before the broker decides whether to ack or nack the message
+func (p RetryPolicy) withRetry(topic string, handler broker.MessageHandler) broker.MessageHandler {
+	return func(ctx context.Context, message []byte) error {
+		delay := p.BaseDelay
+
+		var err error
+		for attempt := 0; ; attempt++ {
+			if err = handler(ctx, message); err == nil {
+				return nil
+			}
+			if attempt >= p.MaxRetries {
+				break
+			}
+
+			log.Printf("[WARN] handler for %s failed (attempt %d/%d), retrying in %s: %v",
+				topic, attempt+1, p.MaxRetries+1, delay, err)
+
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				// Shutting down, let the broker requeue or dead-letter the message
+				return ctx.Err()
+			}
+			delay *= 2
+		}
+
+		log.Printf("[ERROR] handler for %s failed after %d attempts, giving up: %v", topic, p.MaxRetries+1, err)
+
+		if p.DeadLetter {
+			return err
+		}
+		return nil
+	}
+}
@@ -0,0 +1,79 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// flakyHandler fails the first failures calls, then succeeds
+type flakyHandler struct {
+	failures int
+	calls    int
+}
+
+func (h *flakyHandler) handle(ctx context.Context, message []byte) error {
+	h.calls++
+	if h.calls <= h.failures {
+		return errors.New("temporarily unavailable")
+	}
+	return nil
+}
+
+func TestRetryPolicy_SucceedsAfterTransientFailures(t *testing.T) {
+	handler := &flakyHandler{failures: 2}
+	policy := RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond}
+
+	err := policy.withRetry("user.created", handler.handle)(context.Background(), []byte("{}"))
+
+	// A nil error is what makes the broker ack the message
+	assert.NoError(t, err)
+	assert.Equal(t, 3, handler.calls)
+}
+
+func TestRetryPolicy_BacksOffExponentially(t *testing.T) {
+	handler := &flakyHandler{failures: 3}
+	policy := RetryPolicy{MaxRetries: 3, BaseDelay: 10 * time.Millisecond}
+
+	start := time.Now()
+	err := policy.withRetry("user.created", handler.handle)(context.Background(), []byte("{}"))
+
+	assert.NoError(t, err)
+	// 10ms + 20ms + 40ms between the four attempts
+	assert.GreaterOrEqual(t, time.Since(start), 70*time.Millisecond)
+}
+
+func TestRetryPolicy_ExhaustedRetriesAreAcked(t *testing.T) {
+	handler := &flakyHandler{failures: 10}
+	policy := RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond}
+
+	err := policy.withRetry("user.created", handler.handle)(context.Background(), []byte("{}"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, handler.calls)
+}
+
+func TestRetryPolicy_ExhaustedRetriesAreDeadLettered(t *testing.T) {
+	handler := &flakyHandler{failures: 10}
+	policy := RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond, DeadLetter: true}
+
+	err := policy.withRetry("user.created", handler.handle)(context.Background(), []byte("{}"))
+
+	assert.Error(t, err)
+	assert.Equal(t, 3, handler.calls)
+}
+
+func TestRetryPolicy_StopsWhenContextIsCancelled(t *testing.T) {
+	handler := &flakyHandler{failures: 10}
+	policy := RetryPolicy{MaxRetries: 5, BaseDelay: time.Hour}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := policy.withRetry("user.created", handler.handle)(ctx, []byte("{}"))
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, handler.calls)
+}
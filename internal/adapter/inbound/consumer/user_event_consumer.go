@@ -8,17 +8,32 @@ import (
 
 	"github.com/gieart87/gohexaclean/internal/domain"
 	"github.com/gieart87/gohexaclean/internal/port/outbound/broker"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/service"
+	"github.com/google/uuid"
 )
 
 // UserEventConsumer consumes user domain events
 type UserEventConsumer struct {
-	broker broker.MessageBroker
+	broker    broker.MessageBroker
+	taskQueue service.TaskQueue
+	retry     RetryPolicy
+	webhooks  *WebhookSender
+	dedup     *Deduplicator
 }
 
 // NewUserEventConsumer creates a new user event consumer
-func NewUserEventConsumer(broker broker.MessageBroker) *UserEventConsumer {
+// taskQueue receives the welcome email of every created user and the alert of every login,
+// it may be nil when background jobs are disabled
+// Handlers are retried according to retry before the message is acked or dead-lettered
+// webhooks, when not nil, receives every event once its handler succeeded and is closed by Stop
+// dedup, when not nil, skips events whose handler already succeeded
+func NewUserEventConsumer(broker broker.MessageBroker, taskQueue service.TaskQueue, retry RetryPolicy, webhooks *WebhookSender, dedup *Deduplicator) *UserEventConsumer {
 	return &UserEventConsumer{
-		broker: broker,
+		broker:    broker,
+		taskQueue: taskQueue,
+		retry:     retry,
+		webhooks:  webhooks,
+		dedup:     dedup,
 	}
 }
 
@@ -29,28 +44,54 @@ func (c *UserEventConsumer) Start(ctx context.Context) error {
 	}
 
 	// Subscribe to user created events
-	if err := c.broker.Subscribe(ctx, "user.created", c.handleUserCreated); err != nil {
-		return fmt.Errorf("failed to subscribe to user.created: %w", err)
+	if err := c.subscribe(ctx, "user.created", c.handleUserCreated); err != nil {
+		return err
 	}
 
 	// Subscribe to user updated events
-	if err := c.broker.Subscribe(ctx, "user.updated", c.handleUserUpdated); err != nil {
-		return fmt.Errorf("failed to subscribe to user.updated: %w", err)
+	if err := c.subscribe(ctx, "user.updated", c.handleUserUpdated); err != nil {
+		return err
 	}
 
 	// Subscribe to user deleted events
-	if err := c.broker.Subscribe(ctx, "user.deleted", c.handleUserDeleted); err != nil {
-		return fmt.Errorf("failed to subscribe to user.deleted: %w", err)
+	if err := c.subscribe(ctx, "user.deleted", c.handleUserDeleted); err != nil {
+		return err
 	}
 
 	// Subscribe to user logged in events
-	if err := c.broker.Subscribe(ctx, "user.logged_in", c.handleUserLoggedIn); err != nil {
-		return fmt.Errorf("failed to subscribe to user.logged_in: %w", err)
+	if err := c.subscribe(ctx, "user.logged_in", c.handleUserLoggedIn); err != nil {
+		return err
 	}
 
 	return nil
 }
 
+// subscribe registers handler for topic with retries, followed by the webhook delivery
+// Brokers allow one subscription per topic, so webhooks share it rather than subscribing themselves
+// Webhooks are delivered in the background and their failures don't fail the message
+// Duplicates are skipped before either runs
+func (c *UserEventConsumer) subscribe(ctx context.Context, topic string, handler broker.MessageHandler) error {
+	handle := c.retry.withRetry(topic, handler)
+	if c.webhooks != nil {
+		deliver := c.webhooks.handler(topic)
+		retried := handle
+		handle = func(ctx context.Context, message []byte) error {
+			if err := retried(ctx, message); err != nil {
+				return err
+			}
+			return deliver(ctx, message)
+		}
+	}
+	if c.dedup != nil {
+		handle = c.dedup.wrap(topic, handle)
+	}
+
+	if err := c.broker.Subscribe(ctx, topic, handle); err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", topic, err)
+	}
+	return nil
+}
+
 // Stop stops consuming user events
 func (c *UserEventConsumer) Stop() error {
 	if c.broker == nil {
@@ -64,6 +105,10 @@ func (c *UserEventConsumer) Stop() error {
 		}
 	}
 
+	if c.webhooks != nil {
+		c.webhooks.Close()
+	}
+
 	return nil
 }
 
@@ -77,9 +122,19 @@ func (c *UserEventConsumer) handleUserCreated(ctx context.Context, message []byt
 	log.Printf("[EVENT] User Created: ID=%s, Email=%s, Name=%s, At=%s",
 		event.AggregateID(), event.Email, event.Name, event.OccurredAt())
 
-	// Add your business logic here
+	// Send the welcome email, a failed enqueue is returned so the message is retried
+	if c.taskQueue != nil {
+		userID, err := uuid.Parse(event.AggregateID())
+		if err != nil {
+			return fmt.Errorf("invalid user id in user created event: %w", err)
+		}
+		if err := c.taskQueue.EnqueueWelcomeEmail(ctx, userID, event.Email, event.Name); err != nil {
+			return err
+		}
+	}
+
+	// Add more business logic here
 	// For example:
-	// - Send welcome email
 	// - Create user profile in another service
 	// - Update analytics
 	// - Send notification
@@ -136,11 +191,21 @@ func (c *UserEventConsumer) handleUserLoggedIn(ctx context.Context, message []by
 	log.Printf("[EVENT] User Logged In: ID=%s, Email=%s, At=%s",
 		event.AggregateID(), event.Email, event.OccurredAt())
 
+	// Alert the user about the sign-in, a failed enqueue is returned so the message is retried
+	if c.taskQueue != nil {
+		userID, err := uuid.Parse(event.AggregateID())
+		if err != nil {
+			return fmt.Errorf("invalid user id in user logged in event: %w", err)
+		}
+		if err := c.taskQueue.EnqueueLoginAlert(ctx, userID, event.Email, event.OccurredAt()); err != nil {
+			return err
+		}
+	}
+
 	// Add your business logic here
 	// For example:
 	// - Track login analytics
 	// - Update last login timestamp
-	// - Send login notification
 	// - Check for suspicious activity
 
 	return nil
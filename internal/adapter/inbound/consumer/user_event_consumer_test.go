@@ -0,0 +1,138 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gieart87/gohexaclean/internal/adapter/outbound/inmem"
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/gieart87/gohexaclean/internal/infra/config"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserEventConsumer_UserCreatedEnqueuesWelcomeEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	messageBroker := inmem.NewBroker()
+	require.NoError(t, messageBroker.Connect(ctx))
+	defer messageBroker.Close()
+
+	userID := uuid.New()
+	enqueued := make(chan struct{})
+	taskQueue := mock.NewMockTaskQueue(ctrl)
+	taskQueue.EXPECT().
+		EnqueueWelcomeEmail(gomock.Any(), userID, "jane@example.com", "Jane").
+		DoAndReturn(func(context.Context, uuid.UUID, string, string) error {
+			close(enqueued)
+			return nil
+		})
+
+	consumer := NewUserEventConsumer(messageBroker, taskQueue, RetryPolicy{}, nil, nil)
+	require.NoError(t, consumer.Start(ctx))
+	defer consumer.Stop()
+
+	event := domain.NewUserCreatedEvent(userID, "jane@example.com", "Jane")
+	require.NoError(t, messageBroker.Publish(ctx, "user.created", event))
+
+	select {
+	case <-enqueued:
+	case <-time.After(time.Second):
+		t.Fatal("user.created event did not enqueue the welcome email")
+	}
+}
+
+func TestUserEventConsumer_HandleUserCreated_ReturnsEnqueueError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	enqueueErr := errors.New("redis unavailable")
+	taskQueue := mock.NewMockTaskQueue(ctrl)
+	taskQueue.EXPECT().EnqueueWelcomeEmail(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(enqueueErr)
+
+	consumer := NewUserEventConsumer(nil, taskQueue, RetryPolicy{}, nil, nil)
+	message, err := json.Marshal(domain.NewUserCreatedEvent(uuid.New(), "jane@example.com", "Jane"))
+	require.NoError(t, err)
+
+	// Returning the error lets the retry policy redeliver the message
+	err = consumer.handleUserCreated(context.Background(), message)
+	assert.ErrorIs(t, err, enqueueErr)
+}
+
+func TestUserEventConsumer_HandleUserCreated_WithoutTaskQueue(t *testing.T) {
+	consumer := NewUserEventConsumer(nil, nil, RetryPolicy{}, nil, nil)
+	message, err := json.Marshal(domain.NewUserCreatedEvent(uuid.New(), "jane@example.com", "Jane"))
+	require.NoError(t, err)
+
+	assert.NoError(t, consumer.handleUserCreated(context.Background(), message))
+}
+
+func TestUserEventConsumer_HandleUserLoggedIn_EnqueuesLoginAlert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	event := domain.NewUserLoggedInEvent(userID, "jane@example.com")
+
+	taskQueue := mock.NewMockTaskQueue(ctrl)
+	taskQueue.EXPECT().
+		EnqueueLoginAlert(gomock.Any(), userID, "jane@example.com", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ uuid.UUID, _ string, loggedInAt time.Time) error {
+			assert.True(t, loggedInAt.Equal(event.OccurredAt()))
+			return nil
+		})
+
+	consumer := NewUserEventConsumer(nil, taskQueue, RetryPolicy{}, nil, nil)
+	message, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	assert.NoError(t, consumer.handleUserLoggedIn(context.Background(), message))
+}
+
+func TestUserEventConsumer_DeliversWebhookAfterHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	messageBroker := inmem.NewBroker()
+	require.NoError(t, messageBroker.Connect(ctx))
+	defer messageBroker.Close()
+
+	delivered := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		delivered <- r.Header.Get(HeaderWebhookEvent)
+	}))
+	defer server.Close()
+
+	taskQueue := mock.NewMockTaskQueue(ctrl)
+	taskQueue.EXPECT().EnqueueWelcomeEmail(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	webhooks := NewWebhookSender(&config.WebhookConfig{URLs: []string{server.URL}, Secret: "secret"}, nil)
+	consumer := NewUserEventConsumer(messageBroker, taskQueue, RetryPolicy{}, webhooks, nil)
+	require.NoError(t, consumer.Start(ctx))
+	defer consumer.Stop()
+
+	event := domain.NewUserCreatedEvent(uuid.New(), "jane@example.com", "Jane")
+	require.NoError(t, messageBroker.Publish(ctx, "user.created", event))
+
+	select {
+	case topic := <-delivered:
+		assert.Equal(t, "user.created", topic)
+	case <-time.After(time.Second):
+		t.Fatal("user.created event was not delivered to the webhook")
+	}
+}
@@ -0,0 +1,217 @@
+package consumer
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gieart87/gohexaclean/internal/infra/config"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/broker"
+)
+
+// Webhook delivery headers, the signature is the hex HMAC-SHA256 of "<timestamp>.<body>"
+const (
+	HeaderWebhookEvent     = "X-Webhook-Event"
+	HeaderWebhookTimestamp = "X-Webhook-Timestamp"
+	HeaderWebhookSignature = "X-Webhook-Signature"
+)
+
+const (
+	defaultWebhookTimeout    = 5 * time.Second
+	defaultWebhookRetryDelay = time.Second
+	// maxConcurrentWebhooks bounds the deliveries in flight, Send blocks the consumer beyond it
+	maxConcurrentWebhooks = 64
+	// webhookDeadLetterQueue names webhook failures in the dead-letter archive
+	webhookDeadLetterQueue = "webhooks"
+)
+
+// WebhookSender POSTs consumed user events to the configured partner URLs
+// Deliveries run in the background, one goroutine per URL, so neither the consumer nor the other
+// partners wait on a slow one. Every URL is retried with exponential backoff on its own, deliveries
+// that still fail are logged and archived when a dead-letter archive is configured
+type WebhookSender struct {
+	client      *http.Client
+	urls        []string
+	secret      []byte
+	maxRetries  int
+	retryDelay  time.Duration
+	deadLetters broker.DeadLetterArchiver
+
+	// ctx is cancelled by Close to abort the deliveries still in flight
+	ctx    context.Context
+	cancel context.CancelFunc
+	slots  chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewWebhookSender creates a webhook sender, it returns nil when no URL is configured
+// deadLetters keeps deliveries that failed for good, it may be nil to only log them
+func NewWebhookSender(cfg *config.WebhookConfig, deadLetters broker.DeadLetterArchiver) *WebhookSender {
+	if !cfg.Enabled() {
+		return nil
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+	retryDelay := cfg.RetryDelay
+	if retryDelay <= 0 {
+		retryDelay = defaultWebhookRetryDelay
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &WebhookSender{
+		client:      &http.Client{Timeout: timeout},
+		urls:        cfg.URLs,
+		secret:      []byte(cfg.Secret),
+		maxRetries:  cfg.MaxRetries,
+		retryDelay:  retryDelay,
+		deadLetters: deadLetters,
+		ctx:         ctx,
+		cancel:      cancel,
+		slots:       make(chan struct{}, maxConcurrentWebhooks),
+	}
+}
+
+// Send starts delivering the event to every URL and returns without waiting for the deliveries
+// A delivery failure never fails the consumed message, so a redelivery can't re-run its handler
+func (s *WebhookSender) Send(topic string, payload []byte) {
+	// The broker may reuse the message buffer once the handler returns
+	payload = bytes.Clone(payload)
+
+	for _, url := range s.urls {
+		if !s.acquire() {
+			s.deadLetter(url, topic, payload, s.ctx.Err())
+			continue
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer func() { <-s.slots }()
+
+			if err := s.deliver(s.ctx, url, topic, payload); err != nil {
+				log.Printf("[ERROR] webhook %s to %s failed after %d attempts: %v", topic, url, s.maxRetries+1, err)
+				s.deadLetter(url, topic, payload, err)
+			}
+		}()
+	}
+}
+
+// acquire takes a delivery slot, waiting while all are in use, it fails once the sender is closed
+func (s *WebhookSender) acquire() bool {
+	if s.ctx.Err() != nil {
+		return false
+	}
+
+	select {
+	case s.slots <- struct{}{}:
+		return true
+	case <-s.ctx.Done():
+		return false
+	}
+}
+
+// Close aborts the deliveries still in flight and waits for them, aborted deliveries are dead-lettered
+func (s *WebhookSender) Close() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+// handler returns a message handler delivering the messages of topic
+func (s *WebhookSender) handler(topic string) broker.MessageHandler {
+	return func(ctx context.Context, message []byte) error {
+		s.Send(topic, message)
+		return nil
+	}
+}
+
+// deadLetter archives a delivery that failed for good, tagged with its URL and error
+func (s *WebhookSender) deadLetter(url, topic string, payload []byte, cause error) {
+	if s.deadLetters == nil {
+		return
+	}
+
+	err := s.deadLetters.Archive(context.Background(), webhookDeadLetterQueue, broker.Message{
+		Topic:     topic,
+		Body:      payload,
+		Timestamp: time.Now().Unix(),
+		Headers:   map[string]string{"webhook_url": url, "error": cause.Error()},
+	})
+	if err != nil {
+		log.Printf("[ERROR] failed to dead-letter webhook %s to %s: %v", topic, url, err)
+	}
+}
+
+// deliver POSTs payload to url, retrying network errors, 429 and 5xx responses
+func (s *WebhookSender) deliver(ctx context.Context, url, topic string, payload []byte) error {
+	delay := s.retryDelay
+
+	for attempt := 0; ; attempt++ {
+		retryable, err := s.post(ctx, url, topic, payload)
+		if err == nil {
+			return nil
+		}
+		if !retryable || attempt >= s.maxRetries {
+			return err
+		}
+
+		log.Printf("[WARN] webhook %s to %s failed (attempt %d/%d), retrying in %s: %v",
+			topic, url, attempt+1, s.maxRetries+1, delay, err)
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		delay *= 2
+	}
+}
+
+// post makes one delivery attempt and reports whether a failure is worth retrying
+func (s *WebhookSender) post(ctx context.Context, url, topic string, payload []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return false, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderWebhookEvent, topic)
+	req.Header.Set(HeaderWebhookTimestamp, timestamp)
+	req.Header.Set(HeaderWebhookSignature, "sha256="+SignWebhook(s.secret, timestamp, payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("webhook responded %d", resp.StatusCode)
+	default:
+		return false, fmt.Errorf("webhook rejected the delivery with %d", resp.StatusCode)
+	}
+}
+
+// SignWebhook returns the hex HMAC-SHA256 of "<timestamp>.<payload>", receivers recompute it to
+// authenticate a delivery and reject stale timestamps to prevent replays
+func SignWebhook(secret []byte, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,196 @@
+package consumer
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gieart87/gohexaclean/internal/infra/config"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/broker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// statusServer answers each delivery with the next status of statuses, repeating the last one
+func statusServer(t *testing.T, calls *int32, statuses ...int) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(calls, 1))
+		if n > len(statuses) {
+			n = len(statuses)
+		}
+		w.WriteHeader(statuses[n-1])
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// recordingArchiver keeps the dead-lettered webhook deliveries
+type recordingArchiver struct {
+	mu       sync.Mutex
+	queue    string
+	messages []broker.Message
+}
+
+func (a *recordingArchiver) Archive(ctx context.Context, queue string, message broker.Message) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.queue = queue
+	a.messages = append(a.messages, message)
+	return nil
+}
+
+func (a *recordingArchiver) archived() []broker.Message {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]broker.Message(nil), a.messages...)
+}
+
+// blockingServer holds every delivery until the test ends, counting them on started
+func blockingServer(t *testing.T, started chan<- struct{}) *httptest.Server {
+	t.Helper()
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+	return server
+}
+
+func TestNewWebhookSender_DisabledWithoutURLs(t *testing.T) {
+	assert.Nil(t, NewWebhookSender(&config.WebhookConfig{Secret: "secret"}, nil))
+}
+
+func TestWebhookSender_SignsDelivery(t *testing.T) {
+	payload := []byte(`{"user_id":"8d7f0c2e-1111-4c3e-9a59-6a2d4c1f0b7e"}`)
+
+	var got *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sender := NewWebhookSender(&config.WebhookConfig{URLs: []string{server.URL}, Secret: "secret"}, nil)
+	sender.Send("user.created", payload)
+	sender.wg.Wait()
+
+	require.NotNil(t, got)
+	assert.Equal(t, payload, body)
+	assert.Equal(t, "user.created", got.Header.Get(HeaderWebhookEvent))
+	assert.Equal(t, "application/json", got.Header.Get("Content-Type"))
+
+	timestamp := got.Header.Get(HeaderWebhookTimestamp)
+	require.NotEmpty(t, timestamp)
+	assert.Equal(t, "sha256="+SignWebhook([]byte("secret"), timestamp, payload), got.Header.Get(HeaderWebhookSignature))
+}
+
+func TestWebhookSender_RetriesServerErrors(t *testing.T) {
+	var calls int32
+	server := statusServer(t, &calls, http.StatusInternalServerError, http.StatusTooManyRequests, http.StatusOK)
+
+	archive := &recordingArchiver{}
+	sender := NewWebhookSender(&config.WebhookConfig{
+		URLs: []string{server.URL}, Secret: "secret", MaxRetries: 3, RetryDelay: time.Millisecond,
+	}, archive)
+
+	sender.Send("user.updated", []byte("{}"))
+	sender.wg.Wait()
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	assert.Empty(t, archive.archived())
+}
+
+func TestWebhookSender_DoesNotRetryClientErrors(t *testing.T) {
+	var calls int32
+	server := statusServer(t, &calls, http.StatusBadRequest)
+
+	archive := &recordingArchiver{}
+	sender := NewWebhookSender(&config.WebhookConfig{
+		URLs: []string{server.URL}, Secret: "secret", MaxRetries: 3, RetryDelay: time.Millisecond,
+	}, archive)
+
+	sender.Send("user.deleted", []byte("{}"))
+	sender.wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	require.Len(t, archive.archived(), 1)
+	assert.Contains(t, archive.archived()[0].Headers["error"], "400")
+}
+
+func TestWebhookSender_DeadLettersEachFailedURL(t *testing.T) {
+	var failing, healthy int32
+	bad := statusServer(t, &failing, http.StatusServiceUnavailable)
+	good := statusServer(t, &healthy, http.StatusOK)
+
+	archive := &recordingArchiver{}
+	sender := NewWebhookSender(&config.WebhookConfig{
+		URLs: []string{bad.URL, good.URL}, Secret: "secret", MaxRetries: 1, RetryDelay: time.Millisecond,
+	}, archive)
+
+	// The consumed message succeeds whatever happens to its webhooks, so a redelivery can't re-run its handler
+	require.NoError(t, sender.handler("user.created")(context.Background(), []byte(`{"id":"1"}`)))
+	sender.wg.Wait()
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&failing))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&healthy))
+
+	archived := archive.archived()
+	require.Len(t, archived, 1)
+	assert.Equal(t, webhookDeadLetterQueue, archive.queue)
+	assert.Equal(t, "user.created", archived[0].Topic)
+	assert.Equal(t, []byte(`{"id":"1"}`), archived[0].Body)
+	assert.Equal(t, bad.URL, archived[0].Headers["webhook_url"])
+}
+
+func TestWebhookSender_SlowURLDoesNotBlockOthers(t *testing.T) {
+	started := make(chan struct{}, 1)
+	slow := blockingServer(t, started)
+
+	var healthy int32
+	good := statusServer(t, &healthy, http.StatusOK)
+
+	sender := NewWebhookSender(&config.WebhookConfig{URLs: []string{slow.URL, good.URL}, Secret: "secret"}, nil)
+	defer sender.Close()
+
+	// Send returns while the slow partner still holds its delivery
+	sender.Send("user.created", []byte("{}"))
+	<-started
+
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&healthy) == 1 }, time.Second, 5*time.Millisecond,
+		"the healthy partner should not wait for the slow one")
+}
+
+func TestWebhookSender_CloseDeadLettersAbortedDeliveries(t *testing.T) {
+	started := make(chan struct{}, 1)
+	slow := blockingServer(t, started)
+
+	archive := &recordingArchiver{}
+	sender := NewWebhookSender(&config.WebhookConfig{URLs: []string{slow.URL}, Secret: "secret"}, archive)
+
+	sender.Send("user.deleted", []byte("{}"))
+	<-started
+	sender.Close()
+
+	archived := archive.archived()
+	require.Len(t, archived, 1)
+	assert.Contains(t, archived[0].Headers["error"], context.Canceled.Error())
+
+	// Events sent after Close are dead-lettered right away
+	sender.Send("user.deleted", []byte("{}"))
+	assert.Len(t, archive.archived(), 2)
+}
@@ -0,0 +1,35 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	pb "github.com/gieart87/gohexaclean/api/proto/user"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// NewHandler returns an HTTP handler that translates REST calls into gRPC calls over conn
+// Routes come from api/proto/user_gateway.yaml, the Authorization header is forwarded as metadata
+func NewHandler(ctx context.Context, conn *grpc.ClientConn) (http.Handler, error) {
+	mux := runtime.NewServeMux(
+		// snake_case fields match the JSON produced by the Fiber API
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{
+				UseProtoNames:   true,
+				EmitUnpopulated: true,
+			},
+			UnmarshalOptions: protojson.UnmarshalOptions{
+				DiscardUnknown: true,
+			},
+		}),
+	)
+
+	if err := pb.RegisterUserServiceHandler(ctx, mux, conn); err != nil {
+		return nil, fmt.Errorf("failed to register user service gateway: %w", err)
+	}
+
+	return mux, nil
+}
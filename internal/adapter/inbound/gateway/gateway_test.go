@@ -0,0 +1,115 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/gieart87/gohexaclean/api/proto/user"
+	"github.com/gieart87/gohexaclean/internal/adapter/inbound/grpc/handler"
+	"github.com/gieart87/gohexaclean/internal/adapter/inbound/grpc/interceptor"
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/gieart87/gohexaclean/internal/dto/request"
+	"github.com/gieart87/gohexaclean/internal/dto/response"
+	"github.com/gieart87/gohexaclean/internal/port/inbound/mock"
+	"github.com/gieart87/gohexaclean/pkg/pagination"
+	"github.com/gieart87/gohexaclean/pkg/validation"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// setupGatewayTest serves the real gRPC user handler over bufconn and returns a gateway in front of it
+func setupGatewayTest(t *testing.T) (http.Handler, *mock.MockUserServicePort) {
+	ctrl := gomock.NewController(t)
+	mockService := mock.NewMockUserServicePort(ctrl)
+
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(interceptor.ErrorUnaryInterceptor()))
+	pb.RegisterUserServiceServer(grpcServer, handler.NewUserHandlerGRPC(mockService, validation.DefaultPasswordPolicy(), pagination.Default))
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	gw, err := NewHandler(context.Background(), conn)
+	require.NoError(t, err)
+
+	return gw, mockService
+}
+
+func TestGateway_LoginReachesGRPCHandler(t *testing.T) {
+	gw, mockService := setupGatewayTest(t)
+
+	userID := uuid.New()
+	mockService.EXPECT().
+		Login(gomock.Any(), &request.LoginRequest{Email: "john@example.com", Password: "secret123"}).
+		Return(&response.LoginResponse{
+			Token: "jwt-token",
+			User: &response.UserResponse{
+				ID:        userID,
+				Email:     "john@example.com",
+				Name:      "John Doe",
+				IsActive:  true,
+				CreatedAt: time.Now(),
+				UpdatedAt: time.Now(),
+			},
+		}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/auth/login",
+		strings.NewReader(`{"email":"john@example.com","password":"secret123"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	gw.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var body struct {
+		Token string `json:"token"`
+		User  struct {
+			ID       string `json:"id"`
+			Email    string `json:"email"`
+			IsActive bool   `json:"is_active"`
+		} `json:"user"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "jwt-token", body.Token)
+	assert.Equal(t, userID.String(), body.User.ID)
+	assert.Equal(t, "john@example.com", body.User.Email)
+	assert.True(t, body.User.IsActive)
+}
+
+func TestGateway_MapsGRPCErrorsToHTTPStatus(t *testing.T) {
+	gw, mockService := setupGatewayTest(t)
+
+	userID := uuid.New()
+	mockService.EXPECT().
+		GetUserByID(gomock.Any(), userID).
+		Return(nil, domain.ErrUserNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/users/"+userID.String(), nil)
+	rec := httptest.NewRecorder()
+
+	gw.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
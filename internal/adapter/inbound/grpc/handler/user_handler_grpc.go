@@ -2,24 +2,41 @@ package handler
 
 import (
 	"context"
+	"fmt"
+	"math"
 
+	"github.com/gieart87/gohexaclean/internal/domain"
 	"github.com/gieart87/gohexaclean/internal/dto/request"
+	"github.com/gieart87/gohexaclean/internal/dto/response"
 	"github.com/gieart87/gohexaclean/internal/port/inbound"
+	"github.com/gieart87/gohexaclean/pkg/pagination"
+	"github.com/gieart87/gohexaclean/pkg/validation"
 	pb "github.com/gieart87/gohexaclean/api/proto/user"
 	"github.com/google/uuid"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+const (
+	// defaultStreamBatchSize is used when StreamUsers is called without a limit
+	defaultStreamBatchSize = 100
+	// maxStreamBatchSize bounds how many users a stream holds in memory at once
+	maxStreamBatchSize = 1000
+)
+
 // UserHandlerGRPC implements the gRPC user service
 type UserHandlerGRPC struct {
 	pb.UnimplementedUserServiceServer
-	userService inbound.UserServicePort
+	userService    inbound.UserServicePort
+	passwordPolicy validation.PasswordPolicy
+	pageBounds     pagination.Bounds
 }
 
 // NewUserHandlerGRPC creates a new gRPC user handler
-func NewUserHandlerGRPC(userService inbound.UserServicePort) *UserHandlerGRPC {
+func NewUserHandlerGRPC(userService inbound.UserServicePort, passwordPolicy validation.PasswordPolicy, pageBounds pagination.Bounds) *UserHandlerGRPC {
 	return &UserHandlerGRPC{
-		userService: userService,
+		userService:    userService,
+		passwordPolicy: passwordPolicy,
+		pageBounds:     pageBounds,
 	}
 }
 
@@ -31,8 +48,10 @@ func (h *UserHandlerGRPC) CreateUser(ctx context.Context, req *pb.CreateUserRequ
 		Password: req.Password,
 	}
 
+	createReq.Normalize()
+
 	// Validate request
-	if err := createReq.Validate(); err != nil {
+	if err := createReq.ValidateWithPolicy(h.passwordPolicy); err != nil {
 		return nil, err
 	}
 
@@ -47,7 +66,7 @@ func (h *UserHandlerGRPC) CreateUser(ctx context.Context, req *pb.CreateUserRequ
 			Id:        registerResp.User.ID.String(),
 			Email:     registerResp.User.Email,
 			Name:      registerResp.User.Name,
-			IsActive:  true, // Active by default for new users
+			IsActive:  registerResp.User.IsActive,
 			CreatedAt: timestamppb.New(registerResp.User.CreatedAt),
 			UpdatedAt: timestamppb.New(registerResp.User.UpdatedAt),
 		},
@@ -58,7 +77,7 @@ func (h *UserHandlerGRPC) CreateUser(ctx context.Context, req *pb.CreateUserRequ
 func (h *UserHandlerGRPC) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.UserResponse, error) {
 	id, err := uuid.Parse(req.Id)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
 	}
 
 	user, err := h.userService.GetUserByID(ctx, id)
@@ -70,7 +89,7 @@ func (h *UserHandlerGRPC) GetUser(ctx context.Context, req *pb.GetUserRequest) (
 		Id:        user.ID.String(),
 		Email:     user.Email,
 		Name:      user.Name,
-		IsActive:  true, // No soft delete check in response, assume active
+		IsActive:  user.IsActive,
 		CreatedAt: timestamppb.New(user.CreatedAt),
 		UpdatedAt: timestamppb.New(user.UpdatedAt),
 	}, nil
@@ -80,7 +99,7 @@ func (h *UserHandlerGRPC) GetUser(ctx context.Context, req *pb.GetUserRequest) (
 func (h *UserHandlerGRPC) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UserResponse, error) {
 	id, err := uuid.Parse(req.Id)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
 	}
 
 	updateReq := &request.UpdateUserRequest{
@@ -101,7 +120,7 @@ func (h *UserHandlerGRPC) UpdateUser(ctx context.Context, req *pb.UpdateUserRequ
 		Id:        user.ID.String(),
 		Email:     user.Email,
 		Name:      user.Name,
-		IsActive:  true, // No soft delete check in response, assume active
+		IsActive:  user.IsActive,
 		CreatedAt: timestamppb.New(user.CreatedAt),
 		UpdatedAt: timestamppb.New(user.UpdatedAt),
 	}, nil
@@ -111,7 +130,7 @@ func (h *UserHandlerGRPC) UpdateUser(ctx context.Context, req *pb.UpdateUserRequ
 func (h *UserHandlerGRPC) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*pb.DeleteUserResponse, error) {
 	id, err := uuid.Parse(req.Id)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
 	}
 
 	if err := h.userService.DeleteUser(ctx, id); err != nil {
@@ -129,17 +148,14 @@ func (h *UserHandlerGRPC) DeleteUser(ctx context.Context, req *pb.DeleteUserRequ
 
 // ListUsers lists users with pagination
 func (h *UserHandlerGRPC) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
-	page := int(req.Page)
-	limit := int(req.Limit)
+	page, limit := h.pageBounds.Normalize(int(req.Page), int(req.Limit))
 
-	if page < 1 {
-		page = 1
-	}
-	if limit < 1 || limit > 100 {
-		limit = 10
+	sort, err := request.ParseUserSort(req.Sort)
+	if err != nil {
+		return nil, err
 	}
 
-	users, total, err := h.userService.ListUsers(ctx, page, limit)
+	users, total, err := h.userService.ListUsers(ctx, page, limit, sort, domain.ListOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -150,20 +166,47 @@ func (h *UserHandlerGRPC) ListUsers(ctx context.Context, req *pb.ListUsersReques
 			Id:        user.ID.String(),
 			Email:     user.Email,
 			Name:      user.Name,
-			IsActive:  true, // No soft delete check in response, assume active
+			IsActive:  user.IsActive,
 			CreatedAt: timestamppb.New(user.CreatedAt),
 			UpdatedAt: timestamppb.New(user.UpdatedAt),
 		}
 	}
 
+	// total_pages is an int32, cap the count instead of letting a huge total wrap negative
+	totalPages := min(pagination.TotalPages(total, limit), math.MaxInt32)
+
 	return &pb.ListUsersResponse{
 		Users: pbUsers,
-		Total: total,
-		Page:  req.Page,
-		Limit: req.Limit,
+		Total:      total,
+		Page:       int32(page),
+		Limit:      int32(limit),
+		TotalPages: int32(totalPages),
+		HasNext:    pagination.HasNext(page, limit, total),
 	}, nil
 }
 
+// StreamUsers streams every user for exports that are too large for a single ListUsers page
+func (h *UserHandlerGRPC) StreamUsers(req *pb.ListUsersRequest, stream pb.UserService_StreamUsersServer) error {
+	batchSize := int(req.Limit)
+	if batchSize < 1 {
+		batchSize = defaultStreamBatchSize
+	}
+	if batchSize > maxStreamBatchSize {
+		batchSize = maxStreamBatchSize
+	}
+
+	return h.userService.StreamUsers(stream.Context(), batchSize, func(user *response.UserResponse) error {
+		return stream.Send(&pb.UserResponse{
+			Id:        user.ID.String(),
+			Email:     user.Email,
+			Name:      user.Name,
+			IsActive:  user.IsActive,
+			CreatedAt: timestamppb.New(user.CreatedAt),
+			UpdatedAt: timestamppb.New(user.UpdatedAt),
+		})
+	})
+}
+
 // Login authenticates a user
 func (h *UserHandlerGRPC) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
 	loginReq := &request.LoginRequest{
@@ -171,6 +214,8 @@ func (h *UserHandlerGRPC) Login(ctx context.Context, req *pb.LoginRequest) (*pb.
 		Password: req.Password,
 	}
 
+	loginReq.Normalize()
+
 	// Validate request
 	if err := loginReq.Validate(); err != nil {
 		return nil, err
@@ -187,7 +232,7 @@ func (h *UserHandlerGRPC) Login(ctx context.Context, req *pb.LoginRequest) (*pb.
 			Id:        loginResp.User.ID.String(),
 			Email:     loginResp.User.Email,
 			Name:      loginResp.User.Name,
-			IsActive:  true, // No soft delete check in response, assume active
+			IsActive:  loginResp.User.IsActive,
 			CreatedAt: timestamppb.New(loginResp.User.CreatedAt),
 			UpdatedAt: timestamppb.New(loginResp.User.UpdatedAt),
 		},
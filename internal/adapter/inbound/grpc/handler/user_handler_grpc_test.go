@@ -0,0 +1,254 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net"
+	"testing"
+
+	pb "github.com/gieart87/gohexaclean/api/proto/user"
+	"github.com/gieart87/gohexaclean/internal/adapter/inbound/grpc/interceptor"
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/gieart87/gohexaclean/internal/dto/response"
+	"github.com/gieart87/gohexaclean/internal/port/inbound/mock"
+	"github.com/gieart87/gohexaclean/pkg/pagination"
+	"github.com/gieart87/gohexaclean/pkg/validation"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestUserHandlerGRPC_GetUser_ReportsActiveStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		active bool
+	}{
+		{name: "active user", active: true},
+		{name: "suspended user", active: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mock.NewMockUserServicePort(ctrl)
+			handler := NewUserHandlerGRPC(mockService, validation.DefaultPasswordPolicy(), pagination.Default)
+
+			id := uuid.New()
+			mockService.EXPECT().
+				GetUserByID(gomock.Any(), id).
+				Return(&response.UserResponse{ID: id, Email: "jane@example.com", Name: "Jane", IsActive: tt.active}, nil)
+
+			resp, err := handler.GetUser(context.Background(), &pb.GetUserRequest{Id: id.String()})
+
+			require.NoError(t, err)
+			assert.Equal(t, id.String(), resp.Id)
+			assert.Equal(t, tt.active, resp.IsActive)
+		})
+	}
+}
+
+func TestUserHandlerGRPC_ListUsers_PaginationMetadata(t *testing.T) {
+	tests := []struct {
+		name           string
+		page           int32
+		wantTotalPages int32
+		wantHasNext    bool
+	}{
+		{name: "middle page", page: 2, wantTotalPages: 4, wantHasNext: true},
+		{name: "partial last page", page: 4, wantTotalPages: 4, wantHasNext: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mock.NewMockUserServicePort(ctrl)
+			handler := NewUserHandlerGRPC(mockService, validation.DefaultPasswordPolicy(), pagination.Default)
+
+			// 31 users over pages of 10 leave a remainder of 1 on the last page
+			mockService.EXPECT().
+				ListUsers(gomock.Any(), int(tt.page), 10, domain.DefaultUserSort, domain.ListOptions{}).
+				Return([]*response.UserResponse{{ID: uuid.New(), Email: "jane@example.com", Name: "Jane"}}, int64(31), nil)
+
+			resp, err := handler.ListUsers(context.Background(), &pb.ListUsersRequest{Page: tt.page, Limit: 10})
+
+			require.NoError(t, err)
+			assert.Equal(t, int64(31), resp.Total)
+			assert.Equal(t, tt.page, resp.Page)
+			assert.Equal(t, int32(10), resp.Limit)
+			assert.Equal(t, tt.wantTotalPages, resp.TotalPages)
+			assert.Equal(t, tt.wantHasNext, resp.HasNext)
+		})
+	}
+}
+
+func TestUserHandlerGRPC_ListUsers_Sort(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mock.NewMockUserServicePort(ctrl)
+	handler := NewUserHandlerGRPC(mockService, validation.DefaultPasswordPolicy(), pagination.Default)
+
+	mockService.EXPECT().
+		ListUsers(gomock.Any(), 1, 10, domain.UserSort{Field: domain.UserSortName, Desc: true}, domain.ListOptions{}).
+		Return([]*response.UserResponse{}, int64(0), nil)
+
+	_, err := handler.ListUsers(context.Background(), &pb.ListUsersRequest{Page: 1, Limit: 10, Sort: "-name"})
+	require.NoError(t, err)
+
+	// Unknown fields are rejected before the service is called
+	_, err = handler.ListUsers(context.Background(), &pb.ListUsersRequest{Page: 1, Limit: 10, Sort: "password"})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+}
+
+func TestUserHandlerGRPC_ListUsers_ReportsNormalizedPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mock.NewMockUserServicePort(ctrl)
+	handler := NewUserHandlerGRPC(mockService, validation.DefaultPasswordPolicy(), pagination.Default)
+
+	mockService.EXPECT().
+		ListUsers(gomock.Any(), 1, pagination.DefaultLimit, domain.DefaultUserSort, domain.ListOptions{}).
+		Return([]*response.UserResponse{}, int64(0), nil)
+
+	resp, err := handler.ListUsers(context.Background(), &pb.ListUsersRequest{})
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), resp.Page)
+	assert.Equal(t, int32(pagination.DefaultLimit), resp.Limit)
+	assert.Equal(t, int32(0), resp.TotalPages)
+	assert.False(t, resp.HasNext)
+}
+
+func TestUserHandlerGRPC_ListUsers_CapsTotalPages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mock.NewMockUserServicePort(ctrl)
+	handler := NewUserHandlerGRPC(mockService, validation.DefaultPasswordPolicy(), pagination.Default)
+
+	// One user per page makes the page count overflow an int32
+	mockService.EXPECT().
+		ListUsers(gomock.Any(), 1, 1, domain.DefaultUserSort, domain.ListOptions{}).
+		Return([]*response.UserResponse{}, int64(1)<<40, nil)
+
+	resp, err := handler.ListUsers(context.Background(), &pb.ListUsersRequest{Page: 1, Limit: 1})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1)<<40, resp.Total)
+	assert.Equal(t, int32(math.MaxInt32), resp.TotalPages)
+	assert.True(t, resp.HasNext)
+}
+
+// serveUserHandler serves the handler over bufconn behind the error interceptors and returns a client for it
+func serveUserHandler(t *testing.T, userService *mock.MockUserServicePort) pb.UserServiceClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(interceptor.ErrorUnaryInterceptor()),
+		grpc.ChainStreamInterceptor(interceptor.ErrorStreamInterceptor()),
+	)
+	pb.RegisterUserServiceServer(server, NewUserHandlerGRPC(userService, validation.DefaultPasswordPolicy(), pagination.Default))
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return pb.NewUserServiceClient(conn)
+}
+
+// streamUsersFrom makes the mocked StreamUsers send users, then return err
+func streamUsersFrom(users []*response.UserResponse, err error) func(context.Context, int, func(*response.UserResponse) error) error {
+	return func(ctx context.Context, batchSize int, fn func(*response.UserResponse) error) error {
+		for _, user := range users {
+			if sendErr := fn(user); sendErr != nil {
+				return sendErr
+			}
+		}
+		return err
+	}
+}
+
+func TestUserHandlerGRPC_StreamUsers_SendsEveryUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mock.NewMockUserServicePort(ctrl)
+	client := serveUserHandler(t, mockService)
+
+	users := []*response.UserResponse{
+		{ID: uuid.New(), Email: "jane@example.com", Name: "Jane", IsActive: true},
+		{ID: uuid.New(), Email: "john@example.com", Name: "John", IsActive: false},
+		{ID: uuid.New(), Email: "joe@example.com", Name: "Joe", IsActive: true},
+	}
+	mockService.EXPECT().
+		StreamUsers(gomock.Any(), defaultStreamBatchSize, gomock.Any()).
+		DoAndReturn(streamUsersFrom(users, nil))
+
+	stream, err := client.StreamUsers(context.Background(), &pb.ListUsersRequest{})
+	require.NoError(t, err)
+
+	for _, want := range users {
+		got, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, want.ID.String(), got.Id)
+		assert.Equal(t, want.Email, got.Email)
+		assert.Equal(t, want.IsActive, got.IsActive)
+	}
+
+	_, err = stream.Recv()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestUserHandlerGRPC_StreamUsers_MidStreamError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mock.NewMockUserServicePort(ctrl)
+	client := serveUserHandler(t, mockService)
+
+	users := []*response.UserResponse{
+		{ID: uuid.New(), Email: "jane@example.com", Name: "Jane"},
+		{ID: uuid.New(), Email: "john@example.com", Name: "John"},
+	}
+	mockService.EXPECT().
+		StreamUsers(gomock.Any(), maxStreamBatchSize, gomock.Any()).
+		DoAndReturn(streamUsersFrom(users, errors.New("connection reset by peer")))
+
+	stream, err := client.StreamUsers(context.Background(), &pb.ListUsersRequest{Limit: maxStreamBatchSize + 1})
+	require.NoError(t, err)
+
+	// Users sent before the failure still arrive, then the stream ends with the mapped status
+	for _, want := range users {
+		got, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, want.ID.String(), got.Id)
+	}
+
+	_, err = stream.Recv()
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, io.EOF)
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
@@ -0,0 +1,82 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Server wraps the standard grpc.health.v1 health server so orchestrators
+// (Kubernetes, load balancers, grpc_health_probe) can check the gRPC server
+type Server struct {
+	server   *health.Server
+	services []string
+}
+
+// NewServer creates a new health server for the given service names.
+// All services start as NOT_SERVING until SetServing is called.
+func NewServer(services ...string) *Server {
+	s := &Server{
+		server:   health.NewServer(),
+		services: services,
+	}
+	s.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	return s
+}
+
+// Register registers the health service on the given gRPC server
+func (s *Server) Register(registrar grpc.ServiceRegistrar) {
+	healthpb.RegisterHealthServer(registrar, s.server)
+}
+
+// SetServing marks the server and all registered services as SERVING
+func (s *Server) SetServing() {
+	s.setStatus(healthpb.HealthCheckResponse_SERVING)
+}
+
+// SetNotServing marks the server and all registered services as NOT_SERVING
+func (s *Server) SetNotServing() {
+	s.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+// Watch runs check every interval until ctx is done, reporting SERVING while it
+// succeeds and NOT_SERVING while it fails. The first check runs immediately.
+func (s *Server) Watch(ctx context.Context, interval time.Duration, check func(context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		checkCtx, cancel := context.WithTimeout(ctx, interval)
+		err := check(checkCtx)
+		cancel()
+
+		if err != nil {
+			s.SetNotServing()
+		} else {
+			s.SetServing()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown sets all services to NOT_SERVING and ignores any future status updates
+// Call this right before GracefulStop so clients stop routing new requests
+func (s *Server) Shutdown() {
+	s.server.Shutdown()
+}
+
+// setStatus sets the overall ("") status and the status of every registered service
+func (s *Server) setStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	s.server.SetServingStatus("", status)
+	for _, service := range s.services {
+		s.server.SetServingStatus(service, status)
+	}
+}
@@ -0,0 +1,107 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const testServiceName = "user.UserService"
+
+func setupHealthTest(t *testing.T) (*Server, healthpb.HealthClient) {
+	listener := bufconn.Listen(1024 * 1024)
+
+	grpcServer := grpc.NewServer()
+	healthServer := NewServer(testServiceName)
+	healthServer.Register(grpcServer)
+
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return healthServer, healthpb.NewHealthClient(conn)
+}
+
+func TestServer_Check_NotServingByDefault(t *testing.T) {
+	_, client := setupHealthTest(t)
+
+	resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+}
+
+func TestServer_Check_Serving(t *testing.T) {
+	healthServer, client := setupHealthTest(t)
+	healthServer.SetServing()
+
+	resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+
+	resp, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: testServiceName})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+}
+
+func TestServer_Check_NotServingAfterShutdown(t *testing.T) {
+	healthServer, client := setupHealthTest(t)
+	healthServer.SetServing()
+	healthServer.Shutdown()
+
+	resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: testServiceName})
+
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+}
+
+func TestServer_Watch_FollowsDependencyCheck(t *testing.T) {
+	healthServer, client := setupHealthTest(t)
+
+	var healthy atomic.Bool
+	healthy.Store(true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go healthServer.Watch(ctx, 10*time.Millisecond, func(context.Context) error {
+		if healthy.Load() {
+			return nil
+		}
+		return errors.New("database unreachable")
+	})
+
+	status := func() healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: testServiceName})
+		require.NoError(t, err)
+		return resp.Status
+	}
+
+	assert.Eventually(t, func() bool {
+		return status() == healthpb.HealthCheckResponse_SERVING
+	}, time.Second, 5*time.Millisecond)
+
+	healthy.Store(false)
+	assert.Eventually(t, func() bool {
+		return status() == healthpb.HealthCheckResponse_NOT_SERVING
+	}, time.Second, 5*time.Millisecond)
+}
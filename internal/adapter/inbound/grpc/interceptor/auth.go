@@ -0,0 +1,125 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gieart87/gohexaclean/pkg/auth"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type contextKey string
+
+// roleKey holds the authenticated user's role, the user ID is stored with auth.WithUserID
+const roleKey contextKey = "role"
+
+// TokenVersionProvider returns a user's current token version (implemented by the user service)
+type TokenVersionProvider interface {
+	GetTokenVersion(ctx context.Context, id uuid.UUID) (int, error)
+}
+
+// AuthUnaryInterceptor validates the bearer token in the "authorization" metadata of every unary call
+// publicMethods are full method names (e.g. /user.UserService/Login) that skip authentication
+// tokenVersions is optional; when set, tokens issued before the user's last revocation are rejected
+func AuthUnaryInterceptor(tokenOpts auth.TokenOptions, tokenVersions TokenVersionProvider, publicMethods ...string) grpc.UnaryServerInterceptor {
+	a := newAuthenticator(tokenOpts, tokenVersions, publicMethods)
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := a.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// AuthStreamInterceptor applies the same bearer token checks as AuthUnaryInterceptor to streaming calls
+func AuthStreamInterceptor(tokenOpts auth.TokenOptions, tokenVersions TokenVersionProvider, publicMethods ...string) grpc.StreamServerInterceptor {
+	a := newAuthenticator(tokenOpts, tokenVersions, publicMethods)
+
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticatedStream exposes the context carrying the caller's identity to stream handlers
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the authenticated context
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// authenticator holds the token checks shared by the unary and stream interceptors
+type authenticator struct {
+	tokenOpts     auth.TokenOptions
+	tokenVersions TokenVersionProvider
+	public        map[string]struct{}
+}
+
+func newAuthenticator(tokenOpts auth.TokenOptions, tokenVersions TokenVersionProvider, publicMethods []string) *authenticator {
+	public := make(map[string]struct{}, len(publicMethods))
+	for _, method := range publicMethods {
+		public[method] = struct{}{}
+	}
+	return &authenticator{tokenOpts: tokenOpts, tokenVersions: tokenVersions, public: public}
+}
+
+// authenticate validates the caller's token and returns ctx with their ID and role attached
+func (a *authenticator) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	if _, ok := a.public[fullMethod]; ok {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing authorization metadata")
+	}
+
+	// Expect "Bearer <token>"
+	parts := strings.Split(values[0], " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return nil, status.Error(codes.Unauthenticated, "invalid authorization metadata format")
+	}
+
+	claims, err := auth.ValidateJWT(parts[1], a.tokenOpts)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+	}
+
+	// Reject tokens minted before the user's tokens were revoked
+	if a.tokenVersions != nil {
+		version, err := a.tokenVersions.GetTokenVersion(ctx, claims.UserID)
+		if err != nil || claims.TokenVersion < version {
+			return nil, status.Error(codes.Unauthenticated, "token has been revoked")
+		}
+	}
+
+	ctx = auth.WithUserID(ctx, claims.UserID)
+	ctx = context.WithValue(ctx, roleKey, claims.Role)
+
+	return ctx, nil
+}
+
+// UserIDFromContext returns the authenticated user's ID set by the auth interceptors
+func UserIDFromContext(ctx context.Context) (uuid.UUID, bool) {
+	return auth.UserIDFromContext(ctx)
+}
+
+// RoleFromContext returns the authenticated user's role set by the auth interceptors
+func RoleFromContext(ctx context.Context) (string, bool) {
+	role, ok := ctx.Value(roleKey).(string)
+	return role, ok
+}
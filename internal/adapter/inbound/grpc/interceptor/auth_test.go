@@ -0,0 +1,161 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/gieart87/gohexaclean/pkg/auth"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var testTokenOpts = auth.TokenOptions{Secret: "test-secret", Issuer: "gohexaclean", Audience: "gohexaclean-api"}
+
+const (
+	getUserMethod     = "/user.UserService/GetUser"
+	loginMethod       = "/user.UserService/Login"
+	createUserMethod  = "/user.UserService/CreateUser"
+	streamUsersMethod = "/user.UserService/StreamUsers"
+)
+
+// tokenVersions is a fixed TokenVersionProvider
+type tokenVersions struct {
+	version int
+	err     error
+}
+
+func (p tokenVersions) GetTokenVersion(ctx context.Context, id uuid.UUID) (int, error) {
+	return p.version, p.err
+}
+
+// invoke runs the interceptor for method with the given authorization metadata, returning the handler's context
+func invoke(t *testing.T, interceptor grpc.UnaryServerInterceptor, method, authorization string) (context.Context, error) {
+	t.Helper()
+
+	ctx := context.Background()
+	if authorization != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", authorization))
+	}
+
+	var handlerCtx context.Context
+	_, err := interceptor(ctx, "request", &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		handlerCtx = ctx
+		return "response", nil
+	})
+	return handlerCtx, err
+}
+
+func TestAuthUnaryInterceptor_ValidToken(t *testing.T) {
+	userID := uuid.New()
+	token, err := auth.GenerateJWT(userID, "test@example.com", domain.RoleAdmin, 1, testTokenOpts, time.Hour)
+	require.NoError(t, err)
+
+	interceptor := AuthUnaryInterceptor(testTokenOpts, tokenVersions{version: 1}, loginMethod, createUserMethod)
+
+	ctx, err := invoke(t, interceptor, getUserMethod, "Bearer "+token)
+
+	require.NoError(t, err)
+	gotID, ok := UserIDFromContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, userID, gotID)
+	role, _ := RoleFromContext(ctx)
+	assert.Equal(t, domain.RoleAdmin, role)
+}
+
+func TestAuthUnaryInterceptor_RejectsProtectedMethod(t *testing.T) {
+	validToken, err := auth.GenerateJWT(uuid.New(), "test@example.com", domain.RoleUser, 0, testTokenOpts, time.Hour)
+	require.NoError(t, err)
+	otherSecretToken, err := auth.GenerateJWT(uuid.New(), "test@example.com", domain.RoleUser, 0, auth.TokenOptions{Secret: "other-secret"}, time.Hour)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		authorization string
+		versions      TokenVersionProvider
+	}{
+		{"missing metadata", "", nil},
+		{"not a bearer token", "Basic " + validToken, nil},
+		{"invalid signature", "Bearer " + otherSecretToken, nil},
+		{"revoked token", "Bearer " + validToken, tokenVersions{version: 1}},
+		{"token version lookup fails", "Bearer " + validToken, tokenVersions{err: errors.New("user not found")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor := AuthUnaryInterceptor(testTokenOpts, tt.versions, loginMethod, createUserMethod)
+
+			ctx, err := invoke(t, interceptor, getUserMethod, tt.authorization)
+
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+			assert.Nil(t, ctx, "handler must not run")
+		})
+	}
+}
+
+func TestAuthUnaryInterceptor_PublicMethodsSkipAuth(t *testing.T) {
+	interceptor := AuthUnaryInterceptor(testTokenOpts, nil, loginMethod, createUserMethod)
+
+	for _, method := range []string{loginMethod, createUserMethod} {
+		ctx, err := invoke(t, interceptor, method, "")
+
+		require.NoError(t, err, method)
+		_, ok := UserIDFromContext(ctx)
+		assert.False(t, ok)
+	}
+}
+
+// fakeServerStream is a grpc.ServerStream carrying only a context
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// invokeStream runs the stream interceptor for method with the given authorization metadata, returning the handler's context
+func invokeStream(t *testing.T, interceptor grpc.StreamServerInterceptor, method, authorization string) (context.Context, error) {
+	t.Helper()
+
+	ctx := context.Background()
+	if authorization != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", authorization))
+	}
+
+	var handlerCtx context.Context
+	err := interceptor(nil, &fakeServerStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: method, IsServerStream: true}, func(srv interface{}, stream grpc.ServerStream) error {
+		handlerCtx = stream.Context()
+		return nil
+	})
+	return handlerCtx, err
+}
+
+func TestAuthStreamInterceptor_ValidToken(t *testing.T) {
+	userID := uuid.New()
+	token, err := auth.GenerateJWT(userID, "test@example.com", domain.RoleAdmin, 1, testTokenOpts, time.Hour)
+	require.NoError(t, err)
+
+	interceptor := AuthStreamInterceptor(testTokenOpts, tokenVersions{version: 1}, loginMethod)
+	ctx, err := invokeStream(t, interceptor, streamUsersMethod, "Bearer "+token)
+
+	require.NoError(t, err)
+	gotID, ok := UserIDFromContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, userID, gotID)
+}
+
+func TestAuthStreamInterceptor_MissingToken(t *testing.T) {
+	interceptor := AuthStreamInterceptor(testTokenOpts, nil, loginMethod)
+	_, err := invokeStream(t, interceptor, streamUsersMethod, "")
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -0,0 +1,76 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	pkgErrors "github.com/gieart87/gohexaclean/pkg/errors"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorUnaryInterceptor converts errors returned by handlers into gRPC status errors,
+// using the same domain error mapping as the HTTP adapter so both protocols agree
+func ErrorUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, toStatusError(err)
+		}
+		return resp, nil
+	}
+}
+
+// ErrorStreamInterceptor applies the ErrorUnaryInterceptor mapping to streaming calls
+func ErrorStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := handler(srv, ss); err != nil {
+			return toStatusError(err)
+		}
+		return nil
+	}
+}
+
+// toStatusError maps err to a status error, leaving errors that already carry a status untouched
+func toStatusError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var validationErrs validation.Errors
+	var validationErr validation.Error
+	if errors.As(err, &validationErrs) || errors.As(err, &validationErr) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	appErr := pkgErrors.MapDomainError(err)
+	return status.Error(grpcCode(appErr.Code), appErr.Message)
+}
+
+// grpcCode returns the gRPC code equivalent to an HTTP status from MapDomainError
+func grpcCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,89 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/gieart87/gohexaclean/internal/dto/request"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// invokeWithError runs the error interceptor around a handler failing with err
+func invokeWithError(method string, err error) error {
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+	_, got := ErrorUnaryInterceptor()(context.Background(), "request", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, err
+	})
+	return got
+}
+
+func TestErrorUnaryInterceptor_GetUserMissingIDIsNotFound(t *testing.T) {
+	// The user service wraps repository errors, so matching must see through the wrapping
+	err := invokeWithError(getUserMethod, fmt.Errorf("failed to get user: %w", domain.ErrUserNotFound))
+
+	st, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.NotFound, st.Code())
+	assert.Equal(t, "User not found", st.Message())
+}
+
+func TestErrorUnaryInterceptor_ValidationFailureIsInvalidArgument(t *testing.T) {
+	validationErr := (&request.CreateUserRequest{Email: "not-an-email"}).Validate()
+	assert.Error(t, validationErr)
+
+	err := invokeWithError(createUserMethod, validationErr)
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestErrorUnaryInterceptor_MapsDomainErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"already exists", domain.ErrUserAlreadyExists, codes.AlreadyExists},
+		{"invalid credentials", domain.ErrInvalidCredentials, codes.Unauthenticated},
+		{"account inactive", domain.ErrAccountInactive, codes.PermissionDenied},
+		{"invalid input", fmt.Errorf("%w: invalid UUID length: 3", domain.ErrInvalidInput), codes.InvalidArgument},
+		{"deadline exceeded", context.DeadlineExceeded, codes.DeadlineExceeded},
+		{"unknown error", errors.New("boom"), codes.Internal},
+		{"existing status", status.Error(codes.Unauthenticated, "missing token"), codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, status.Code(invokeWithError(getUserMethod, tt.err)))
+		})
+	}
+}
+
+func TestErrorUnaryInterceptor_PassesThroughSuccess(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: getUserMethod}
+	resp, err := ErrorUnaryInterceptor()(context.Background(), "request", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "response", nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "response", resp)
+}
+
+func TestErrorStreamInterceptor_MapsDomainErrors(t *testing.T) {
+	info := &grpc.StreamServerInfo{FullMethod: streamUsersMethod, IsServerStream: true}
+	err := ErrorStreamInterceptor()(nil, nil, info, func(srv interface{}, stream grpc.ServerStream) error {
+		return fmt.Errorf("failed to stream users: %w", domain.ErrInvalidInput)
+	})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	err = ErrorStreamInterceptor()(nil, nil, info, func(srv interface{}, stream grpc.ServerStream) error {
+		return nil
+	})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,74 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Access is what a method requires of the caller beyond a valid token
+type Access int
+
+const (
+	// AdminOnly admits callers with the admin role
+	AdminOnly Access = iota + 1
+	// OwnerOrAdmin admits admins and the user named by the request's ID
+	OwnerOrAdmin
+)
+
+// RolePolicy maps full method names to the access they require, methods left out only need a valid token
+type RolePolicy map[string]Access
+
+// idRequest is implemented by requests acting on one user
+type idRequest interface {
+	GetId() string
+}
+
+// RoleUnaryInterceptor enforces policy on unary calls, it runs after the auth interceptor has attached the caller
+func RoleUnaryInterceptor(policy RolePolicy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := policy.check(ctx, info.FullMethod, req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// RoleStreamInterceptor enforces policy on streaming calls
+// The request isn't read yet when it runs, so OwnerOrAdmin only admits admins here
+func RoleStreamInterceptor(policy RolePolicy) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := policy.check(ss.Context(), info.FullMethod, nil); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// check returns PermissionDenied unless the caller in ctx may invoke method with req
+func (p RolePolicy) check(ctx context.Context, method string, req interface{}) error {
+	access, ok := p[method]
+	if !ok {
+		return nil
+	}
+
+	if role, _ := RoleFromContext(ctx); role == domain.RoleAdmin {
+		return nil
+	}
+
+	if access == OwnerOrAdmin {
+		if r, ok := req.(idRequest); ok {
+			callerID, authenticated := UserIDFromContext(ctx)
+			targetID, err := uuid.Parse(r.GetId())
+			if authenticated && err == nil && targetID == callerID {
+				return nil
+			}
+		}
+	}
+
+	return status.Error(codes.PermissionDenied, "insufficient permissions")
+}
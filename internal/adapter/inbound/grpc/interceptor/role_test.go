@@ -0,0 +1,106 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/gieart87/gohexaclean/pkg/auth"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	listUsersMethod  = "/user.UserService/ListUsers"
+	updateUserMethod = "/user.UserService/UpdateUser"
+	deleteUserMethod = "/user.UserService/DeleteUser"
+)
+
+var testRolePolicy = RolePolicy{
+	listUsersMethod:   AdminOnly,
+	deleteUserMethod:  AdminOnly,
+	updateUserMethod:  OwnerOrAdmin,
+	streamUsersMethod: AdminOnly,
+}
+
+// userRequest is a request acting on the user with ID id
+type userRequest struct {
+	id string
+}
+
+func (r userRequest) GetId() string {
+	return r.id
+}
+
+// callerContext returns a context carrying the identity the auth interceptors attach
+func callerContext(id uuid.UUID, role string) context.Context {
+	ctx := auth.WithUserID(context.Background(), id)
+	return context.WithValue(ctx, roleKey, role)
+}
+
+func TestRoleUnaryInterceptor(t *testing.T) {
+	callerID := uuid.New()
+	interceptor := RoleUnaryInterceptor(testRolePolicy)
+
+	tests := []struct {
+		name     string
+		role     string
+		method   string
+		req      interface{}
+		wantCode codes.Code
+	}{
+		{"admin lists users", domain.RoleAdmin, listUsersMethod, userRequest{}, codes.OK},
+		{"user can't list users", domain.RoleUser, listUsersMethod, userRequest{}, codes.PermissionDenied},
+		{"user can't delete themselves", domain.RoleUser, deleteUserMethod, userRequest{id: callerID.String()}, codes.PermissionDenied},
+		{"admin updates another user", domain.RoleAdmin, updateUserMethod, userRequest{id: uuid.NewString()}, codes.OK},
+		{"user updates themselves", domain.RoleUser, updateUserMethod, userRequest{id: callerID.String()}, codes.OK},
+		{"user can't update another user", domain.RoleUser, updateUserMethod, userRequest{id: uuid.NewString()}, codes.PermissionDenied},
+		{"malformed ID isn't the caller's", domain.RoleUser, updateUserMethod, userRequest{id: "not-a-uuid"}, codes.PermissionDenied},
+		{"methods without a rule only need a token", domain.RoleUser, getUserMethod, userRequest{id: uuid.NewString()}, codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			_, err := interceptor(callerContext(callerID, tt.role), tt.req, &grpc.UnaryServerInfo{FullMethod: tt.method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				return "response", nil
+			})
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantCode == codes.OK, called)
+		})
+	}
+}
+
+func TestRoleUnaryInterceptor_UnauthenticatedCallerIsDenied(t *testing.T) {
+	interceptor := RoleUnaryInterceptor(testRolePolicy)
+
+	_, err := interceptor(context.Background(), userRequest{id: uuid.NewString()}, &grpc.UnaryServerInfo{FullMethod: updateUserMethod}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "response", nil
+	})
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestRoleStreamInterceptor_StreamUsersRequiresAdmin(t *testing.T) {
+	interceptor := RoleStreamInterceptor(testRolePolicy)
+
+	for role, wantCode := range map[string]codes.Code{
+		domain.RoleAdmin: codes.OK,
+		domain.RoleUser:  codes.PermissionDenied,
+	} {
+		called := false
+		stream := &fakeServerStream{ctx: callerContext(uuid.New(), role)}
+		err := interceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: streamUsersMethod, IsServerStream: true}, func(srv interface{}, stream grpc.ServerStream) error {
+			called = true
+			return nil
+		})
+
+		assert.Equal(t, wantCode, status.Code(err), role)
+		assert.Equal(t, wantCode == codes.OK, called, role)
+	}
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"time"
+
+	"github.com/gieart87/gohexaclean/internal/infra/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+const (
+	defaultMaxMsgSize       = 1024 * 1024 * 10 // 10MB
+	defaultKeepaliveMinTime = 5 * time.Minute
+)
+
+// Options builds the gRPC server options for message limits and connection keepalive
+func Options(cfg *config.GRPCConfig) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(orDefault(cfg.MaxRecvMsgSize, defaultMaxMsgSize)),
+		grpc.MaxSendMsgSize(orDefault(cfg.MaxSendMsgSize, defaultMaxMsgSize)),
+		grpc.KeepaliveParams(KeepaliveParams(cfg)),
+		grpc.KeepaliveEnforcementPolicy(KeepaliveEnforcementPolicy(cfg)),
+	}
+}
+
+// KeepaliveParams closes idle and long-lived connections so clients reconnect and rebalance
+// Zero durations keep the gRPC defaults
+func KeepaliveParams(cfg *config.GRPCConfig) keepalive.ServerParameters {
+	return keepalive.ServerParameters{
+		MaxConnectionIdle:     cfg.MaxConnectionIdle,
+		MaxConnectionAge:      cfg.MaxConnectionAge,
+		MaxConnectionAgeGrace: cfg.MaxConnectionAgeGrace,
+		Time:                  cfg.KeepaliveTime,
+		Timeout:               cfg.KeepaliveTimeout,
+	}
+}
+
+// KeepaliveEnforcementPolicy disconnects clients that ping more often than keepalive_min_time
+// or send pings without any active stream
+func KeepaliveEnforcementPolicy(cfg *config.GRPCConfig) keepalive.EnforcementPolicy {
+	minTime := cfg.KeepaliveMinTime
+	if minTime <= 0 {
+		minTime = defaultKeepaliveMinTime
+	}
+	return keepalive.EnforcementPolicy{
+		MinTime:             minTime,
+		PermitWithoutStream: false,
+	}
+}
+
+// orDefault returns value when it is positive and fallback otherwise
+func orDefault(value, fallback int) int {
+	if value <= 0 {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gieart87/gohexaclean/internal/infra/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestKeepaliveParams_FromConfig(t *testing.T) {
+	cfg := &config.GRPCConfig{
+		MaxConnectionIdle:     5 * time.Minute,
+		MaxConnectionAge:      10 * time.Minute,
+		MaxConnectionAgeGrace: 30 * time.Second,
+		KeepaliveTime:         2 * time.Minute,
+		KeepaliveTimeout:      20 * time.Second,
+	}
+
+	assert.Equal(t, keepalive.ServerParameters{
+		MaxConnectionIdle:     5 * time.Minute,
+		MaxConnectionAge:      10 * time.Minute,
+		MaxConnectionAgeGrace: 30 * time.Second,
+		Time:                  2 * time.Minute,
+		Timeout:               20 * time.Second,
+	}, KeepaliveParams(cfg))
+}
+
+func TestKeepaliveEnforcementPolicy(t *testing.T) {
+	policy := KeepaliveEnforcementPolicy(&config.GRPCConfig{KeepaliveMinTime: time.Minute})
+	assert.Equal(t, time.Minute, policy.MinTime)
+	assert.False(t, policy.PermitWithoutStream)
+
+	policy = KeepaliveEnforcementPolicy(&config.GRPCConfig{})
+	assert.Equal(t, defaultKeepaliveMinTime, policy.MinTime)
+}
+
+func TestOptions_EnforcesMaxRecvMsgSize(t *testing.T) {
+	listener := bufconn.Listen(1024 * 1024)
+
+	grpcServer := grpc.NewServer(Options(&config.GRPCConfig{MaxRecvMsgSize: 1024})...)
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	client := healthpb.NewHealthClient(conn)
+
+	_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: strings.Repeat("a", 2048)})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	assert.NoError(t, err)
+}
+
+func TestOrDefault(t *testing.T) {
+	assert.Equal(t, 4096, orDefault(4096, defaultMaxMsgSize))
+	assert.Equal(t, defaultMaxMsgSize, orDefault(0, defaultMaxMsgSize))
+}
@@ -17,6 +17,63 @@ const (
 	BearerAuthScopes = "BearerAuth.Scopes"
 )
 
+// BulkCreateUsersRequest defines model for BulkCreateUsersRequest.
+type BulkCreateUsersRequest struct {
+	// Users Users to create
+	Users []CreateUserRequest `json:"users"`
+}
+
+// BulkCreateUsersResponse defines model for BulkCreateUsersResponse.
+type BulkCreateUsersResponse struct {
+	Data    *[]BulkItemResult `json:"data,omitempty"`
+	Message *string           `json:"message,omitempty"`
+	Meta    *struct {
+		RequestId *openapi_types.UUID `json:"request_id,omitempty"`
+		Timestamp *time.Time          `json:"timestamp,omitempty"`
+	} `json:"meta,omitempty"`
+	Success *bool `json:"success,omitempty"`
+}
+
+// BulkDeleteUsersRequest defines model for BulkDeleteUsersRequest.
+type BulkDeleteUsersRequest struct {
+	// Ids IDs of the users to delete
+	Ids []openapi_types.UUID `json:"ids"`
+}
+
+// BulkDeleteUsersResponse defines model for BulkDeleteUsersResponse.
+type BulkDeleteUsersResponse struct {
+	Data *struct {
+		// Deleted Number of users deleted
+		Deleted *int64 `json:"deleted,omitempty"`
+
+		// NotFound Requested IDs that matched no user
+		NotFound *[]openapi_types.UUID `json:"not_found,omitempty"`
+	} `json:"data,omitempty"`
+	Message *string `json:"message,omitempty"`
+	Meta    *struct {
+		RequestId *openapi_types.UUID `json:"request_id,omitempty"`
+		Timestamp *time.Time          `json:"timestamp,omitempty"`
+	} `json:"meta,omitempty"`
+	Success *bool `json:"success,omitempty"`
+}
+
+// BulkItemResult defines model for BulkItemResult.
+type BulkItemResult struct {
+	// Error Why the item failed, set when success is false
+	Error *string `json:"error,omitempty"`
+
+	// Index Position of the item in the request
+	Index   *int  `json:"index,omitempty"`
+	Success *bool `json:"success,omitempty"`
+	User    *User `json:"user,omitempty"`
+}
+
+// ChangeEmailRequest defines model for ChangeEmailRequest.
+type ChangeEmailRequest struct {
+	// Email New email address, used once verified
+	Email openapi_types.Email `json:"email"`
+}
+
 // CreateUserRequest defines model for CreateUserRequest.
 type CreateUserRequest struct {
 	// Email User email address
@@ -68,12 +125,36 @@ type LoginResponse struct {
 	Success *bool `json:"success,omitempty"`
 }
 
+// PaginatedUserEventResponse defines model for PaginatedUserEventResponse.
+type PaginatedUserEventResponse struct {
+	Data    *[]UserEvent `json:"data,omitempty"`
+	Message *string      `json:"message,omitempty"`
+	Meta    *struct {
+		Pagination *struct {
+			// HasNext Set when there is a page after this one
+			HasNext    *bool  `json:"has_next,omitempty"`
+			Page       *int   `json:"page,omitempty"`
+			PerPage    *int   `json:"per_page,omitempty"`
+			Total      *int64 `json:"total,omitempty"`
+			TotalPages *int   `json:"total_pages,omitempty"`
+		} `json:"pagination,omitempty"`
+		RequestId *openapi_types.UUID `json:"request_id,omitempty"`
+		Timestamp *time.Time          `json:"timestamp,omitempty"`
+	} `json:"meta,omitempty"`
+	Success *bool `json:"success,omitempty"`
+}
+
 // PaginatedUserResponse defines model for PaginatedUserResponse.
 type PaginatedUserResponse struct {
 	Data    *[]User `json:"data,omitempty"`
 	Message *string `json:"message,omitempty"`
 	Meta    *struct {
 		Pagination *struct {
+			// Approximate Set when total is estimated rather than counted
+			Approximate *bool `json:"approximate,omitempty"`
+
+			// HasNext Set when there is a page after this one
+			HasNext    *bool  `json:"has_next,omitempty"`
 			Page       *int   `json:"page,omitempty"`
 			PerPage    *int   `json:"per_page,omitempty"`
 			Total      *int64 `json:"total,omitempty"`
@@ -110,19 +191,54 @@ type User struct {
 	// Email User email address
 	Email *openapi_types.Email `json:"email,omitempty"`
 
+	// EmailVerified Whether the current email address has been verified
+	EmailVerified *bool `json:"email_verified,omitempty"`
+
 	// Id Unique user identifier
 	Id *openapi_types.UUID `json:"id,omitempty"`
 
 	// IsActive User active status
 	IsActive *bool `json:"is_active,omitempty"`
 
+	// LastLoginAt Last successful login timestamp, omitted if the user never logged in
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+
 	// Name User full name
 	Name *string `json:"name,omitempty"`
 
+	// PendingEmail Email address waiting for verification, omitted when no change is pending
+	PendingEmail *openapi_types.Email `json:"pending_email,omitempty"`
+
 	// UpdatedAt Last update timestamp
 	UpdatedAt *time.Time `json:"updated_at,omitempty"`
 }
 
+// UserEvent defines model for UserEvent.
+type UserEvent struct {
+	// Data The event as it was published
+	Data *map[string]interface{} `json:"data,omitempty"`
+
+	// Id Event identifier
+	Id *openapi_types.UUID `json:"id,omitempty"`
+
+	// OccurredAt When the event happened
+	OccurredAt *time.Time `json:"occurred_at,omitempty"`
+
+	// Type Event type (user.created, user.updated, user.logged_in, user.deleted)
+	Type *string `json:"type,omitempty"`
+}
+
+// UserListResponse defines model for UserListResponse.
+type UserListResponse struct {
+	Data    *[]User `json:"data,omitempty"`
+	Message *string `json:"message,omitempty"`
+	Meta    *struct {
+		RequestId *openapi_types.UUID `json:"request_id,omitempty"`
+		Timestamp *time.Time          `json:"timestamp,omitempty"`
+	} `json:"meta,omitempty"`
+	Success *bool `json:"success,omitempty"`
+}
+
 // UserResponse defines model for UserResponse.
 type UserResponse struct {
 	Data    *User   `json:"data,omitempty"`
@@ -134,15 +250,64 @@ type UserResponse struct {
 	Success *bool `json:"success,omitempty"`
 }
 
+// VerifyEmailRequest defines model for VerifyEmailRequest.
+type VerifyEmailRequest struct {
+	// Token Token mailed to the new email address
+	Token string `json:"token"`
+}
+
 // ListUsersParams defines parameters for ListUsers.
 type ListUsersParams struct {
 	// Page Page number
 	Page *int `form:"page,omitempty" json:"page,omitempty"`
 
-	// Limit Items per page
+	// Limit Items per page, capped at pagination.max_limit (100 unless configured)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Fields Comma-separated list of fields to return (e.g. id,name)
+	Fields *string `form:"fields,omitempty" json:"fields,omitempty"`
+
+	// Sort Sort field, prefix with - for descending (name, email, created_at, updated_at)
+	Sort *string `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// ApproxCount Estimate the total from table statistics instead of counting every row.
+	// Much faster on very large tables; the pagination meta is then marked `approximate`.
+	ApproxCount *bool `form:"approx_count,omitempty" json:"approx_count,omitempty"`
+}
+
+// SearchUsersParams defines parameters for SearchUsers.
+type SearchUsersParams struct {
+	// Q Search terms
+	Q string `form:"q" json:"q"`
+
+	// Limit Maximum number of results, capped at pagination.max_limit (100 unless configured)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetUserByIdParams defines parameters for GetUserById.
+type GetUserByIdParams struct {
+	// Include Comma-separated list of related resources to include
+	Include *string `form:"include,omitempty" json:"include,omitempty"`
+
+	// Fields Comma-separated list of fields to return (e.g. id,name)
+	Fields *string `form:"fields,omitempty" json:"fields,omitempty"`
+}
+
+// ListUserEventsParams defines parameters for ListUserEvents.
+type ListUserEventsParams struct {
+	// Page Page number
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// Limit Items per page, capped at pagination.max_limit (100 unless configured)
 	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
+// BulkCreateUsersJSONRequestBody defines body for BulkCreateUsers for application/json ContentType.
+type BulkCreateUsersJSONRequestBody = BulkCreateUsersRequest
+
+// BulkDeleteUsersJSONRequestBody defines body for BulkDeleteUsers for application/json ContentType.
+type BulkDeleteUsersJSONRequestBody = BulkDeleteUsersRequest
+
 // UpdateUserJSONRequestBody defines body for UpdateUser for application/json ContentType.
 type UpdateUserJSONRequestBody = UpdateUserRequest
 
@@ -152,26 +317,71 @@ type LoginJSONRequestBody = LoginRequest
 // RegisterJSONRequestBody defines body for Register for application/json ContentType.
 type RegisterJSONRequestBody = CreateUserRequest
 
+// ChangeEmailJSONRequestBody defines body for ChangeEmail for application/json ContentType.
+type ChangeEmailJSONRequestBody = ChangeEmailRequest
+
+// VerifyEmailJSONRequestBody defines body for VerifyEmail for application/json ContentType.
+type VerifyEmailJSONRequestBody = VerifyEmailRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// List users
 	// (GET /admin/users)
 	ListUsers(c *fiber.Ctx, params ListUsersParams) error
+	// Create many users at once
+	// (POST /admin/users/bulk-create)
+	BulkCreateUsers(c *fiber.Ctx) error
+	// Delete many users at once
+	// (POST /admin/users/bulk-delete)
+	BulkDeleteUsers(c *fiber.Ctx) error
+	// Export users as CSV
+	// (GET /admin/users/export)
+	ExportUsers(c *fiber.Ctx) error
+	// Export users as JSON Lines
+	// (GET /admin/users/export.jsonl)
+	ExportUsersJSONL(c *fiber.Ctx) error
+	// Search users
+	// (GET /admin/users/search)
+	SearchUsers(c *fiber.Ctx, params SearchUsersParams) error
 	// Delete user
 	// (DELETE /admin/users/{id})
 	DeleteUser(c *fiber.Ctx, id openapi_types.UUID) error
 	// Get user by ID
 	// (GET /admin/users/{id})
-	GetUserById(c *fiber.Ctx, id openapi_types.UUID) error
+	GetUserById(c *fiber.Ctx, id openapi_types.UUID, params GetUserByIdParams) error
 	// Update user
 	// (PUT /admin/users/{id})
 	UpdateUser(c *fiber.Ctx, id openapi_types.UUID) error
+	// Activate user
+	// (POST /admin/users/{id}/activate)
+	ActivateUser(c *fiber.Ctx, id openapi_types.UUID) error
+	// Deactivate user
+	// (POST /admin/users/{id}/deactivate)
+	DeactivateUser(c *fiber.Ctx, id openapi_types.UUID) error
+	// Revoke all tokens of a user
+	// (POST /admin/users/{id}/revoke-tokens)
+	RevokeUserTokens(c *fiber.Ctx, id openapi_types.UUID) error
 	// User login
 	// (POST /auth/login)
 	Login(c *fiber.Ctx) error
+	// Delete current user
+	// (DELETE /auth/me)
+	DeleteCurrentUser(c *fiber.Ctx) error
+	// Get current user
+	// (GET /auth/me)
+	GetCurrentUser(c *fiber.Ctx) error
 	// Register new user
 	// (POST /auth/register)
 	Register(c *fiber.Ctx) error
+	// Change a user's email
+	// (POST /users/{id}/email)
+	ChangeEmail(c *fiber.Ctx, id openapi_types.UUID) error
+	// Verify a pending email change
+	// (POST /users/{id}/email/verify)
+	VerifyEmail(c *fiber.Ctx, id openapi_types.UUID) error
+	// List a user's recent events
+	// (GET /users/{id}/events)
+	ListUserEvents(c *fiber.Ctx, id openapi_types.UUID, params ListUserEventsParams) error
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -211,9 +421,101 @@ func (siw *ServerInterfaceWrapper) ListUsers(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
 	}
 
+	// ------------- Optional query parameter "fields" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "fields", query, &params.Fields)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter fields: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", query, &params.Sort)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter sort: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "approx_count" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "approx_count", query, &params.ApproxCount)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter approx_count: %w", err).Error())
+	}
+
 	return siw.Handler.ListUsers(c, params)
 }
 
+// BulkCreateUsers operation middleware
+func (siw *ServerInterfaceWrapper) BulkCreateUsers(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.BulkCreateUsers(c)
+}
+
+// BulkDeleteUsers operation middleware
+func (siw *ServerInterfaceWrapper) BulkDeleteUsers(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.BulkDeleteUsers(c)
+}
+
+// ExportUsers operation middleware
+func (siw *ServerInterfaceWrapper) ExportUsers(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.ExportUsers(c)
+}
+
+// ExportUsersJSONL operation middleware
+func (siw *ServerInterfaceWrapper) ExportUsersJSONL(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.ExportUsersJSONL(c)
+}
+
+// SearchUsers operation middleware
+func (siw *ServerInterfaceWrapper) SearchUsers(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchUsersParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Required query parameter "q" -------------
+
+	if paramValue := c.Query("q"); paramValue != "" {
+
+	} else {
+		return fiber.NewError(fiber.StatusBadRequest, "Query argument q is required, but not found")
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "q", query, &params.Q)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter q: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	return siw.Handler.SearchUsers(c, params)
+}
+
 // DeleteUser operation middleware
 func (siw *ServerInterfaceWrapper) DeleteUser(c *fiber.Ctx) error {
 
@@ -247,7 +549,30 @@ func (siw *ServerInterfaceWrapper) GetUserById(c *fiber.Ctx) error {
 
 	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
-	return siw.Handler.GetUserById(c, id)
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUserByIdParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "include" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include", query, &params.Include)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter include: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "fields" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "fields", query, &params.Fields)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter fields: %w", err).Error())
+	}
+
+	return siw.Handler.GetUserById(c, id, params)
 }
 
 // UpdateUser operation middleware
@@ -268,18 +593,165 @@ func (siw *ServerInterfaceWrapper) UpdateUser(c *fiber.Ctx) error {
 	return siw.Handler.UpdateUser(c, id)
 }
 
+// ActivateUser operation middleware
+func (siw *ServerInterfaceWrapper) ActivateUser(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Params("id"), &id)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.ActivateUser(c, id)
+}
+
+// DeactivateUser operation middleware
+func (siw *ServerInterfaceWrapper) DeactivateUser(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Params("id"), &id)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.DeactivateUser(c, id)
+}
+
+// RevokeUserTokens operation middleware
+func (siw *ServerInterfaceWrapper) RevokeUserTokens(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Params("id"), &id)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.RevokeUserTokens(c, id)
+}
+
 // Login operation middleware
 func (siw *ServerInterfaceWrapper) Login(c *fiber.Ctx) error {
 
 	return siw.Handler.Login(c)
 }
 
+// DeleteCurrentUser operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCurrentUser(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.DeleteCurrentUser(c)
+}
+
+// GetCurrentUser operation middleware
+func (siw *ServerInterfaceWrapper) GetCurrentUser(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetCurrentUser(c)
+}
+
 // Register operation middleware
 func (siw *ServerInterfaceWrapper) Register(c *fiber.Ctx) error {
 
 	return siw.Handler.Register(c)
 }
 
+// ChangeEmail operation middleware
+func (siw *ServerInterfaceWrapper) ChangeEmail(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Params("id"), &id)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.ChangeEmail(c, id)
+}
+
+// VerifyEmail operation middleware
+func (siw *ServerInterfaceWrapper) VerifyEmail(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Params("id"), &id)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.VerifyEmail(c, id)
+}
+
+// ListUserEvents operation middleware
+func (siw *ServerInterfaceWrapper) ListUserEvents(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Params("id"), &id)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListUserEventsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	return siw.Handler.ListUserEvents(c, id, params)
+}
+
 // FiberServerOptions provides options for the Fiber server.
 type FiberServerOptions struct {
 	BaseURL     string
@@ -303,14 +775,40 @@ func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, option
 
 	router.Get(options.BaseURL+"/admin/users", wrapper.ListUsers)
 
+	router.Post(options.BaseURL+"/admin/users/bulk-create", wrapper.BulkCreateUsers)
+
+	router.Post(options.BaseURL+"/admin/users/bulk-delete", wrapper.BulkDeleteUsers)
+
+	router.Get(options.BaseURL+"/admin/users/export", wrapper.ExportUsers)
+
+	router.Get(options.BaseURL+"/admin/users/export.jsonl", wrapper.ExportUsersJSONL)
+
+	router.Get(options.BaseURL+"/admin/users/search", wrapper.SearchUsers)
+
 	router.Delete(options.BaseURL+"/admin/users/:id", wrapper.DeleteUser)
 
 	router.Get(options.BaseURL+"/admin/users/:id", wrapper.GetUserById)
 
 	router.Put(options.BaseURL+"/admin/users/:id", wrapper.UpdateUser)
 
+	router.Post(options.BaseURL+"/admin/users/:id/activate", wrapper.ActivateUser)
+
+	router.Post(options.BaseURL+"/admin/users/:id/deactivate", wrapper.DeactivateUser)
+
+	router.Post(options.BaseURL+"/admin/users/:id/revoke-tokens", wrapper.RevokeUserTokens)
+
 	router.Post(options.BaseURL+"/auth/login", wrapper.Login)
 
+	router.Delete(options.BaseURL+"/auth/me", wrapper.DeleteCurrentUser)
+
+	router.Get(options.BaseURL+"/auth/me", wrapper.GetCurrentUser)
+
 	router.Post(options.BaseURL+"/auth/register", wrapper.Register)
 
+	router.Post(options.BaseURL+"/users/:id/email", wrapper.ChangeEmail)
+
+	router.Post(options.BaseURL+"/users/:id/email/verify", wrapper.VerifyEmail)
+
+	router.Get(options.BaseURL+"/users/:id/events", wrapper.ListUserEvents)
+
 }
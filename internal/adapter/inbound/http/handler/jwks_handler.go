@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"github.com/gieart87/gohexaclean/pkg/auth"
+	"github.com/gofiber/fiber/v2"
+)
+
+// JWKSHandler publishes the public key other services verify our RS256 tokens with
+type JWKSHandler struct {
+	jwks auth.JWKS
+}
+
+// NewJWKSHandler creates a new JWKS handler for the configured signing keys
+func NewJWKSHandler(tokenOpts auth.TokenOptions) *JWKSHandler {
+	return &JWKSHandler{jwks: auth.NewJWKS(tokenOpts)}
+}
+
+// ServeJWKS serves the JSON Web Key Set
+// GET /.well-known/jwks.json
+func (h *JWKSHandler) ServeJWKS(c *fiber.Ctx) error {
+	// Keys only change on redeploy, verifiers may cache the set briefly
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return c.JSON(h.jwks)
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gieart87/gohexaclean/pkg/auth"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWKSHandler_ServeJWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	app := fiber.New()
+	app.Get("/.well-known/jwks.json", NewJWKSHandler(auth.TokenOptions{
+		Algorithm: auth.AlgorithmRS256,
+		PublicKey: &key.PublicKey,
+	}).ServeJWKS)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/.well-known/jwks.json", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "public, max-age=300", resp.Header.Get(fiber.HeaderCacheControl))
+
+	var jwks auth.JWKS
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&jwks))
+	require.Len(t, jwks.Keys, 1)
+	assert.Equal(t, auth.KeyID(&key.PublicKey), jwks.Keys[0].KeyID)
+	assert.Equal(t, "RSA", jwks.Keys[0].KeyType)
+	assert.Equal(t, "RS256", jwks.Keys[0].Algorithm)
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString(key.PublicKey.N.Bytes()), jwks.Keys[0].Modulus)
+	assert.Equal(t, "AQAB", jwks.Keys[0].Exponent)
+}
+
+func TestJWKSHandler_ServeJWKS_ListsRetiredKeys(t *testing.T) {
+	active, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	retired, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	app := fiber.New()
+	app.Get("/.well-known/jwks.json", NewJWKSHandler(auth.TokenOptions{
+		Algorithm:         auth.AlgorithmRS256,
+		PublicKey:         &active.PublicKey,
+		RetiredPublicKeys: []*rsa.PublicKey{&retired.PublicKey},
+	}).ServeJWKS)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/.well-known/jwks.json", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var jwks auth.JWKS
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&jwks))
+	require.Len(t, jwks.Keys, 2)
+	assert.Equal(t, auth.KeyID(&active.PublicKey), jwks.Keys[0].KeyID)
+	assert.Equal(t, auth.KeyID(&retired.PublicKey), jwks.Keys[1].KeyID)
+}
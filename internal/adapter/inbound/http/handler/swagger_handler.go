@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"github.com/gieart87/gohexaclean/api/openapi"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -68,3 +69,9 @@ func (h *SwaggerHandler) ServeSwaggerUI(c *fiber.Ctx) error {
 	c.Set("Content-Type", "text/html")
 	return c.SendString(html)
 }
+
+// ServeSpec serves the OpenAPI spec embedded at build time, so it doesn't depend on the working directory
+func (h *SwaggerHandler) ServeSpec(c *fiber.Ctx) error {
+	c.Set("Content-Type", "application/x-yaml")
+	return c.Send(openapi.UserAPISpec)
+}
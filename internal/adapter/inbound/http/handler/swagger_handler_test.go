@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwaggerHandler_ServeSpec_IndependentOfWorkingDirectory(t *testing.T) {
+	// The spec file isn't reachable from here, as in a container image that only ships the binary
+	t.Chdir(t.TempDir())
+
+	app := fiber.New()
+	app.Get("/swagger/spec", NewSwaggerHandler().ServeSpec)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/swagger/spec", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/x-yaml", resp.Header.Get("Content-Type"))
+	assert.Contains(t, string(body), "openapi:")
+	assert.Contains(t, string(body), "/auth/login:")
+}
@@ -0,0 +1,21 @@
+package user
+
+import (
+	"github.com/gieart87/gohexaclean/pkg/response"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// ActivateUser handles reactivating a deactivated user account
+// Protected endpoint - requires admin role
+// POST /admin/users/{id}/activate
+func (h *Handler) ActivateUser(c *fiber.Ctx, id openapi_types.UUID) error {
+	if err := h.userService.SetActive(c.UserContext(), uuid.UUID(id), true); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(
+			response.NewErrorResponse("Failed to activate user", err),
+		)
+	}
+
+	return response.Write(c, h.envelope(c), fiber.StatusOK, "User activated successfully", nil)
+}
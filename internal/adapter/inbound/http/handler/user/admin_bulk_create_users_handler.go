@@ -0,0 +1,35 @@
+package user
+
+import (
+	"github.com/gieart87/gohexaclean/internal/dto/request"
+	"github.com/gieart87/gohexaclean/pkg/httputil"
+	"github.com/gieart87/gohexaclean/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+// BulkCreateUsers handles creating many users at once, each item reports its own outcome
+// Protected endpoint - requires admin role
+// POST /admin/users/bulk-create
+func (h *Handler) BulkCreateUsers(c *fiber.Ctx) error {
+	// The passwords are checked against the configured policy rather than the default one
+	createReq, err := httputil.BindAndValidateWith(c, func(req request.BulkCreateUsersRequest) error {
+		return req.ValidateWithPolicy(h.passwordPolicy)
+	})
+	if err != nil {
+		return httputil.Respond(c, err)
+	}
+
+	users := make([]*request.CreateUserRequest, len(createReq.Users))
+	for i := range createReq.Users {
+		users[i] = &createReq.Users[i]
+	}
+
+	results, err := h.userService.CreateUsers(c.UserContext(), users)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			response.NewErrorResponse("Failed to create users", err),
+		)
+	}
+
+	return response.Write(c, h.envelope(c), fiber.StatusOK, "Users processed", results)
+}
@@ -0,0 +1,35 @@
+package user
+
+import (
+	"errors"
+
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/gieart87/gohexaclean/internal/dto/request"
+	"github.com/gieart87/gohexaclean/pkg/httputil"
+	"github.com/gieart87/gohexaclean/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+// BulkDeleteUsers handles deleting many users in one transaction
+// Protected endpoint - requires admin role
+// POST /admin/users/bulk-delete
+func (h *Handler) BulkDeleteUsers(c *fiber.Ctx) error {
+	deleteReq, err := httputil.BindAndValidate[request.BulkDeleteUsersRequest](c)
+	if err != nil {
+		return httputil.Respond(c, err)
+	}
+
+	result, err := h.userService.DeleteUsers(c.UserContext(), deleteReq.IDs)
+	if errors.Is(err, domain.ErrInvalidInput) {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			response.NewErrorResponse("Invalid bulk delete request", err),
+		)
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			response.NewErrorResponse("Failed to delete users", err),
+		)
+	}
+
+	return response.Write(c, h.envelope(c), fiber.StatusOK, "Users deleted successfully", result)
+}
@@ -0,0 +1,21 @@
+package user
+
+import (
+	"github.com/gieart87/gohexaclean/pkg/response"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// DeactivateUser handles temporarily disabling a user account and ending their sessions
+// Protected endpoint - requires admin role
+// POST /admin/users/{id}/deactivate
+func (h *Handler) DeactivateUser(c *fiber.Ctx, id openapi_types.UUID) error {
+	if err := h.userService.SetActive(c.UserContext(), uuid.UUID(id), false); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(
+			response.NewErrorResponse("Failed to deactivate user", err),
+		)
+	}
+
+	return response.Write(c, h.envelope(c), fiber.StatusOK, "User deactivated successfully", nil)
+}
@@ -11,13 +11,11 @@ import (
 // Protected endpoint - requires authentication
 // DELETE /users/{id}
 func (h *Handler) DeleteUser(c *fiber.Ctx, id openapi_types.UUID) error {
-	if err := h.userService.DeleteUser(c.Context(), uuid.UUID(id)); err != nil {
+	if err := h.userService.DeleteUser(c.UserContext(), uuid.UUID(id)); err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(
 			response.NewErrorResponse("Failed to delete user", err),
 		)
 	}
 
-	return c.JSON(
-		response.NewSuccessResponse("User deleted successfully", nil),
-	)
+	return response.Write(c, h.envelope(c), fiber.StatusOK, "User deleted successfully", nil)
 }
@@ -0,0 +1,41 @@
+package user
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+
+	dto "github.com/gieart87/gohexaclean/internal/dto/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ExportUsersJSONL streams every user as JSON Lines, one UserResponse object per line
+// Protected endpoint - requires admin role
+// GET /admin/users/export.jsonl
+func (h *Handler) ExportUsersJSONL(c *fiber.Ctx) error {
+	return streamExport(c, "application/x-ndjson", h.writeUsersJSONL)
+}
+
+// writeUsersJSONL writes one line per user, flushing after every batch so clients can process incrementally
+func (h *Handler) writeUsersJSONL(ctx context.Context, w *bufio.Writer) error {
+	// Encode terminates every value with a newline
+	enc := json.NewEncoder(w)
+
+	rows := 0
+	err := h.userService.StreamUsers(ctx, exportBatchSize, func(user *dto.UserResponse) error {
+		if err := enc.Encode(user); err != nil {
+			return err
+		}
+
+		rows++
+		if rows%exportBatchSize == 0 {
+			return w.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
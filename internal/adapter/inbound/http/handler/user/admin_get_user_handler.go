@@ -1,6 +1,11 @@
 package user
 
 import (
+	"strconv"
+
+	"github.com/gieart87/gohexaclean/internal/adapter/inbound/http/generated/userapi"
+	"github.com/gieart87/gohexaclean/internal/dto/request"
+	dto "github.com/gieart87/gohexaclean/internal/dto/response"
 	"github.com/gieart87/gohexaclean/pkg/response"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -9,16 +14,64 @@ import (
 
 // GetUserById handles getting user by ID
 // Protected endpoint - requires authentication
-// GET /users/{id}
-func (h *Handler) GetUserById(c *fiber.Ctx, id openapi_types.UUID) error {
-	user, err := h.userService.GetUserByID(c.Context(), uuid.UUID(id))
+// GET /users/{id}?include=...&fields=...
+// Responds 304 Not Modified when If-None-Match matches the user's current ETag
+func (h *Handler) GetUserById(c *fiber.Ctx, id openapi_types.UUID, params userapi.GetUserByIdParams) error {
+	var includes []string
+	if params.Include != nil {
+		var err error
+		includes, err = request.ParseIncludes(*params.Include, request.UserIncludes)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(
+				response.NewErrorResponse("Invalid include parameter", err),
+			)
+		}
+	}
+
+	fields, err := parseUserFields(params.Fields)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			response.NewErrorResponse("Invalid fields parameter", err),
+		)
+	}
+
+	user, err := h.userService.GetUserByID(c.UserContext(), uuid.UUID(id), includes...)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(
 			response.NewErrorResponse("User not found", err),
 		)
 	}
 
-	return c.JSON(
-		response.NewSuccessResponse("User retrieved successfully", user),
+	c.Set(fiber.HeaderETag, userETag(user, string(c.Request().URI().QueryString())))
+	if c.Fresh() {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	if len(fields) == 0 {
+		return response.Write(c, h.envelope(c), fiber.StatusOK, "User retrieved successfully", user)
+	}
+
+	selected, err := response.SelectFields(user, fields)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			response.NewErrorResponse("Failed to select fields", err),
+		)
+	}
+
+	return response.Write(c, h.envelope(c), fiber.StatusOK, "User retrieved successfully", selected)
+}
+
+// userETag identifies a user representation by its last modification and the query shaping the body
+func userETag(user *dto.UserResponse, query string) string {
+	lastLogin := ""
+	if user.LastLoginAt != nil {
+		lastLogin = strconv.FormatInt(user.LastLoginAt.UnixNano(), 10)
+	}
+
+	return response.WeakETag(
+		user.ID.String(),
+		strconv.FormatInt(user.UpdatedAt.UnixNano(), 10),
+		lastLogin,
+		query,
 	)
 }
@@ -2,40 +2,63 @@ package user
 
 import (
 	"github.com/gieart87/gohexaclean/internal/adapter/inbound/http/generated/userapi"
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/gieart87/gohexaclean/internal/dto/request"
 	"github.com/gieart87/gohexaclean/pkg/response"
 	"github.com/gofiber/fiber/v2"
 )
 
 // ListUsers handles listing users with pagination
 // Protected endpoint - requires authentication
-// GET /users
+// GET /users?fields=...&sort=-created_at&approx_count=true
 func (h *Handler) ListUsers(c *fiber.Ctx, params userapi.ListUsersParams) error {
-	page := 1
+	var page, limit int
 	if params.Page != nil {
 		page = *params.Page
 	}
-
-	limit := 10
 	if params.Limit != nil {
 		limit = *params.Limit
 	}
+	page, limit = h.pageBounds.Normalize(page, limit)
 
-	// Validate pagination params
-	if page < 1 {
-		page = 1
+	fields, err := parseUserFields(params.Fields)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			response.NewErrorResponse("Invalid fields parameter", err),
+		)
 	}
-	if limit < 1 || limit > 100 {
-		limit = 10
+
+	var rawSort string
+	if params.Sort != nil {
+		rawSort = *params.Sort
+	}
+	sort, err := request.ParseUserSort(rawSort)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			response.NewErrorResponse("Invalid sort parameter", err),
+		)
 	}
 
-	users, total, err := h.userService.ListUsers(c.Context(), page, limit)
+	opts := domain.ListOptions{ApproxCount: params.ApproxCount != nil && *params.ApproxCount}
+	users, total, err := h.userService.ListUsers(c.UserContext(), page, limit, sort, opts)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(
 			response.NewErrorResponse("Failed to list users", err),
 		)
 	}
 
-	return c.JSON(
-		response.NewPaginatedResponse("Users retrieved successfully", users, page, limit, total),
-	)
+	var data interface{} = users
+	if len(fields) > 0 {
+		selected, err := response.SelectFieldsEach(users, fields)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(
+				response.NewErrorResponse("Failed to select fields", err),
+			)
+		}
+		data = selected
+	}
+
+	resp := response.NewPaginatedResponse("Users retrieved successfully", data, page, limit, total)
+	resp.Meta.Pagination.Approximate = opts.ApproxCount
+	return response.WritePaginated(c, h.envelope(c), resp)
 }
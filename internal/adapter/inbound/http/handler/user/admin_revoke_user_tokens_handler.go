@@ -0,0 +1,21 @@
+package user
+
+import (
+	"github.com/gieart87/gohexaclean/pkg/response"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// RevokeUserTokens handles force-logout of a user by invalidating all their tokens
+// Protected endpoint - requires admin role
+// POST /admin/users/{id}/revoke-tokens
+func (h *Handler) RevokeUserTokens(c *fiber.Ctx, id openapi_types.UUID) error {
+	if err := h.userService.RevokeAllTokens(c.UserContext(), uuid.UUID(id)); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(
+			response.NewErrorResponse("Failed to revoke user tokens", err),
+		)
+	}
+
+	return response.Write(c, h.envelope(c), fiber.StatusOK, "User tokens revoked successfully", nil)
+}
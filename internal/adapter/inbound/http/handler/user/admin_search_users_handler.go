@@ -0,0 +1,40 @@
+package user
+
+import (
+	"errors"
+
+	"github.com/gieart87/gohexaclean/internal/adapter/inbound/http/generated/userapi"
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/gieart87/gohexaclean/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+// searchDefaultLimit returns more results by default than a listing page, ranked results thin out quickly
+const searchDefaultLimit = 20
+
+// SearchUsers handles ranked full-text search over user names and emails
+// Protected endpoint - requires admin role
+// GET /admin/users/search?q=...&limit=...
+func (h *Handler) SearchUsers(c *fiber.Ctx, params userapi.SearchUsersParams) error {
+	var limit int
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	searchBounds := h.pageBounds
+	searchBounds.DefaultLimit = searchBounds.Limit(searchDefaultLimit)
+	limit = searchBounds.Limit(limit)
+
+	users, err := h.userService.SearchUsers(c.UserContext(), params.Q, limit)
+	if errors.Is(err, domain.ErrInvalidInput) {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			response.NewErrorResponse("Invalid search query", err),
+		)
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			response.NewErrorResponse("Failed to search users", err),
+		)
+	}
+
+	return response.Write(c, h.envelope(c), fiber.StatusOK, "Users retrieved successfully", users)
+}
@@ -0,0 +1,28 @@
+package user
+
+import (
+	"github.com/gieart87/gohexaclean/pkg/response"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// DeleteCurrentUser handles deleting the account of the token holder
+// Protected endpoint - requires authentication
+// DELETE /auth/me
+func (h *Handler) DeleteCurrentUser(c *fiber.Ctx) error {
+	// Set by AuthMiddleware from the token claims, so only the caller's own account can be deleted here
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(
+			response.NewErrorResponse("Unauthorized", nil),
+		)
+	}
+
+	if err := h.userService.DeleteUser(c.UserContext(), userID); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(
+			response.NewErrorResponse("Failed to delete user", err),
+		)
+	}
+
+	return response.Write(c, h.envelope(c), fiber.StatusOK, "Account deleted successfully", nil)
+}
@@ -0,0 +1,29 @@
+package user
+
+import (
+	"github.com/gieart87/gohexaclean/pkg/response"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// GetCurrentUser handles fetching the profile of the token holder
+// Protected endpoint - requires authentication
+// GET /auth/me
+func (h *Handler) GetCurrentUser(c *fiber.Ctx) error {
+	// Set by AuthMiddleware from the token claims
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(
+			response.NewErrorResponse("Unauthorized", nil),
+		)
+	}
+
+	user, err := h.userService.GetUserByID(c.UserContext(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(
+			response.NewErrorResponse("User not found", err),
+		)
+	}
+
+	return response.Write(c, h.envelope(c), fiber.StatusOK, "User retrieved successfully", user)
+}
@@ -1,21 +1,60 @@
 package user
 
 import (
+	"strings"
+
 	"github.com/gieart87/gohexaclean/internal/adapter/inbound/http/generated/userapi"
+	dto "github.com/gieart87/gohexaclean/internal/dto/response"
 	"github.com/gieart87/gohexaclean/internal/port/inbound"
+	"github.com/gieart87/gohexaclean/pkg/pagination"
+	"github.com/gieart87/gohexaclean/pkg/response"
+	"github.com/gieart87/gohexaclean/pkg/validation"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
 // Handler implements userapi.ServerInterface for user-related endpoints
 type Handler struct {
-	userService inbound.UserServicePort
+	userService    inbound.UserServicePort
+	passwordPolicy validation.PasswordPolicy
+	pageBounds     pagination.Bounds
+	bareResponses  bool // success responses default to the bare data payload instead of the envelope
 }
 
 // NewHandler creates a new user handler that implements userapi.ServerInterface
-func NewHandler(userService inbound.UserServicePort) *Handler {
+func NewHandler(userService inbound.UserServicePort, passwordPolicy validation.PasswordPolicy, pageBounds pagination.Bounds, bareResponses bool) *Handler {
 	return &Handler{
-		userService: userService,
+		userService:    userService,
+		passwordPolicy: passwordPolicy,
+		pageBounds:     pageBounds,
+		bareResponses:  bareResponses,
 	}
 }
 
 // Ensure Handler implements ServerInterface at compile time
 var _ userapi.ServerInterface = (*Handler)(nil)
+
+// envelope reports whether the success response to c is enveloped, ?envelope= overrides the configured default
+func (h *Handler) envelope(c *fiber.Ctx) bool {
+	return response.WantsEnvelope(c, !h.bareResponses)
+}
+
+// registeredLocation returns the Location of user id registered through c: the user resource route,
+// under whatever base path the router mounts the register route on
+// The matched route is used rather than the request path, which may carry a trailing slash
+func registeredLocation(c *fiber.Ctx, id uuid.UUID) string {
+	return strings.TrimSuffix(c.Route().Path, "/auth/register") + "/admin/users/" + id.String()
+}
+
+// parseUserFields parses the optional fields parameter, rejecting names that aren't on UserResponse
+func parseUserFields(raw *string) ([]string, error) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	fields := response.ParseFields(*raw)
+	if err := response.ValidateFields(dto.UserResponse{}, fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
@@ -1,18 +1,26 @@
 package user
 
 import (
+	"bufio"
 	"bytes"
+	"context"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gieart87/gohexaclean/internal/adapter/inbound/http/generated/userapi"
 	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/gieart87/gohexaclean/internal/dto/request"
 	"github.com/gieart87/gohexaclean/internal/dto/response"
 	"github.com/gieart87/gohexaclean/internal/port/inbound/mock"
+	"github.com/gieart87/gohexaclean/pkg/pagination"
+	"github.com/gieart87/gohexaclean/pkg/validation"
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -24,7 +32,7 @@ import (
 func setupHandlerTest(t *testing.T) (*Handler, *mock.MockUserServicePort, *gomock.Controller, *fiber.App) {
 	ctrl := gomock.NewController(t)
 	mockService := mock.NewMockUserServicePort(ctrl)
-	handler := NewHandler(mockService)
+	handler := NewHandler(mockService, validation.DefaultPasswordPolicy(), pagination.Default, false)
 
 	app := fiber.New()
 
@@ -68,6 +76,7 @@ func TestHandler_Register(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "/admin/users/"+userResp.ID.String(), resp.Header.Get("Location"))
 
 	body, _ := io.ReadAll(resp.Body)
 	var result map[string]interface{}
@@ -121,6 +130,37 @@ func TestHandler_CreateUser_ValidationError_ShortPassword(t *testing.T) {
 	assert.Equal(t, "VALIDATION_ERROR", result["error_code"])
 }
 
+func TestHandler_Register_PasswordPolicyViolation(t *testing.T) {
+	_, mockService, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	handler := NewHandler(mockService, validation.PasswordPolicy{MinLength: 8, RejectCommon: true}, pagination.Default, false)
+	app.Post("/auth/register", handler.Register)
+
+	req := userapi.CreateUserRequest{
+		Email:    "test@example.com",
+		Name:     "Test User",
+		Password: "password123",
+	}
+
+	reqBody, _ := json.Marshal(req)
+	httpReq, _ := http.NewRequest(http.MethodPost, "/auth/register", bytes.NewReader(reqBody))
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusUnprocessableEntity, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &result))
+
+	errs, ok := result["errors"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, []interface{}{"password is too common"}, errs["password"])
+}
+
 func TestHandler_CreateUser_ValidationError_ShortName(t *testing.T) {
 	handler, _, ctrl, app := setupHandlerTest(t)
 	defer ctrl.Finish()
@@ -289,13 +329,109 @@ func TestHandler_Login_InvalidCredentials(t *testing.T) {
 	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
 }
 
+func TestHandler_GetCurrentUser(t *testing.T) {
+	handler, mockService, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	app.Get("/auth/me", func(c *fiber.Ctx) error {
+		// Simulate AuthMiddleware having validated the token
+		c.Locals("userID", userID)
+		return c.Next()
+	}, handler.GetCurrentUser)
+
+	userResp := &response.UserResponse{
+		ID:        userID,
+		Email:     "me@example.com",
+		Name:      "Current User",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	mockService.EXPECT().
+		GetUserByID(gomock.Any(), userID).
+		Return(userResp, nil)
+
+	httpReq, _ := http.NewRequest(http.MethodGet, "/auth/me", nil)
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result map[string]interface{}
+	json.Unmarshal(body, &result)
+
+	assert.Equal(t, "User retrieved successfully", result["message"])
+	data, ok := result["data"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, userID.String(), data["id"])
+}
+
+func TestHandler_GetCurrentUser_Unauthenticated(t *testing.T) {
+	handler, _, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	app.Get("/auth/me", handler.GetCurrentUser)
+
+	httpReq, _ := http.NewRequest(http.MethodGet, "/auth/me", nil)
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestHandler_DeleteCurrentUser(t *testing.T) {
+	handler, mockService, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	app.Delete("/auth/me", func(c *fiber.Ctx) error {
+		// Simulate AuthMiddleware having validated the token
+		c.Locals("userID", userID)
+		return c.Next()
+	}, handler.DeleteCurrentUser)
+
+	// Only the token holder's own account is deleted
+	mockService.EXPECT().DeleteUser(gomock.Any(), userID).Return(nil)
+
+	httpReq, _ := http.NewRequest(http.MethodDelete, "/auth/me", nil)
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result map[string]interface{}
+	json.Unmarshal(body, &result)
+
+	assert.Equal(t, "Account deleted successfully", result["message"])
+}
+
+func TestHandler_DeleteCurrentUser_Unauthenticated(t *testing.T) {
+	handler, _, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	app.Delete("/auth/me", handler.DeleteCurrentUser)
+
+	httpReq, _ := http.NewRequest(http.MethodDelete, "/auth/me", nil)
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+}
+
 func TestHandler_GetUserById(t *testing.T) {
 	handler, mockService, ctrl, app := setupHandlerTest(t)
 	defer ctrl.Finish()
 
 	userID := uuid.New()
 	app.Get("/admin/users/:id", func(c *fiber.Ctx) error {
-		return handler.GetUserById(c, openapi_types.UUID(userID))
+		return handler.GetUserById(c, openapi_types.UUID(userID), userapi.GetUserByIdParams{})
 	})
 
 	userResp := &response.UserResponse{
@@ -325,13 +461,159 @@ func TestHandler_GetUserById(t *testing.T) {
 	assert.NotNil(t, result["data"])
 }
 
+func TestHandler_GetUserById_Envelope(t *testing.T) {
+	tests := []struct {
+		name          string
+		bareResponses bool
+		query         string
+		wantEnvelope  bool
+	}{
+		{name: "enveloped by default", wantEnvelope: true},
+		{name: "bare on request", query: "?envelope=false", wantEnvelope: false},
+		{name: "bare by config", bareResponses: true, wantEnvelope: false},
+		{name: "enveloped on request despite config", bareResponses: true, query: "?envelope=true", wantEnvelope: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockService := mock.NewMockUserServicePort(ctrl)
+			handler := NewHandler(mockService, validation.DefaultPasswordPolicy(), pagination.Default, tt.bareResponses)
+
+			userID := uuid.New()
+			app := fiber.New()
+			app.Get("/admin/users/:id", func(c *fiber.Ctx) error {
+				return handler.GetUserById(c, openapi_types.UUID(userID), userapi.GetUserByIdParams{})
+			})
+
+			mockService.EXPECT().
+				GetUserByID(gomock.Any(), userID).
+				Return(&response.UserResponse{ID: userID, Email: "test@example.com", Name: "Test User"}, nil)
+
+			httpReq, _ := http.NewRequest(http.MethodGet, "/admin/users/"+userID.String()+tt.query, nil)
+			resp, err := app.Test(httpReq)
+			require.NoError(t, err)
+			assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+
+			user := body
+			if tt.wantEnvelope {
+				assert.Equal(t, true, body["success"])
+				assert.Equal(t, "User retrieved successfully", body["message"])
+				require.IsType(t, map[string]interface{}{}, body["data"])
+				user = body["data"].(map[string]interface{})
+			} else {
+				assert.NotContains(t, body, "success")
+				assert.NotContains(t, body, "data")
+			}
+			assert.Equal(t, userID.String(), user["id"])
+			assert.Equal(t, "test@example.com", user["email"])
+		})
+	}
+}
+
+func TestHandler_GetUserById_BareKeepsErrorEnvelope(t *testing.T) {
+	handler, mockService, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	app.Get("/admin/users/:id", func(c *fiber.Ctx) error {
+		return handler.GetUserById(c, openapi_types.UUID(userID), userapi.GetUserByIdParams{})
+	})
+
+	mockService.EXPECT().
+		GetUserByID(gomock.Any(), userID).
+		Return(nil, domain.ErrUserNotFound)
+
+	httpReq, _ := http.NewRequest(http.MethodGet, "/admin/users/"+userID.String()+"?envelope=false", nil)
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+
+	var body map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, false, body["success"])
+	assert.Equal(t, "User not found", body["message"])
+}
+
+func TestHandler_GetUserById_ETag(t *testing.T) {
+	handler, mockService, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	app.Get("/admin/users/:id", func(c *fiber.Ctx) error {
+		return handler.GetUserById(c, openapi_types.UUID(userID), userapi.GetUserByIdParams{})
+	})
+
+	userResp := &response.UserResponse{
+		ID:        userID,
+		Email:     "test@example.com",
+		Name:      "Test User",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	mockService.EXPECT().
+		GetUserByID(gomock.Any(), userID).
+		Return(userResp, nil).
+		Times(2)
+
+	// First request returns the body along with its ETag
+	httpReq, _ := http.NewRequest(http.MethodGet, "/admin/users/"+userID.String(), nil)
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	etag := resp.Header.Get(fiber.HeaderETag)
+	require.NotEmpty(t, etag)
+
+	// Conditional request with the same ETag is answered without a body
+	httpReq, _ = http.NewRequest(http.MethodGet, "/admin/users/"+userID.String(), nil)
+	httpReq.Header.Set(fiber.HeaderIfNoneMatch, etag)
+	resp, err = app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusNotModified, resp.StatusCode)
+	assert.Equal(t, etag, resp.Header.Get(fiber.HeaderETag))
+	body, _ := io.ReadAll(resp.Body)
+	assert.Empty(t, body)
+}
+
+func TestHandler_GetUserById_ETagChangesAfterUpdate(t *testing.T) {
+	handler, mockService, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	app.Get("/admin/users/:id", func(c *fiber.Ctx) error {
+		return handler.GetUserById(c, openapi_types.UUID(userID), userapi.GetUserByIdParams{})
+	})
+
+	updatedAt := time.Now()
+	staleETag := userETag(&response.UserResponse{ID: userID, UpdatedAt: updatedAt.Add(-time.Minute)}, "")
+
+	mockService.EXPECT().
+		GetUserByID(gomock.Any(), userID).
+		Return(&response.UserResponse{ID: userID, UpdatedAt: updatedAt}, nil)
+
+	httpReq, _ := http.NewRequest(http.MethodGet, "/admin/users/"+userID.String(), nil)
+	httpReq.Header.Set(fiber.HeaderIfNoneMatch, staleETag)
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.NotEqual(t, staleETag, resp.Header.Get(fiber.HeaderETag))
+}
+
 func TestHandler_GetUserById_NotFound(t *testing.T) {
 	handler, mockService, ctrl, app := setupHandlerTest(t)
 	defer ctrl.Finish()
 
 	userID := uuid.New()
 	app.Get("/admin/users/:id", func(c *fiber.Ctx) error {
-		return handler.GetUserById(c, openapi_types.UUID(userID))
+		return handler.GetUserById(c, openapi_types.UUID(userID), userapi.GetUserByIdParams{})
 	})
 
 	mockService.EXPECT().
@@ -346,6 +628,98 @@ func TestHandler_GetUserById_NotFound(t *testing.T) {
 	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
 }
 
+func TestHandler_GetUserById_WithInclude(t *testing.T) {
+	handler, mockService, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	originalIncludes := request.UserIncludes
+	request.UserIncludes = []string{"roles"}
+	t.Cleanup(func() { request.UserIncludes = originalIncludes })
+
+	userID := uuid.New()
+	include := "roles"
+	app.Get("/admin/users/:id", func(c *fiber.Ctx) error {
+		return handler.GetUserById(c, openapi_types.UUID(userID), userapi.GetUserByIdParams{Include: &include})
+	})
+
+	mockService.EXPECT().
+		GetUserByID(gomock.Any(), userID, "roles").
+		Return(&response.UserResponse{ID: userID}, nil)
+
+	httpReq, _ := http.NewRequest(http.MethodGet, "/admin/users/"+userID.String()+"?include=roles", nil)
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestHandler_GetUserById_InvalidInclude(t *testing.T) {
+	handler, _, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	include := "password_resets"
+	app.Get("/admin/users/:id", func(c *fiber.Ctx) error {
+		return handler.GetUserById(c, openapi_types.UUID(userID), userapi.GetUserByIdParams{Include: &include})
+	})
+
+	// Service must not be called for an unsupported include
+	httpReq, _ := http.NewRequest(http.MethodGet, "/admin/users/"+userID.String()+"?include=password_resets", nil)
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestHandler_GetUserById_WithFields(t *testing.T) {
+	handler, mockService, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	fields := "id,name"
+	app.Get("/admin/users/:id", func(c *fiber.Ctx) error {
+		return handler.GetUserById(c, openapi_types.UUID(userID), userapi.GetUserByIdParams{Fields: &fields})
+	})
+
+	mockService.EXPECT().
+		GetUserByID(gomock.Any(), userID).
+		Return(&response.UserResponse{ID: userID, Email: "test@example.com", Name: "Test User"}, nil)
+
+	httpReq, _ := http.NewRequest(http.MethodGet, "/admin/users/"+userID.String()+"?fields=id,name", nil)
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &result))
+
+	assert.Equal(t, map[string]interface{}{"id": userID.String(), "name": "Test User"}, result["data"])
+}
+
+func TestHandler_GetUserById_InvalidFields(t *testing.T) {
+	handler, _, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	fields := "id,password"
+	app.Get("/admin/users/:id", func(c *fiber.Ctx) error {
+		return handler.GetUserById(c, openapi_types.UUID(userID), userapi.GetUserByIdParams{Fields: &fields})
+	})
+
+	// Service must not be called for an unknown field
+	httpReq, _ := http.NewRequest(http.MethodGet, "/admin/users/"+userID.String()+"?fields=id,password", nil)
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
 func TestHandler_UpdateUser(t *testing.T) {
 	handler, mockService, ctrl, app := setupHandlerTest(t)
 	defer ctrl.Finish()
@@ -490,45 +864,345 @@ func TestHandler_DeleteUser(t *testing.T) {
 	assert.Equal(t, "User deleted successfully", result["message"])
 }
 
-func TestHandler_DeleteUser_NotFound(t *testing.T) {
+func TestHandler_BulkCreateUsers(t *testing.T) {
 	handler, mockService, ctrl, app := setupHandlerTest(t)
 	defer ctrl.Finish()
 
-	userID := uuid.New()
-	app.Delete("/admin/users/:id", func(c *fiber.Ctx) error {
-		return handler.DeleteUser(c, openapi_types.UUID(userID))
-	})
+	app.Post("/admin/users/bulk-create", handler.BulkCreateUsers)
 
+	created := &response.UserResponse{ID: uuid.New(), Email: "jane@example.com", Name: "Jane Doe"}
 	mockService.EXPECT().
-		DeleteUser(gomock.Any(), userID).
-		Return(domain.ErrUserNotFound)
-
-	httpReq, _ := http.NewRequest(http.MethodDelete, "/admin/users/"+userID.String(), nil)
+		CreateUsers(gomock.Any(), []*request.CreateUserRequest{
+			{Email: "jane@example.com", Name: "Jane Doe", Password: "password123"},
+			{Email: "john@example.com", Name: "John Doe", Password: "password123"},
+		}).
+		Return([]*response.BulkItemResult{
+			{Index: 0, Success: true, User: created},
+			{Index: 1, Success: false, Error: domain.ErrUserAlreadyExists.Error()},
+		}, nil)
+
+	// Emails are normalized like a single registration's
+	reqBody, _ := json.Marshal(map[string]interface{}{"users": []map[string]string{
+		{"email": " Jane@Example.com ", "name": "Jane Doe", "password": "password123"},
+		{"email": "john@example.com", "name": "John Doe", "password": "password123"},
+	}})
+	httpReq, _ := http.NewRequest(http.MethodPost, "/admin/users/bulk-create", bytes.NewReader(reqBody))
+	httpReq.Header.Set("Content-Type", "application/json")
 
 	resp, err := app.Test(httpReq)
 	require.NoError(t, err)
 
-	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Message string                     `json:"message"`
+		Data    []*response.BulkItemResult `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+
+	assert.Equal(t, "Users processed", result.Message)
+	require.Len(t, result.Data, 2)
+	assert.True(t, result.Data[0].Success)
+	assert.Equal(t, created.ID, result.Data[0].User.ID)
+	assert.False(t, result.Data[1].Success)
+	assert.Equal(t, domain.ErrUserAlreadyExists.Error(), result.Data[1].Error)
 }
 
-func TestHandler_ListUsers(t *testing.T) {
-	handler, mockService, ctrl, app := setupHandlerTest(t)
+func TestHandler_BulkCreateUsers_PasswordPolicyViolation(t *testing.T) {
+	_, mockService, ctrl, app := setupHandlerTest(t)
 	defer ctrl.Finish()
 
-	page := 1
-	limit := 10
+	handler := NewHandler(mockService, validation.PasswordPolicy{MinLength: 8, RejectCommon: true}, pagination.Default, false)
+	app.Post("/admin/users/bulk-create", handler.BulkCreateUsers)
 
-	app.Get("/admin/users", func(c *fiber.Ctx) error {
-		params := userapi.ListUsersParams{
-			Page:  &page,
-			Limit: &limit,
-		}
-		return handler.ListUsers(c, params)
-	})
+	reqBody, _ := json.Marshal(map[string]interface{}{"users": []userapi.CreateUserRequest{
+		{Email: "jane@example.com", Name: "Jane Doe", Password: "password123"},
+	}})
+	httpReq, _ := http.NewRequest(http.MethodPost, "/admin/users/bulk-create", bytes.NewReader(reqBody))
+	httpReq.Header.Set("Content-Type", "application/json")
 
-	users := []*response.UserResponse{
-		{
-			ID:        uuid.New(),
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusUnprocessableEntity, resp.StatusCode)
+
+	var result struct {
+		Errors map[string][]string `json:"errors"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	require.Len(t, result.Errors["users"], 1)
+	assert.Contains(t, result.Errors["users"][0], "password is too common")
+}
+
+func TestHandler_BulkCreateUsers_ValidationError(t *testing.T) {
+	handler, _, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	app.Post("/admin/users/bulk-create", handler.BulkCreateUsers)
+
+	httpReq, _ := http.NewRequest(http.MethodPost, "/admin/users/bulk-create", bytes.NewReader([]byte(`{"users":[]}`)))
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusUnprocessableEntity, resp.StatusCode)
+}
+
+func TestHandler_BulkCreateUsers_ServiceError(t *testing.T) {
+	handler, mockService, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	app.Post("/admin/users/bulk-create", handler.BulkCreateUsers)
+
+	mockService.EXPECT().
+		CreateUsers(gomock.Any(), gomock.Any()).
+		Return(nil, context.Canceled)
+
+	reqBody, _ := json.Marshal(map[string]interface{}{"users": []userapi.CreateUserRequest{
+		{Email: "jane@example.com", Name: "Jane Doe", Password: "password123"},
+	}})
+	httpReq, _ := http.NewRequest(http.MethodPost, "/admin/users/bulk-create", bytes.NewReader(reqBody))
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+}
+
+func TestHandler_BulkDeleteUsers(t *testing.T) {
+	handler, mockService, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	app.Post("/admin/users/bulk-delete", handler.BulkDeleteUsers)
+
+	deletedID := uuid.New()
+	missingID := uuid.New()
+
+	mockService.EXPECT().
+		DeleteUsers(gomock.Any(), []uuid.UUID{deletedID, missingID}).
+		Return(&response.BulkDeleteResponse{Deleted: 1, NotFound: []uuid.UUID{missingID}}, nil)
+
+	reqBody, _ := json.Marshal(map[string]interface{}{"ids": []uuid.UUID{deletedID, missingID}})
+	httpReq, _ := http.NewRequest(http.MethodPost, "/admin/users/bulk-delete", bytes.NewReader(reqBody))
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Message string                      `json:"message"`
+		Data    response.BulkDeleteResponse `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+
+	assert.Equal(t, "Users deleted successfully", result.Message)
+	assert.Equal(t, int64(1), result.Data.Deleted)
+	assert.Equal(t, []uuid.UUID{missingID}, result.Data.NotFound)
+}
+
+func TestHandler_BulkDeleteUsers_ValidationError(t *testing.T) {
+	handler, _, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	app.Post("/admin/users/bulk-delete", handler.BulkDeleteUsers)
+
+	httpReq, _ := http.NewRequest(http.MethodPost, "/admin/users/bulk-delete", bytes.NewReader([]byte(`{"ids":[]}`)))
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusUnprocessableEntity, resp.StatusCode)
+}
+
+func TestHandler_BulkDeleteUsers_ServiceError(t *testing.T) {
+	handler, mockService, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	app.Post("/admin/users/bulk-delete", handler.BulkDeleteUsers)
+
+	mockService.EXPECT().
+		DeleteUsers(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("database unavailable"))
+
+	reqBody, _ := json.Marshal(map[string]interface{}{"ids": []uuid.UUID{uuid.New()}})
+	httpReq, _ := http.NewRequest(http.MethodPost, "/admin/users/bulk-delete", bytes.NewReader(reqBody))
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+}
+
+func TestHandler_DeleteUser_NotFound(t *testing.T) {
+	handler, mockService, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	app.Delete("/admin/users/:id", func(c *fiber.Ctx) error {
+		return handler.DeleteUser(c, openapi_types.UUID(userID))
+	})
+
+	mockService.EXPECT().
+		DeleteUser(gomock.Any(), userID).
+		Return(domain.ErrUserNotFound)
+
+	httpReq, _ := http.NewRequest(http.MethodDelete, "/admin/users/"+userID.String(), nil)
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestHandler_RevokeUserTokens(t *testing.T) {
+	handler, mockService, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	app.Post("/admin/users/:id/revoke-tokens", func(c *fiber.Ctx) error {
+		return handler.RevokeUserTokens(c, openapi_types.UUID(userID))
+	})
+
+	mockService.EXPECT().
+		RevokeAllTokens(gomock.Any(), userID).
+		Return(nil)
+
+	httpReq, _ := http.NewRequest(http.MethodPost, "/admin/users/"+userID.String()+"/revoke-tokens", nil)
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestHandler_RevokeUserTokens_NotFound(t *testing.T) {
+	handler, mockService, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	app.Post("/admin/users/:id/revoke-tokens", func(c *fiber.Ctx) error {
+		return handler.RevokeUserTokens(c, openapi_types.UUID(userID))
+	})
+
+	mockService.EXPECT().
+		RevokeAllTokens(gomock.Any(), userID).
+		Return(domain.ErrUserNotFound)
+
+	httpReq, _ := http.NewRequest(http.MethodPost, "/admin/users/"+userID.String()+"/revoke-tokens", nil)
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestHandler_Login_AccountInactive(t *testing.T) {
+	handler, mockService, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	app.Post("/auth/login", handler.Login)
+
+	mockService.EXPECT().
+		Login(gomock.Any(), gomock.Any()).
+		Return(nil, domain.ErrAccountInactive)
+
+	reqBody, _ := json.Marshal(userapi.LoginRequest{Email: "test@example.com", Password: "password123"})
+	httpReq, _ := http.NewRequest(http.MethodPost, "/auth/login", bytes.NewReader(reqBody))
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+}
+
+func TestHandler_DeactivateUser(t *testing.T) {
+	handler, mockService, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	app.Post("/admin/users/:id/deactivate", func(c *fiber.Ctx) error {
+		return handler.DeactivateUser(c, openapi_types.UUID(userID))
+	})
+
+	mockService.EXPECT().
+		SetActive(gomock.Any(), userID, false).
+		Return(nil)
+
+	httpReq, _ := http.NewRequest(http.MethodPost, "/admin/users/"+userID.String()+"/deactivate", nil)
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestHandler_ActivateUser(t *testing.T) {
+	handler, mockService, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	app.Post("/admin/users/:id/activate", func(c *fiber.Ctx) error {
+		return handler.ActivateUser(c, openapi_types.UUID(userID))
+	})
+
+	mockService.EXPECT().
+		SetActive(gomock.Any(), userID, true).
+		Return(nil)
+
+	httpReq, _ := http.NewRequest(http.MethodPost, "/admin/users/"+userID.String()+"/activate", nil)
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestHandler_ActivateUser_NotFound(t *testing.T) {
+	handler, mockService, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	app.Post("/admin/users/:id/activate", func(c *fiber.Ctx) error {
+		return handler.ActivateUser(c, openapi_types.UUID(userID))
+	})
+
+	mockService.EXPECT().
+		SetActive(gomock.Any(), userID, true).
+		Return(domain.ErrUserNotFound)
+
+	httpReq, _ := http.NewRequest(http.MethodPost, "/admin/users/"+userID.String()+"/activate", nil)
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestHandler_ListUsers(t *testing.T) {
+	handler, mockService, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	page := 1
+	limit := 10
+
+	app.Get("/admin/users", func(c *fiber.Ctx) error {
+		params := userapi.ListUsersParams{
+			Page:  &page,
+			Limit: &limit,
+		}
+		return handler.ListUsers(c, params)
+	})
+
+	users := []*response.UserResponse{
+		{
+			ID:        uuid.New(),
 			Email:     "user1@example.com",
 			Name:      "User 1",
 			CreatedAt: time.Now(),
@@ -544,7 +1218,7 @@ func TestHandler_ListUsers(t *testing.T) {
 	}
 
 	mockService.EXPECT().
-		ListUsers(gomock.Any(), page, limit).
+		ListUsers(gomock.Any(), page, limit, domain.DefaultUserSort, domain.ListOptions{}).
 		Return(users, int64(2), nil)
 
 	httpReq, _ := http.NewRequest(http.MethodGet, "/admin/users?page=1&limit=10", nil)
@@ -562,6 +1236,34 @@ func TestHandler_ListUsers(t *testing.T) {
 	assert.NotNil(t, result["data"])
 }
 
+func TestHandler_ListUsers_ApproxCount(t *testing.T) {
+	handler, mockService, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	approx := true
+	app.Get("/admin/users", func(c *fiber.Ctx) error {
+		return handler.ListUsers(c, userapi.ListUsersParams{ApproxCount: &approx})
+	})
+
+	mockService.EXPECT().
+		ListUsers(gomock.Any(), 1, 10, domain.DefaultUserSort, domain.ListOptions{ApproxCount: true}).
+		Return([]*response.UserResponse{}, int64(1250000), nil)
+
+	httpReq, _ := http.NewRequest(http.MethodGet, "/admin/users?approx_count=true", nil)
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var result userapi.PaginatedUserResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	require.NotNil(t, result.Meta)
+	require.NotNil(t, result.Meta.Pagination)
+	assert.Equal(t, int64(1250000), *result.Meta.Pagination.Total)
+	require.NotNil(t, result.Meta.Pagination.Approximate)
+	assert.True(t, *result.Meta.Pagination.Approximate)
+}
+
 func TestHandler_ListUsers_DefaultPagination(t *testing.T) {
 	handler, mockService, ctrl, app := setupHandlerTest(t)
 	defer ctrl.Finish()
@@ -574,7 +1276,7 @@ func TestHandler_ListUsers_DefaultPagination(t *testing.T) {
 	users := []*response.UserResponse{}
 
 	mockService.EXPECT().
-		ListUsers(gomock.Any(), 1, 10).
+		ListUsers(gomock.Any(), 1, 10, domain.DefaultUserSort, domain.ListOptions{}).
 		Return(users, int64(0), nil)
 
 	httpReq, _ := http.NewRequest(http.MethodGet, "/admin/users", nil)
@@ -602,9 +1304,9 @@ func TestHandler_ListUsers_InvalidPagination(t *testing.T) {
 
 	users := []*response.UserResponse{}
 
-	// Should normalize to page=1, limit=10
+	// Should normalize to page=1 and cap the limit at 100
 	mockService.EXPECT().
-		ListUsers(gomock.Any(), 1, 10).
+		ListUsers(gomock.Any(), 1, pagination.MaxLimit, domain.DefaultUserSort, domain.ListOptions{}).
 		Return(users, int64(0), nil)
 
 	httpReq, _ := http.NewRequest(http.MethodGet, "/admin/users", nil)
@@ -615,6 +1317,134 @@ func TestHandler_ListUsers_InvalidPagination(t *testing.T) {
 	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
 }
 
+func TestHandler_ListUsers_ConfiguredBounds(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     *int
+		wantLimit int
+	}{
+		{name: "no limit uses the configured default", wantLimit: 25},
+		{name: "over the configured max is capped", limit: func() *int { l := 500; return &l }(), wantLimit: 250},
+		{name: "above the package max within the configured one", limit: func() *int { l := 150; return &l }(), wantLimit: 150},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mock.NewMockUserServicePort(ctrl)
+			handler := NewHandler(mockService, validation.DefaultPasswordPolicy(), pagination.Bounds{DefaultLimit: 25, MaxLimit: 250}, false)
+
+			app := fiber.New()
+			app.Get("/admin/users", func(c *fiber.Ctx) error {
+				return handler.ListUsers(c, userapi.ListUsersParams{Limit: tt.limit})
+			})
+
+			mockService.EXPECT().
+				ListUsers(gomock.Any(), 1, tt.wantLimit, domain.DefaultUserSort, domain.ListOptions{}).
+				Return([]*response.UserResponse{}, int64(0), nil)
+
+			httpReq, _ := http.NewRequest(http.MethodGet, "/admin/users", nil)
+			resp, err := app.Test(httpReq)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		})
+	}
+}
+
+func TestHandler_ListUsers_WithFields(t *testing.T) {
+	handler, mockService, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	fields := "id"
+	app.Get("/admin/users", func(c *fiber.Ctx) error {
+		return handler.ListUsers(c, userapi.ListUsersParams{Fields: &fields})
+	})
+
+	users := []*response.UserResponse{
+		{ID: uuid.New(), Email: "user1@example.com", Name: "User 1"},
+		{ID: uuid.New(), Email: "user2@example.com", Name: "User 2"},
+	}
+
+	mockService.EXPECT().
+		ListUsers(gomock.Any(), 1, 10, domain.DefaultUserSort, domain.ListOptions{}).
+		Return(users, int64(2), nil)
+
+	httpReq, _ := http.NewRequest(http.MethodGet, "/admin/users?fields=id", nil)
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &result))
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"id": users[0].ID.String()},
+		map[string]interface{}{"id": users[1].ID.String()},
+	}, result["data"])
+}
+
+func TestHandler_ListUsers_InvalidFields(t *testing.T) {
+	handler, _, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	fields := "nickname"
+	app.Get("/admin/users", func(c *fiber.Ctx) error {
+		return handler.ListUsers(c, userapi.ListUsersParams{Fields: &fields})
+	})
+
+	httpReq, _ := http.NewRequest(http.MethodGet, "/admin/users?fields=nickname", nil)
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestHandler_ListUsers_WithSort(t *testing.T) {
+	handler, mockService, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	sort := "-name"
+	app.Get("/admin/users", func(c *fiber.Ctx) error {
+		return handler.ListUsers(c, userapi.ListUsersParams{Sort: &sort})
+	})
+
+	mockService.EXPECT().
+		ListUsers(gomock.Any(), 1, 10, domain.UserSort{Field: domain.UserSortName, Desc: true}, domain.ListOptions{}).
+		Return([]*response.UserResponse{}, int64(0), nil)
+
+	httpReq, _ := http.NewRequest(http.MethodGet, "/admin/users?sort=-name", nil)
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestHandler_ListUsers_InvalidSort(t *testing.T) {
+	handler, _, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	sort := "password"
+	app.Get("/admin/users", func(c *fiber.Ctx) error {
+		return handler.ListUsers(c, userapi.ListUsersParams{Sort: &sort})
+	})
+
+	httpReq, _ := http.NewRequest(http.MethodGet, "/admin/users?sort=password", nil)
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
 func TestHandler_ListUsers_ServiceError(t *testing.T) {
 	handler, mockService, ctrl, app := setupHandlerTest(t)
 	defer ctrl.Finish()
@@ -631,7 +1461,7 @@ func TestHandler_ListUsers_ServiceError(t *testing.T) {
 	})
 
 	mockService.EXPECT().
-		ListUsers(gomock.Any(), page, limit).
+		ListUsers(gomock.Any(), page, limit, domain.DefaultUserSort, domain.ListOptions{}).
 		Return(nil, int64(0), errors.New("database error"))
 
 	httpReq, _ := http.NewRequest(http.MethodGet, "/admin/users", nil)
@@ -641,3 +1471,430 @@ func TestHandler_ListUsers_ServiceError(t *testing.T) {
 
 	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
 }
+
+func TestHandler_SearchUsers(t *testing.T) {
+	handler, mockService, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	limit := 5
+	app.Get("/admin/users/search", func(c *fiber.Ctx) error {
+		return handler.SearchUsers(c, userapi.SearchUsersParams{Q: "john", Limit: &limit})
+	})
+
+	users := []*response.UserResponse{
+		{ID: uuid.New(), Email: "john@example.com", Name: "John Doe"},
+	}
+
+	mockService.EXPECT().
+		SearchUsers(gomock.Any(), "john", limit).
+		Return(users, nil)
+
+	httpReq, _ := http.NewRequest(http.MethodGet, "/admin/users/search?q=john&limit=5", nil)
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &result))
+
+	assert.Equal(t, "Users retrieved successfully", result["message"])
+	assert.Len(t, result["data"], 1)
+}
+
+func TestHandler_SearchUsers_DefaultLimit(t *testing.T) {
+	handler, mockService, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	invalidLimit := 0
+	app.Get("/admin/users/search", func(c *fiber.Ctx) error {
+		return handler.SearchUsers(c, userapi.SearchUsersParams{Q: "john", Limit: &invalidLimit})
+	})
+
+	mockService.EXPECT().
+		SearchUsers(gomock.Any(), "john", 20).
+		Return([]*response.UserResponse{}, nil)
+
+	httpReq, _ := http.NewRequest(http.MethodGet, "/admin/users/search?q=john", nil)
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestHandler_SearchUsers_EmptyQuery(t *testing.T) {
+	handler, mockService, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	app.Get("/admin/users/search", func(c *fiber.Ctx) error {
+		return handler.SearchUsers(c, userapi.SearchUsersParams{Q: "  "})
+	})
+
+	mockService.EXPECT().
+		SearchUsers(gomock.Any(), "  ", 20).
+		Return(nil, fmt.Errorf("%w: search query is empty", domain.ErrInvalidInput))
+
+	httpReq, _ := http.NewRequest(http.MethodGet, "/admin/users/search", nil)
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestHandler_ExportUsers(t *testing.T) {
+	handler, mockService, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	app.Get("/admin/users/export", handler.ExportUsers)
+
+	createdAt := time.Date(2025, 11, 16, 12, 0, 0, 0, time.UTC)
+	users := []*response.UserResponse{
+		{ID: uuid.New(), Email: "john@example.com", Name: "John Doe", CreatedAt: createdAt},
+		{ID: uuid.New(), Email: "jane@example.com", Name: "Doe, Jane", CreatedAt: createdAt.Add(time.Hour)},
+		{ID: uuid.New(), Email: "eve@example.com", Name: "=HYPERLINK(\"http://evil\")", CreatedAt: createdAt.Add(2 * time.Hour)},
+	}
+
+	mockService.EXPECT().
+		StreamUsers(gomock.Any(), exportBatchSize, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ int, fn func(*response.UserResponse) error) error {
+			for _, user := range users {
+				if err := fn(user); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+	httpReq, _ := http.NewRequest(http.MethodGet, "/admin/users/export", nil)
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/csv; charset=utf-8", resp.Header.Get(fiber.HeaderContentType))
+	assert.Equal(t, "attachment; filename=users.csv", resp.Header.Get(fiber.HeaderContentDisposition))
+
+	records, err := csv.NewReader(resp.Body).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"id", "email", "name", "created_at"},
+		{users[0].ID.String(), "john@example.com", "John Doe", "2025-11-16T12:00:00Z"},
+		{users[1].ID.String(), "jane@example.com", "Doe, Jane", "2025-11-16T13:00:00Z"},
+		{users[2].ID.String(), "eve@example.com", "'=HYPERLINK(\"http://evil\")", "2025-11-16T14:00:00Z"},
+	}, records)
+}
+
+func TestHandler_ExportUsers_Empty(t *testing.T) {
+	handler, mockService, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	app.Get("/admin/users/export", handler.ExportUsers)
+
+	mockService.EXPECT().StreamUsers(gomock.Any(), exportBatchSize, gomock.Any()).Return(nil)
+
+	httpReq, _ := http.NewRequest(http.MethodGet, "/admin/users/export", nil)
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "id,email,name,created_at\n", string(body))
+}
+
+func TestHandler_ExportUsersJSONL(t *testing.T) {
+	handler, mockService, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	app.Get("/admin/users/export.jsonl", handler.ExportUsersJSONL)
+
+	createdAt := time.Date(2025, 11, 16, 12, 0, 0, 0, time.UTC)
+	users := make([]*response.UserResponse, exportBatchSize+2)
+	for i := range users {
+		users[i] = &response.UserResponse{
+			ID:        uuid.New(),
+			Email:     fmt.Sprintf("user%d@example.com", i),
+			Name:      fmt.Sprintf("User %d", i),
+			IsActive:  true,
+			CreatedAt: createdAt.Add(time.Duration(i) * time.Second),
+			UpdatedAt: createdAt,
+		}
+	}
+
+	mockService.EXPECT().
+		StreamUsers(gomock.Any(), exportBatchSize, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ int, fn func(*response.UserResponse) error) error {
+			for _, user := range users {
+				if err := fn(user); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+	httpReq, _ := http.NewRequest(http.MethodGet, "/admin/users/export.jsonl", nil)
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/x-ndjson", resp.Header.Get(fiber.HeaderContentType))
+
+	var decoded []response.UserResponse
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		var user response.UserResponse
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &user))
+		decoded = append(decoded, user)
+	}
+	require.NoError(t, scanner.Err())
+
+	require.Len(t, decoded, len(users))
+	for i, user := range users {
+		assert.Equal(t, user.ID, decoded[i].ID)
+		assert.Equal(t, user.Email, decoded[i].Email)
+		assert.True(t, user.CreatedAt.Equal(decoded[i].CreatedAt))
+	}
+}
+
+func TestHandler_ExportUsersJSONL_StopsOnStreamError(t *testing.T) {
+	handler, mockService, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	app.Get("/admin/users/export.jsonl", handler.ExportUsersJSONL)
+
+	mockService.EXPECT().
+		StreamUsers(gomock.Any(), exportBatchSize, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ int, fn func(*response.UserResponse) error) error {
+			if err := fn(&response.UserResponse{ID: uuid.New(), Email: "john@example.com"}); err != nil {
+				return err
+			}
+			return errors.New("connection reset")
+		})
+
+	httpReq, _ := http.NewRequest(http.MethodGet, "/admin/users/export.jsonl", nil)
+
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+
+	// The status was sent before the failure, the body ends after the last complete line
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(string(body), "\n"), "\n")
+	require.Len(t, lines, 1)
+	var user response.UserResponse
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &user))
+	assert.Equal(t, "john@example.com", user.Email)
+}
+
+func TestHandler_ListUserEvents_Authorization(t *testing.T) {
+	ownerID := uuid.New()
+
+	tests := []struct {
+		name       string
+		callerID   uuid.UUID
+		role       string
+		wantStatus int
+	}{
+		{name: "own events", callerID: ownerID, role: domain.RoleUser, wantStatus: fiber.StatusOK},
+		{name: "another user's events", callerID: uuid.New(), role: domain.RoleUser, wantStatus: fiber.StatusForbidden},
+		{name: "admin sees any user's events", callerID: uuid.New(), role: domain.RoleAdmin, wantStatus: fiber.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService, ctrl, app := setupHandlerTest(t)
+			defer ctrl.Finish()
+
+			app.Get("/users/:id/events", func(c *fiber.Ctx) error {
+				// Simulate AuthMiddleware having validated the token
+				c.Locals("userID", tt.callerID)
+				c.Locals("role", tt.role)
+				return handler.ListUserEvents(c, openapi_types.UUID(ownerID), userapi.ListUserEventsParams{})
+			})
+
+			if tt.wantStatus == fiber.StatusOK {
+				mockService.EXPECT().
+					ListUserEvents(gomock.Any(), ownerID, 1, 10).
+					Return([]*response.UserEventResponse{
+						{ID: uuid.New(), Type: "user.logged_in", OccurredAt: time.Now(), Data: json.RawMessage(`{"email":"jane@example.com"}`)},
+					}, int64(1), nil)
+			}
+
+			httpReq, _ := http.NewRequest(http.MethodGet, "/users/"+ownerID.String()+"/events", nil)
+			resp, err := app.Test(httpReq)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestHandler_ListUserEvents_Paginated(t *testing.T) {
+	handler, mockService, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	page, limit := 2, 5
+	app.Get("/users/:id/events", func(c *fiber.Ctx) error {
+		c.Locals("userID", userID)
+		c.Locals("role", domain.RoleUser)
+		return handler.ListUserEvents(c, openapi_types.UUID(userID), userapi.ListUserEventsParams{Page: &page, Limit: &limit})
+	})
+
+	mockService.EXPECT().
+		ListUserEvents(gomock.Any(), userID, 2, 5).
+		Return([]*response.UserEventResponse{
+			{ID: uuid.New(), Type: "user.updated", OccurredAt: time.Now(), Data: json.RawMessage(`{"name":"Jane"}`)},
+		}, int64(6), nil)
+
+	httpReq, _ := http.NewRequest(http.MethodGet, "/users/"+userID.String()+"/events", nil)
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var body struct {
+		Data []struct {
+			Type string          `json:"type"`
+			Data json.RawMessage `json:"data"`
+		} `json:"data"`
+		Meta struct {
+			Pagination struct {
+				Page       int   `json:"page"`
+				Total      int64 `json:"total"`
+				TotalPages int   `json:"total_pages"`
+			} `json:"pagination"`
+		} `json:"meta"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Len(t, body.Data, 1)
+	assert.Equal(t, "user.updated", body.Data[0].Type)
+	assert.JSONEq(t, `{"name":"Jane"}`, string(body.Data[0].Data))
+	assert.Equal(t, 2, body.Meta.Pagination.Page)
+	assert.Equal(t, int64(6), body.Meta.Pagination.Total)
+	assert.Equal(t, 2, body.Meta.Pagination.TotalPages)
+}
+
+func TestHandler_ChangeEmail(t *testing.T) {
+	ownerID := uuid.New()
+
+	tests := []struct {
+		name       string
+		callerID   uuid.UUID
+		role       string
+		serviceErr error
+		wantStatus int
+	}{
+		{name: "own email", callerID: ownerID, role: domain.RoleUser, wantStatus: fiber.StatusAccepted},
+		{name: "admin changes any user's email", callerID: uuid.New(), role: domain.RoleAdmin, wantStatus: fiber.StatusAccepted},
+		{name: "another user's email", callerID: uuid.New(), role: domain.RoleUser, wantStatus: fiber.StatusForbidden},
+		{name: "email taken", callerID: ownerID, role: domain.RoleUser, serviceErr: domain.ErrUserAlreadyExists, wantStatus: fiber.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService, ctrl, app := setupHandlerTest(t)
+			defer ctrl.Finish()
+
+			app.Post("/users/:id/email", func(c *fiber.Ctx) error {
+				c.Locals("userID", tt.callerID)
+				c.Locals("role", tt.role)
+				return handler.ChangeEmail(c, openapi_types.UUID(ownerID))
+			})
+
+			if tt.wantStatus != fiber.StatusForbidden {
+				mockService.EXPECT().
+					ChangeEmail(gomock.Any(), ownerID, "jane.new@example.com").
+					Return(tt.serviceErr)
+			}
+
+			httpReq, _ := http.NewRequest(http.MethodPost, "/users/"+ownerID.String()+"/email", strings.NewReader(`{"email":"Jane.New@Example.com"}`))
+			httpReq.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(httpReq)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestHandler_ChangeEmail_InvalidEmail(t *testing.T) {
+	handler, _, ctrl, app := setupHandlerTest(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	app.Post("/users/:id/email", func(c *fiber.Ctx) error {
+		c.Locals("userID", userID)
+		c.Locals("role", domain.RoleUser)
+		return handler.ChangeEmail(c, openapi_types.UUID(userID))
+	})
+
+	httpReq, _ := http.NewRequest(http.MethodPost, "/users/"+userID.String()+"/email", strings.NewReader(`{"email":"not-an-email"}`))
+	httpReq.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, fiber.StatusUnprocessableEntity, resp.StatusCode)
+}
+
+func TestHandler_VerifyEmail(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{name: "verified", wantStatus: fiber.StatusOK},
+		{name: "invalid token", serviceErr: domain.ErrInvalidVerificationToken, wantStatus: fiber.StatusBadRequest},
+		{name: "nothing pending", serviceErr: domain.ErrNoPendingEmailChange, wantStatus: fiber.StatusBadRequest},
+		{name: "email taken meanwhile", serviceErr: domain.ErrUserAlreadyExists, wantStatus: fiber.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService, ctrl, app := setupHandlerTest(t)
+			defer ctrl.Finish()
+
+			app.Post("/users/:id/email/verify", func(c *fiber.Ctx) error {
+				c.Locals("userID", userID)
+				c.Locals("role", domain.RoleUser)
+				return handler.VerifyEmail(c, openapi_types.UUID(userID))
+			})
+
+			var user *response.UserResponse
+			if tt.serviceErr == nil {
+				user = &response.UserResponse{ID: userID, Email: "jane@new.example.com", EmailVerified: true}
+			}
+			mockService.EXPECT().
+				VerifyEmail(gomock.Any(), userID, "mailed-token").
+				Return(user, tt.serviceErr)
+
+			httpReq, _ := http.NewRequest(http.MethodPost, "/users/"+userID.String()+"/email/verify", strings.NewReader(`{"token":"mailed-token"}`))
+			httpReq.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(httpReq)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.wantStatus, resp.StatusCode)
+			if tt.serviceErr == nil {
+				var body struct {
+					Data response.UserResponse `json:"data"`
+				}
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+				assert.Equal(t, "jane@new.example.com", body.Data.Email)
+				assert.True(t, body.Data.EmailVerified)
+			}
+		})
+	}
+}
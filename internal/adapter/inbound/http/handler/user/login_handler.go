@@ -1,8 +1,11 @@
 package user
 
 import (
-	"github.com/gieart87/gohexaclean/internal/adapter/inbound/http/generated/userapi"
+	"errors"
+
+	"github.com/gieart87/gohexaclean/internal/domain"
 	"github.com/gieart87/gohexaclean/internal/dto/request"
+	"github.com/gieart87/gohexaclean/pkg/httputil"
 	"github.com/gieart87/gohexaclean/pkg/response"
 	"github.com/gofiber/fiber/v2"
 )
@@ -11,34 +14,22 @@ import (
 // Public endpoint - no authentication required
 // POST /auth/login
 func (h *Handler) Login(c *fiber.Ctx) error {
-	var req userapi.LoginRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(
-			response.NewErrorResponse("Invalid request body", err),
-		)
-	}
-
-	// Convert generated type to domain DTO
-	loginReq := &request.LoginRequest{
-		Email:    string(req.Email),
-		Password: req.Password,
+	loginReq, err := httputil.BindAndValidate[request.LoginRequest](c)
+	if err != nil {
+		return httputil.Respond(c, err)
 	}
 
-	// Validate request
-	if err := loginReq.Validate(); err != nil {
-		return c.Status(fiber.StatusUnprocessableEntity).JSON(
-			response.NewValidationErrorResponse("Validation failed", response.ParseValidationErrors(err)),
+	loginResp, err := h.userService.Login(c.UserContext(), &loginReq)
+	if errors.Is(err, domain.ErrAccountInactive) {
+		return c.Status(fiber.StatusForbidden).JSON(
+			response.NewErrorResponse("Account is inactive", err),
 		)
 	}
-
-	loginResp, err := h.userService.Login(c.Context(), loginReq)
 	if err != nil {
 		return c.Status(fiber.StatusUnauthorized).JSON(
 			response.NewErrorResponse("Invalid credentials", err),
 		)
 	}
 
-	return c.JSON(
-		response.NewSuccessResponse("Login successful", loginResp),
-	)
+	return response.Write(c, h.envelope(c), fiber.StatusOK, "Login successful", loginResp)
 }
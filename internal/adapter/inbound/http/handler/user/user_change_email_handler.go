@@ -0,0 +1,58 @@
+package user
+
+import (
+	"errors"
+
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/gieart87/gohexaclean/internal/dto/request"
+	"github.com/gieart87/gohexaclean/pkg/httputil"
+	"github.com/gieart87/gohexaclean/pkg/response"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// ChangeEmail handles requesting an email change, the new address is mailed a verification token
+// Protected endpoint - users change their own email, admins change anyone's
+// POST /users/{id}/email
+func (h *Handler) ChangeEmail(c *fiber.Ctx, id openapi_types.UUID) error {
+	// Set by AuthMiddleware from the token claims
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(
+			response.NewErrorResponse("Unauthorized", nil),
+		)
+	}
+	if role, _ := c.Locals("role").(string); userID != uuid.UUID(id) && role != domain.RoleAdmin {
+		return c.Status(fiber.StatusForbidden).JSON(
+			response.NewErrorResponse("Forbidden", nil),
+		)
+	}
+
+	changeReq, err := httputil.BindAndValidate[request.ChangeEmailRequest](c)
+	if err != nil {
+		return httputil.Respond(c, err)
+	}
+
+	err = h.userService.ChangeEmail(c.UserContext(), uuid.UUID(id), changeReq.Email)
+	switch {
+	case err == nil:
+		return response.Write(c, h.envelope(c), fiber.StatusAccepted, "Verification email sent", nil)
+	case errors.Is(err, domain.ErrUserAlreadyExists):
+		return c.Status(fiber.StatusConflict).JSON(
+			response.NewErrorResponse("Email already taken", err),
+		)
+	case errors.Is(err, domain.ErrUserNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(
+			response.NewErrorResponse("User not found", err),
+		)
+	case errors.Is(err, domain.ErrInvalidInput):
+		return c.Status(fiber.StatusBadRequest).JSON(
+			response.NewErrorResponse("Failed to change email", err),
+		)
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			response.NewErrorResponse("Failed to change email", err),
+		)
+	}
+}
@@ -0,0 +1,46 @@
+package user
+
+import (
+	"github.com/gieart87/gohexaclean/internal/adapter/inbound/http/generated/userapi"
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/gieart87/gohexaclean/pkg/response"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// ListUserEvents handles listing a user's recent domain events, newest first
+// Protected endpoint - users see their own events, admins see anyone's
+// GET /users/{id}/events?page=...&limit=...
+func (h *Handler) ListUserEvents(c *fiber.Ctx, id openapi_types.UUID, params userapi.ListUserEventsParams) error {
+	// Set by AuthMiddleware from the token claims
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(
+			response.NewErrorResponse("Unauthorized", nil),
+		)
+	}
+	if role, _ := c.Locals("role").(string); userID != uuid.UUID(id) && role != domain.RoleAdmin {
+		return c.Status(fiber.StatusForbidden).JSON(
+			response.NewErrorResponse("Forbidden", nil),
+		)
+	}
+
+	var page, limit int
+	if params.Page != nil {
+		page = *params.Page
+	}
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	page, limit = h.pageBounds.Normalize(page, limit)
+
+	events, total, err := h.userService.ListUserEvents(c.UserContext(), uuid.UUID(id), page, limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			response.NewErrorResponse("Failed to list user events", err),
+		)
+	}
+
+	return response.WritePaginated(c, h.envelope(c), response.NewPaginatedResponse("User events retrieved successfully", events, page, limit, total))
+}
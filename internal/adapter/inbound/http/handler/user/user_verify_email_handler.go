@@ -0,0 +1,58 @@
+package user
+
+import (
+	"errors"
+
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/gieart87/gohexaclean/internal/dto/request"
+	"github.com/gieart87/gohexaclean/pkg/httputil"
+	"github.com/gieart87/gohexaclean/pkg/response"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// VerifyEmail handles completing a pending email change with the mailed token
+// Protected endpoint - users verify their own email, admins verify anyone's
+// POST /users/{id}/email/verify
+func (h *Handler) VerifyEmail(c *fiber.Ctx, id openapi_types.UUID) error {
+	// Set by AuthMiddleware from the token claims
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(
+			response.NewErrorResponse("Unauthorized", nil),
+		)
+	}
+	if role, _ := c.Locals("role").(string); userID != uuid.UUID(id) && role != domain.RoleAdmin {
+		return c.Status(fiber.StatusForbidden).JSON(
+			response.NewErrorResponse("Forbidden", nil),
+		)
+	}
+
+	verifyReq, err := httputil.BindAndValidate[request.VerifyEmailRequest](c)
+	if err != nil {
+		return httputil.Respond(c, err)
+	}
+
+	user, err := h.userService.VerifyEmail(c.UserContext(), uuid.UUID(id), verifyReq.Token)
+	switch {
+	case err == nil:
+		return response.Write(c, h.envelope(c), fiber.StatusOK, "Email verified successfully", user)
+	case errors.Is(err, domain.ErrInvalidVerificationToken), errors.Is(err, domain.ErrNoPendingEmailChange):
+		return c.Status(fiber.StatusBadRequest).JSON(
+			response.NewErrorResponse("Failed to verify email", err),
+		)
+	case errors.Is(err, domain.ErrUserAlreadyExists):
+		return c.Status(fiber.StatusConflict).JSON(
+			response.NewErrorResponse("Email already taken", err),
+		)
+	case errors.Is(err, domain.ErrUserNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(
+			response.NewErrorResponse("User not found", err),
+		)
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			response.NewErrorResponse("Failed to verify email", err),
+		)
+	}
+}
@@ -1,15 +1,23 @@
 package middleware
 
 import (
+	"context"
 	"strings"
 
 	"github.com/gieart87/gohexaclean/pkg/auth"
 	"github.com/gieart87/gohexaclean/pkg/response"
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
+// TokenVersionProvider returns a user's current token version (implemented by the user service)
+type TokenVersionProvider interface {
+	GetTokenVersion(ctx context.Context, id uuid.UUID) (int, error)
+}
+
 // AuthMiddleware creates a JWT authentication middleware
-func AuthMiddleware(jwtSecret string) fiber.Handler {
+// tokenVersions is optional; when set, tokens issued before the user's last revocation are rejected
+func AuthMiddleware(tokenOpts auth.TokenOptions, tokenVersions TokenVersionProvider) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		// Get authorization header
 		authHeader := c.Get("Authorization")
@@ -30,16 +38,47 @@ func AuthMiddleware(jwtSecret string) fiber.Handler {
 		token := parts[1]
 
 		// Validate token
-		claims, err := auth.ValidateJWT(token, jwtSecret)
+		claims, err := auth.ValidateJWT(token, tokenOpts)
 		if err != nil {
 			return c.Status(fiber.StatusUnauthorized).JSON(
 				response.NewErrorResponse("Invalid or expired token", err),
 			)
 		}
 
-		// Store user ID in context
+		// Reject tokens minted before the user's tokens were revoked
+		if tokenVersions != nil {
+			version, err := tokenVersions.GetTokenVersion(c.UserContext(), claims.UserID)
+			if err != nil || claims.TokenVersion < version {
+				return c.Status(fiber.StatusUnauthorized).JSON(
+					response.NewErrorResponse("Token has been revoked", nil),
+				)
+			}
+		}
+
+		// Store user ID and role in context
 		c.Locals("userID", claims.UserID)
+		c.Locals("role", claims.Role)
+
+		// Expose the actor to services through the request context (e.g. for audit logging)
+		c.SetUserContext(auth.WithUserID(c.UserContext(), claims.UserID))
 
 		return c.Next()
 	}
 }
+
+// RequireRole allows the request only if the authenticated user has one of the given roles
+// Must be registered after AuthMiddleware
+func RequireRole(roles ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		role, _ := c.Locals("role").(string)
+		for _, allowed := range roles {
+			if role == allowed {
+				return c.Next()
+			}
+		}
+
+		return c.Status(fiber.StatusForbidden).JSON(
+			response.NewErrorResponse("Insufficient permissions", nil),
+		)
+	}
+}
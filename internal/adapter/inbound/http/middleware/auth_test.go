@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/gieart87/gohexaclean/pkg/auth"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testTokenOpts = auth.TokenOptions{Secret: "test-secret", Issuer: "gohexaclean", Audience: "gohexaclean-api"}
+
+// stubTokenVersions serves token versions from a map
+type stubTokenVersions map[uuid.UUID]int
+
+func (s stubTokenVersions) GetTokenVersion(ctx context.Context, id uuid.UUID) (int, error) {
+	version, ok := s[id]
+	if !ok {
+		return 0, domain.ErrUserNotFound
+	}
+	return version, nil
+}
+
+func newAuthTestApp(versions TokenVersionProvider, roles ...string) *fiber.App {
+	app := fiber.New()
+	handlers := []fiber.Handler{AuthMiddleware(testTokenOpts, versions)}
+	if len(roles) > 0 {
+		handlers = append(handlers, RequireRole(roles...))
+	}
+	handlers = append(handlers, func(c *fiber.Ctx) error {
+		return c.SendString(c.Locals("userID").(uuid.UUID).String())
+	})
+	app.Get("/protected", handlers...)
+	return app
+}
+
+func authRequest(t *testing.T, app *fiber.App, token string) int {
+	req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	return resp.StatusCode
+}
+
+func TestAuthMiddleware_RejectsTokenAfterRevocation(t *testing.T) {
+	userID := uuid.New()
+	versions := stubTokenVersions{userID: 0}
+	app := newAuthTestApp(versions)
+
+	token, err := auth.GenerateJWT(userID, "test@example.com", domain.RoleUser, 0, testTokenOpts, time.Hour)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusOK, authRequest(t, app, token))
+
+	// Revocation bumps the version; the old token must no longer be accepted
+	versions[userID] = 1
+	assert.Equal(t, fiber.StatusUnauthorized, authRequest(t, app, token))
+
+	// A token minted after revocation carries the new version
+	newToken, err := auth.GenerateJWT(userID, "test@example.com", domain.RoleUser, 1, testTokenOpts, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, authRequest(t, app, newToken))
+}
+
+func TestAuthMiddleware_UnknownUser(t *testing.T) {
+	app := newAuthTestApp(stubTokenVersions{})
+
+	token, err := auth.GenerateJWT(uuid.New(), "test@example.com", domain.RoleUser, 0, testTokenOpts, time.Hour)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusUnauthorized, authRequest(t, app, token))
+}
+
+func TestAuthMiddleware_MissingToken(t *testing.T) {
+	app := newAuthTestApp(nil)
+
+	assert.Equal(t, fiber.StatusUnauthorized, authRequest(t, app, ""))
+}
+
+func TestRequireRole(t *testing.T) {
+	app := newAuthTestApp(nil, domain.RoleAdmin)
+
+	userToken, err := auth.GenerateJWT(uuid.New(), "user@example.com", domain.RoleUser, 0, testTokenOpts, time.Hour)
+	require.NoError(t, err)
+	adminToken, err := auth.GenerateJWT(uuid.New(), "admin@example.com", domain.RoleAdmin, 0, testTokenOpts, time.Hour)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusForbidden, authRequest(t, app, userToken))
+	assert.Equal(t, fiber.StatusOK, authRequest(t, app, adminToken))
+}
+
+func TestAuthMiddleware_SetsUserIDOnUserContext(t *testing.T) {
+	userID := uuid.New()
+	app := fiber.New()
+	app.Get("/protected", AuthMiddleware(testTokenOpts, nil), func(c *fiber.Ctx) error {
+		id, ok := auth.UserIDFromContext(c.UserContext())
+		if !ok {
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+		return c.SendString(id.String())
+	})
+
+	token, err := auth.GenerateJWT(userID, "test@example.com", domain.RoleUser, 0, testTokenOpts, time.Hour)
+	require.NoError(t, err)
+
+	req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, userID.String(), string(body))
+}
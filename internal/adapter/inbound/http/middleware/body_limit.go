@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gieart87/gohexaclean/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+// BodyLimitMiddleware rejects requests whose body is larger than the limit of their path with 413
+// routeLimits maps path prefixes to their own limit, matched on whole path segments so /upload
+// doesn't cover /uploads-admin; the longest matching prefix wins and other
+// paths get defaultLimit; the server-wide fiber BodyLimit must be at least the largest limit
+func BodyLimitMiddleware(defaultLimit int, routeLimits map[string]int) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		limit := bodyLimitFor(c.Path(), defaultLimit, routeLimits)
+		if limit <= 0 {
+			return c.Next()
+		}
+
+		// The raw body is measured, c.Body() would decompress it first
+		if len(c.Request().Body()) > limit {
+			return c.Status(fiber.StatusRequestEntityTooLarge).JSON(
+				response.NewErrorResponseWithCode("Request body too large", "REQUEST_ENTITY_TOO_LARGE",
+					fmt.Errorf("request body exceeds %d bytes", limit)),
+			)
+		}
+
+		return c.Next()
+	}
+}
+
+// bodyLimitFor returns the limit of the longest prefix in routeLimits matching path
+func bodyLimitFor(path string, defaultLimit int, routeLimits map[string]int) int {
+	limit, matched := defaultLimit, 0
+	for prefix, l := range routeLimits {
+		if len(prefix) > matched && hasPathPrefix(path, prefix) {
+			limit, matched = l, len(prefix)
+		}
+	}
+	return limit
+}
+
+// hasPathPrefix reports whether path is prefix or lies below it
+func hasPathPrefix(path, prefix string) bool {
+	if !strings.HasPrefix(path, prefix) {
+		return false
+	}
+	rest := path[len(prefix):]
+	return rest == "" || rest[0] == '/' || strings.HasSuffix(prefix, "/")
+}
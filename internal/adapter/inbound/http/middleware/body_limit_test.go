@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gieart87/gohexaclean/pkg/response"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBodyLimitMiddleware(t *testing.T) {
+	app := fiber.New()
+	app.Use(BodyLimitMiddleware(64, map[string]int{
+		"/auth":          16,
+		"/bulk":          256,
+		"/bulk/tiny":     8,
+		"/api/v1/upload": 256,
+	}))
+	app.Post("/*", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	tests := []struct {
+		name       string
+		path       string
+		size       int
+		wantStatus int
+	}{
+		{name: "default within limit", path: "/users", size: 64, wantStatus: fiber.StatusNoContent},
+		{name: "default over limit", path: "/users", size: 65, wantStatus: fiber.StatusRequestEntityTooLarge},
+		{name: "auth over its smaller limit", path: "/auth/login", size: 17, wantStatus: fiber.StatusRequestEntityTooLarge},
+		{name: "bulk over default within its larger limit", path: "/bulk", size: 200, wantStatus: fiber.StatusNoContent},
+		{name: "longest prefix wins", path: "/bulk/tiny", size: 9, wantStatus: fiber.StatusRequestEntityTooLarge},
+		{name: "prefix covers paths below it", path: "/api/v1/upload/avatar", size: 200, wantStatus: fiber.StatusNoContent},
+		{name: "prefix with trailing slash", path: "/api/v1/upload/", size: 200, wantStatus: fiber.StatusNoContent},
+		{name: "near miss gets the default", path: "/api/v1/uploads-admin", size: 200, wantStatus: fiber.StatusRequestEntityTooLarge},
+		{name: "near miss of a nested prefix", path: "/bulk/tinyish", size: 200, wantStatus: fiber.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodPost, tt.path, bytes.NewReader(bytes.Repeat([]byte("a"), tt.size)))
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestBodyLimitMiddleware_ErrorResponse(t *testing.T) {
+	app := fiber.New()
+	app.Use(BodyLimitMiddleware(8, nil))
+	app.Post("/users", func(c *fiber.Ctx) error {
+		t.Fatal("handler must not run for an oversized body")
+		return nil
+	})
+
+	req, _ := http.NewRequest(http.MethodPost, "/users", bytes.NewReader([]byte(`{"name":"oversized"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, fiber.StatusRequestEntityTooLarge, resp.StatusCode)
+
+	var body response.ErrorResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.False(t, body.Success)
+	assert.Equal(t, "Request body too large", body.Message)
+	assert.Equal(t, "REQUEST_ENTITY_TOO_LARGE", body.ErrorCode)
+}
@@ -0,0 +1,15 @@
+package middleware
+
+import (
+	"github.com/gieart87/gohexaclean/internal/infra/config"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/compress"
+)
+
+// CompressionMiddleware creates a response compression middleware (gzip, deflate, brotli)
+// Compression is negotiated from the client's Accept-Encoding header
+func CompressionMiddleware(cfg *config.CompressionConfig) fiber.Handler {
+	return compress.New(compress.Config{
+		Level: compress.Level(cfg.Level),
+	})
+}
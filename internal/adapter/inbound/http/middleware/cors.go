@@ -1,30 +1,34 @@
 package middleware
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/gieart87/gohexaclean/internal/infra/config"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 )
 
 // CORSMiddleware creates a CORS middleware
-func CORSMiddleware(cfg *config.CORSConfig) fiber.Handler {
-	origins := joinStrings(cfg.AllowOrigins, ",")
-
-	// Security: If origins is wildcard, disable credentials
-	// Cannot use AllowCredentials=true with AllowOrigins="*"
-	allowCredentials := true
-	if origins == "*" {
-		allowCredentials = false
+// With credentials enabled browsers reject a wildcard origin, so "*" is refused here
+// and the allowed request origin is echoed back instead
+func CORSMiddleware(cfg *config.CORSConfig) (fiber.Handler, error) {
+	if cfg.AllowCredentials {
+		for _, origin := range cfg.AllowOrigins {
+			if strings.TrimSpace(origin) == "*" {
+				return nil, fmt.Errorf("cors: allow_credentials requires explicit origins, not %q", origin)
+			}
+		}
 	}
 
 	return cors.New(cors.Config{
-		AllowOrigins:     origins,
+		AllowOrigins:     joinStrings(cfg.AllowOrigins, ","),
 		AllowMethods:     joinStrings(cfg.AllowMethods, ","),
 		AllowHeaders:     joinStrings(cfg.AllowHeaders, ","),
-		AllowCredentials: allowCredentials,
-		ExposeHeaders:    "Content-Length",
-		MaxAge:           300,
-	})
+		AllowCredentials: cfg.AllowCredentials,
+		ExposeHeaders:    joinStrings(cfg.ExposeHeaders, ","),
+		MaxAge:           cfg.MaxAge,
+	}), nil
 }
 
 // joinStrings joins string slice with separator
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gieart87/gohexaclean/internal/infra/config"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCORSApp(t *testing.T, cfg *config.CORSConfig) *fiber.App {
+	handler, err := CORSMiddleware(cfg)
+	require.NoError(t, err)
+
+	app := fiber.New()
+	app.Use(handler)
+	app.Get("/users", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+	return app
+}
+
+func TestCORSMiddleware_CredentialedPreflight(t *testing.T) {
+	app := newCORSApp(t, &config.CORSConfig{
+		AllowOrigins:     []string{"https://app.example.com", "https://admin.example.com"},
+		AllowMethods:     []string{"GET", "POST"},
+		AllowHeaders:     []string{"Content-Type", "Authorization"},
+		AllowCredentials: true,
+		ExposeHeaders:    []string{"Content-Length", "ETag"},
+		MaxAge:           600,
+	})
+
+	req, _ := http.NewRequest(http.MethodOptions, "/users", nil)
+	req.Header.Set("Origin", "https://admin.example.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "https://admin.example.com", resp.Header.Get("Access-Control-Allow-Origin"), "origin should be echoed, not *")
+	assert.Equal(t, "true", resp.Header.Get("Access-Control-Allow-Credentials"))
+	assert.Equal(t, "GET,POST", resp.Header.Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "Content-Type,Authorization", resp.Header.Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "600", resp.Header.Get("Access-Control-Max-Age"))
+}
+
+func TestCORSMiddleware_ExposeHeaders(t *testing.T) {
+	app := newCORSApp(t, &config.CORSConfig{
+		AllowOrigins:     []string{"https://app.example.com"},
+		AllowCredentials: true,
+		ExposeHeaders:    []string{"Content-Length", "ETag"},
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/users", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://app.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "Content-Length,ETag", resp.Header.Get("Access-Control-Expose-Headers"))
+}
+
+func TestCORSMiddleware_DisallowedOrigin(t *testing.T) {
+	app := newCORSApp(t, &config.CORSConfig{
+		AllowOrigins:     []string{"https://app.example.com"},
+		AllowCredentials: true,
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/users", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORSMiddleware_WildcardWithCredentialsRejected(t *testing.T) {
+	handler, err := CORSMiddleware(&config.CORSConfig{
+		AllowOrigins:     []string{"https://app.example.com", "*"},
+		AllowCredentials: true,
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, handler)
+}
+
+func TestCORSMiddleware_WildcardWithoutCredentials(t *testing.T) {
+	app := newCORSApp(t, &config.CORSConfig{AllowOrigins: []string{"*"}})
+
+	req, _ := http.NewRequest(http.MethodGet, "/users", nil)
+	req.Header.Set("Origin", "https://any.example.com")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, "*", resp.Header.Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, resp.Header.Get("Access-Control-Allow-Credentials"))
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apperrors "github.com/gieart87/gohexaclean/pkg/errors"
+	"github.com/gieart87/gohexaclean/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ErrorHandler is the Fiber error handler, it renders every unhandled error as a response.ErrorResponse
+// AppErrors keep their status and message, fiber errors keep their status, anything else is a 500
+// whose details are not exposed to the client
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	code := fiber.StatusInternalServerError
+	message := "Internal server error"
+
+	var appErr *apperrors.AppError
+	var fiberErr *fiber.Error
+	switch {
+	case errors.As(err, &appErr):
+		code = appErr.Code
+		message = appErr.Message
+	case errors.As(err, &fiberErr):
+		code = fiberErr.Code
+		message = fiberErr.Message
+	}
+
+	return c.Status(code).JSON(
+		response.NewErrorResponseWithCode(message, errorCode(code), nil),
+	)
+}
+
+// errorCode derives an error code from the HTTP status, e.g. 404 -> NOT_FOUND
+func errorCode(status int) string {
+	text := http.StatusText(status)
+	if text == "" {
+		return "UNKNOWN_ERROR"
+	}
+
+	var b strings.Builder
+	for _, r := range strings.ToUpper(text) {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == ' ' || r == '-':
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	apperrors "github.com/gieart87/gohexaclean/pkg/errors"
+	"github.com/gieart87/gohexaclean/pkg/response"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newErrorApp() *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Use(recover.New())
+	app.Get("/panic", func(c *fiber.Ctx) error {
+		panic("boom")
+	})
+	app.Get("/app-error", func(c *fiber.Ctx) error {
+		return fmt.Errorf("lookup: %w", apperrors.NotFound("User not found", errors.New("no rows")))
+	})
+	app.Get("/fiber-error", func(c *fiber.Ctx) error {
+		return fiber.NewError(fiber.StatusTeapot, "short and stout")
+	})
+	app.Get("/plain-error", func(c *fiber.Ctx) error {
+		return errors.New("dial tcp 10.0.0.1:5432: connection refused")
+	})
+	return app
+}
+
+func doErrorRequest(t *testing.T, app *fiber.App, path string) (int, response.ErrorResponse, map[string]interface{}) {
+	req, _ := http.NewRequest(http.MethodGet, path, nil)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var raw map[string]interface{}
+	var body response.ErrorResponse
+	require.NoError(t, json.Unmarshal(data, &raw))
+	require.NoError(t, json.Unmarshal(data, &body))
+
+	return resp.StatusCode, body, raw
+}
+
+func TestErrorHandler_Panic(t *testing.T) {
+	status, body, raw := doErrorRequest(t, newErrorApp(), "/panic")
+
+	assert.Equal(t, fiber.StatusInternalServerError, status)
+	assert.False(t, body.Success)
+	assert.Equal(t, "Internal server error", body.Message)
+	assert.Equal(t, "INTERNAL_SERVER_ERROR", body.ErrorCode)
+	assert.NotEmpty(t, body.Meta.RequestID)
+	assert.False(t, body.Meta.Timestamp.IsZero())
+	assert.NotContains(t, raw, "error", "the legacy error field should be gone")
+}
+
+func TestErrorHandler_AppError(t *testing.T) {
+	status, body, _ := doErrorRequest(t, newErrorApp(), "/app-error")
+
+	assert.Equal(t, fiber.StatusNotFound, status)
+	assert.Equal(t, "User not found", body.Message)
+	assert.Equal(t, "NOT_FOUND", body.ErrorCode)
+	assert.Empty(t, body.Errors, "wrapped error details should not leak")
+}
+
+func TestErrorHandler_FiberError(t *testing.T) {
+	status, body, _ := doErrorRequest(t, newErrorApp(), "/fiber-error")
+
+	assert.Equal(t, fiber.StatusTeapot, status)
+	assert.Equal(t, "short and stout", body.Message)
+	assert.Equal(t, "IM_A_TEAPOT", body.ErrorCode)
+}
+
+func TestErrorHandler_RouteNotFound(t *testing.T) {
+	status, body, _ := doErrorRequest(t, newErrorApp(), "/missing")
+
+	assert.Equal(t, fiber.StatusNotFound, status)
+	assert.Equal(t, "NOT_FOUND", body.ErrorCode)
+}
+
+func TestErrorHandler_PlainErrorHidesDetails(t *testing.T) {
+	status, body, _ := doErrorRequest(t, newErrorApp(), "/plain-error")
+
+	assert.Equal(t, fiber.StatusInternalServerError, status)
+	assert.Equal(t, "Internal server error", body.Message)
+	assert.NotContains(t, body.Message, "10.0.0.1")
+}
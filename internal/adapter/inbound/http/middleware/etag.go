@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ETagMiddleware creates a middleware that sets an ETag on successful GET responses
+// and returns 304 Not Modified when the client's If-None-Match header matches.
+// The ETag is computed from the serialized response body with per-request metadata
+// (meta.request_id, meta.timestamp) removed, so identical data yields the same ETag.
+// Responses that already carry an ETag are left alone, their handler owns the validator
+// and answers If-None-Match itself.
+func ETagMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		// Only read endpoints are cacheable
+		if c.Method() != fiber.MethodGet && c.Method() != fiber.MethodHead {
+			return c.Next()
+		}
+
+		// Process request so the JSON body is serialized
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		// Hashing a streamed body would buffer all of it, defeating the stream
+		if c.Response().StatusCode() != fiber.StatusOK || c.Response().IsBodyStream() {
+			return nil
+		}
+		if len(c.Response().Header.Peek(fiber.HeaderETag)) > 0 {
+			return nil
+		}
+
+		body := c.Response().Body()
+		if len(body) == 0 {
+			return nil
+		}
+
+		etag := computeETag(body)
+		c.Set(fiber.HeaderETag, etag)
+
+		if etagMatches(c.Get(fiber.HeaderIfNoneMatch), etag) {
+			c.Context().ResetBody()
+			return c.SendStatus(fiber.StatusNotModified)
+		}
+
+		return nil
+	}
+}
+
+// computeETag returns a strong ETag for the response body
+func computeETag(body []byte) string {
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err == nil {
+		if meta, ok := payload["meta"].(map[string]interface{}); ok {
+			delete(meta, "request_id")
+			delete(meta, "timestamp")
+		}
+		// json.Marshal sorts map keys, so the normalized body is deterministic
+		if normalized, err := json.Marshal(payload); err == nil {
+			body = normalized
+		}
+	}
+
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches reports whether the If-None-Match header matches the given ETag
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
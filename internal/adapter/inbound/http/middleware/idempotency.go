@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/gieart87/gohexaclean/internal/port/outbound/service"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/telemetry"
+	"github.com/gieart87/gohexaclean/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// HeaderIdempotencyKey is the request header clients set to make a mutating request safe to retry
+	HeaderIdempotencyKey = "Idempotency-Key"
+	// HeaderIdempotentReplayed marks responses served from the idempotency store
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+
+	idempotencyKeyPrefix    = "idempotency:"
+	idempotencyLockPrefix   = "idempotency-lock:"
+	maxIdempotencyKeyLength = 255
+	// idempotencyLockTTL bounds how long a crashed replica can hold a key, well above the request timeout
+	idempotencyLockTTL = time.Minute
+)
+
+// idempotencyRecord is the stored outcome of the first request made with a key
+type idempotencyRecord struct {
+	RequestHash string `json:"request_hash"`
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
+
+// IdempotencyMiddleware replays the stored response when a mutating request is retried with the
+// same Idempotency-Key within ttl, and responds 409 when the key is reused with a different body.
+// Keys are scoped per method and route. A retry arriving while the first request with its key is
+// still running gets 409 instead of executing concurrently, guarded by a distributed lock.
+// Requests without the header are processed normally. Requests with it get 503 while the lock
+// can't be taken, such as during a Redis outage, so concurrent retries never both execute; they
+// are counted as idempotency.unavailable. 5xx responses are not stored so they can be retried.
+func IdempotencyMiddleware(cache service.CacheService, ttl time.Duration, metrics telemetry.MetricsService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		switch c.Method() {
+		case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions:
+			return c.Next()
+		}
+
+		key := c.Get(HeaderIdempotencyKey)
+		if key == "" {
+			return c.Next()
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			return c.Status(fiber.StatusBadRequest).JSON(
+				response.NewErrorResponse("Invalid Idempotency-Key", errors.New("idempotency key must be at most 255 characters")),
+			)
+		}
+
+		ctx := c.UserContext()
+		scopedKey := c.Method() + ":" + c.Path() + ":" + key
+		cacheKey := idempotencyKeyPrefix + scopedKey
+		requestHash := hashRequestBody(c.Body())
+
+		if record, ok := loadIdempotencyRecord(c, cache, cacheKey); ok {
+			return replayIdempotencyRecord(c, record, requestHash, metrics)
+		}
+
+		// Only one request per key executes at a time, a lock error means the cache is down
+		release, acquired, err := cache.AcquireLock(ctx, idempotencyLockPrefix+scopedKey, idempotencyLockTTL)
+		if err != nil {
+			if metrics != nil {
+				metrics.IncrementCounter("idempotency.unavailable", map[string]string{"method": c.Method()}, 1)
+			}
+			return c.Status(fiber.StatusServiceUnavailable).JSON(
+				response.NewErrorResponse("Idempotency-Key unavailable", errors.New("idempotency keys can't be checked right now, retry later")),
+			)
+		}
+		if !acquired {
+			return c.Status(fiber.StatusConflict).JSON(
+				response.NewErrorResponse("Idempotency-Key in use", errors.New("a request with this idempotency key is still being processed")),
+			)
+		}
+		defer release()
+
+		// The request holding the lock may have finished between the lookup and acquiring it
+		if record, ok := loadIdempotencyRecord(c, cache, cacheKey); ok {
+			return replayIdempotencyRecord(c, record, requestHash, metrics)
+		}
+
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		status := c.Response().StatusCode()
+		if status >= fiber.StatusInternalServerError {
+			return nil
+		}
+
+		record := idempotencyRecord{
+			RequestHash: requestHash,
+			Status:      status,
+			ContentType: string(c.Response().Header.ContentType()),
+			Body:        append([]byte(nil), c.Response().Body()...),
+		}
+		// Failing to store only loses replay protection, the response itself already succeeded
+		_ = cache.Set(ctx, cacheKey, record, ttl)
+
+		return nil
+	}
+}
+
+// loadIdempotencyRecord returns the stored outcome of the first request made with cacheKey
+func loadIdempotencyRecord(c *fiber.Ctx, cache service.CacheService, cacheKey string) (idempotencyRecord, bool) {
+	var record idempotencyRecord
+	cached, err := cache.Get(c.UserContext(), cacheKey)
+	if err != nil {
+		return record, false
+	}
+	if err := json.Unmarshal([]byte(cached), &record); err != nil {
+		return record, false
+	}
+	return record, true
+}
+
+// replayIdempotencyRecord sends the stored response, or 409 when the key was used for a different body
+func replayIdempotencyRecord(c *fiber.Ctx, record idempotencyRecord, requestHash string, metrics telemetry.MetricsService) error {
+	if record.RequestHash != requestHash {
+		return c.Status(fiber.StatusConflict).JSON(
+			response.NewErrorResponse("Idempotency-Key conflict", errors.New("idempotency key was already used with a different request body")),
+		)
+	}
+
+	if metrics != nil {
+		metrics.IncrementCounter("idempotency.replays", map[string]string{"method": c.Method()}, 1)
+	}
+
+	c.Set(HeaderIdempotentReplayed, "true")
+	if record.ContentType != "" {
+		c.Set(fiber.HeaderContentType, record.ContentType)
+	}
+	return c.Status(record.Status).Send(record.Body)
+}
+
+// hashRequestBody fingerprints the request body to detect a key reused for a different request
+func hashRequestBody(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,223 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	cacheredis "github.com/gieart87/gohexaclean/internal/adapter/outbound/redis"
+	servicemock "github.com/gieart87/gohexaclean/internal/port/outbound/service/mock"
+	telemetrymock "github.com/gieart87/gohexaclean/internal/port/outbound/telemetry/mock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testIdempotencyTTL = time.Hour
+
+// setupIdempotencyTest returns an app whose POST /users handler counts executions
+func setupIdempotencyTest(t *testing.T) (*fiber.App, *miniredis.Miniredis, *int32) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	var calls int32
+	app := fiber.New()
+	app.Use(IdempotencyMiddleware(cacheredis.NewCacheServiceRedis(client), testIdempotencyTTL, nil))
+	app.Post("/users", func(c *fiber.Ctx) error {
+		n := atomic.AddInt32(&calls, 1)
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"call": n})
+	})
+
+	return app, mr, &calls
+}
+
+// postUser sends POST /users with the given idempotency key and body
+func postUser(t *testing.T, app *fiber.App, key, body string) (*http.Response, string) {
+	t.Helper()
+
+	req, _ := http.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(HeaderIdempotencyKey, key)
+	}
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	respBody, _ := io.ReadAll(resp.Body)
+	return resp, string(respBody)
+}
+
+func TestIdempotencyMiddleware_ReplaysResponse(t *testing.T) {
+	app, _, calls := setupIdempotencyTest(t)
+
+	first, firstBody := postUser(t, app, "key-1", `{"email":"a@example.com"}`)
+	second, secondBody := postUser(t, app, "key-1", `{"email":"a@example.com"}`)
+
+	assert.Equal(t, fiber.StatusCreated, first.StatusCode)
+	assert.Empty(t, first.Header.Get(HeaderIdempotentReplayed))
+
+	assert.Equal(t, fiber.StatusCreated, second.StatusCode)
+	assert.Equal(t, "true", second.Header.Get(HeaderIdempotentReplayed))
+	assert.Equal(t, fiber.MIMEApplicationJSON, second.Header.Get(fiber.HeaderContentType))
+	assert.Equal(t, firstBody, secondBody)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls), "handler should run once")
+}
+
+func TestIdempotencyMiddleware_ConflictingBody(t *testing.T) {
+	app, _, calls := setupIdempotencyTest(t)
+
+	postUser(t, app, "key-1", `{"email":"a@example.com"}`)
+	resp, _ := postUser(t, app, "key-1", `{"email":"b@example.com"}`)
+
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestIdempotencyMiddleware_ExpiresAfterTTL(t *testing.T) {
+	app, mr, calls := setupIdempotencyTest(t)
+
+	postUser(t, app, "key-1", `{"email":"a@example.com"}`)
+	mr.FastForward(testIdempotencyTTL + time.Second)
+	resp, body := postUser(t, app, "key-1", `{"email":"a@example.com"}`)
+
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get(HeaderIdempotentReplayed))
+	assert.JSONEq(t, `{"call":2}`, body)
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+}
+
+func TestIdempotencyMiddleware_WithoutKey(t *testing.T) {
+	app, _, calls := setupIdempotencyTest(t)
+
+	postUser(t, app, "", `{"email":"a@example.com"}`)
+	postUser(t, app, "", `{"email":"a@example.com"}`)
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+}
+
+func TestIdempotencyMiddleware_KeysAreIndependent(t *testing.T) {
+	app, _, calls := setupIdempotencyTest(t)
+
+	postUser(t, app, "key-1", `{"email":"a@example.com"}`)
+	postUser(t, app, "key-2", `{"email":"a@example.com"}`)
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+}
+
+func TestIdempotencyMiddleware_DoesNotStoreServerErrors(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	var calls int32
+	app := fiber.New()
+	app.Use(IdempotencyMiddleware(cacheredis.NewCacheServiceRedis(client), testIdempotencyTTL, nil))
+	app.Post("/users", func(c *fiber.Ctx) error {
+		atomic.AddInt32(&calls, 1)
+		return c.SendStatus(fiber.StatusServiceUnavailable)
+	})
+
+	postUser(t, app, "key-1", `{}`)
+	resp, _ := postUser(t, app, "key-1", `{}`)
+
+	assert.Equal(t, fiber.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls), "failed requests should be retried")
+}
+
+func TestIdempotencyMiddleware_ConcurrentRetryRejectedWhileInFlight(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	var calls int32
+	release := make(chan struct{})
+	app := fiber.New()
+	app.Use(IdempotencyMiddleware(cacheredis.NewCacheServiceRedis(client), testIdempotencyTTL, nil))
+	app.Post("/users", func(c *fiber.Ctx) error {
+		n := atomic.AddInt32(&calls, 1)
+		<-release
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"call": n})
+	})
+
+	firstDone := make(chan string, 1)
+	go func() {
+		_, body := postUser(t, app, "key-1", `{"email":"a@example.com"}`)
+		firstDone <- body
+	}()
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&calls) == 1 }, time.Second, 5*time.Millisecond)
+
+	// The retry races the first request and must not execute the handler again
+	retry, _ := postUser(t, app, "key-1", `{"email":"a@example.com"}`)
+	assert.Equal(t, fiber.StatusConflict, retry.StatusCode)
+
+	close(release)
+	firstBody := <-firstDone
+
+	// Once the first request finished, its response is replayed
+	replay, replayBody := postUser(t, app, "key-1", `{"email":"a@example.com"}`)
+	assert.Equal(t, fiber.StatusCreated, replay.StatusCode)
+	assert.Equal(t, "true", replay.Header.Get(HeaderIdempotentReplayed))
+	assert.Equal(t, firstBody, replayBody)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestIdempotencyMiddleware_RejectsKeyedRequestsWhileCacheDown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cache := servicemock.NewMockCacheService(ctrl)
+	cache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", errors.New("connection refused")).AnyTimes()
+	cache.EXPECT().AcquireLock(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, false, errors.New("connection refused")).Times(1)
+
+	metrics := telemetrymock.NewMockMetricsService(ctrl)
+	metrics.EXPECT().IncrementCounter("idempotency.unavailable", map[string]string{"method": fiber.MethodPost}, float64(1)).Times(1)
+
+	var calls int32
+	app := fiber.New()
+	app.Use(IdempotencyMiddleware(cache, testIdempotencyTTL, metrics))
+	app.Post("/users", func(c *fiber.Ctx) error {
+		atomic.AddInt32(&calls, 1)
+		return c.SendStatus(fiber.StatusCreated)
+	})
+
+	resp, _ := postUser(t, app, "key-1", `{"email":"a@example.com"}`)
+	assert.Equal(t, fiber.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&calls))
+
+	// Requests without a key don't need the lock
+	resp, _ = postUser(t, app, "", `{"email":"a@example.com"}`)
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestIdempotencyMiddleware_CountsReplays(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	// Only the replayed request is counted, not the first one or a conflicting body
+	metrics := telemetrymock.NewMockMetricsService(ctrl)
+	metrics.EXPECT().IncrementCounter("idempotency.replays", map[string]string{"method": fiber.MethodPost}, float64(1)).Times(1)
+
+	app := fiber.New()
+	app.Use(IdempotencyMiddleware(cacheredis.NewCacheServiceRedis(client), testIdempotencyTTL, metrics))
+	app.Post("/users", func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"ok": true})
+	})
+
+	postUser(t, app, "key-1", `{"email":"a@example.com"}`)
+	postUser(t, app, "key-1", `{"email":"a@example.com"}`)
+	postUser(t, app, "key-1", `{"email":"b@example.com"}`)
+}
@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gieart87/gohexaclean/pkg/response"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpec = `
+openapi: 3.0.3
+info:
+  title: Test API
+  version: 1.0.0
+servers:
+  - url: https://api.example.com/api/v1
+paths:
+  /items/{id}:
+    put:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+        - name: notify
+          in: query
+          schema:
+            type: boolean
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+                  minLength: 3
+      responses:
+        '200':
+          description: OK
+`
+
+// newValidatedApp serves PUT /api/v1/items/:id behind the validator, the handler accepts anything
+func newValidatedApp(t *testing.T) *fiber.App {
+	t.Helper()
+
+	validator, err := OpenAPIValidationMiddleware([]byte(testSpec), "/api/v1")
+	require.NoError(t, err)
+
+	app := fiber.New()
+	app.Use(validator)
+	app.Put("/api/v1/items/:id", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+	app.Get("/api/v1/unlisted", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+	return app
+}
+
+func TestOpenAPIValidationMiddleware(t *testing.T) {
+	const validID = "8d7f0c2e-1111-4c3e-9a59-6a2d4c1f0b7e"
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		wantStatus int
+		wantDetail string
+	}{
+		{name: "valid request", method: "PUT", target: "/api/v1/items/" + validID, body: `{"name":"widget"}`, wantStatus: fiber.StatusOK},
+		{name: "body below minLength", method: "PUT", target: "/api/v1/items/" + validID, body: `{"name":"ab"}`, wantStatus: fiber.StatusBadRequest, wantDetail: `"/name": minimum string length is 3`},
+		{name: "missing required property", method: "PUT", target: "/api/v1/items/" + validID, body: `{}`, wantStatus: fiber.StatusBadRequest, wantDetail: `property "name" is missing`},
+		{name: "wrong property type", method: "PUT", target: "/api/v1/items/" + validID, body: `{"name":42}`, wantStatus: fiber.StatusBadRequest, wantDetail: "/name"},
+		{name: "missing body", method: "PUT", target: "/api/v1/items/" + validID, wantStatus: fiber.StatusBadRequest, wantDetail: "request body"},
+		{name: "invalid query parameter", method: "PUT", target: "/api/v1/items/" + validID + "?notify=maybe", body: `{"name":"widget"}`, wantStatus: fiber.StatusBadRequest, wantDetail: `parameter "notify"`},
+		{name: "method not in spec", method: "DELETE", target: "/api/v1/items/" + validID, wantStatus: fiber.StatusMethodNotAllowed},
+		{name: "path not in spec", method: "GET", target: "/api/v1/unlisted", wantStatus: fiber.StatusOK},
+	}
+
+	app := newValidatedApp(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.body != "" {
+				req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			}
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			if tt.wantDetail == "" {
+				return
+			}
+
+			var body response.ErrorResponse
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			require.Len(t, body.Errors["detail"], 1)
+			assert.Contains(t, body.Errors["detail"][0], tt.wantDetail)
+		})
+	}
+}
+
+func TestOpenAPIValidationMiddleware_RejectsInvalidSpec(t *testing.T) {
+	_, err := OpenAPIValidationMiddleware([]byte("openapi: 3.0.3\npaths: {}\n"), "/api/v1")
+	assert.Error(t, err)
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"github.com/gieart87/gohexaclean/pkg/requestid"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// maxRequestIDLength bounds client supplied request IDs so they can't bloat logs and audit rows
+const maxRequestIDLength = 128
+
+// RequestIDMiddleware tags each request with an ID, reusing the client's X-Request-ID when present
+// The ID is echoed in the response header and stored in the request context
+func RequestIDMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id := c.Get(requestid.Header)
+		if id == "" || len(id) > maxRequestIDLength {
+			id = uuid.New().String()
+		}
+
+		c.Set(requestid.Header, id)
+		c.Locals("requestID", id)
+		c.SetUserContext(requestid.NewContext(c.UserContext(), id))
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gieart87/gohexaclean/pkg/requestid"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRequestIDTestApp() *fiber.App {
+	app := fiber.New()
+	app.Use(RequestIDMiddleware())
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString(requestid.FromContext(c.UserContext()))
+	})
+	return app
+}
+
+func TestRequestIDMiddleware_GeneratesID(t *testing.T) {
+	resp, err := newRequestIDTestApp().Test(httptestRequest(""))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	id := resp.Header.Get(requestid.Header)
+	_, parseErr := uuid.Parse(id)
+	assert.NoError(t, parseErr)
+	assert.Equal(t, id, string(body), "handler context carries the same ID as the response header")
+}
+
+func TestRequestIDMiddleware_ReusesClientID(t *testing.T) {
+	resp, err := newRequestIDTestApp().Test(httptestRequest("client-abc"))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, "client-abc", resp.Header.Get(requestid.Header))
+}
+
+func TestRequestIDMiddleware_ReplacesOversizedID(t *testing.T) {
+	oversized := strings.Repeat("a", maxRequestIDLength+1)
+
+	resp, err := newRequestIDTestApp().Test(httptestRequest(oversized))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	id := resp.Header.Get(requestid.Header)
+	assert.NotEqual(t, oversized, id)
+	assert.NotEmpty(t, id)
+}
+
+func httptestRequest(id string) *http.Request {
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	if id != "" {
+		req.Header.Set(requestid.Header, id)
+	}
+	return req
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"fmt"
+	"mime"
+
+	"github.com/gieart87/gohexaclean/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequireJSON rejects POST, PUT and PATCH requests whose body isn't declared as application/json with 415
+// Requests without a body pass whatever their Content-Type, so bodiless actions such as activate keep working
+func RequireJSON() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		switch c.Method() {
+		case fiber.MethodPost, fiber.MethodPut, fiber.MethodPatch:
+		default:
+			return c.Next()
+		}
+
+		if len(c.Request().Body()) == 0 {
+			return c.Next()
+		}
+
+		contentType := string(c.Request().Header.ContentType())
+		if mediaType, _, err := mime.ParseMediaType(contentType); err == nil && mediaType == fiber.MIMEApplicationJSON {
+			return c.Next()
+		}
+
+		if contentType == "" {
+			contentType = "none"
+		}
+		return c.Status(fiber.StatusUnsupportedMediaType).JSON(
+			response.NewErrorResponseWithCode("Unsupported media type", "UNSUPPORTED_MEDIA_TYPE",
+				fmt.Errorf("content type must be %s, got %s", fiber.MIMEApplicationJSON, contentType)),
+		)
+	}
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gieart87/gohexaclean/pkg/response"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequireJSON(t *testing.T) {
+	app := fiber.New()
+	app.Use(RequireJSON())
+	app.All("/users", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	tests := []struct {
+		name        string
+		method      string
+		contentType string
+		body        string
+		wantStatus  int
+	}{
+		{name: "json", method: http.MethodPost, contentType: "application/json", body: `{"name":"Jane"}`, wantStatus: fiber.StatusNoContent},
+		{name: "json with charset", method: http.MethodPut, contentType: "Application/JSON; charset=utf-8", body: `{"name":"Jane"}`, wantStatus: fiber.StatusNoContent},
+		{name: "form encoded", method: http.MethodPost, contentType: "application/x-www-form-urlencoded", body: "name=Jane", wantStatus: fiber.StatusUnsupportedMediaType},
+		{name: "plain text", method: http.MethodPatch, contentType: "text/plain", body: `{"name":"Jane"}`, wantStatus: fiber.StatusUnsupportedMediaType},
+		{name: "missing content type", method: http.MethodPost, body: `{"name":"Jane"}`, wantStatus: fiber.StatusUnsupportedMediaType},
+		{name: "empty body without content type", method: http.MethodPost, wantStatus: fiber.StatusNoContent},
+		{name: "delete is not checked", method: http.MethodDelete, contentType: "text/plain", body: "x", wantStatus: fiber.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(tt.method, "/users", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestRequireJSON_ErrorResponse(t *testing.T) {
+	app := fiber.New()
+	app.Use(RequireJSON())
+	app.Post("/auth/login", func(c *fiber.Ctx) error {
+		t.Fatal("handler must not run for a form-encoded body")
+		return nil
+	})
+
+	req, _ := http.NewRequest(http.MethodPost, "/auth/login", strings.NewReader("email=jane%40example.com"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, fiber.StatusUnsupportedMediaType, resp.StatusCode)
+
+	var body response.ErrorResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.False(t, body.Success)
+	assert.Equal(t, "Unsupported media type", body.Message)
+	assert.Equal(t, "UNSUPPORTED_MEDIA_TYPE", body.ErrorCode)
+}
@@ -1,8 +1,10 @@
 package middleware
 
 import (
-	"context"
+	"errors"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gieart87/gohexaclean/internal/port/outbound/telemetry"
@@ -10,36 +12,39 @@ import (
 )
 
 // TelemetryMiddleware creates middleware for collecting HTTP metrics and traces
+// metrics and tracing must not be nil, pass the noop implementations to disable them
 func TelemetryMiddleware(metrics telemetry.MetricsService, tracing telemetry.TracingService) fiber.Handler {
+	inFlight := &inFlightGauge{metrics: metrics}
+
 	return func(c *fiber.Ctx) error {
 		start := time.Now()
 
-		// Start tracing span if tracing is enabled
-		var span telemetry.Span
-		if tracing != nil {
-			operationName := c.Method() + " " + c.Route().Path
-			var ctx context.Context
-			span, ctx = tracing.StartSpan(c.UserContext(), operationName)
-			c.SetUserContext(ctx)
-			defer span.Finish()
-
-			// Set span tags
-			span.SetTag("http.method", c.Method())
-			span.SetTag("http.url", c.OriginalURL())
-			span.SetTag("http.route", c.Route().Path)
-		}
+		// Track concurrent requests for capacity planning
+		inFlight.add(1)
+		defer inFlight.add(-1)
+
+		// The route isn't matched until c.Next runs, so route based naming and tags are set afterwards
+		span, ctx := tracing.StartSpan(c.UserContext(), "http.request")
+		c.SetUserContext(ctx)
+		defer span.Finish()
+
+		span.SetTag("http.method", c.Method())
 
 		// Process request
 		err := c.Next()
 
-		// Record metrics if metrics service is enabled
-		if metrics != nil {
+		// Tag with the route template rather than the raw URL, so IDs and query strings
+		// neither explode cardinality nor leak into telemetry
+		route, matched := matchedRoute(c, err)
+		statusCode := responseStatus(c, err)
+
+		// Unmatched requests are skipped so probes for random paths don't pile up under an empty route label
+		if matched {
 			duration := time.Since(start)
-			statusCode := c.Response().StatusCode()
 
 			tags := map[string]string{
 				"method": c.Method(),
-				"route":  c.Route().Path,
+				"route":  route,
 				"status": strconv.Itoa(statusCode),
 			}
 
@@ -59,20 +64,86 @@ func TelemetryMiddleware(metrics telemetry.MetricsService, tracing telemetry.Tra
 			}
 		}
 
-		// Update span with status code if tracing is enabled
-		if span != nil {
-			statusCode := c.Response().StatusCode()
-			span.SetTag("http.status_code", statusCode)
+		// Update span with route and status code
+		span.SetName(c.Method() + " " + route)
+		span.SetTag("http.route", route)
+		span.SetTag("http.url", route)
+		span.SetTag("http.target", route)
 
-			if statusCode >= 400 {
-				span.SetTag("error", true)
-			}
+		span.SetTag("http.status_code", statusCode)
 
-			if err != nil {
-				span.SetError(err)
-			}
+		if statusCode >= 400 {
+			span.SetTag("error", true)
+		}
+
+		if err != nil {
+			span.SetError(err)
 		}
 
 		return err
 	}
 }
+
+// unmatchedRoute labels spans of requests that matched no route
+const unmatchedRoute = "unmatched"
+
+// matchedRoute returns the template of the route that handled the request, e.g. /api/v1/users/:id
+// Fiber reports a request no route matched by ending the chain with its own "Cannot METHOD path"
+// not found error (or 405 when only other methods match), c.Route() is then just the last middleware.
+// Catch-all handlers answering any path (app.Use("/", ...) or a "/*" route, e.g. a custom 404 page)
+// are treated as unmatched too, their template says nothing about the request.
+func matchedRoute(c *fiber.Ctx, err error) (string, bool) {
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		notFound := fiberErr.Code == fiber.StatusNotFound && strings.HasPrefix(fiberErr.Message, "Cannot ")
+		if notFound || fiberErr == fiber.ErrMethodNotAllowed {
+			return unmatchedRoute, false
+		}
+	}
+
+	route := c.Route()
+	if route == nil || route.Path == "" || isCatchAll(route.Path, c.Path()) {
+		return unmatchedRoute, false
+	}
+	return route.Path, true
+}
+
+// isCatchAll reports whether routePath matches every request path rather than describing path
+// A "/" route only stands for the root when the request is for the root, otherwise it is a middleware prefix
+func isCatchAll(routePath, path string) bool {
+	switch routePath {
+	case "*", "/*":
+		return true
+	case "/":
+		return path != "/"
+	}
+	return false
+}
+
+// responseStatus returns the status code the client will see
+// Errors returned down the chain are rendered by the error handler after this middleware, so take their code
+func responseStatus(c *fiber.Ctx, err error) int {
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Code
+	}
+	return c.Response().StatusCode()
+}
+
+// inFlightGauge reports the number of requests currently being served
+// Changing the count and reporting it happen under one lock, so reports land in order
+// and the last one is always the current count, even once traffic stops
+type inFlightGauge struct {
+	metrics telemetry.MetricsService
+
+	mu    sync.Mutex
+	count int64
+}
+
+func (g *inFlightGauge) add(delta int64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.count += delta
+	g.metrics.SetGauge("http.requests.in_flight", nil, float64(g.count))
+}
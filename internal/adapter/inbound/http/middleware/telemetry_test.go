@@ -0,0 +1,271 @@
+package middleware
+
+import (
+	"context"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gieart87/gohexaclean/internal/adapter/outbound/noop"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/telemetry"
+	telemetrymock "github.com/gieart87/gohexaclean/internal/port/outbound/telemetry/mock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gaugeRecorder keeps the latest value reported for the in-flight gauge
+type gaugeRecorder struct {
+	mu     sync.Mutex
+	latest float64
+}
+
+func (r *gaugeRecorder) set(_ string, _ map[string]string, value float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.latest = value
+}
+
+func (r *gaugeRecorder) value() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.latest
+}
+
+func TestTelemetryMiddleware_TracksInFlightRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gauge := &gaugeRecorder{}
+	metrics := telemetrymock.NewMockMetricsService(ctrl)
+	metrics.EXPECT().SetGauge("http.requests.in_flight", gomock.Any(), gomock.Any()).Do(gauge.set).AnyTimes()
+	metrics.EXPECT().IncrementCounter(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	metrics.EXPECT().RecordTiming(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	release := make(chan struct{})
+	app := fiber.New()
+	app.Use(TelemetryMiddleware(metrics, noop.NewNoopTracingService()))
+	app.Get("/slow", func(c *fiber.Ctx) error {
+		<-release
+		return c.SendString("done")
+	})
+
+	const requests = 2
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := app.Test(httptest.NewRequest("GET", "/slow", nil), -1)
+			if assert.NoError(t, err) {
+				_ = resp.Body.Close()
+			}
+		}()
+	}
+
+	require.Eventually(t, func() bool { return gauge.value() == requests }, time.Second, 5*time.Millisecond,
+		"gauge should count both slow requests while they are being served")
+
+	// Finish the requests one at a time
+	release <- struct{}{}
+	require.Eventually(t, func() bool { return gauge.value() == requests-1 }, time.Second, 5*time.Millisecond)
+	release <- struct{}{}
+	wg.Wait()
+
+	assert.Equal(t, 0.0, gauge.value())
+}
+
+func TestInFlightGauge_LastReportIsCurrentCount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gauge := &gaugeRecorder{}
+	metrics := telemetrymock.NewMockMetricsService(ctrl)
+	metrics.EXPECT().SetGauge("http.requests.in_flight", gomock.Any(), gomock.Any()).Do(gauge.set).AnyTimes()
+
+	inFlight := &inFlightGauge{metrics: metrics}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			inFlight.add(1)
+			inFlight.add(-1)
+		}()
+	}
+	wg.Wait()
+
+	// A report of a stale count overtaking the final one would leave the gauge above zero with no traffic
+	assert.Equal(t, 0.0, gauge.value())
+}
+
+// recordingSpan keeps the name and tags set on a span
+type recordingSpan struct {
+	name     string
+	tags     map[string]interface{}
+	finished bool
+}
+
+func (s *recordingSpan) SetTag(key string, value interface{}) { s.tags[key] = value }
+func (s *recordingSpan) SetName(name string)                  { s.name = name }
+func (s *recordingSpan) SetError(error)                       {}
+func (s *recordingSpan) Finish()                              { s.finished = true }
+
+// recordingTracer hands out recordingSpans and remembers the last one started
+type recordingTracer struct {
+	last *recordingSpan
+}
+
+func (t *recordingTracer) StartSpan(ctx context.Context, name string, _ ...interface{}) (telemetry.Span, context.Context) {
+	t.last = &recordingSpan{name: name, tags: make(map[string]interface{})}
+	return t.last, ctx
+}
+
+func (t *recordingTracer) StartChildSpan(ctx context.Context, name string) (telemetry.Span, context.Context) {
+	return t.StartSpan(ctx, name)
+}
+
+func (t *recordingTracer) Close() error { return nil }
+
+func TestTelemetryMiddleware_NormalizesParameterizedRoute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedTags := map[string]string{"method": "GET", "route": "/api/v1/users/:id", "status": "200"}
+	metrics := telemetrymock.NewMockMetricsService(ctrl)
+	metrics.EXPECT().SetGauge("http.requests.in_flight", gomock.Any(), gomock.Any()).AnyTimes()
+	metrics.EXPECT().IncrementCounter("http.requests.total", expectedTags, 1.0)
+	metrics.EXPECT().IncrementCounter("http.requests.success", expectedTags, 1.0)
+	metrics.EXPECT().RecordTiming("http.request.duration", expectedTags, gomock.Any())
+
+	tracer := &recordingTracer{}
+	app := fiber.New()
+	app.Use(TelemetryMiddleware(metrics, tracer))
+	app.Get("/api/v1/users/:id", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/users/8d7f0c2e-1111-4c3e-9a59-6a2d4c1f0b7e?email=jane@example.com", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	span := tracer.last
+	require.NotNil(t, span)
+	assert.True(t, span.finished)
+	assert.Equal(t, "GET /api/v1/users/:id", span.name)
+	assert.Equal(t, "/api/v1/users/:id", span.tags["http.route"])
+	assert.Equal(t, "/api/v1/users/:id", span.tags["http.url"])
+	assert.Equal(t, "/api/v1/users/:id", span.tags["http.target"])
+	assert.Equal(t, 200, span.tags["http.status_code"])
+}
+
+func TestTelemetryMiddleware_SkipsMetricsForUnmatchedRoute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Only the in-flight gauge may be touched, any request metric fails the test
+	metrics := telemetrymock.NewMockMetricsService(ctrl)
+	metrics.EXPECT().SetGauge("http.requests.in_flight", gomock.Any(), gomock.Any()).AnyTimes()
+
+	tracer := &recordingTracer{}
+	app := fiber.New()
+	app.Use(TelemetryMiddleware(metrics, tracer))
+	app.Get("/api/v1/users/:id", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/wp-admin/setup.php?step=1", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	require.NotNil(t, tracer.last)
+	assert.Equal(t, "GET unmatched", tracer.last.name)
+	assert.Equal(t, "unmatched", tracer.last.tags["http.url"])
+	assert.Equal(t, fiber.StatusNotFound, tracer.last.tags["http.status_code"])
+}
+
+func TestTelemetryMiddleware_WorksWithNoopTelemetry(t *testing.T) {
+	app := fiber.New()
+	app.Use(TelemetryMiddleware(noop.NewNoopMetricsService(), noop.NewNoopTracingService()))
+	app.Get("/ping", func(c *fiber.Ctx) error {
+		return c.SendString("pong")
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/ping", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestTelemetryMiddleware_CatchAllHandlersAreUnmatched(t *testing.T) {
+	notFound := func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusNotFound).SendString("custom not found")
+	}
+
+	tests := []struct {
+		name     string
+		register func(app *fiber.App)
+	}{
+		{name: "middleware catch-all", register: func(app *fiber.App) { app.Use(notFound) }},
+		{name: "wildcard route", register: func(app *fiber.App) { app.Get("/*", notFound) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Only the in-flight gauge may be touched, any request metric fails the test
+			metrics := telemetrymock.NewMockMetricsService(ctrl)
+			metrics.EXPECT().SetGauge("http.requests.in_flight", gomock.Any(), gomock.Any()).AnyTimes()
+
+			tracer := &recordingTracer{}
+			app := fiber.New()
+			app.Use(TelemetryMiddleware(metrics, tracer))
+			app.Get("/api/v1/users/:id", func(c *fiber.Ctx) error {
+				return c.SendString("ok")
+			})
+			tt.register(app)
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/wp-admin/setup.php", nil))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+			require.NotNil(t, tracer.last)
+			assert.Equal(t, "GET unmatched", tracer.last.name)
+			assert.Equal(t, "unmatched", tracer.last.tags["http.route"])
+		})
+	}
+}
+
+func TestTelemetryMiddleware_RootRouteIsMatched(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedTags := map[string]string{"method": "GET", "route": "/", "status": "200"}
+	metrics := telemetrymock.NewMockMetricsService(ctrl)
+	metrics.EXPECT().SetGauge("http.requests.in_flight", gomock.Any(), gomock.Any()).AnyTimes()
+	metrics.EXPECT().IncrementCounter("http.requests.total", expectedTags, 1.0)
+	metrics.EXPECT().IncrementCounter("http.requests.success", expectedTags, 1.0)
+	metrics.EXPECT().RecordTiming("http.request.duration", expectedTags, gomock.Any())
+
+	tracer := &recordingTracer{}
+	app := fiber.New()
+	app.Use(TelemetryMiddleware(metrics, tracer))
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString("home")
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.NotNil(t, tracer.last)
+	assert.Equal(t, "GET /", tracer.last.name)
+}
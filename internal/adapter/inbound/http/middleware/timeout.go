@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gieart87/gohexaclean/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+// TimeoutMiddleware bounds each request with a context deadline
+// The handler runs on the request goroutine, so downstream calls must use c.UserContext()
+// to observe the deadline; once it expires the response is replaced with 504 Gateway Timeout
+func TimeoutMiddleware(timeout time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if timeout <= 0 {
+			return c.Next()
+		}
+
+		ctx, cancel := context.WithTimeout(c.UserContext(), timeout)
+		defer cancel()
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) || errors.Is(err, context.DeadlineExceeded) {
+			c.Response().ResetBody()
+			return c.Status(fiber.StatusGatewayTimeout).JSON(
+				response.NewErrorResponseWithCode("Request timeout", "REQUEST_TIMEOUT", nil),
+			)
+		}
+
+		return err
+	}
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gieart87/gohexaclean/pkg/response"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeoutMiddleware_SlowHandler(t *testing.T) {
+	handlerErr := make(chan error, 1)
+
+	app := fiber.New()
+	app.Use(TimeoutMiddleware(50 * time.Millisecond))
+	app.Get("/slow", func(c *fiber.Ctx) error {
+		select {
+		case <-c.UserContext().Done():
+			handlerErr <- c.UserContext().Err()
+			return c.UserContext().Err()
+		case <-time.After(time.Second):
+			handlerErr <- nil
+			return c.SendString("done")
+		}
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/slow", nil)
+	resp, err := app.Test(req, 2000)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, fiber.StatusGatewayTimeout, resp.StatusCode)
+	assert.ErrorIs(t, <-handlerErr, context.DeadlineExceeded)
+
+	var body response.ErrorResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.False(t, body.Success)
+	assert.Equal(t, "REQUEST_TIMEOUT", body.ErrorCode)
+}
+
+func TestTimeoutMiddleware_FastHandler(t *testing.T) {
+	app := fiber.New()
+	app.Use(TimeoutMiddleware(time.Second))
+	app.Get("/fast", func(c *fiber.Ctx) error {
+		_, hasDeadline := c.UserContext().Deadline()
+		assert.True(t, hasDeadline)
+		return c.SendString("ok")
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/fast", nil)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestTimeoutMiddleware_Disabled(t *testing.T) {
+	app := fiber.New()
+	app.Use(TimeoutMiddleware(0))
+	app.Get("/", func(c *fiber.Ctx) error {
+		_, hasDeadline := c.UserContext().Deadline()
+		assert.False(t, hasDeadline)
+		return c.SendString("ok")
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
@@ -0,0 +1,139 @@
+package router
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/gieart87/gohexaclean/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+// openAPIParam matches OpenAPI-style path parameters such as {id}
+var openAPIParam = regexp.MustCompile(`\{([^}/]+)\}`)
+
+// methodFilter wraps a fiber.Router and, at registration time, replaces the handlers of
+// disabled methods/routes with a 405 response. Used to run read-only deployments from the same binary
+type methodFilter struct {
+	fiber.Router
+	prefix          string
+	disabledMethods map[string]struct{}
+	disabledRoutes  map[string]struct{}
+}
+
+// newMethodFilter returns router unchanged when nothing is disabled
+// Routes are "METHOD /full/path", with parameters written as :id or {id}
+func newMethodFilter(router fiber.Router, prefix string, disabledMethods, disabledRoutes []string) fiber.Router {
+	if len(disabledMethods) == 0 && len(disabledRoutes) == 0 {
+		return router
+	}
+
+	f := &methodFilter{
+		Router:          router,
+		prefix:          prefix,
+		disabledMethods: make(map[string]struct{}, len(disabledMethods)),
+		disabledRoutes:  make(map[string]struct{}, len(disabledRoutes)),
+	}
+
+	for _, method := range disabledMethods {
+		f.disabledMethods[strings.ToUpper(strings.TrimSpace(method))] = struct{}{}
+	}
+
+	for _, route := range disabledRoutes {
+		method, path, ok := strings.Cut(strings.TrimSpace(route), " ")
+		if !ok {
+			continue
+		}
+		path = openAPIParam.ReplaceAllString(strings.TrimSpace(path), ":$1")
+		f.disabledRoutes[routeKey(method, path)] = struct{}{}
+	}
+
+	return f
+}
+
+// Get registers GET (and HEAD, like fiber) routes
+func (f *methodFilter) Get(path string, handlers ...fiber.Handler) fiber.Router {
+	f.Add(fiber.MethodHead, path, handlers...)
+	return f.Add(fiber.MethodGet, path, handlers...)
+}
+
+// Head registers HEAD routes
+func (f *methodFilter) Head(path string, handlers ...fiber.Handler) fiber.Router {
+	return f.Add(fiber.MethodHead, path, handlers...)
+}
+
+// Post registers POST routes
+func (f *methodFilter) Post(path string, handlers ...fiber.Handler) fiber.Router {
+	return f.Add(fiber.MethodPost, path, handlers...)
+}
+
+// Put registers PUT routes
+func (f *methodFilter) Put(path string, handlers ...fiber.Handler) fiber.Router {
+	return f.Add(fiber.MethodPut, path, handlers...)
+}
+
+// Patch registers PATCH routes
+func (f *methodFilter) Patch(path string, handlers ...fiber.Handler) fiber.Router {
+	return f.Add(fiber.MethodPatch, path, handlers...)
+}
+
+// Delete registers DELETE routes
+func (f *methodFilter) Delete(path string, handlers ...fiber.Handler) fiber.Router {
+	return f.Add(fiber.MethodDelete, path, handlers...)
+}
+
+// All registers the route for every default method, like fiber
+func (f *methodFilter) All(path string, handlers ...fiber.Handler) fiber.Router {
+	for _, method := range fiber.DefaultMethods {
+		f.Add(method, path, handlers...)
+	}
+	return f
+}
+
+// Group returns a filtered sub-router, so routes registered through it are disabled like direct ones
+func (f *methodFilter) Group(prefix string, handlers ...fiber.Handler) fiber.Router {
+	return &methodFilter{
+		Router:          f.Router.Group(prefix, handlers...),
+		prefix:          f.prefix + prefix,
+		disabledMethods: f.disabledMethods,
+		disabledRoutes:  f.disabledRoutes,
+	}
+}
+
+// Route calls fn with a filtered sub-router for prefix
+func (f *methodFilter) Route(prefix string, fn func(router fiber.Router), name ...string) fiber.Router {
+	group := f.Group(prefix)
+	if len(name) > 0 {
+		group.Name(name[0])
+	}
+	fn(group)
+	return group
+}
+
+// Add registers a route, swapping in the 405 handler if the method or route is disabled
+func (f *methodFilter) Add(method, path string, handlers ...fiber.Handler) fiber.Router {
+	if f.isDisabled(method, path) {
+		handlers = []fiber.Handler{methodNotAllowed}
+	}
+	return f.Router.Add(method, path, handlers...)
+}
+
+// isDisabled reports whether the method or the exact route has been disabled
+func (f *methodFilter) isDisabled(method, path string) bool {
+	if _, ok := f.disabledMethods[strings.ToUpper(method)]; ok {
+		return true
+	}
+	_, ok := f.disabledRoutes[routeKey(method, f.prefix+path)]
+	return ok
+}
+
+// routeKey builds the lookup key for a method and full path
+func routeKey(method, path string) string {
+	return strings.ToUpper(method) + " " + strings.TrimRight(path, "/")
+}
+
+// methodNotAllowed responds to requests for disabled methods/routes
+func methodNotAllowed(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusMethodNotAllowed).JSON(
+		response.NewErrorResponseWithCode("Method not allowed on this deployment", "METHOD_DISABLED", nil),
+	)
+}
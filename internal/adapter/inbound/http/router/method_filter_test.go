@@ -0,0 +1,46 @@
+package router
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMethodFilter_GroupedRoutes(t *testing.T) {
+	app := fiber.New()
+	api := newMethodFilter(app.Group("/api/v1"), "/api/v1", []string{"PUT"}, []string{"DELETE /api/v1/admin/users/{id}"})
+
+	ok := func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) }
+	admin := api.Group("/admin")
+	admin.Delete("/users/:id", ok)
+	admin.Get("/users/:id", ok)
+	admin.Put("/users/:id", ok)
+	api.Route("/reports", func(reports fiber.Router) {
+		reports.Put("/:id", ok)
+		reports.Post("/:id", ok)
+	})
+
+	tests := []struct {
+		method string
+		path   string
+		want   int
+	}{
+		{method: http.MethodDelete, path: "/api/v1/admin/users/42", want: fiber.StatusMethodNotAllowed},
+		{method: http.MethodPut, path: "/api/v1/admin/users/42", want: fiber.StatusMethodNotAllowed},
+		{method: http.MethodGet, path: "/api/v1/admin/users/42", want: fiber.StatusNoContent},
+		{method: http.MethodPut, path: "/api/v1/reports/7", want: fiber.StatusMethodNotAllowed},
+		{method: http.MethodPost, path: "/api/v1/reports/7", want: fiber.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package router
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// routeMiddleware wraps a fiber.Router and prepends handlers to every route registered through it
+// Unlike Use, the handlers only run for requests that matched one of those routes, and they are
+// dropped together with the route's own handlers when methodFilter disables it
+type routeMiddleware struct {
+	fiber.Router
+	handlers []fiber.Handler
+}
+
+// withRouteMiddleware returns router unchanged when there is nothing to prepend
+func withRouteMiddleware(router fiber.Router, handlers ...fiber.Handler) fiber.Router {
+	var active []fiber.Handler
+	for _, h := range handlers {
+		if h != nil {
+			active = append(active, h)
+		}
+	}
+	if len(active) == 0 {
+		return router
+	}
+	return &routeMiddleware{Router: router, handlers: active}
+}
+
+// Get registers GET (and HEAD, like fiber) routes
+func (r *routeMiddleware) Get(path string, handlers ...fiber.Handler) fiber.Router {
+	r.Add(fiber.MethodHead, path, handlers...)
+	return r.Add(fiber.MethodGet, path, handlers...)
+}
+
+// Head registers HEAD routes
+func (r *routeMiddleware) Head(path string, handlers ...fiber.Handler) fiber.Router {
+	return r.Add(fiber.MethodHead, path, handlers...)
+}
+
+// Post registers POST routes
+func (r *routeMiddleware) Post(path string, handlers ...fiber.Handler) fiber.Router {
+	return r.Add(fiber.MethodPost, path, handlers...)
+}
+
+// Put registers PUT routes
+func (r *routeMiddleware) Put(path string, handlers ...fiber.Handler) fiber.Router {
+	return r.Add(fiber.MethodPut, path, handlers...)
+}
+
+// Patch registers PATCH routes
+func (r *routeMiddleware) Patch(path string, handlers ...fiber.Handler) fiber.Router {
+	return r.Add(fiber.MethodPatch, path, handlers...)
+}
+
+// Delete registers DELETE routes
+func (r *routeMiddleware) Delete(path string, handlers ...fiber.Handler) fiber.Router {
+	return r.Add(fiber.MethodDelete, path, handlers...)
+}
+
+// Add registers a route with the wrapped handlers running first
+func (r *routeMiddleware) Add(method, path string, handlers ...fiber.Handler) fiber.Router {
+	chain := make([]fiber.Handler, 0, len(r.handlers)+len(handlers))
+	chain = append(chain, r.handlers...)
+	chain = append(chain, handlers...)
+	return r.Router.Add(method, path, chain...)
+}
@@ -1,16 +1,21 @@
 package router
 
 import (
-	"os"
-
 	"github.com/gieart87/gohexaclean/internal/adapter/inbound/http/generated/healthapi"
 	"github.com/gieart87/gohexaclean/internal/adapter/inbound/http/generated/userapi"
 	"github.com/gieart87/gohexaclean/internal/adapter/inbound/http/handler"
 	"github.com/gieart87/gohexaclean/internal/adapter/inbound/http/handler/health"
 	"github.com/gieart87/gohexaclean/internal/adapter/inbound/http/handler/user"
+	"github.com/gieart87/gohexaclean/internal/adapter/inbound/http/middleware"
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/gieart87/gohexaclean/internal/infra/config"
 	"github.com/gieart87/gohexaclean/internal/infra/logger"
 	"github.com/gieart87/gohexaclean/internal/port/inbound"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/service"
 	"github.com/gieart87/gohexaclean/internal/port/outbound/telemetry"
+	"github.com/gieart87/gohexaclean/pkg/auth"
+	"github.com/gieart87/gohexaclean/pkg/pagination"
+	"github.com/gieart87/gohexaclean/pkg/validation"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -18,50 +23,111 @@ import (
 func SetupRoutes(
 	app *fiber.App,
 	userService inbound.UserServicePort,
-	jwtSecret string,
+	passwordPolicy validation.PasswordPolicy,
+	pageBounds pagination.Bounds,
+	cacheService service.CacheService,
+	httpConfig *config.HTTPConfig,
+	tokenOpts auth.TokenOptions,
 	log *logger.Logger,
 	metricsService telemetry.MetricsService,
 	tracingService telemetry.TracingService,
+	requestValidator fiber.Handler,
 ) {
-	// API v1 group
-	api := app.Group("/api/v1")
+	// API v1 group, with disabled methods/routes answered by 405
+	api := newMethodFilter(app.Group("/api/v1"), "/api/v1", httpConfig.DisabledMethods, httpConfig.DisabledRoutes)
+
+	// Request body limits, tight for the public auth routes and loose for bulk endpoints
+	api.Use(middleware.BodyLimitMiddleware(httpConfig.GetMaxBodySize(), map[string]int{
+		"/api/v1/auth":                    httpConfig.GetAuthMaxBodySize(),
+		"/api/v1/admin/users/bulk-create": httpConfig.GetBulkMaxBodySize(),
+		"/api/v1/admin/users/bulk-delete": httpConfig.GetBulkMaxBodySize(),
+	}))
+
+	// Mutating requests with a body must send JSON, BodyParser would otherwise half-parse forms or text
+	api.Use(middleware.RequireJSON())
+
+	// Response compression (registered before ETag so the hash covers the uncompressed JSON)
+	if httpConfig.Compression.Enabled {
+		api.Use(middleware.CompressionMiddleware(&httpConfig.Compression))
+	}
+
+	// ETag / conditional GET support for read endpoints
+	api.Use(middleware.ETagMiddleware())
+
+	// Request deadline propagated to handlers through c.UserContext()
+	api.Use(middleware.TimeoutMiddleware(httpConfig.RequestTimeout))
+
+	// Retried POST/PUT/DELETE requests carrying an Idempotency-Key replay the first response
+	if cacheService != nil {
+		api.Use(middleware.IdempotencyMiddleware(cacheService, httpConfig.GetIdempotencyTTL(), metricsService))
+	}
 
 	// Swagger documentation
 	swaggerHandler := handler.NewSwaggerHandler()
 	api.Get("/swagger", swaggerHandler.ServeSwaggerUI)
-	api.Get("/swagger/spec", func(c *fiber.Ctx) error {
-		// Read OpenAPI spec file
-		spec, err := os.ReadFile("api/openapi/user-api.yaml")
-		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "Failed to load API specification",
-			})
-		}
-		c.Set("Content-Type", "application/x-yaml")
-		return c.Send(spec)
-	})
+	api.Get("/swagger/spec", swaggerHandler.ServeSpec)
+
+	// Publish the RS256 verification key at the conventional location, outside the versioned API
+	if tokenOpts.Algorithm == auth.AlgorithmRS256 {
+		app.Get("/.well-known/jwks.json", handler.NewJWKSHandler(tokenOpts).ServeJWKS)
+	}
 
 	// Create health handler that implements healthapi.ServerInterface
 	healthHandler := health.NewHandler()
 
 	// Create user handler that implements userapi.ServerInterface
-	userHandler := user.NewHandler(userService)
+	userHandler := user.NewHandler(userService, passwordPolicy, pageBounds, httpConfig.BareResponses)
 
 	// Auto-register health routes from OpenAPI spec
 	// This will create: GET /health (public - health check)
 	healthapi.RegisterHandlers(api, healthHandler)
 
-	// Auto-register user routes from OpenAPI spec
+	// Authenticated routes: any valid token, regardless of role
+	api.Get("/auth/me", middleware.AuthMiddleware(tokenOpts, userService))
+	api.Delete("/auth/me", middleware.AuthMiddleware(tokenOpts, userService))
+	api.Post("/users/:id/email", middleware.AuthMiddleware(tokenOpts, userService))
+	api.Post("/users/:id/email/verify", middleware.AuthMiddleware(tokenOpts, userService))
+	api.Get("/users/:id/events", middleware.AuthMiddleware(tokenOpts, userService))
+
+	// Admin-only routes: auth and role checks run first, then fall through to the generated handler
+	requireAdmin := []fiber.Handler{
+		middleware.AuthMiddleware(tokenOpts, userService),
+		middleware.RequireRole(domain.RoleAdmin),
+	}
+	api.Post("/admin/users/:id/revoke-tokens", requireAdmin...)
+	api.Post("/admin/users/:id/activate", requireAdmin...)
+	api.Post("/admin/users/:id/deactivate", requireAdmin...)
+	api.Get("/admin/users/search", requireAdmin...)
+	api.Post("/admin/users/bulk-create", requireAdmin...)
+	api.Post("/admin/users/bulk-delete", requireAdmin...)
+	api.Get("/admin/users/export", requireAdmin...)
+	api.Get("/admin/users/export.jsonl", requireAdmin...)
+
+	// Auto-register user routes from OpenAPI spec, each request checked against the spec before its handler runs
 	// This will create routes for:
 	// Auth:
 	// - POST /auth/login (public - login)
 	// - POST /auth/register (public - register)
+	// - GET /auth/me (protected - current user profile)
+	// - DELETE /auth/me (protected - delete own account)
+	// Users:
+	// - POST /users/{id}/email (protected - request an email change, own account or admin)
+	// - POST /users/{id}/email/verify (protected - confirm the mailed token, own account or admin)
+	// - GET /users/{id}/events (protected - own activity timeline, admins see any user's)
 	// Admin:
 	// - GET /admin/users (protected - list users)
+	// - GET /admin/users/search (admin - full-text search)
+	// - POST /admin/users/bulk-create (admin - create many users, each reported on its own)
+	// - POST /admin/users/bulk-delete (admin - delete many users in one transaction)
+	// - GET /admin/users/export (admin - CSV download)
+	// - GET /admin/users/export.jsonl (admin - JSON Lines stream)
 	// - GET /admin/users/{id} (protected - get user)
 	// - PUT /admin/users/{id} (protected - update user)
 	// - DELETE /admin/users/{id} (protected - delete user)
-	userapi.RegisterHandlers(api, userHandler)
+	// - POST /admin/users/{id}/revoke-tokens (admin - force logout)
+	// - POST /admin/users/{id}/activate (admin - reactivate account)
+	// - POST /admin/users/{id}/deactivate (admin - disable account)
+	userapi.RegisterHandlers(withRouteMiddleware(api, requestValidator), userHandler)
 
 	// Note: For protected routes, you'll need to add auth middleware
 	// This can be done by creating a custom wrapper or using middleware in specific routes
@@ -0,0 +1,638 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gieart87/gohexaclean/api/openapi"
+	"github.com/gieart87/gohexaclean/internal/adapter/inbound/http/middleware"
+	"github.com/gieart87/gohexaclean/internal/adapter/outbound/cached"
+	"github.com/gieart87/gohexaclean/internal/adapter/outbound/event"
+	"github.com/gieart87/gohexaclean/internal/app"
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/gieart87/gohexaclean/internal/dto/request"
+	"github.com/gieart87/gohexaclean/internal/dto/response"
+	"github.com/gieart87/gohexaclean/internal/infra/config"
+	"github.com/gieart87/gohexaclean/internal/infra/logger"
+	"github.com/gieart87/gohexaclean/internal/port/inbound/mock"
+	brokermock "github.com/gieart87/gohexaclean/internal/port/outbound/broker/mock"
+	repomock "github.com/gieart87/gohexaclean/internal/port/outbound/repository/mock"
+	servicemock "github.com/gieart87/gohexaclean/internal/port/outbound/service/mock"
+	"github.com/gieart87/gohexaclean/pkg/auth"
+	"github.com/gieart87/gohexaclean/pkg/pagination"
+	"github.com/gieart87/gohexaclean/pkg/validation"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testTokenOpts = auth.TokenOptions{Secret: "test-secret", Issuer: "gohexaclean", Audience: "gohexaclean-api"}
+
+func setupRouterTest(t *testing.T, httpConfig *config.HTTPConfig) (*fiber.App, *mock.MockUserServicePort, *gomock.Controller) {
+	ctrl := gomock.NewController(t)
+	mockService := mock.NewMockUserServicePort(ctrl)
+
+	requestValidator, err := middleware.OpenAPIValidationMiddleware(openapi.UserAPISpec, "/api/v1")
+	require.NoError(t, err)
+
+	app := fiber.New()
+	SetupRoutes(app, mockService, validation.DefaultPasswordPolicy(), pagination.Default, nil, httpConfig, testTokenOpts, logger.NewDefaultLogger(), nil, nil, requestValidator)
+
+	return app, mockService, ctrl
+}
+
+func TestSetupRoutes_ETag_NotModifiedOnRepeatedRequest(t *testing.T) {
+	app, mockService, ctrl := setupRouterTest(t, &config.HTTPConfig{})
+	defer ctrl.Finish()
+
+	users := []*response.UserResponse{
+		{
+			ID:        uuid.New(),
+			Email:     "user1@example.com",
+			Name:      "User 1",
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
+	}
+
+	mockService.EXPECT().
+		ListUsers(gomock.Any(), 1, 10, domain.DefaultUserSort, domain.ListOptions{}).
+		Return(users, int64(1), nil).
+		Times(2)
+
+	// First request returns the full body and an ETag
+	firstReq, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/users", nil)
+	firstResp, err := app.Test(firstReq)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, firstResp.StatusCode)
+
+	etag := firstResp.Header.Get(fiber.HeaderETag)
+	require.NotEmpty(t, etag)
+
+	// Repeated request with the ETag returns 304 even though request_id/timestamp differ
+	secondReq, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/users", nil)
+	secondReq.Header.Set(fiber.HeaderIfNoneMatch, etag)
+	secondResp, err := app.Test(secondReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusNotModified, secondResp.StatusCode)
+	assert.Equal(t, etag, secondResp.Header.Get(fiber.HeaderETag))
+}
+
+func TestSetupRoutes_ETag_KeepsHandlerETag(t *testing.T) {
+	app, mockService, ctrl := setupRouterTest(t, &config.HTTPConfig{})
+	defer ctrl.Finish()
+
+	user := &response.UserResponse{ID: uuid.New(), Email: "user1@example.com", Name: "User 1", UpdatedAt: time.Now()}
+	mockService.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil).Times(2)
+
+	path := "/api/v1/admin/users/" + user.ID.String()
+	firstReq, _ := http.NewRequest(http.MethodGet, path, nil)
+	firstResp, err := app.Test(firstReq)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, firstResp.StatusCode)
+
+	// The handler's weak ETag survives the api group's body-hash ETag
+	etag := firstResp.Header.Get(fiber.HeaderETag)
+	require.True(t, strings.HasPrefix(etag, "W/"), "got ETag %q", etag)
+
+	secondReq, _ := http.NewRequest(http.MethodGet, path, nil)
+	secondReq.Header.Set(fiber.HeaderIfNoneMatch, etag)
+	secondResp, err := app.Test(secondReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusNotModified, secondResp.StatusCode)
+	assert.Equal(t, etag, secondResp.Header.Get(fiber.HeaderETag))
+}
+
+func TestSetupRoutes_BareListing(t *testing.T) {
+	app, mockService, ctrl := setupRouterTest(t, &config.HTTPConfig{})
+	defer ctrl.Finish()
+
+	users := []*response.UserResponse{{ID: uuid.New(), Email: "user1@example.com", Name: "User 1"}}
+	mockService.EXPECT().
+		ListUsers(gomock.Any(), 1, 10, domain.DefaultUserSort, domain.ListOptions{}).
+		Return(users, int64(31), nil)
+
+	// The envelope parameter isn't in the spec and must pass request validation
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/users?envelope=false", nil)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "31", resp.Header.Get("X-Total-Count"))
+
+	var body []response.UserResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Len(t, body, 1)
+	assert.Equal(t, users[0].ID, body[0].ID)
+}
+
+func TestSetupRoutes_ETag_ModifiedWhenDataChanges(t *testing.T) {
+	app, mockService, ctrl := setupRouterTest(t, &config.HTTPConfig{})
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		mockService.EXPECT().
+			ListUsers(gomock.Any(), 1, 10, domain.DefaultUserSort, domain.ListOptions{}).
+			Return([]*response.UserResponse{{ID: uuid.New(), Name: "User 1"}}, int64(1), nil),
+		mockService.EXPECT().
+			ListUsers(gomock.Any(), 1, 10, domain.DefaultUserSort, domain.ListOptions{}).
+			Return([]*response.UserResponse{{ID: uuid.New(), Name: "User 2"}}, int64(1), nil),
+	)
+
+	firstReq, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/users", nil)
+	firstResp, err := app.Test(firstReq)
+	require.NoError(t, err)
+	etag := firstResp.Header.Get(fiber.HeaderETag)
+
+	secondReq, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/users", nil)
+	secondReq.Header.Set(fiber.HeaderIfNoneMatch, etag)
+	secondResp, err := app.Test(secondReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusOK, secondResp.StatusCode)
+	assert.NotEqual(t, etag, secondResp.Header.Get(fiber.HeaderETag))
+}
+
+func TestSetupRoutes_Compression(t *testing.T) {
+	app, mockService, ctrl := setupRouterTest(t, &config.HTTPConfig{
+		Compression: config.CompressionConfig{Enabled: true},
+	})
+	defer ctrl.Finish()
+
+	mockService.EXPECT().
+		ListUsers(gomock.Any(), 1, 10, domain.DefaultUserSort, domain.ListOptions{}).
+		Return([]*response.UserResponse{}, int64(0), nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/users", nil)
+	req.Header.Set(fiber.HeaderAcceptEncoding, "gzip")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "gzip", resp.Header.Get(fiber.HeaderContentEncoding))
+}
+
+func TestSetupRoutes_RequestTimeoutPropagatesToService(t *testing.T) {
+	app, mockService, ctrl := setupRouterTest(t, &config.HTTPConfig{
+		RequestTimeout: 50 * time.Millisecond,
+	})
+	defer ctrl.Finish()
+
+	mockService.EXPECT().
+		ListUsers(gomock.Any(), 1, 10, domain.DefaultUserSort, domain.ListOptions{}).
+		DoAndReturn(func(ctx context.Context, page, limit int, sort domain.UserSort, opts domain.ListOptions) ([]*response.UserResponse, int64, error) {
+			<-ctx.Done()
+			return nil, 0, ctx.Err()
+		})
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/users", nil)
+	resp, err := app.Test(req, 2000)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusGatewayTimeout, resp.StatusCode)
+}
+
+func TestSetupRoutes_DisabledMethods(t *testing.T) {
+	app, mockService, ctrl := setupRouterTest(t, &config.HTTPConfig{
+		DisabledMethods: []string{"post", "DELETE"},
+	})
+	defer ctrl.Finish()
+
+	mockService.EXPECT().
+		ListUsers(gomock.Any(), 1, 10, domain.DefaultUserSort, domain.ListOptions{}).
+		Return([]*response.UserResponse{}, int64(0), nil)
+
+	postReq, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/login", nil)
+	postResp, err := app.Test(postReq)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusMethodNotAllowed, postResp.StatusCode)
+
+	getReq, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/users", nil)
+	getResp, err := app.Test(getReq)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, getResp.StatusCode)
+}
+
+func TestSetupRoutes_AuthBodyLimit(t *testing.T) {
+	app, _, ctrl := setupRouterTest(t, &config.HTTPConfig{AuthMaxBodySize: 64})
+	defer ctrl.Finish()
+
+	// The service is never called, the body is rejected before validation
+	body := `{"email":"jane@example.com","password":"` + strings.Repeat("x", 64) + `"}`
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/login", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, fiber.StatusRequestEntityTooLarge, resp.StatusCode)
+}
+
+func TestSetupRoutes_RequireJSON(t *testing.T) {
+	app, _, ctrl := setupRouterTest(t, &config.HTTPConfig{})
+	defer ctrl.Finish()
+
+	// The service is never called, the form body is rejected before it is parsed
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/login", strings.NewReader("email=jane%40example.com&password=secret"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, fiber.StatusUnsupportedMediaType, resp.StatusCode)
+}
+
+func TestSetupRoutes_DisabledRoutes(t *testing.T) {
+	app, mockService, ctrl := setupRouterTest(t, &config.HTTPConfig{
+		DisabledRoutes: []string{"DELETE /api/v1/admin/users/{id}"},
+	})
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	mockService.EXPECT().
+		GetUserByID(gomock.Any(), userID).
+		Return(&response.UserResponse{ID: userID}, nil)
+
+	deleteReq, _ := http.NewRequest(http.MethodDelete, "/api/v1/admin/users/"+userID.String(), nil)
+	deleteResp, err := app.Test(deleteReq)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusMethodNotAllowed, deleteResp.StatusCode)
+
+	getReq, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/users/"+userID.String(), nil)
+	getResp, err := app.Test(getReq)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, getResp.StatusCode)
+}
+
+func TestSetupRoutes_RevokeTokens_RequiresAdmin(t *testing.T) {
+	app, mockService, ctrl := setupRouterTest(t, &config.HTTPConfig{})
+	defer ctrl.Finish()
+
+	adminID := uuid.New()
+	userID := uuid.New()
+	path := "/api/v1/admin/users/" + userID.String() + "/revoke-tokens"
+
+	mockService.EXPECT().GetTokenVersion(gomock.Any(), gomock.Any()).Return(0, nil).AnyTimes()
+	mockService.EXPECT().RevokeAllTokens(gomock.Any(), userID).Return(nil)
+
+	// No token
+	req, _ := http.NewRequest(http.MethodPost, path, nil)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+
+	// Regular user
+	userToken, err := auth.GenerateJWT(userID, "user@example.com", domain.RoleUser, 0, testTokenOpts, time.Hour)
+	require.NoError(t, err)
+	req, _ = http.NewRequest(http.MethodPost, path, nil)
+	req.Header.Set("Authorization", "Bearer "+userToken)
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+
+	// Admin
+	adminToken, err := auth.GenerateJWT(adminID, "admin@example.com", domain.RoleAdmin, 0, testTokenOpts, time.Hour)
+	require.NoError(t, err)
+	req, _ = http.NewRequest(http.MethodPost, path, nil)
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestSetupRoutes_CurrentUser_RequiresToken(t *testing.T) {
+	app, mockService, ctrl := setupRouterTest(t, &config.HTTPConfig{})
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	mockService.EXPECT().GetTokenVersion(gomock.Any(), userID).Return(0, nil)
+	mockService.EXPECT().GetUserByID(gomock.Any(), userID).Return(&response.UserResponse{ID: userID}, nil)
+
+	// No token
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/auth/me", nil)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+
+	// Any role may read its own profile
+	token, err := auth.GenerateJWT(userID, "user@example.com", domain.RoleUser, 0, testTokenOpts, time.Hour)
+	require.NoError(t, err)
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/auth/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestSetupRoutes_DeleteCurrentUser_PublishesEventAndRevokesToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := repomock.NewMockUserRepository(ctrl)
+	cache := servicemock.NewMockCacheService(ctrl)
+	messageBroker := brokermock.NewMockMessageBroker(ctrl)
+	userService := app.NewUserService(cached.NewCachedUserRepository(userRepo, cache, time.Minute, time.Minute), nil, &config.JWTConfig{},
+		event.NewUserEventPublisher(messageBroker), nil, nil, &config.BulkConfig{}, nil, nil, "", nil)
+
+	requestValidator, err := middleware.OpenAPIValidationMiddleware(openapi.UserAPISpec, "/api/v1")
+	require.NoError(t, err)
+
+	fiberApp := fiber.New()
+	SetupRoutes(fiberApp, userService, validation.DefaultPasswordPolicy(), pagination.Default, nil, &config.HTTPConfig{}, testTokenOpts, logger.NewDefaultLogger(), nil, nil, requestValidator)
+
+	userID := uuid.New()
+	token, err := auth.GenerateJWT(userID, "user@example.com", domain.RoleUser, 0, testTokenOpts, time.Hour)
+	require.NoError(t, err)
+
+	userKey := "user:id:" + userID.String()
+	gomock.InOrder(
+		cache.EXPECT().Get(gomock.Any(), userKey).Return("", errors.New("cache miss")),
+		userRepo.EXPECT().FindByID(gomock.Any(), userID).Return(&domain.User{ID: userID, Email: "user@example.com"}, nil),
+		cache.EXPECT().Set(gomock.Any(), userKey, gomock.Any(), time.Minute).Return(nil),
+		cache.EXPECT().Exists(gomock.Any(), userKey+":invalidated").Return(false, nil),
+		userRepo.EXPECT().Delete(gomock.Any(), userID).Return(nil),
+		cache.EXPECT().Set(gomock.Any(), userKey+":invalidated", gomock.Any(), gomock.Any()).Return(nil),
+		cache.EXPECT().Delete(gomock.Any(), userKey).Return(nil),
+		cache.EXPECT().Set(gomock.Any(), "users:count:invalidated", gomock.Any(), gomock.Any()).Return(nil),
+		cache.EXPECT().Delete(gomock.Any(), "users:count").Return(nil),
+	)
+	messageBroker.EXPECT().
+		Publish(gomock.Any(), "user.deleted", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, message interface{}) error {
+			deleted, ok := message.(*domain.UserDeletedEvent)
+			require.True(t, ok)
+			assert.Equal(t, userID.String(), deleted.AggregateID())
+			return nil
+		})
+
+	req, _ := http.NewRequest(http.MethodDelete, "/api/v1/auth/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := fiberApp.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	// The cached user was dropped and the user is gone, so the same token is rejected
+	cache.EXPECT().Get(gomock.Any(), userKey).Return("", errors.New("cache miss"))
+	userRepo.EXPECT().FindByID(gomock.Any(), userID).Return(nil, domain.ErrUserNotFound)
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/auth/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = fiberApp.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestSetupRoutes_UserEvents_RequiresToken(t *testing.T) {
+	app, mockService, ctrl := setupRouterTest(t, &config.HTTPConfig{})
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	mockService.EXPECT().GetTokenVersion(gomock.Any(), userID).Return(0, nil).Times(2)
+	mockService.EXPECT().ListUserEvents(gomock.Any(), userID, 1, 10).Return([]*response.UserEventResponse{}, int64(0), nil)
+
+	path := "/api/v1/users/" + userID.String() + "/events"
+
+	// No token
+	req, _ := http.NewRequest(http.MethodGet, path, nil)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+
+	token, err := auth.GenerateJWT(userID, "user@example.com", domain.RoleUser, 0, testTokenOpts, time.Hour)
+	require.NoError(t, err)
+
+	// Own events
+	req, _ = http.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	// Someone else's events
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/users/"+uuid.New().String()+"/events", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+}
+
+func TestSetupRoutes_RejectsRequestsViolatingOpenAPISpec(t *testing.T) {
+	app, mockService, ctrl := setupRouterTest(t, &config.HTTPConfig{})
+	defer ctrl.Finish()
+
+	// LoginRequest.Validate only requires a password, the spec requires at least 6 characters
+	mockService.EXPECT().Login(gomock.Any(), gomock.Any()).Times(0)
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/login", strings.NewReader(`{"email":"user@example.com","password":"abc"}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "minimum string length is 6")
+}
+
+func TestSetupRoutes_ExportUsers_RequiresAdmin(t *testing.T) {
+	app, mockService, ctrl := setupRouterTest(t, &config.HTTPConfig{})
+	defer ctrl.Finish()
+
+	adminID := uuid.New()
+	path := "/api/v1/admin/users/export"
+
+	mockService.EXPECT().GetTokenVersion(gomock.Any(), gomock.Any()).Return(0, nil).AnyTimes()
+	mockService.EXPECT().
+		StreamUsers(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ int, fn func(*response.UserResponse) error) error {
+			return fn(&response.UserResponse{ID: adminID, Email: "admin@example.com", Name: "Admin"})
+		})
+
+	// Regular user
+	userToken, err := auth.GenerateJWT(uuid.New(), "user@example.com", domain.RoleUser, 0, testTokenOpts, time.Hour)
+	require.NoError(t, err)
+	req, _ := http.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set("Authorization", "Bearer "+userToken)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+
+	// Admin, streamed through the full middleware chain
+	adminToken, err := auth.GenerateJWT(adminID, "admin@example.com", domain.RoleAdmin, 0, testTokenOpts, time.Hour)
+	require.NoError(t, err)
+	req, _ = http.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get(fiber.HeaderETag))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "id,email,name,created_at\n"+adminID.String()+",admin@example.com,Admin,")
+}
+
+func TestSetupRoutes_ExportUsersJSONL(t *testing.T) {
+	app, mockService, ctrl := setupRouterTest(t, &config.HTTPConfig{})
+	defer ctrl.Finish()
+
+	adminID := uuid.New()
+	mockService.EXPECT().GetTokenVersion(gomock.Any(), adminID).Return(0, nil)
+	mockService.EXPECT().
+		StreamUsers(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ int, fn func(*response.UserResponse) error) error {
+			return fn(&response.UserResponse{ID: adminID, Email: "admin@example.com"})
+		})
+
+	// Served by the export, not taken as GET /admin/users/{id} with id "export.jsonl"
+	adminToken, err := auth.GenerateJWT(adminID, "admin@example.com", domain.RoleAdmin, 0, testTokenOpts, time.Hour)
+	require.NoError(t, err)
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/users/export.jsonl", nil)
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/x-ndjson", resp.Header.Get(fiber.HeaderContentType))
+}
+
+func TestSetupRoutes_BulkCreateUsers_RequiresAdmin(t *testing.T) {
+	app, mockService, ctrl := setupRouterTest(t, &config.HTTPConfig{})
+	defer ctrl.Finish()
+
+	path := "/api/v1/admin/users/bulk-create"
+	body := `{"users":[{"email":"jane@example.com","name":"Jane Doe","password":"password123"}]}`
+
+	mockService.EXPECT().GetTokenVersion(gomock.Any(), gomock.Any()).Return(0, nil).AnyTimes()
+	mockService.EXPECT().
+		CreateUsers(gomock.Any(), []*request.CreateUserRequest{{Email: "jane@example.com", Name: "Jane Doe", Password: "password123"}}).
+		Return([]*response.BulkItemResult{{Index: 0, Success: true}}, nil)
+
+	// Regular user
+	userToken, err := auth.GenerateJWT(uuid.New(), "user@example.com", domain.RoleUser, 0, testTokenOpts, time.Hour)
+	require.NoError(t, err)
+	req, _ := http.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+userToken)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+
+	// Admin
+	adminToken, err := auth.GenerateJWT(uuid.New(), "admin@example.com", domain.RoleAdmin, 0, testTokenOpts, time.Hour)
+	require.NoError(t, err)
+	req, _ = http.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestSetupRoutes_BulkDeleteUsers_RequiresAdmin(t *testing.T) {
+	app, mockService, ctrl := setupRouterTest(t, &config.HTTPConfig{})
+	defer ctrl.Finish()
+
+	adminID := uuid.New()
+	targetID := uuid.New()
+	path := "/api/v1/admin/users/bulk-delete"
+	body := `{"ids":["` + targetID.String() + `"]}`
+
+	mockService.EXPECT().GetTokenVersion(gomock.Any(), gomock.Any()).Return(0, nil).AnyTimes()
+	mockService.EXPECT().
+		DeleteUsers(gomock.Any(), []uuid.UUID{targetID}).
+		Return(&response.BulkDeleteResponse{Deleted: 1, NotFound: []uuid.UUID{}}, nil)
+
+	// Regular user
+	userToken, err := auth.GenerateJWT(uuid.New(), "user@example.com", domain.RoleUser, 0, testTokenOpts, time.Hour)
+	require.NoError(t, err)
+	req, _ := http.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+userToken)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+
+	// Admin
+	adminToken, err := auth.GenerateJWT(adminID, "admin@example.com", domain.RoleAdmin, 0, testTokenOpts, time.Hour)
+	require.NoError(t, err)
+	req, _ = http.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	// Over the spec's maxItems, rejected before reaching the service
+	ids := make([]string, 1001)
+	for i := range ids {
+		ids[i] = `"` + uuid.NewString() + `"`
+	}
+	req, _ = http.NewRequest(http.MethodPost, path, strings.NewReader(`{"ids":[`+strings.Join(ids, ",")+`]}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestSetupRoutes_RegistrationOnlyThroughAuthRegister(t *testing.T) {
+	app, mockService, ctrl := setupRouterTest(t, &config.HTTPConfig{})
+	defer ctrl.Finish()
+
+	// Users are only created by POST /auth/register, there is no unvalidated POST /users
+	mockService.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Times(0)
+
+	body := `{"email":"user@example.com","name":"Test User","password":"123"}`
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/users", strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+
+	req, _ = http.NewRequest(http.MethodPost, "/api/v1/auth/register", strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestSetupRoutes_RegisterLocationIsUserResource(t *testing.T) {
+	app, mockService, ctrl := setupRouterTest(t, &config.HTTPConfig{})
+	defer ctrl.Finish()
+
+	// A trailing slash on the request doesn't leak into the Location
+	for _, path := range []string{"/api/v1/auth/register", "/api/v1/auth/register/"} {
+		t.Run(path, func(t *testing.T) {
+			userID := uuid.New()
+			mockService.EXPECT().
+				CreateUser(gomock.Any(), gomock.Any()).
+				Return(&response.LoginResponse{Token: "jwt-token", User: &response.UserResponse{ID: userID, Email: "user@example.com"}}, nil)
+
+			body := `{"email":"user@example.com","name":"Test User","password":"Str0ng-Passw0rd!"}`
+			req, _ := http.NewRequest(http.MethodPost, path, strings.NewReader(body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+			assert.Equal(t, "/api/v1/admin/users/"+userID.String(), resp.Header.Get(fiber.HeaderLocation))
+		})
+	}
+}
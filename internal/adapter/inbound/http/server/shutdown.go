@@ -0,0 +1,19 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Shutdown stops accepting connections and waits up to timeout for in-flight requests
+// timedOut reports whether requests were still open when the timeout elapsed
+func Shutdown(app *fiber.App, timeout time.Duration) (timedOut bool, err error) {
+	err = app.ShutdownWithTimeout(timeout)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true, nil
+	}
+	return false, err
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startApp serves app on a random local port and returns its base URL
+func startApp(t *testing.T, app *fiber.App) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() { _ = app.Listener(ln) }()
+
+	return fmt.Sprintf("http://%s", ln.Addr().String())
+}
+
+// newSlowApp returns an app whose /slow handler signals started and then blocks for delay
+func newSlowApp(delay time.Duration) (*fiber.App, chan struct{}) {
+	started := make(chan struct{}, 1)
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Get("/slow", func(c *fiber.Ctx) error {
+		started <- struct{}{}
+		time.Sleep(delay)
+		return c.SendString("done")
+	})
+	return app, started
+}
+
+func TestShutdown_WaitsForInFlightRequest(t *testing.T) {
+	app, started := newSlowApp(100 * time.Millisecond)
+	baseURL := startApp(t, app)
+
+	result := make(chan int, 1)
+	go func() {
+		resp, err := http.Get(baseURL + "/slow")
+		if err != nil {
+			result <- 0
+			return
+		}
+		resp.Body.Close()
+		result <- resp.StatusCode
+	}()
+	<-started
+
+	timedOut, err := Shutdown(app, 2*time.Second)
+
+	require.NoError(t, err)
+	assert.False(t, timedOut)
+	assert.Equal(t, fiber.StatusOK, <-result, "in-flight request should complete before shutdown returns")
+}
+
+func TestShutdown_RespectsTimeout(t *testing.T) {
+	app, started := newSlowApp(5 * time.Second)
+	baseURL := startApp(t, app)
+
+	go func() {
+		client := &http.Client{Timeout: 10 * time.Second}
+		if resp, err := client.Get(baseURL + "/slow"); err == nil {
+			resp.Body.Close()
+		}
+	}()
+	<-started
+
+	begin := time.Now()
+	timedOut, err := Shutdown(app, 100*time.Millisecond)
+	elapsed := time.Since(begin)
+
+	require.NoError(t, err)
+	assert.True(t, timedOut)
+	assert.Less(t, elapsed, 2*time.Second, "shutdown should not wait for the slow request")
+}
@@ -0,0 +1,71 @@
+package asynq
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gieart87/gohexaclean/internal/infra/asynq/tasks"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/service"
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+)
+
+// TaskQueueAsynq implements TaskQueue interface on top of an Asynq client
+type TaskQueueAsynq struct {
+	client *asynq.Client
+}
+
+// NewTaskQueueAsynq creates a new Asynq task queue
+func NewTaskQueueAsynq(client *asynq.Client) service.TaskQueue {
+	return &TaskQueueAsynq{client: client}
+}
+
+// EnqueueWelcomeEmail enqueues the welcome email task for a newly registered user
+func (q *TaskQueueAsynq) EnqueueWelcomeEmail(ctx context.Context, userID uuid.UUID, email, name string) error {
+	task, err := tasks.NewEmailWelcomeTask(userID.String(), email, name)
+	if err != nil {
+		return fmt.Errorf("failed to create welcome email task: %w", err)
+	}
+
+	info, err := q.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue welcome email task: %w", err)
+	}
+
+	log.Printf("enqueued welcome email task: id=%s queue=%s", info.ID, info.Queue)
+	return nil
+}
+
+// EnqueueLoginAlert enqueues the login alert task for a user who just signed in
+func (q *TaskQueueAsynq) EnqueueLoginAlert(ctx context.Context, userID uuid.UUID, email string, loggedInAt time.Time) error {
+	task, err := tasks.NewLoginAlertTask(userID.String(), email, loggedInAt)
+	if err != nil {
+		return fmt.Errorf("failed to create login alert task: %w", err)
+	}
+
+	info, err := q.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue login alert task: %w", err)
+	}
+
+	log.Printf("enqueued login alert task: id=%s queue=%s", info.ID, info.Queue)
+	return nil
+}
+
+// EnqueueEmailVerification enqueues the task mailing the verification token to a user's new email address
+func (q *TaskQueueAsynq) EnqueueEmailVerification(ctx context.Context, userID uuid.UUID, email, token string, expiresAt time.Time) error {
+	task, err := tasks.NewEmailVerificationTask(userID.String(), email, token, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create email verification task: %w", err)
+	}
+
+	info, err := q.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue email verification task: %w", err)
+	}
+
+	log.Printf("enqueued email verification task: id=%s queue=%s", info.ID, info.Queue)
+	return nil
+}
@@ -0,0 +1,70 @@
+package cached
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gieart87/gohexaclean/internal/port/outbound/repository"
+)
+
+// txKey holds the afterCommit of the transaction a context runs in
+type txKey struct{}
+
+// Transactor decorates a Transactor for the cached repositories called inside its transactions
+// Their reads bypass the cache, so uncommitted rows are never cached, and the entries their writes
+// make stale are dropped once the transaction commits rather than while it can still roll back
+type Transactor struct {
+	inner repository.Transactor
+}
+
+// NewTransactor wraps inner, the transactor the cached repositories' wrapped repositories take part in
+func NewTransactor(inner repository.Transactor) repository.Transactor {
+	return &Transactor{inner: inner}
+}
+
+// WithinTransaction runs fn in inner's transaction, nested calls join the outer one and leave the invalidation to it
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := afterCommitFrom(ctx); ok {
+		return t.inner.WithinTransaction(ctx, fn)
+	}
+
+	committed := &afterCommit{}
+	if err := t.inner.WithinTransaction(context.WithValue(ctx, txKey{}, committed), fn); err != nil {
+		return err
+	}
+
+	// The rows changed whether or not the caller is still waiting, so a cancelled ctx must not skip this
+	committed.run(context.WithoutCancel(ctx))
+	return nil
+}
+
+// afterCommit collects the invalidations of a transaction, run once it commits
+type afterCommit struct {
+	mu    sync.Mutex
+	funcs []func(ctx context.Context)
+}
+
+// add defers fn until the transaction commits
+func (a *afterCommit) add(fn func(ctx context.Context)) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.funcs = append(a.funcs, fn)
+}
+
+// run calls the deferred funcs in the order they were added
+func (a *afterCommit) run(ctx context.Context) {
+	a.mu.Lock()
+	funcs := a.funcs
+	a.funcs = nil
+	a.mu.Unlock()
+
+	for _, fn := range funcs {
+		fn(ctx)
+	}
+}
+
+// afterCommitFrom returns the afterCommit of the transaction ctx runs in, if it runs in one
+func afterCommitFrom(ctx context.Context) (*afterCommit, bool) {
+	a, ok := ctx.Value(txKey{}).(*afterCommit)
+	return a, ok
+}
@@ -0,0 +1,131 @@
+package cached
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/repository"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/repository/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupTransactor wraps a mock transactor that runs fn right away, as a joined transaction would
+func setupTransactor(t *testing.T) repository.Transactor {
+	t.Helper()
+
+	inner := mock.NewMockTransactor(gomock.NewController(t))
+	inner.EXPECT().
+		WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+
+	return NewTransactor(inner)
+}
+
+func TestTransactor_InvalidatesAfterCommit(t *testing.T) {
+	for _, commit := range []bool{true, false} {
+		name := "rollback"
+		if commit {
+			name = "commit"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			repo, inner, mr := setupCachedRepo(t)
+			transactor := setupTransactor(t)
+			ctx := context.Background()
+			user := &domain.User{ID: uuid.New(), Email: "jane@example.com"}
+
+			inner.EXPECT().FindByID(gomock.Any(), user.ID).Return(user, nil).Times(2)
+			inner.EXPECT().Update(gomock.Any(), user).Return(nil)
+
+			_, err := repo.FindByID(ctx, user.ID)
+			require.NoError(t, err)
+
+			rollback := errors.New("rollback")
+			err = transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+				// Reads inside the transaction go to the wrapped repository rather than the cache
+				if _, err := repo.FindByID(ctx, user.ID); err != nil {
+					return err
+				}
+				if err := repo.Update(ctx, user); err != nil {
+					return err
+				}
+
+				// Not dropped before the commit, a concurrent read would cache the old row again
+				assert.True(t, mr.Exists(userIDKey(user.ID)))
+				if !commit {
+					return rollback
+				}
+				return nil
+			})
+
+			if commit {
+				require.NoError(t, err)
+				assert.False(t, mr.Exists(userIDKey(user.ID)))
+			} else {
+				assert.ErrorIs(t, err, rollback)
+				assert.True(t, mr.Exists(userIDKey(user.ID)))
+			}
+		})
+	}
+}
+
+func TestTransactor_NestedLeavesInvalidationToOuter(t *testing.T) {
+	repo, inner, mr := setupCachedRepo(t)
+	transactor := setupTransactor(t)
+	ctx := context.Background()
+	user := &domain.User{ID: uuid.New(), Email: "jane@example.com"}
+
+	inner.EXPECT().FindByID(gomock.Any(), user.ID).Return(user, nil)
+	inner.EXPECT().Update(gomock.Any(), user).Return(nil)
+
+	_, err := repo.FindByID(ctx, user.ID)
+	require.NoError(t, err)
+
+	err = transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			return repo.Update(ctx, user)
+		}); err != nil {
+			return err
+		}
+
+		// The inner call returning isn't the commit
+		assert.True(t, mr.Exists(userIDKey(user.ID)))
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.False(t, mr.Exists(userIDKey(user.ID)))
+}
+
+func TestTransactor_CancelledCallerStillInvalidates(t *testing.T) {
+	repo, inner, mr := setupCachedRepo(t)
+	transactor := setupTransactor(t)
+	user := &domain.User{ID: uuid.New(), Email: "jane@example.com"}
+
+	inner.EXPECT().FindByID(gomock.Any(), user.ID).Return(user, nil)
+	inner.EXPECT().Update(gomock.Any(), user).Return(nil)
+
+	_, err := repo.FindByID(context.Background(), user.ID)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err = transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := repo.Update(ctx, user); err != nil {
+			return err
+		}
+		// The caller goes away after the transaction's last write, the commit still went through
+		cancel()
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.False(t, mr.Exists(userIDKey(user.ID)))
+}
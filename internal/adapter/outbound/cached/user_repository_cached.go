@@ -0,0 +1,278 @@
+package cached
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/gieart87/gohexaclean/internal/infra/cache"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/repository"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/service"
+	"github.com/google/uuid"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// DefaultUserTTL bounds how long a cached user is served when no TTL is configured
+	DefaultUserTTL = 5 * time.Minute
+	// DefaultCountTTL bounds how long the cached user count is served when no TTL is configured
+	DefaultCountTTL = 30 * time.Second
+	// loadTimeout bounds a shared load, it no longer follows the deadline of the caller that started it
+	loadTimeout = 10 * time.Second
+)
+
+// userCountKey holds the total number of users shown alongside paginated lists
+const userCountKey = "users:count"
+
+// KeyPrefixes covers every key CachedUserRepository writes, for purging entries whose invalidation was lost
+var KeyPrefixes = []string{"user:", userCountKey}
+
+// CachedUserRepository decorates a UserRepository with read-through caching
+// Users are cached by ID without their credentials, whose JSON tags leave them out, so anything
+// checking a password or verification token reads the wrapped repository or a transaction
+// Every write through the repository drops the entries it makes stale, the cache is best-effort
+// and its failures fall back to the wrapped repository
+// Transactions must be started through this package's Transactor, which defers those drops until they commit
+type CachedUserRepository struct {
+	inner    repository.UserRepository
+	cache    service.CacheService
+	ttl      time.Duration
+	countTTL time.Duration
+
+	// loads collapses concurrent cache misses for the same key into one load
+	loads *singleflight.Group
+}
+
+// NewCachedUserRepository wraps inner, caching users for ttl and the user count for countTTL
+func NewCachedUserRepository(inner repository.UserRepository, cache service.CacheService, ttl, countTTL time.Duration) repository.UserRepository {
+	if ttl <= 0 {
+		ttl = DefaultUserTTL
+	}
+	if countTTL <= 0 {
+		countTTL = DefaultCountTTL
+	}
+
+	return &CachedUserRepository{
+		inner:    inner,
+		cache:    cache,
+		ttl:      ttl,
+		countTTL: countTTL,
+		loads:    &singleflight.Group{},
+	}
+}
+
+// userIDKey returns the cache key holding a user
+func userIDKey(id uuid.UUID) string {
+	return "user:id:" + id.String()
+}
+
+// Create stores user and drops the cached count
+func (r *CachedUserRepository) Create(ctx context.Context, user *domain.User) error {
+	if err := r.inner.Create(ctx, user); err != nil {
+		return err
+	}
+	r.invalidate(ctx, userCountKey)
+	return nil
+}
+
+// FindByID reads the user through the cache
+// Lookups preloading includes and reads inside a transaction always go to the wrapped repository
+func (r *CachedUserRepository) FindByID(ctx context.Context, id uuid.UUID, includes ...string) (*domain.User, error) {
+	if _, inTx := afterCommitFrom(ctx); len(includes) > 0 || inTx {
+		return r.inner.FindByID(ctx, id, includes...)
+	}
+
+	key := userIDKey(id)
+	if user, err := cache.GetJSON[domain.User](ctx, r.cache, key); err == nil {
+		return &user, nil
+	}
+
+	// Only one caller loads a missing key, the rest wait for its result
+	// The load is detached from that caller, so cancelling it doesn't fail the others
+	loaded := r.loads.DoChan(key, func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), loadTimeout)
+		defer cancel()
+
+		user, err := r.inner.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		r.fill(ctx, key, func() error { return cache.SetJSON(ctx, r.cache, key, user, r.ttl) })
+		return user, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-loaded:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		// Callers share the loaded value, hand each one its own copy
+		user := *result.Val.(*domain.User)
+		return &user, nil
+	}
+}
+
+// FindByEmail always reads the wrapped repository, logins need the password hash cached users leave out
+func (r *CachedUserRepository) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return r.inner.FindByEmail(ctx, email)
+}
+
+func (r *CachedUserRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*domain.User, error) {
+	return r.inner.FindByIDs(ctx, ids)
+}
+
+// Update saves user and drops its cached entry
+func (r *CachedUserRepository) Update(ctx context.Context, user *domain.User) error {
+	if err := r.inner.Update(ctx, user); err != nil {
+		return err
+	}
+	r.invalidate(ctx, userIDKey(user.ID))
+	return nil
+}
+
+// UpdateEmail saves the email change and drops the cached user
+func (r *CachedUserRepository) UpdateEmail(ctx context.Context, user *domain.User) error {
+	if err := r.inner.UpdateEmail(ctx, user); err != nil {
+		return err
+	}
+	r.invalidate(ctx, userIDKey(user.ID))
+	return nil
+}
+
+// Delete removes the user and drops its cached entry and the count
+func (r *CachedUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.inner.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.invalidate(ctx, userIDKey(id), userCountKey)
+	return nil
+}
+
+// DeleteBatch removes the users and drops their cached entries and the count
+func (r *CachedUserRepository) DeleteBatch(ctx context.Context, ids []uuid.UUID) (int64, []uuid.UUID, error) {
+	deleted, notFound, err := r.inner.DeleteBatch(ctx, ids)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	keys := make([]string, 0, len(ids)+1)
+	for _, id := range ids {
+		keys = append(keys, userIDKey(id))
+	}
+	if deleted > 0 {
+		keys = append(keys, userCountKey)
+	}
+	r.invalidate(ctx, keys...)
+
+	return deleted, notFound, nil
+}
+
+func (r *CachedUserRepository) List(ctx context.Context, offset, limit int, sort domain.UserSort) ([]*domain.User, error) {
+	return r.inner.List(ctx, offset, limit, sort)
+}
+
+func (r *CachedUserRepository) ListAfter(ctx context.Context, cursor *domain.UserCursor, limit int) ([]*domain.User, error) {
+	return r.inner.ListAfter(ctx, cursor, limit)
+}
+
+// Count reads the total through the cache so COUNT(*) runs at most once per count TTL
+func (r *CachedUserRepository) Count(ctx context.Context) (int64, error) {
+	if _, inTx := afterCommitFrom(ctx); inTx {
+		return r.inner.Count(ctx)
+	}
+
+	if cached, err := r.cache.Get(ctx, userCountKey); err == nil {
+		if total, err := strconv.ParseInt(cached, 10, 64); err == nil {
+			return total, nil
+		}
+	}
+
+	total, err := r.inner.Count(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	r.fill(ctx, userCountKey, func() error { return r.cache.Set(ctx, userCountKey, total, r.countTTL) })
+	return total, nil
+}
+
+func (r *CachedUserRepository) CountApprox(ctx context.Context) (int64, error) {
+	return r.inner.CountApprox(ctx)
+}
+
+func (r *CachedUserRepository) Search(ctx context.Context, query string, limit int) ([]*domain.User, error) {
+	return r.inner.Search(ctx, query, limit)
+}
+
+func (r *CachedUserRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	return r.inner.ExistsByEmail(ctx, email)
+}
+
+// IncrementTokenVersion bumps the version and drops the cached user, so revoked tokens are rejected right away
+func (r *CachedUserRepository) IncrementTokenVersion(ctx context.Context, id uuid.UUID) (int, error) {
+	version, err := r.inner.IncrementTokenVersion(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	r.invalidate(ctx, userIDKey(id))
+	return version, nil
+}
+
+// UpdateLastLogin records the login and drops the cached user
+func (r *CachedUserRepository) UpdateLastLogin(ctx context.Context, id uuid.UUID, at time.Time) error {
+	if err := r.inner.UpdateLastLogin(ctx, id, at); err != nil {
+		return err
+	}
+	r.invalidate(ctx, userIDKey(id))
+	return nil
+}
+
+// SetActive updates the active flag and drops the cached user
+func (r *CachedUserRepository) SetActive(ctx context.Context, id uuid.UUID, active bool) error {
+	if err := r.inner.SetActive(ctx, id, active); err != nil {
+		return err
+	}
+	r.invalidate(ctx, userIDKey(id))
+	return nil
+}
+
+// invalidate drops keys from the cache, or defers that to the commit inside a transaction
+func (r *CachedUserRepository) invalidate(ctx context.Context, keys ...string) {
+	if committed, inTx := afterCommitFrom(ctx); inTx {
+		committed.add(func(ctx context.Context) { r.drop(ctx, keys...) })
+		return
+	}
+	r.drop(ctx, keys...)
+}
+
+// drop removes keys from the cache
+func (r *CachedUserRepository) drop(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		// The marker goes first, a load that read the old row and stores it after this delete still sees it
+		_ = r.cache.Set(ctx, invalidatedKey(key), 1, loadTimeout)
+		_ = r.cache.Delete(ctx, key)
+	}
+}
+
+// fill stores a loaded value with set, then drops it again if key was invalidated meanwhile
+// A write landing between the load and the set would otherwise leave the old value cached for its TTL,
+// including a revoked token version. Checking the marker after the set covers writes from other instances too
+func (r *CachedUserRepository) fill(ctx context.Context, key string, set func() error) {
+	if err := set(); err != nil {
+		return
+	}
+	if stale, err := r.cache.Exists(ctx, invalidatedKey(key)); err != nil || stale {
+		_ = r.cache.Delete(ctx, key)
+	}
+}
+
+// invalidatedKey marks key as recently invalidated, for as long as a load may take
+func invalidatedKey(key string) string {
+	return key + ":invalidated"
+}
+
+// Ensure CachedUserRepository implements UserRepository at compile time
+var _ repository.UserRepository = (*CachedUserRepository)(nil)
@@ -0,0 +1,389 @@
+package cached
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	redisadapter "github.com/gieart87/gohexaclean/internal/adapter/outbound/redis"
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/repository"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/repository/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupCachedRepo wraps a mock repository with a cache backed by miniredis
+func setupCachedRepo(t *testing.T) (repository.UserRepository, *mock.MockUserRepository, *miniredis.Miniredis) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	inner := mock.NewMockUserRepository(ctrl)
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return NewCachedUserRepository(inner, redisadapter.NewCacheServiceRedis(client), time.Minute, time.Minute), inner, mr
+}
+
+func TestCachedUserRepository_FindByID_ReadThrough(t *testing.T) {
+	repo, inner, mr := setupCachedRepo(t)
+	ctx := context.Background()
+
+	token := "token-hash"
+	user := &domain.User{ID: uuid.New(), Email: "jane@example.com", Name: "Jane", Password: "hash", TokenVersion: 2, EmailVerificationToken: &token}
+	inner.EXPECT().FindByID(gomock.Any(), user.ID).Return(user, nil).Times(1)
+
+	first, err := repo.FindByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.True(t, mr.Exists(userIDKey(user.ID)), "the loaded user should be cached")
+
+	// Credentials never reach the cache
+	cached, err := mr.Get(userIDKey(user.ID))
+	require.NoError(t, err)
+	assert.NotContains(t, cached, "hash")
+
+	// Served from the cache, the inner repository is only asked once
+	second, err := repo.FindByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, first.Email, second.Email)
+	assert.Empty(t, second.Password)
+	assert.Nil(t, second.EmailVerificationToken)
+	assert.Equal(t, 2, second.TokenVersion)
+	assert.NotSame(t, first, second)
+}
+
+func TestCachedUserRepository_FindByID_WithIncludesBypassesCache(t *testing.T) {
+	repo, inner, mr := setupCachedRepo(t)
+
+	user := &domain.User{ID: uuid.New()}
+	inner.EXPECT().FindByID(gomock.Any(), user.ID, "profile").Return(user, nil).Times(2)
+
+	for i := 0; i < 2; i++ {
+		_, err := repo.FindByID(context.Background(), user.ID, "profile")
+		require.NoError(t, err)
+	}
+	assert.False(t, mr.Exists(userIDKey(user.ID)))
+}
+
+func TestCachedUserRepository_FindByID_NotFoundIsNotCached(t *testing.T) {
+	repo, inner, _ := setupCachedRepo(t)
+
+	id := uuid.New()
+	inner.EXPECT().FindByID(gomock.Any(), id).Return(nil, domain.ErrUserNotFound).Times(2)
+
+	for i := 0; i < 2; i++ {
+		_, err := repo.FindByID(context.Background(), id)
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	}
+}
+
+func TestCachedUserRepository_FindByID_ConcurrentMissesLoadOnce(t *testing.T) {
+	repo, inner, _ := setupCachedRepo(t)
+
+	const callers = 20
+	user := &domain.User{ID: uuid.New(), Email: "jane@example.com"}
+
+	var loads atomic.Int32
+	inner.EXPECT().
+		FindByID(gomock.Any(), user.ID).
+		DoAndReturn(func(ctx context.Context, id uuid.UUID, includes ...string) (*domain.User, error) {
+			loads.Add(1)
+			// Hold the load so the other callers join the flight instead of starting their own
+			time.Sleep(50 * time.Millisecond)
+			return user, nil
+		}).
+		MinTimes(1)
+
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := repo.FindByID(context.Background(), user.ID)
+			if assert.NoError(t, err) {
+				assert.Equal(t, user.ID, got.ID)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), loads.Load())
+}
+
+func TestCachedUserRepository_FindByID_CancelledCallerDoesNotFailWaiters(t *testing.T) {
+	repo, inner, _ := setupCachedRepo(t)
+
+	user := &domain.User{ID: uuid.New(), Email: "jane@example.com"}
+	started := make(chan struct{})
+	release := make(chan struct{})
+	inner.EXPECT().
+		FindByID(gomock.Any(), user.ID).
+		DoAndReturn(func(ctx context.Context, id uuid.UUID, includes ...string) (*domain.User, error) {
+			close(started)
+			<-release
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			return user, nil
+		}).
+		Times(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := repo.FindByID(ctx, user.ID)
+		firstErr <- err
+	}()
+	<-started
+
+	type result struct {
+		user *domain.User
+		err  error
+	}
+	waiter := make(chan result, 1)
+	go func() {
+		got, err := repo.FindByID(context.Background(), user.ID)
+		waiter <- result{got, err}
+	}()
+
+	// Give the waiter time to join the flight, then drop the caller that started it
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	assert.ErrorIs(t, <-firstErr, context.Canceled)
+
+	close(release)
+	got := <-waiter
+	require.NoError(t, got.err)
+	assert.Equal(t, user.ID, got.user.ID)
+}
+
+func TestCachedUserRepository_UpdateInvalidates(t *testing.T) {
+	repo, inner, mr := setupCachedRepo(t)
+	ctx := context.Background()
+
+	user := &domain.User{ID: uuid.New(), Email: "jane@example.com", Name: "Jane"}
+	renamed := *user
+	renamed.Name = "Jane Doe"
+
+	gomock.InOrder(
+		inner.EXPECT().FindByID(gomock.Any(), user.ID).Return(user, nil),
+		inner.EXPECT().Update(gomock.Any(), &renamed).Return(nil),
+		inner.EXPECT().FindByID(gomock.Any(), user.ID).Return(&renamed, nil),
+	)
+
+	_, err := repo.FindByID(ctx, user.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Update(ctx, &renamed))
+	assert.False(t, mr.Exists(userIDKey(user.ID)), "the update should drop the cached user")
+
+	got, err := repo.FindByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Jane Doe", got.Name)
+}
+
+func TestCachedUserRepository_FailedWriteKeepsCache(t *testing.T) {
+	repo, inner, mr := setupCachedRepo(t)
+	ctx := context.Background()
+
+	user := &domain.User{ID: uuid.New(), Email: "jane@example.com"}
+	inner.EXPECT().FindByID(gomock.Any(), user.ID).Return(user, nil)
+	inner.EXPECT().Update(gomock.Any(), user).Return(errors.New("connection reset"))
+
+	_, err := repo.FindByID(ctx, user.ID)
+	require.NoError(t, err)
+
+	assert.Error(t, repo.Update(ctx, user))
+	assert.True(t, mr.Exists(userIDKey(user.ID)))
+}
+
+func TestCachedUserRepository_WritesInvalidateUser(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name   string
+		expect func(inner *mock.MockUserRepository)
+		write  func(repo repository.UserRepository) error
+	}{
+		{
+			name:   "delete",
+			expect: func(inner *mock.MockUserRepository) { inner.EXPECT().Delete(gomock.Any(), id).Return(nil) },
+			write:  func(repo repository.UserRepository) error { return repo.Delete(context.Background(), id) },
+		},
+		{
+			name: "delete batch",
+			expect: func(inner *mock.MockUserRepository) {
+				inner.EXPECT().DeleteBatch(gomock.Any(), []uuid.UUID{id}).Return(int64(1), []uuid.UUID{}, nil)
+			},
+			write: func(repo repository.UserRepository) error {
+				_, _, err := repo.DeleteBatch(context.Background(), []uuid.UUID{id})
+				return err
+			},
+		},
+		{
+			name: "token version",
+			expect: func(inner *mock.MockUserRepository) {
+				inner.EXPECT().IncrementTokenVersion(gomock.Any(), id).Return(1, nil)
+			},
+			write: func(repo repository.UserRepository) error {
+				_, err := repo.IncrementTokenVersion(context.Background(), id)
+				return err
+			},
+		},
+		{
+			name: "last login",
+			expect: func(inner *mock.MockUserRepository) {
+				inner.EXPECT().UpdateLastLogin(gomock.Any(), id, gomock.Any()).Return(nil)
+			},
+			write: func(repo repository.UserRepository) error {
+				return repo.UpdateLastLogin(context.Background(), id, time.Now())
+			},
+		},
+		{
+			name:   "set active",
+			expect: func(inner *mock.MockUserRepository) { inner.EXPECT().SetActive(gomock.Any(), id, false).Return(nil) },
+			write:  func(repo repository.UserRepository) error { return repo.SetActive(context.Background(), id, false) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, inner, mr := setupCachedRepo(t)
+			inner.EXPECT().FindByID(gomock.Any(), id).Return(&domain.User{ID: id}, nil)
+			tt.expect(inner)
+
+			_, err := repo.FindByID(context.Background(), id)
+			require.NoError(t, err)
+			require.True(t, mr.Exists(userIDKey(id)))
+
+			require.NoError(t, tt.write(repo))
+			assert.False(t, mr.Exists(userIDKey(id)))
+		})
+	}
+}
+
+func TestCachedUserRepository_FindByID_RevokeDuringLoadIsNotCached(t *testing.T) {
+	repo, inner, mr := setupCachedRepo(t)
+	ctx := context.Background()
+	id := uuid.New()
+
+	// The revocation commits and drops the key after the load read the old version, but before it is cached
+	gomock.InOrder(
+		inner.EXPECT().
+			FindByID(gomock.Any(), id).
+			DoAndReturn(func(ctx context.Context, id uuid.UUID, includes ...string) (*domain.User, error) {
+				_, err := repo.IncrementTokenVersion(context.Background(), id)
+				require.NoError(t, err)
+				return &domain.User{ID: id, TokenVersion: 1}, nil
+			}),
+		inner.EXPECT().IncrementTokenVersion(gomock.Any(), id).Return(2, nil),
+		inner.EXPECT().FindByID(gomock.Any(), id).Return(&domain.User{ID: id, TokenVersion: 2}, nil),
+	)
+
+	loaded, err := repo.FindByID(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, 1, loaded.TokenVersion)
+	assert.False(t, mr.Exists(userIDKey(id)), "the version read before the revocation must not stay cached")
+
+	current, err := repo.FindByID(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, 2, current.TokenVersion)
+}
+
+func TestCachedUserRepository_FindByID_InvalidationDuringSharedLoad(t *testing.T) {
+	repo, inner, mr := setupCachedRepo(t)
+	id := uuid.New()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	gomock.InOrder(
+		inner.EXPECT().
+			FindByID(gomock.Any(), id).
+			DoAndReturn(func(ctx context.Context, id uuid.UUID, includes ...string) (*domain.User, error) {
+				close(started)
+				<-release
+				return &domain.User{ID: id, TokenVersion: 1}, nil
+			}),
+		// Callers arriving after the shared load finished start their own
+		inner.EXPECT().FindByID(gomock.Any(), id).Return(&domain.User{ID: id, TokenVersion: 2}, nil).MinTimes(1),
+	)
+	inner.EXPECT().IncrementTokenVersion(gomock.Any(), id).Return(2, nil)
+
+	const callers = 5
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := repo.FindByID(context.Background(), id)
+			if assert.NoError(t, err) {
+				assert.Equal(t, id, got.ID)
+			}
+		}()
+	}
+
+	// The revocation lands while the callers wait on the load that read the old row
+	<-started
+	_, err := repo.IncrementTokenVersion(context.Background(), id)
+	require.NoError(t, err)
+	close(release)
+	wg.Wait()
+
+	assert.False(t, mr.Exists(userIDKey(id)), "the shared load must not cache the user it read before the revocation")
+
+	current, err := repo.FindByID(context.Background(), id)
+	require.NoError(t, err)
+	assert.Equal(t, 2, current.TokenVersion)
+}
+
+func TestCachedUserRepository_FindByEmail_ReadsInner(t *testing.T) {
+	repo, inner, mr := setupCachedRepo(t)
+	ctx := context.Background()
+
+	// Logins look users up by email and need the password hash, so every lookup reaches the inner repository
+	user := &domain.User{ID: uuid.New(), Email: "jane@example.com", Password: "hash"}
+	inner.EXPECT().FindByEmail(gomock.Any(), "jane@example.com").Return(user, nil).Times(2)
+
+	for i := 0; i < 2; i++ {
+		got, err := repo.FindByEmail(ctx, "jane@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, "hash", got.Password)
+	}
+	assert.Empty(t, mr.Keys())
+}
+
+func TestCachedUserRepository_CountInvalidatedByCreate(t *testing.T) {
+	repo, inner, _ := setupCachedRepo(t)
+	ctx := context.Background()
+
+	gomock.InOrder(
+		inner.EXPECT().Count(gomock.Any()).Return(int64(1), nil),
+		inner.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil),
+		inner.EXPECT().Count(gomock.Any()).Return(int64(2), nil),
+	)
+
+	total, err := repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+
+	// Served from the cache
+	total, err = repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+
+	require.NoError(t, repo.Create(ctx, &domain.User{ID: uuid.New()}))
+
+	// The create dropped the cached count, so it is recounted
+	total, err = repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+}
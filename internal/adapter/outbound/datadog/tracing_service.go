@@ -56,6 +56,11 @@ func (s *DatadogSpan) SetTag(key string, value interface{}) {
 	s.span.SetTag(key, value)
 }
 
+// SetName sets the span's resource, Datadog groups spans of one operation by resource
+func (s *DatadogSpan) SetName(name string) {
+	s.span.SetTag(ext.ResourceName, name)
+}
+
 // SetError marks the span as having an error
 func (s *DatadogSpan) SetError(err error) {
 	s.span.SetTag(ext.Error, err)
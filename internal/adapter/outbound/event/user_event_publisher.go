@@ -71,3 +71,17 @@ func (p *UserEventPublisher) PublishUserLoggedIn(ctx context.Context, event *dom
 
 	return nil
 }
+
+// PublishBatch publishes several events to the same topic in one call
+// Depending on the broker the batch is delivered atomically, see MessageBroker.PublishBatch
+func (p *UserEventPublisher) PublishBatch(ctx context.Context, topic string, events []domain.Event) error {
+	if p.broker == nil || len(events) == 0 {
+		return nil
+	}
+
+	if err := p.broker.PublishBatch(ctx, topic, events); err != nil {
+		return fmt.Errorf("failed to publish %d %s events: %w", len(events), topic, err)
+	}
+
+	return nil
+}
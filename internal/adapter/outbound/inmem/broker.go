@@ -0,0 +1,197 @@
+package inmem
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/broker"
+)
+
+// defaultBufferSize is the number of undelivered publishes queued per topic before Publish blocks,
+// a batch takes a single slot
+const defaultBufferSize = 100
+
+// Broker implements the MessageBroker interface in memory, delivering messages to
+// subscribers through a buffered channel per topic. Messages published to a topic
+// with no subscriber are dropped, like an exchange with no bound queue.
+type Broker struct {
+	mu            sync.RWMutex
+	connected     bool
+	bufferSize    int
+	subscriptions map[string]*subscription
+}
+
+// subscription queues each publish as one entry, so a batch is either queued whole or not at all
+type subscription struct {
+	messages chan [][]byte
+	cancel   context.CancelFunc
+	done     <-chan struct{}
+}
+
+// NewBroker creates a new in-memory message broker
+func NewBroker() *Broker {
+	return &Broker{
+		bufferSize:    defaultBufferSize,
+		subscriptions: make(map[string]*subscription),
+	}
+}
+
+// Connect marks the broker as ready
+func (b *Broker) Connect(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.connected = true
+	return nil
+}
+
+// Close stops all subscriptions
+func (b *Broker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for topic, sub := range b.subscriptions {
+		sub.cancel()
+		delete(b.subscriptions, topic)
+	}
+	b.connected = false
+
+	return nil
+}
+
+// Health reports whether the broker is connected
+func (b *Broker) Health() error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if !b.connected {
+		return fmt.Errorf("in-memory broker is not connected")
+	}
+
+	return nil
+}
+
+// Publish delivers an event to the topic's subscriber, blocking while its buffer is full
+func (b *Broker) Publish(ctx context.Context, topic string, event domain.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	sub, err := b.subscriber(topic)
+	if err != nil {
+		return err
+	}
+
+	return deliver(ctx, sub, [][]byte{body})
+}
+
+// PublishBatch encodes all events before delivering any, so a bad event publishes nothing
+// The batch is queued as a whole, a full buffer or a done ctx fails it before any event is delivered
+func (b *Broker) PublishBatch(ctx context.Context, topic string, events []domain.Event) error {
+	bodies := make([][]byte, len(events))
+	for i, event := range events {
+		body, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal event: %w", err)
+		}
+		bodies[i] = body
+	}
+
+	sub, err := b.subscriber(topic)
+	if err != nil {
+		return err
+	}
+
+	return deliver(ctx, sub, bodies)
+}
+
+// Subscribe starts delivering messages published to topic to handler until Unsubscribe, Close or ctx is done
+func (b *Broker) Subscribe(ctx context.Context, topic string, handler broker.MessageHandler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.connected {
+		return fmt.Errorf("in-memory broker is not connected")
+	}
+
+	if _, exists := b.subscriptions[topic]; exists {
+		return fmt.Errorf("already subscribed to topic: %s", topic)
+	}
+
+	subCtx, cancel := context.WithCancel(ctx)
+	sub := &subscription{
+		messages: make(chan [][]byte, b.bufferSize),
+		cancel:   cancel,
+		done:     subCtx.Done(),
+	}
+	b.subscriptions[topic] = sub
+
+	go func() {
+		for {
+			select {
+			case <-subCtx.Done():
+				return
+			case bodies := <-sub.messages:
+				for _, body := range bodies {
+					if subCtx.Err() != nil {
+						return
+					}
+					if err := handler(subCtx, body); err != nil {
+						log.Printf("in-memory broker: handler for topic %s failed: %v", topic, err)
+					}
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Unsubscribe stops delivery to the topic's handler
+func (b *Broker) Unsubscribe(topic string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub, exists := b.subscriptions[topic]
+	if !exists {
+		return fmt.Errorf("not subscribed to topic: %s", topic)
+	}
+
+	sub.cancel()
+	delete(b.subscriptions, topic)
+
+	return nil
+}
+
+// subscriber returns the topic's subscription, or nil when nobody is subscribed
+func (b *Broker) subscriber(topic string) (*subscription, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if !b.connected {
+		return nil, fmt.Errorf("in-memory broker is not connected")
+	}
+
+	return b.subscriptions[topic], nil
+}
+
+// deliver queues bodies for sub; the lock is not held so a full buffer can't block Unsubscribe
+func deliver(ctx context.Context, sub *subscription, bodies [][]byte) error {
+	if sub == nil {
+		return nil
+	}
+
+	select {
+	case sub.messages <- bodies:
+		return nil
+	case <-sub.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to publish message: %w", ctx.Err())
+	}
+}
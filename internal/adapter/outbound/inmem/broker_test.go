@@ -0,0 +1,185 @@
+package inmem
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newConnectedBroker(t *testing.T) *Broker {
+	t.Helper()
+
+	b := NewBroker()
+	require.NoError(t, b.Connect(context.Background()))
+	t.Cleanup(func() { _ = b.Close() })
+	return b
+}
+
+func TestBroker_PublishDeliversToSubscriber(t *testing.T) {
+	b := newConnectedBroker(t)
+	received := make(chan []byte, 1)
+
+	err := b.Subscribe(context.Background(), "user.created", func(ctx context.Context, message []byte) error {
+		received <- message
+		return nil
+	})
+	require.NoError(t, err)
+
+	event := domain.NewUserCreatedEvent(uuid.New(), "test@example.com", "Test User")
+	require.NoError(t, b.Publish(context.Background(), "user.created", event))
+
+	select {
+	case message := <-received:
+		var got domain.UserCreatedEvent
+		require.NoError(t, json.Unmarshal(message, &got))
+		assert.Equal(t, event.EventID(), got.EventID())
+		assert.Equal(t, event.Email, got.Email)
+	case <-time.After(time.Second):
+		t.Fatal("event was not delivered")
+	}
+}
+
+func TestBroker_PublishBatchDeliversInOrder(t *testing.T) {
+	b := newConnectedBroker(t)
+	received := make(chan []byte, 3)
+
+	require.NoError(t, b.Subscribe(context.Background(), "user.deleted", func(ctx context.Context, message []byte) error {
+		received <- message
+		return nil
+	}))
+
+	events := []domain.Event{
+		domain.NewUserDeletedEvent(uuid.New()),
+		domain.NewUserDeletedEvent(uuid.New()),
+		domain.NewUserDeletedEvent(uuid.New()),
+	}
+	require.NoError(t, b.PublishBatch(context.Background(), "user.deleted", events))
+
+	for _, event := range events {
+		select {
+		case message := <-received:
+			var got domain.UserDeletedEvent
+			require.NoError(t, json.Unmarshal(message, &got))
+			assert.Equal(t, event.EventID(), got.EventID())
+		case <-time.After(time.Second):
+			t.Fatal("event was not delivered")
+		}
+	}
+}
+
+func TestBroker_PublishBatchIsAllOrNothing(t *testing.T) {
+	b := NewBroker()
+	b.bufferSize = 1
+	require.NoError(t, b.Connect(context.Background()))
+	defer b.Close()
+
+	started := make(chan struct{}, 1)
+	block := make(chan struct{})
+	received := make(chan string, 10)
+	require.NoError(t, b.Subscribe(context.Background(), "user.deleted", func(ctx context.Context, message []byte) error {
+		var event domain.UserDeletedEvent
+		if err := json.Unmarshal(message, &event); err != nil {
+			return err
+		}
+		received <- event.EventID()
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-block
+		return nil
+	}))
+
+	first := domain.NewUserDeletedEvent(uuid.New())
+	require.NoError(t, b.Publish(context.Background(), "user.deleted", first))
+	<-started
+
+	// The handler holds the first event and the buffer has a single slot, the whole batch fits in it
+	queued := []domain.Event{domain.NewUserDeletedEvent(uuid.New()), domain.NewUserDeletedEvent(uuid.New())}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	require.NoError(t, b.PublishBatch(ctx, "user.deleted", queued))
+
+	// Now the buffer is full, the next batch fails without delivering any of its events
+	rejected := []domain.Event{domain.NewUserDeletedEvent(uuid.New()), domain.NewUserDeletedEvent(uuid.New())}
+	err := b.PublishBatch(ctx, "user.deleted", rejected)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(block)
+	for _, want := range []domain.Event{first, queued[0], queued[1]} {
+		select {
+		case got := <-received:
+			assert.Equal(t, want.EventID(), got)
+		case <-time.After(time.Second):
+			t.Fatal("event was not delivered")
+		}
+	}
+
+	select {
+	case got := <-received:
+		t.Fatalf("event %s of the failed batch was delivered", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestBroker_UnsubscribeStopsDelivery(t *testing.T) {
+	b := newConnectedBroker(t)
+	received := make(chan []byte, 1)
+
+	require.NoError(t, b.Subscribe(context.Background(), "user.created", func(ctx context.Context, message []byte) error {
+		received <- message
+		return nil
+	}))
+	require.NoError(t, b.Unsubscribe("user.created"))
+
+	err := b.Publish(context.Background(), "user.created", domain.NewUserCreatedEvent(uuid.New(), "test@example.com", "Test"))
+	require.NoError(t, err)
+
+	select {
+	case <-received:
+		t.Fatal("event delivered after unsubscribe")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	assert.Error(t, b.Unsubscribe("user.created"))
+}
+
+func TestBroker_RequiresConnect(t *testing.T) {
+	b := NewBroker()
+
+	assert.Error(t, b.Health())
+	assert.Error(t, b.Publish(context.Background(), "user.created", domain.NewUserDeletedEvent(uuid.New())))
+	assert.Error(t, b.Subscribe(context.Background(), "user.created", func(ctx context.Context, message []byte) error { return nil }))
+}
+
+func TestBroker_PublishRespectsContextWhenBufferFull(t *testing.T) {
+	b := NewBroker()
+	b.bufferSize = 1
+	require.NoError(t, b.Connect(context.Background()))
+	defer b.Close()
+
+	block := make(chan struct{})
+	defer close(block)
+	require.NoError(t, b.Subscribe(context.Background(), "user.created", func(ctx context.Context, message []byte) error {
+		<-block
+		return nil
+	}))
+
+	event := domain.NewUserDeletedEvent(uuid.New())
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// The handler holds one message and the buffer the next, the third must wait for ctx
+	var err error
+	for i := 0; i < 3 && err == nil; i++ {
+		err = b.Publish(ctx, "user.created", event)
+	}
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,36 @@
+package noop
+
+import (
+	"time"
+
+	"github.com/gieart87/gohexaclean/internal/port/outbound/telemetry"
+)
+
+// NoopMetricsService implements telemetry.MetricsService by discarding every metric
+// It stands in when metrics are disabled so callers never need nil checks
+type NoopMetricsService struct{}
+
+// NewNoopMetricsService creates a metrics service that records nothing
+func NewNoopMetricsService() telemetry.MetricsService {
+	return NoopMetricsService{}
+}
+
+// IncrementCounter does nothing
+func (NoopMetricsService) IncrementCounter(string, map[string]string, float64) {}
+
+// SetGauge does nothing
+func (NoopMetricsService) SetGauge(string, map[string]string, float64) {}
+
+// RecordHistogram does nothing
+func (NoopMetricsService) RecordHistogram(string, map[string]string, float64) {}
+
+// RecordDistribution does nothing
+func (NoopMetricsService) RecordDistribution(string, map[string]string, float64) {}
+
+// RecordTiming does nothing
+func (NoopMetricsService) RecordTiming(string, map[string]string, time.Duration) {}
+
+// Close does nothing
+func (NoopMetricsService) Close() error {
+	return nil
+}
@@ -0,0 +1,42 @@
+package noop
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoopTracingService_SpanIsSafeToUse(t *testing.T) {
+	tracing := NewNoopTracingService()
+	ctx := context.Background()
+
+	span, spanCtx := tracing.StartSpan(ctx, "operation")
+	assert.Equal(t, ctx, spanCtx)
+
+	child, _ := tracing.StartChildSpan(spanCtx, "child")
+
+	assert.NotPanics(t, func() {
+		span.SetTag("key", "value")
+		span.SetName("renamed")
+		span.SetError(errors.New("boom"))
+		child.Finish()
+		span.Finish()
+	})
+	assert.NoError(t, tracing.Close())
+}
+
+func TestNoopMetricsService_IsSafeToUse(t *testing.T) {
+	metrics := NewNoopMetricsService()
+
+	assert.NotPanics(t, func() {
+		metrics.IncrementCounter("counter", nil, 1)
+		metrics.SetGauge("gauge", map[string]string{"k": "v"}, 2)
+		metrics.RecordHistogram("histogram", nil, 3)
+		metrics.RecordDistribution("distribution", nil, 4)
+		metrics.RecordTiming("timing", nil, time.Second)
+	})
+	assert.NoError(t, metrics.Close())
+}
@@ -0,0 +1,46 @@
+package noop
+
+import (
+	"context"
+
+	"github.com/gieart87/gohexaclean/internal/port/outbound/telemetry"
+)
+
+// NoopTracingService implements telemetry.TracingService with spans that record nothing
+// It stands in when tracing is disabled so callers never need nil checks
+type NoopTracingService struct{}
+
+// NoopSpan implements telemetry.Span by discarding everything
+type NoopSpan struct{}
+
+// NewNoopTracingService creates a tracing service that records nothing
+func NewNoopTracingService() telemetry.TracingService {
+	return NoopTracingService{}
+}
+
+// StartSpan returns a noop span and the unchanged context
+func (NoopTracingService) StartSpan(ctx context.Context, _ string, _ ...interface{}) (telemetry.Span, context.Context) {
+	return NoopSpan{}, ctx
+}
+
+// StartChildSpan returns a noop span and the unchanged context
+func (NoopTracingService) StartChildSpan(ctx context.Context, _ string) (telemetry.Span, context.Context) {
+	return NoopSpan{}, ctx
+}
+
+// Close does nothing
+func (NoopTracingService) Close() error {
+	return nil
+}
+
+// SetTag does nothing
+func (NoopSpan) SetTag(string, interface{}) {}
+
+// SetName does nothing
+func (NoopSpan) SetName(string) {}
+
+// SetError does nothing
+func (NoopSpan) SetError(error) {}
+
+// Finish does nothing
+func (NoopSpan) Finish() {}
@@ -0,0 +1,30 @@
+package notifier
+
+import (
+	"context"
+	"log"
+
+	"github.com/gieart87/gohexaclean/internal/port/outbound/service"
+)
+
+// LogNotifier implements Notifier interface by logging messages instead of delivering them
+// Only the length of a body is logged, bodies carry verification tokens that must not reach the logs
+// Swap it for an email/SMS provider adapter (SendGrid, SES, Twilio, ...) in production
+type LogNotifier struct{}
+
+// NewLogNotifier creates a new logging notifier
+func NewLogNotifier() service.Notifier {
+	return &LogNotifier{}
+}
+
+// SendEmail logs the email without its body
+func (n *LogNotifier) SendEmail(ctx context.Context, to, subject, body string) error {
+	log.Printf("[EMAIL] to=%s subject=%q body=<redacted, %d bytes>", to, subject, len(body))
+	return nil
+}
+
+// SendSMS logs the text message without its content
+func (n *LogNotifier) SendSMS(ctx context.Context, to, message string) error {
+	log.Printf("[SMS] to=%s message=<redacted, %d bytes>", to, len(message))
+	return nil
+}
@@ -0,0 +1,35 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureLog returns what the standard logger writes until the test ends
+func captureLog(t *testing.T) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+	previous := log.Writer()
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(previous) })
+	return &buf
+}
+
+func TestLogNotifier_RedactsBodies(t *testing.T) {
+	logged := captureLog(t)
+	notifier := NewLogNotifier()
+
+	token := "s3cr3t-verification-token"
+	require.NoError(t, notifier.SendEmail(context.Background(), "jane@example.com", "Verify your email", "Your token is "+token))
+	require.NoError(t, notifier.SendSMS(context.Background(), "+15550100", "Code: "+token))
+
+	assert.Contains(t, logged.String(), "jane@example.com")
+	assert.Contains(t, logged.String(), "Verify your email")
+	assert.NotContains(t, logged.String(), token)
+}
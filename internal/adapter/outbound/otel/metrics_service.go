@@ -2,12 +2,17 @@ package otel
 
 import (
 	"context"
+	"net/http"
+	"sync"
 	"time"
 
 	"github.com/gieart87/gohexaclean/internal/port/outbound/telemetry"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
@@ -18,6 +23,23 @@ import (
 type MetricsServiceOTEL struct {
 	meterProvider *sdkmetric.MeterProvider
 	meter         metric.Meter
+	handler       http.Handler
+
+	// Instruments are created once per name and reused across calls
+	counters   sync.Map // name -> metric.Float64Counter
+	histograms sync.Map // name -> metric.Float64Histogram
+	gauges     sync.Map // name -> *float64Gauge
+}
+
+// float64Gauge holds the latest value per tag-set reported by a single observable gauge callback
+type float64Gauge struct {
+	mu     sync.Mutex
+	values map[attribute.Distinct]gaugeValue
+}
+
+type gaugeValue struct {
+	attrs attribute.Set
+	value float64
 }
 
 // NewMetricsServiceOTEL creates a new OpenTelemetry metrics service
@@ -31,6 +53,31 @@ func NewMetricsServiceOTEL(ctx context.Context, serviceName, collectorEndpoint s
 		return nil, err
 	}
 
+	return newMetricsServiceOTEL(ctx, serviceName, sdkmetric.NewPeriodicReader(exporter))
+}
+
+// NewPrometheusMetricsServiceOTEL creates an OpenTelemetry metrics service backed by a Prometheus exporter,
+// so metrics can be scraped from Handler without running a collector
+func NewPrometheusMetricsServiceOTEL(ctx context.Context, serviceName string) (*MetricsServiceOTEL, error) {
+	// Use a dedicated registry so only the meter provider's metrics are exposed
+	registry := prometheus.NewRegistry()
+
+	exporter, err := otelprometheus.New(otelprometheus.WithRegisterer(registry))
+	if err != nil {
+		return nil, err
+	}
+
+	service, err := newMetricsServiceOTEL(ctx, serviceName, exporter)
+	if err != nil {
+		return nil, err
+	}
+	service.handler = promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+
+	return service, nil
+}
+
+// newMetricsServiceOTEL builds the meter provider around the given reader
+func newMetricsServiceOTEL(ctx context.Context, serviceName string, reader sdkmetric.Reader) (*MetricsServiceOTEL, error) {
 	// Create resource
 	res, err := resource.New(ctx,
 		resource.WithAttributes(
@@ -43,7 +90,7 @@ func NewMetricsServiceOTEL(ctx context.Context, serviceName, collectorEndpoint s
 
 	// Create meter provider
 	meterProvider := sdkmetric.NewMeterProvider(
-		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter)),
+		sdkmetric.WithReader(reader),
 		sdkmetric.WithResource(res),
 	)
 
@@ -59,9 +106,14 @@ func NewMetricsServiceOTEL(ctx context.Context, serviceName, collectorEndpoint s
 	}, nil
 }
 
+// Handler returns the HTTP handler serving scraped metrics, or nil when metrics are pushed to a collector
+func (m *MetricsServiceOTEL) Handler() http.Handler {
+	return m.handler
+}
+
 // IncrementCounter increments a counter metric
 func (m *MetricsServiceOTEL) IncrementCounter(name string, tags map[string]string, value float64) {
-	counter, err := m.meter.Float64Counter(name)
+	counter, err := m.counter(name)
 	if err != nil {
 		return
 	}
@@ -72,19 +124,22 @@ func (m *MetricsServiceOTEL) IncrementCounter(name string, tags map[string]strin
 
 // SetGauge sets a gauge metric
 func (m *MetricsServiceOTEL) SetGauge(name string, tags map[string]string, value float64) {
-	// OTEL doesn't have direct gauge support, use observable gauge
-	attrs := convertTagsToAttributes(tags)
-	_, _ = m.meter.Float64ObservableGauge(name,
-		metric.WithFloat64Callback(func(_ context.Context, observer metric.Float64Observer) error {
-			observer.Observe(value, metric.WithAttributes(attrs...))
-			return nil
-		}),
-	)
+	// OTEL doesn't have direct gauge support, the observable gauge reports the latest value per tag-set
+	gauge, err := m.gauge(name)
+	if err != nil {
+		return
+	}
+
+	attrs := attribute.NewSet(convertTagsToAttributes(tags)...)
+
+	gauge.mu.Lock()
+	gauge.values[attrs.Equivalent()] = gaugeValue{attrs: attrs, value: value}
+	gauge.mu.Unlock()
 }
 
 // RecordHistogram records a histogram metric
 func (m *MetricsServiceOTEL) RecordHistogram(name string, tags map[string]string, value float64) {
-	histogram, err := m.meter.Float64Histogram(name)
+	histogram, err := m.histogram(name)
 	if err != nil {
 		return
 	}
@@ -100,7 +155,7 @@ func (m *MetricsServiceOTEL) RecordDistribution(name string, tags map[string]str
 
 // RecordTiming records a timing metric
 func (m *MetricsServiceOTEL) RecordTiming(name string, tags map[string]string, duration time.Duration) {
-	histogram, err := m.meter.Float64Histogram(name)
+	histogram, err := m.histogram(name)
 	if err != nil {
 		return
 	}
@@ -117,6 +172,67 @@ func (m *MetricsServiceOTEL) Close() error {
 	return nil
 }
 
+// counter returns the cached counter for name, creating it on first use
+func (m *MetricsServiceOTEL) counter(name string) (metric.Float64Counter, error) {
+	if counter, ok := m.counters.Load(name); ok {
+		return counter.(metric.Float64Counter), nil
+	}
+
+	counter, err := m.meter.Float64Counter(name)
+	if err != nil {
+		return nil, err
+	}
+
+	actual, _ := m.counters.LoadOrStore(name, counter)
+	return actual.(metric.Float64Counter), nil
+}
+
+// histogram returns the cached histogram for name, creating it on first use
+func (m *MetricsServiceOTEL) histogram(name string) (metric.Float64Histogram, error) {
+	if histogram, ok := m.histograms.Load(name); ok {
+		return histogram.(metric.Float64Histogram), nil
+	}
+
+	histogram, err := m.meter.Float64Histogram(name)
+	if err != nil {
+		return nil, err
+	}
+
+	actual, _ := m.histograms.LoadOrStore(name, histogram)
+	return actual.(metric.Float64Histogram), nil
+}
+
+// gauge returns the cached gauge for name, registering its observable callback only once
+func (m *MetricsServiceOTEL) gauge(name string) (*float64Gauge, error) {
+	if gauge, ok := m.gauges.Load(name); ok {
+		return gauge.(*float64Gauge), nil
+	}
+
+	gauge := &float64Gauge{values: make(map[attribute.Distinct]gaugeValue)}
+	actual, loaded := m.gauges.LoadOrStore(name, gauge)
+	if loaded {
+		return actual.(*float64Gauge), nil
+	}
+
+	_, err := m.meter.Float64ObservableGauge(name,
+		metric.WithFloat64Callback(func(_ context.Context, observer metric.Float64Observer) error {
+			gauge.mu.Lock()
+			defer gauge.mu.Unlock()
+
+			for _, v := range gauge.values {
+				observer.Observe(v.value, metric.WithAttributeSet(v.attrs))
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		m.gauges.Delete(name)
+		return nil, err
+	}
+
+	return gauge, nil
+}
+
 // convertTagsToAttributes converts a map of tags to OTEL attributes
 func convertTagsToAttributes(tags map[string]string) []attribute.KeyValue {
 	if len(tags) == 0 {
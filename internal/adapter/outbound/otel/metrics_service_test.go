@@ -0,0 +1,177 @@
+package otel
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestPrometheusMetricsServiceOTEL_ScrapesRecordedCounter(t *testing.T) {
+	service, err := NewPrometheusMetricsServiceOTEL(context.Background(), "gohexaclean-test")
+	require.NoError(t, err)
+	defer service.Close()
+	require.NotNil(t, service.Handler())
+
+	service.IncrementCounter("user.login", map[string]string{"status": "success"}, 2)
+
+	server := httptest.NewServer(service.Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(body), "# TYPE user_login_total counter")
+	assert.Regexp(t, `user_login_total\{[^}]*status="success"[^}]*\} 2`, string(body))
+}
+
+// newManualMetricsService builds a metrics service whose metrics are collected on demand
+func newManualMetricsService(t *testing.T) (*MetricsServiceOTEL, *sdkmetric.ManualReader) {
+	t.Helper()
+
+	reader := sdkmetric.NewManualReader()
+	service, err := newMetricsServiceOTEL(context.Background(), "gohexaclean-test", reader)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = service.Close() })
+
+	return service, reader
+}
+
+// collectMetric returns the named metric from a collection, failing the test if it is missing
+func collectMetric(t *testing.T, reader *sdkmetric.ManualReader, name string) metricdata.Metrics {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name == name {
+				return m
+			}
+		}
+	}
+
+	t.Fatalf("metric %q not collected", name)
+	return metricdata.Metrics{}
+}
+
+func TestMetricsServiceOTEL_IncrementCounterReusesInstrument(t *testing.T) {
+	service, reader := newManualMetricsService(t)
+	tags := map[string]string{"status": "success"}
+
+	service.IncrementCounter("user.login", tags, 1)
+	first, ok := service.counters.Load("user.login")
+	require.True(t, ok)
+
+	service.IncrementCounter("user.login", tags, 2)
+	service.IncrementCounter("user.login", tags, 3)
+	second, _ := service.counters.Load("user.login")
+
+	assert.Same(t, first, second)
+
+	sum, ok := collectMetric(t, reader, "user.login").Data.(metricdata.Sum[float64])
+	require.True(t, ok)
+	require.Len(t, sum.DataPoints, 1)
+	assert.Equal(t, 6.0, sum.DataPoints[0].Value)
+}
+
+func TestMetricsServiceOTEL_SetGaugeReportsLatestValuePerTagSet(t *testing.T) {
+	service, reader := newManualMetricsService(t)
+
+	service.SetGauge("db.pool.open", map[string]string{"db": "primary"}, 5)
+	service.SetGauge("db.pool.open", map[string]string{"db": "primary"}, 8)
+	service.SetGauge("db.pool.open", map[string]string{"db": "replica"}, 2)
+
+	gauge, ok := collectMetric(t, reader, "db.pool.open").Data.(metricdata.Gauge[float64])
+	require.True(t, ok)
+	require.Len(t, gauge.DataPoints, 2)
+
+	values := make(map[string]float64)
+	for _, dp := range gauge.DataPoints {
+		db, _ := dp.Attributes.Value(attribute.Key("db"))
+		values[db.AsString()] = dp.Value
+	}
+	assert.Equal(t, map[string]float64{"primary": 8, "replica": 2}, values)
+}
+
+func TestMetricsServiceOTEL_SetGaugeRegistersInstrumentOnce(t *testing.T) {
+	service, reader := newManualMetricsService(t)
+	tags := map[string]string{"db": "primary"}
+
+	service.SetGauge("db.pool.idle", tags, 3)
+	first, ok := service.gauges.Load("db.pool.idle")
+	require.True(t, ok)
+
+	service.SetGauge("db.pool.idle", tags, 4)
+	second, _ := service.gauges.Load("db.pool.idle")
+
+	assert.Same(t, first, second)
+
+	// A re-registered callback would report one data point per registration
+	gauge, ok := collectMetric(t, reader, "db.pool.idle").Data.(metricdata.Gauge[float64])
+	require.True(t, ok)
+	require.Len(t, gauge.DataPoints, 1)
+	assert.Equal(t, 4.0, gauge.DataPoints[0].Value)
+}
+
+func TestMetricsServiceOTEL_RecordHistogramReusesInstrument(t *testing.T) {
+	service, reader := newManualMetricsService(t)
+
+	service.RecordHistogram("http.request.size", nil, 10)
+	first, ok := service.histograms.Load("http.request.size")
+	require.True(t, ok)
+
+	service.RecordHistogram("http.request.size", nil, 30)
+	second, _ := service.histograms.Load("http.request.size")
+
+	assert.Same(t, first, second)
+
+	histogram, ok := collectMetric(t, reader, "http.request.size").Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, histogram.DataPoints, 1)
+	assert.Equal(t, uint64(2), histogram.DataPoints[0].Count)
+	assert.Equal(t, 40.0, histogram.DataPoints[0].Sum)
+}
+
+// Instruments are cached per name, so the hot path shouldn't pay for an instrument lookup on every call
+func BenchmarkMetricsServiceOTEL_IncrementCounter(b *testing.B) {
+	service, err := newMetricsServiceOTEL(context.Background(), "gohexaclean-bench", sdkmetric.NewManualReader())
+	require.NoError(b, err)
+	defer service.Close()
+
+	tags := map[string]string{"method": "GET", "route": "/api/v1/users/:id", "status": "200"}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		service.IncrementCounter("http.requests.total", tags, 1)
+	}
+}
+
+func BenchmarkMetricsServiceOTEL_RecordTiming(b *testing.B) {
+	service, err := newMetricsServiceOTEL(context.Background(), "gohexaclean-bench", sdkmetric.NewManualReader())
+	require.NoError(b, err)
+	defer service.Close()
+
+	tags := map[string]string{"method": "GET", "route": "/api/v1/users/:id", "status": "200"}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		service.RecordTiming("http.request.duration", tags, 15*time.Millisecond)
+	}
+}
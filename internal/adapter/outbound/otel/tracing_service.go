@@ -102,6 +102,11 @@ func (s *OTELSpan) SetTag(key string, value interface{}) {
 	}
 }
 
+// SetName renames the span
+func (s *OTELSpan) SetName(name string) {
+	s.span.SetName(name)
+}
+
 // SetError marks the span as having an error
 func (s *OTELSpan) SetError(err error) {
 	s.span.RecordError(err)
@@ -0,0 +1,33 @@
+package pgsql
+
+import (
+	"context"
+	"time"
+
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/repository"
+	"gorm.io/gorm"
+)
+
+// AuditRepositoryPG implements AuditRepository interface for PostgreSQL using GORM
+type AuditRepositoryPG struct {
+	db           *gorm.DB
+	queryTimeout time.Duration
+}
+
+// NewAuditRepositoryPG creates a new PostgreSQL audit repository
+// queryTimeout bounds each query when the caller's context has no deadline, 0 disables it
+func NewAuditRepositoryPG(db *gorm.DB, queryTimeout time.Duration) repository.AuditRepository {
+	return &AuditRepositoryPG{db: db, queryTimeout: queryTimeout}
+}
+
+// Record inserts an audit entry, inside the caller's transaction when there is one
+func (r *AuditRepositoryPG) Record(ctx context.Context, entry *domain.AuditLog) error {
+	ctx, cancel := withQueryTimeout(ctx, r.queryTimeout)
+	defer cancel()
+
+	if err := dbFromContext(ctx, r.db).Create(entry).Error; err != nil {
+		return mapQueryError(ctx, err)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package pgsql
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditRepositoryPG_Record(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewAuditRepositoryPG(db, 0)
+
+	actorID := uuid.New()
+	entry := domain.NewAuditLog(&actorID, domain.AuditActionUserDeleted, uuid.New(), "req-1")
+	id := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "audit_logs" ("actor_id","action","target_id","request_id","created_at") VALUES ($1,$2,$3,$4,$5) RETURNING "id"`)).
+		WithArgs(actorID, domain.AuditActionUserDeleted, entry.TargetID, "req-1", entry.CreatedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+
+	err := repo.Record(context.Background(), entry)
+	assert.NoError(t, err)
+	assert.Equal(t, id, entry.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuditRepositoryPG_Record_Error(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewAuditRepositoryPG(db, 0)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "audit_logs"`)).
+		WillReturnError(errors.New("connection refused"))
+
+	err := repo.Record(context.Background(), domain.NewAuditLog(nil, domain.AuditActionUserCreated, uuid.New(), ""))
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
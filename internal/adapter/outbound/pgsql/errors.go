@@ -0,0 +1,35 @@
+package pgsql
+
+import (
+	"errors"
+	"fmt"
+
+	dberr "github.com/gieart87/gohexaclean/internal/infra/db"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Postgres SQLSTATE codes for integrity constraint violations
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+	pgNotNullViolation    = "23502"
+	pgCheckViolation      = "23514"
+)
+
+// mapPgError translates Postgres constraint violations into typed database errors
+// Errors that aren't constraint violations are returned unchanged
+func mapPgError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	switch pgErr.Code {
+	case pgUniqueViolation:
+		return fmt.Errorf("%w: %s", dberr.ErrDBDuplicateKey, pgErr.ConstraintName)
+	case pgForeignKeyViolation, pgNotNullViolation, pgCheckViolation:
+		return fmt.Errorf("%w: %s", dberr.ErrDBConstraint, pgErr.ConstraintName)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,58 @@
+package pgsql
+
+import (
+	"context"
+	"time"
+
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EventStorePG implements EventStore interface for PostgreSQL using GORM
+type EventStorePG struct {
+	db           *gorm.DB
+	queryTimeout time.Duration
+}
+
+// NewEventStorePG creates a new PostgreSQL event store
+// queryTimeout bounds each query when the caller's context has no deadline, 0 disables it
+func NewEventStorePG(db *gorm.DB, queryTimeout time.Duration) repository.EventStore {
+	return &EventStorePG{db: db, queryTimeout: queryTimeout}
+}
+
+// Append inserts events in one statement, inside the caller's transaction when there is one
+func (s *EventStorePG) Append(ctx context.Context, events ...*domain.StoredEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	ctx, cancel := withQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	if err := dbFromContext(ctx, s.db).Create(&events).Error; err != nil {
+		return mapQueryError(ctx, err)
+	}
+	return nil
+}
+
+// List returns a page of the aggregate's events, newest first, and how many it has in total
+func (s *EventStorePG) List(ctx context.Context, aggregateID uuid.UUID, offset, limit int) ([]*domain.StoredEvent, int64, error) {
+	ctx, cancel := withQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	// A new session lets the filtered query be reused for both the count and the page
+	conn := dbFromContext(ctx, s.db).Model(&domain.StoredEvent{}).Where("aggregate_id = ?", aggregateID).Session(&gorm.Session{})
+
+	var total int64
+	if err := conn.Count(&total).Error; err != nil {
+		return nil, 0, mapQueryError(ctx, err)
+	}
+
+	var events []*domain.StoredEvent
+	if err := conn.Order("occurred_at DESC, id DESC").Limit(limit).Offset(offset).Find(&events).Error; err != nil {
+		return nil, 0, mapQueryError(ctx, err)
+	}
+	return events, total, nil
+}
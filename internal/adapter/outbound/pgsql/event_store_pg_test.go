@@ -0,0 +1,86 @@
+package pgsql
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventStorePG_Append(t *testing.T) {
+	db, mock := setupTestDB(t)
+	store := NewEventStorePG(db, 0)
+
+	userID := uuid.New()
+	created, err := domain.NewStoredEvent(domain.NewUserCreatedEvent(userID, "jane@example.com", "Jane"))
+	require.NoError(t, err)
+	loggedIn, err := domain.NewStoredEvent(domain.NewUserLoggedInEvent(userID, "jane@example.com"))
+	require.NoError(t, err)
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "events" ("id","type","aggregate_id","payload","occurred_at") VALUES ($1,$2,$3,$4,$5),($6,$7,$8,$9,$10)`)).
+		WithArgs(
+			created.ID, "user.created", userID, created.Payload, created.OccurredAt,
+			loggedIn.ID, "user.logged_in", userID, loggedIn.Payload, loggedIn.OccurredAt,
+		).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err = store.Append(context.Background(), created, loggedIn)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEventStorePG_Append_Nothing(t *testing.T) {
+	db, mock := setupTestDB(t)
+	store := NewEventStorePG(db, 0)
+
+	assert.NoError(t, store.Append(context.Background()))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEventStorePG_Append_Error(t *testing.T) {
+	db, mock := setupTestDB(t)
+	store := NewEventStorePG(db, 0)
+
+	stored, err := domain.NewStoredEvent(domain.NewUserDeletedEvent(uuid.New()))
+	require.NoError(t, err)
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "events"`)).
+		WillReturnError(errors.New("connection refused"))
+
+	assert.Error(t, store.Append(context.Background(), stored))
+}
+
+func TestEventStorePG_List_NewestFirst(t *testing.T) {
+	db, mock := setupTestDB(t)
+	store := NewEventStorePG(db, 0)
+
+	userID := uuid.New()
+	newer, older := uuid.New(), uuid.New()
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "events" WHERE aggregate_id = $1`)).
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "events" WHERE aggregate_id = $1 ORDER BY occurred_at DESC, id DESC LIMIT $2 OFFSET $3`)).
+		WithArgs(userID, 2, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "type", "aggregate_id", "payload", "occurred_at"}).
+			AddRow(newer, "user.logged_in", userID, []byte(`{"email":"jane@example.com"}`), now).
+			AddRow(older, "user.created", userID, []byte(`{"name":"Jane"}`), now.Add(-time.Hour)))
+
+	events, total, err := store.List(context.Background(), userID, 10, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), total)
+	require.Len(t, events, 2)
+	assert.Equal(t, newer, events[0].ID)
+	assert.Equal(t, "user.logged_in", events[0].Type)
+	assert.JSONEq(t, `{"email":"jane@example.com"}`, string(events[0].Payload))
+	assert.Equal(t, older, events[1].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
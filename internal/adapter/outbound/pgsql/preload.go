@@ -0,0 +1,25 @@
+package pgsql
+
+import (
+	"fmt"
+
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"gorm.io/gorm"
+)
+
+// userPreloads maps include names accepted by the API to GORM association paths
+// on domain.User. Register new associations here (e.g. "roles": "Roles").
+var userPreloads = map[string]string{}
+
+// applyPreloads adds a GORM Preload for each requested include.
+// Includes that are not present in the preload map are rejected.
+func applyPreloads(db *gorm.DB, includes []string, preloads map[string]string) (*gorm.DB, error) {
+	for _, include := range includes {
+		association, ok := preloads[include]
+		if !ok {
+			return nil, fmt.Errorf("%w: unsupported include %q", domain.ErrInvalidInput, include)
+		}
+		db = db.Preload(association)
+	}
+	return db, nil
+}
@@ -0,0 +1,17 @@
+package pgsql
+
+import (
+	"context"
+
+	"github.com/gieart87/gohexaclean/internal/port/outbound/telemetry"
+)
+
+// startQuerySpan starts a child span named db.<table>.<operation> so queries show up in the request's trace
+func startQuerySpan(ctx context.Context, tracing telemetry.TracingService, table, operation string) (telemetry.Span, context.Context) {
+	span, ctx := tracing.StartChildSpan(ctx, "db."+table+"."+operation)
+	span.SetTag("db.system", "postgresql")
+	span.SetTag("db.sql.table", table)
+	span.SetTag("db.operation", operation)
+
+	return span, ctx
+}
@@ -0,0 +1,99 @@
+package pgsql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/telemetry"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSpan records what was set on it
+type fakeSpan struct {
+	name     string
+	tags     map[string]interface{}
+	finished bool
+}
+
+func (s *fakeSpan) SetTag(key string, value interface{}) { s.tags[key] = value }
+func (s *fakeSpan) SetName(name string)                  { s.name = name }
+func (s *fakeSpan) SetError(error)                       {}
+func (s *fakeSpan) Finish()                              { s.finished = true }
+
+// fakeTracing keeps every span it starts
+type fakeTracing struct {
+	spans []*fakeSpan
+}
+
+func (t *fakeTracing) StartSpan(ctx context.Context, name string, _ ...interface{}) (telemetry.Span, context.Context) {
+	span := &fakeSpan{name: name, tags: make(map[string]interface{})}
+	t.spans = append(t.spans, span)
+	return span, ctx
+}
+
+func (t *fakeTracing) StartChildSpan(ctx context.Context, name string) (telemetry.Span, context.Context) {
+	return t.StartSpan(ctx, name)
+}
+
+func (t *fakeTracing) Close() error { return nil }
+
+func TestUserRepositoryPG_FindByID_StartsQuerySpan(t *testing.T) {
+	db, mock := setupTestDB(t)
+	tracing := &fakeTracing{}
+	repo := NewUserRepositoryPG(db, 0, tracing)
+
+	id := uuid.New()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE id = $1`)).
+		WithArgs(id, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(id, "test@example.com"))
+
+	_, err := repo.FindByID(context.Background(), id)
+	require.NoError(t, err)
+
+	require.Len(t, tracing.spans, 1)
+	span := tracing.spans[0]
+	assert.Equal(t, "db.users.FindByID", span.name)
+	assert.Equal(t, "users", span.tags["db.sql.table"])
+	assert.Equal(t, "FindByID", span.tags["db.operation"])
+	assert.True(t, span.finished)
+}
+
+func TestUserRepositoryPG_FindByID_FinishesSpanOnError(t *testing.T) {
+	db, mock := setupTestDB(t)
+	tracing := &fakeTracing{}
+	repo := NewUserRepositoryPG(db, 0, tracing)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err := repo.FindByID(context.Background(), uuid.New())
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+
+	require.Len(t, tracing.spans, 1)
+	assert.True(t, tracing.spans[0].finished)
+}
+
+func TestUserRepositoryPG_InTransaction_KeepsTracing(t *testing.T) {
+	db, mock := setupTestDB(t)
+	tracing := &fakeTracing{}
+	repo := NewUserRepositoryPG(db, 0, tracing)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "users"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectCommit()
+
+	err := NewTransactorPG(db).WithinTransaction(context.Background(), func(ctx context.Context) error {
+		_, err := repo.Count(ctx)
+		return err
+	})
+	require.NoError(t, err)
+
+	require.Len(t, tracing.spans, 1)
+	assert.Equal(t, "db.users.Count", tracing.spans[0].name)
+}
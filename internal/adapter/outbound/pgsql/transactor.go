@@ -0,0 +1,40 @@
+package pgsql
+
+import (
+	"context"
+
+	"github.com/gieart87/gohexaclean/internal/port/outbound/repository"
+	"gorm.io/gorm"
+)
+
+// txKey holds the transaction's *gorm.DB in a context
+type txKey struct{}
+
+// TransactorPG implements Transactor with GORM transactions shared through the context
+type TransactorPG struct {
+	db *gorm.DB
+}
+
+// NewTransactorPG creates a new PostgreSQL transactor
+func NewTransactorPG(db *gorm.DB) repository.Transactor {
+	return &TransactorPG{db: db}
+}
+
+// WithinTransaction runs fn in a transaction, or inside the caller's one when ctx already carries it
+func (t *TransactorPG) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	return t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// dbFromContext returns the transaction started by WithinTransaction, falling back to db outside of one
+func dbFromContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
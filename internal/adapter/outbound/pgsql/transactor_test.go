@@ -0,0 +1,101 @@
+package pgsql
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gieart87/gohexaclean/internal/adapter/outbound/noop"
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/gieart87/gohexaclean/pkg/idgen"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactorPG_WithinTransaction_Commit(t *testing.T) {
+	db, mock := setupTestDB(t)
+	transactor := NewTransactorPG(db)
+	users := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
+	audits := NewAuditRepositoryPG(db, 0)
+
+	user := domain.NewUser("test@example.com", "Test User", "hashedpassword", idgen.StrategyUUIDv4)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "users"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(user.ID))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "audit_logs"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+	mock.ExpectCommit()
+
+	err := transactor.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		if err := users.Create(ctx, user); err != nil {
+			return err
+		}
+		return audits.Record(ctx, domain.NewAuditLog(nil, domain.AuditActionUserCreated, user.ID, ""))
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransactorPG_WithinTransaction_RollsBackOnError(t *testing.T) {
+	db, mock := setupTestDB(t)
+	transactor := NewTransactorPG(db)
+	users := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
+
+	user := domain.NewUser("test@example.com", "Test User", "hashedpassword", idgen.StrategyUUIDv4)
+	callbackErr := errors.New("audit failed")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "users"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(user.ID))
+	mock.ExpectRollback()
+
+	err := transactor.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		if err := users.Create(ctx, user); err != nil {
+			return err
+		}
+		return callbackErr
+	})
+
+	assert.ErrorIs(t, err, callbackErr)
+	assert.NoError(t, mock.ExpectationsWereMet(), "the insert must be rolled back, not committed")
+}
+
+func TestTransactorPG_WithinTransaction_NestedJoinsOuter(t *testing.T) {
+	db, mock := setupTestDB(t)
+	transactor := NewTransactorPG(db)
+	users := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
+
+	user := domain.NewUser("test@example.com", "Test User", "hashedpassword", idgen.StrategyUUIDv4)
+
+	// A single BEGIN/COMMIT pair proves the inner call didn't open its own transaction
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "users"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(user.ID))
+	mock.ExpectCommit()
+
+	err := transactor.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		return transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			return users.Create(ctx, user)
+		})
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryPG_OutsideTransaction_UsesBaseDB(t *testing.T) {
+	db, mock := setupTestDB(t)
+	users := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "users"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	_, err := users.Count(context.Background())
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet(), "no transaction should be opened")
+}
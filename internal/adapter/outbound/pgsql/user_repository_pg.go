@@ -3,58 +3,157 @@ package pgsql
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/gieart87/gohexaclean/internal/domain"
+	dberr "github.com/gieart87/gohexaclean/internal/infra/db"
 	"github.com/gieart87/gohexaclean/internal/port/outbound/repository"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/telemetry"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/plugin/dbresolver"
 )
 
+// usersTable is the table UserRepositoryPG queries, used to name its tracing spans
+const usersTable = "users"
+
 // UserRepositoryPG implements UserRepository interface for PostgreSQL using GORM
 type UserRepositoryPG struct {
-	db *gorm.DB
+	db           *gorm.DB
+	queryTimeout time.Duration
+	tracing      telemetry.TracingService
 }
 
 // NewUserRepositoryPG creates a new PostgreSQL user repository
-func NewUserRepositoryPG(db *gorm.DB) repository.UserRepository {
-	return &UserRepositoryPG{db: db}
+// queryTimeout bounds each query when the caller's context has no deadline, 0 disables it
+// Every query runs in a child span of the caller's trace
+func NewUserRepositoryPG(db *gorm.DB, queryTimeout time.Duration, tracing telemetry.TracingService) repository.UserRepository {
+	return &UserRepositoryPG{db: db, queryTimeout: queryTimeout, tracing: tracing}
+}
+
+// conn returns the database handle for ctx, the caller's transaction if it runs in one
+func (r *UserRepositoryPG) conn(ctx context.Context) *gorm.DB {
+	return dbFromContext(ctx, r.db)
+}
+
+// startQuery starts the span for operation and applies the query timeout unless the caller already set a deadline
+// The returned done finishes the span and releases the timeout
+func (r *UserRepositoryPG) startQuery(ctx context.Context, operation string) (context.Context, func()) {
+	span, ctx := startQuerySpan(ctx, r.tracing, usersTable, operation)
+	ctx, cancel := withQueryTimeout(ctx, r.queryTimeout)
+
+	return ctx, func() {
+		cancel()
+		span.Finish()
+	}
+}
+
+// withQueryTimeout bounds ctx by timeout unless it is 0 or the caller already set a deadline
+func withQueryTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// mapQueryError translates deadline errors into ErrDBTimeout and constraint violations into typed errors
+// Drivers report cancellation differently, so the context itself is checked as well
+func mapQueryError(ctx context.Context, err error) error {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %w", dberr.ErrDBTimeout, context.DeadlineExceeded)
+	}
+	return mapPgError(err)
 }
 
 // Create creates a new user
 func (r *UserRepositoryPG) Create(ctx context.Context, user *domain.User) error {
-	if err := r.db.WithContext(ctx).Create(user).Error; err != nil {
+	ctx, done := r.startQuery(ctx, "Create")
+	defer done()
+
+	if err := r.conn(ctx).Create(user).Error; err != nil {
+		err = mapQueryError(ctx, err)
+		if errors.Is(err, dberr.ErrDBDuplicateKey) {
+			// The only unique constraints on users are on the email
+			return fmt.Errorf("%w: %w", domain.ErrUserAlreadyExists, err)
+		}
 		return err
 	}
 	return nil
 }
 
-// FindByID finds a user by ID
-func (r *UserRepositoryPG) FindByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+// FindByID finds a user by ID, preloading any requested related data
+// It reads from the primary: the result backs token version and active checks and is cached,
+// so a lagging replica would bring back revoked tokens and deactivated accounts
+func (r *UserRepositoryPG) FindByID(ctx context.Context, id uuid.UUID, includes ...string) (*domain.User, error) {
+	ctx, done := r.startQuery(ctx, "FindByID")
+	defer done()
+
+	query, err := applyPreloads(r.conn(ctx).Clauses(dbresolver.Write), includes, userPreloads)
+	if err != nil {
+		return nil, err
+	}
+
 	var user domain.User
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&user).Error; err != nil {
+	if err := query.Where("id = ?", id).First(&user).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domain.ErrUserNotFound
 		}
-		return nil, err
+		return nil, mapQueryError(ctx, err)
 	}
 	return &user, nil
 }
 
-// FindByEmail finds a user by email
+// FindByEmail finds a user by email, ignoring case
+// Like FindByID it reads from the primary, login and email verification must see the latest credentials
 func (r *UserRepositoryPG) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
+	ctx, done := r.startQuery(ctx, "FindByEmail")
+	defer done()
+
 	var user domain.User
-	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
+	if err := r.conn(ctx).Clauses(dbresolver.Write).Where("LOWER(email) = LOWER(?)", email).First(&user).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domain.ErrUserNotFound
 		}
-		return nil, err
+		return nil, mapQueryError(ctx, err)
 	}
 	return &user, nil
 }
 
+// FindByIDs finds users by ID with a single IN query
+// Duplicate IDs are collapsed and an empty input returns without querying
+func (r *UserRepositoryPG) FindByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*domain.User, error) {
+	result := make(map[uuid.UUID]*domain.User, len(ids))
+
+	unique := uniqueIDs(ids)
+	if len(unique) == 0 {
+		return result, nil
+	}
+
+	ctx, done := r.startQuery(ctx, "FindByIDs")
+	defer done()
+
+	var users []*domain.User
+	if err := r.conn(ctx).Where("id IN ?", unique).Find(&users).Error; err != nil {
+		return nil, mapQueryError(ctx, err)
+	}
+
+	for _, user := range users {
+		result[user.ID] = user
+	}
+	return result, nil
+}
+
 // Update updates a user
 func (r *UserRepositoryPG) Update(ctx context.Context, user *domain.User) error {
-	result := r.db.WithContext(ctx).Model(&domain.User{}).
+	ctx, done := r.startQuery(ctx, "Update")
+	defer done()
+
+	result := r.conn(ctx).Model(&domain.User{}).
 		Where("id = ?", user.ID).
 		Updates(map[string]interface{}{
 			"name":       user.Name,
@@ -62,7 +161,38 @@ func (r *UserRepositoryPG) Update(ctx context.Context, user *domain.User) error
 		})
 
 	if result.Error != nil {
-		return result.Error
+		return mapQueryError(ctx, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// UpdateEmail saves the user's email and its verification state, leaving the profile untouched
+func (r *UserRepositoryPG) UpdateEmail(ctx context.Context, user *domain.User) error {
+	ctx, done := r.startQuery(ctx, "UpdateEmail")
+	defer done()
+
+	result := r.conn(ctx).Model(&domain.User{}).
+		Where("id = ?", user.ID).
+		Updates(map[string]interface{}{
+			"email":                         user.Email,
+			"email_verified":                user.EmailVerified,
+			"pending_email":                 user.PendingEmail,
+			"email_verification_token":      user.EmailVerificationToken,
+			"email_verification_expires_at": user.EmailVerificationExpiresAt,
+		})
+
+	if result.Error != nil {
+		err := mapQueryError(ctx, result.Error)
+		if errors.Is(err, dberr.ErrDBDuplicateKey) {
+			// Another user took the address between the check and the write
+			return fmt.Errorf("%w: %w", domain.ErrUserAlreadyExists, err)
+		}
+		return err
 	}
 
 	if result.RowsAffected == 0 {
@@ -74,9 +204,12 @@ func (r *UserRepositoryPG) Update(ctx context.Context, user *domain.User) error
 
 // Delete deletes a user (soft delete using GORM)
 func (r *UserRepositoryPG) Delete(ctx context.Context, id uuid.UUID) error {
-	result := r.db.WithContext(ctx).Delete(&domain.User{}, "id = ?", id)
+	ctx, done := r.startQuery(ctx, "Delete")
+	defer done()
+
+	result := r.conn(ctx).Delete(&domain.User{}, "id = ?", id)
 	if result.Error != nil {
-		return result.Error
+		return mapQueryError(ctx, result.Error)
 	}
 
 	if result.RowsAffected == 0 {
@@ -86,33 +219,230 @@ func (r *UserRepositoryPG) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-// List retrieves a list of users with pagination
-func (r *UserRepositoryPG) List(ctx context.Context, offset, limit int) ([]*domain.User, error) {
+// DeleteBatch soft-deletes all users in ids with a single statement run in a transaction
+// It returns how many users were deleted and, in input order, the IDs with no user to delete
+func (r *UserRepositoryPG) DeleteBatch(ctx context.Context, ids []uuid.UUID) (int64, []uuid.UUID, error) {
+	unique := uniqueIDs(ids)
+	if len(unique) == 0 {
+		return 0, []uuid.UUID{}, nil
+	}
+
+	ctx, done := r.startQuery(ctx, "DeleteBatch")
+	defer done()
+
+	var deleted []domain.User
+	err := r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).
+			Where("id IN ?", unique).
+			Delete(&deleted).Error
+	})
+	if err != nil {
+		return 0, nil, mapQueryError(ctx, err)
+	}
+
+	deletedIDs := make(map[uuid.UUID]struct{}, len(deleted))
+	for _, user := range deleted {
+		deletedIDs[user.ID] = struct{}{}
+	}
+
+	notFound := make([]uuid.UUID, 0, len(unique)-len(deleted))
+	for _, id := range unique {
+		if _, ok := deletedIDs[id]; !ok {
+			notFound = append(notFound, id)
+		}
+	}
+
+	return int64(len(deleted)), notFound, nil
+}
+
+// uniqueIDs returns ids without duplicates, keeping the first occurrence's position
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]struct{}, len(ids))
+	unique := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+	return unique
+}
+
+// userSortColumns maps sortable fields to their columns, anything else is rejected rather than interpolated
+var userSortColumns = map[string]string{
+	domain.UserSortName:      "name",
+	domain.UserSortEmail:     "email",
+	domain.UserSortCreatedAt: "created_at",
+	domain.UserSortUpdatedAt: "updated_at",
+}
+
+// List retrieves a list of users with pagination in the given order
+// Ties are broken by id, which follows creation order when IDs are time-ordered
+func (r *UserRepositoryPG) List(ctx context.Context, offset, limit int, sort domain.UserSort) ([]*domain.User, error) {
+	column, ok := userSortColumns[sort.Field]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported sort field %q", domain.ErrInvalidInput, sort.Field)
+	}
+
+	ctx, done := r.startQuery(ctx, "List")
+	defer done()
+
 	var users []*domain.User
-	if err := r.db.WithContext(ctx).
-		Order("created_at DESC").
+	if err := r.conn(ctx).
+		Order(clause.OrderBy{Columns: []clause.OrderByColumn{
+			{Column: clause.Column{Name: column}, Desc: sort.Desc},
+			{Column: clause.Column{Name: "id"}, Desc: sort.Desc},
+		}}).
 		Limit(limit).
 		Offset(offset).
 		Find(&users).Error; err != nil {
-		return nil, err
+		return nil, mapQueryError(ctx, err)
+	}
+	return users, nil
+}
+
+// ListAfter pages through users in (created_at, id) order using keyset pagination,
+// so later pages cost the same as the first and rows aren't skipped when earlier ones are deleted
+func (r *UserRepositoryPG) ListAfter(ctx context.Context, cursor *domain.UserCursor, limit int) ([]*domain.User, error) {
+	ctx, done := r.startQuery(ctx, "ListAfter")
+	defer done()
+
+	query := r.conn(ctx)
+	if cursor != nil {
+		query = query.Where("(created_at, id) > (?, ?)", cursor.CreatedAt, cursor.ID)
+	}
+
+	var users []*domain.User
+	if err := query.
+		Order("created_at ASC, id ASC").
+		Limit(limit).
+		Find(&users).Error; err != nil {
+		return nil, mapQueryError(ctx, err)
+	}
+	return users, nil
+}
+
+// Search ranks users by full-text match of query against the generated search_vector column
+// (name weighted above email), plainto_tsquery treats the query as plain words so no syntax leaks through
+func (r *UserRepositoryPG) Search(ctx context.Context, query string, limit int) ([]*domain.User, error) {
+	ctx, done := r.startQuery(ctx, "Search")
+	defer done()
+
+	var users []*domain.User
+	if err := r.conn(ctx).
+		Where("search_vector @@ plainto_tsquery('simple', ?)", query).
+		Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:  "ts_rank(search_vector, plainto_tsquery('simple', ?)) DESC, created_at DESC",
+			Vars: []interface{}{query},
+		}}).
+		Limit(limit).
+		Find(&users).Error; err != nil {
+		return nil, mapQueryError(ctx, err)
 	}
 	return users, nil
 }
 
 // Count counts total users
 func (r *UserRepositoryPG) Count(ctx context.Context) (int64, error) {
+	ctx, done := r.startQuery(ctx, "Count")
+	defer done()
+
 	var count int64
-	if err := r.db.WithContext(ctx).Model(&domain.User{}).Count(&count).Error; err != nil {
-		return 0, err
+	if err := r.conn(ctx).Model(&domain.User{}).Count(&count).Error; err != nil {
+		return 0, mapQueryError(ctx, err)
 	}
 	return count, nil
 }
 
-// ExistsByEmail checks if a user exists by email
+// CountApprox estimates the number of users from pg_class.reltuples, falling back to Count
+// while the table has never been analyzed
+func (r *UserRepositoryPG) CountApprox(ctx context.Context) (int64, error) {
+	ctx, done := r.startQuery(ctx, "CountApprox")
+	defer done()
+
+	var estimate int64
+	if err := r.conn(ctx).
+		Raw("SELECT reltuples::bigint FROM pg_class WHERE oid = ?::regclass", usersTable).
+		Scan(&estimate).Error; err != nil {
+		return 0, mapQueryError(ctx, err)
+	}
+	if estimate < 0 {
+		return r.Count(ctx)
+	}
+	return estimate, nil
+}
+
+// ExistsByEmail checks if a user exists by email, ignoring case
 func (r *UserRepositoryPG) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	ctx, done := r.startQuery(ctx, "ExistsByEmail")
+	defer done()
+
 	var count int64
-	if err := r.db.WithContext(ctx).Model(&domain.User{}).Where("email = ?", email).Count(&count).Error; err != nil {
-		return false, err
+	if err := r.conn(ctx).Model(&domain.User{}).Where("LOWER(email) = LOWER(?)", email).Count(&count).Error; err != nil {
+		return false, mapQueryError(ctx, err)
 	}
 	return count > 0, nil
 }
+
+// IncrementTokenVersion atomically bumps the token version and returns the new value
+func (r *UserRepositoryPG) IncrementTokenVersion(ctx context.Context, id uuid.UUID) (int, error) {
+	ctx, done := r.startQuery(ctx, "IncrementTokenVersion")
+	defer done()
+
+	var user domain.User
+	result := r.conn(ctx).Model(&user).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "token_version"}}}).
+		Where("id = ?", id).
+		UpdateColumn("token_version", gorm.Expr("token_version + ?", 1))
+
+	if result.Error != nil {
+		return 0, mapQueryError(ctx, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return 0, domain.ErrUserNotFound
+	}
+
+	return user.TokenVersion, nil
+}
+
+// UpdateLastLogin sets last_login_at without touching other columns or updated_at
+func (r *UserRepositoryPG) UpdateLastLogin(ctx context.Context, id uuid.UUID, at time.Time) error {
+	ctx, done := r.startQuery(ctx, "UpdateLastLogin")
+	defer done()
+
+	result := r.conn(ctx).Model(&domain.User{}).
+		Where("id = ?", id).
+		UpdateColumn("last_login_at", at)
+
+	if result.Error != nil {
+		return mapQueryError(ctx, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// SetActive updates the is_active flag, bumping updated_at since it changes the user's state
+func (r *UserRepositoryPG) SetActive(ctx context.Context, id uuid.UUID, active bool) error {
+	ctx, done := r.startQuery(ctx, "SetActive")
+	defer done()
+
+	result := r.conn(ctx).Model(&domain.User{}).
+		Where("id = ?", id).
+		Update("is_active", active)
+
+	if result.Error != nil {
+		return mapQueryError(ctx, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
@@ -2,17 +2,22 @@ package pgsql
 
 import (
 	"context"
+	"errors"
 	"regexp"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gieart87/gohexaclean/internal/adapter/outbound/noop"
 	"github.com/gieart87/gohexaclean/internal/domain"
+	dberr "github.com/gieart87/gohexaclean/internal/infra/db"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
 )
 
 func setupTestDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
@@ -34,7 +39,7 @@ func setupTestDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
 
 func TestUserRepositoryPG_Create(t *testing.T) {
 	db, mock := setupTestDB(t)
-	repo := NewUserRepositoryPG(db)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
 
 	user := &domain.User{
 		ID:       uuid.New(),
@@ -44,7 +49,7 @@ func TestUserRepositoryPG_Create(t *testing.T) {
 	}
 
 	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "users"`)).
-		WithArgs(user.Email, user.Name, user.Password, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), user.ID).
+		WithArgs(user.Email, user.Name, user.Password, domain.RoleUser, 0, true, false, nil, nil, nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), user.ID).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(user.ID))
 
 	err := repo.Create(context.Background(), user)
@@ -52,9 +57,205 @@ func TestUserRepositoryPG_Create(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestUserRepositoryPG_Create_DuplicateEmail(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
+
+	user := &domain.User{
+		ID:       uuid.New(),
+		Email:    "test@example.com",
+		Name:     "Test User",
+		Password: "hashedpassword",
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "users"`)).
+		WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "idx_users_email"})
+
+	err := repo.Create(context.Background(), user)
+	assert.ErrorIs(t, err, domain.ErrUserAlreadyExists)
+	assert.ErrorIs(t, err, dberr.ErrDBDuplicateKey)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryPG_Update_ConstraintViolation(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
+
+	user := &domain.User{
+		ID:        uuid.New(),
+		UpdatedAt: time.Now(),
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET`)).
+		WillReturnError(&pgconn.PgError{Code: "23502", ColumnName: "name"})
+
+	err := repo.Update(context.Background(), user)
+	assert.ErrorIs(t, err, dberr.ErrDBConstraint)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryPG_UpdateEmail(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
+
+	pending := "jane@new.example.com"
+	token := "3f5a"
+	expiresAt := time.Now().Add(time.Hour)
+	user := &domain.User{
+		ID:                         uuid.New(),
+		Email:                      "jane@example.com",
+		PendingEmail:               &pending,
+		EmailVerificationToken:     &token,
+		EmailVerificationExpiresAt: &expiresAt,
+	}
+
+	// Only the email columns are written, the profile is left alone
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "email"=$1,"email_verification_expires_at"=$2,"email_verification_token"=$3,"email_verified"=$4,"pending_email"=$5,"updated_at"=$6 WHERE id = $7 AND "users"."deleted_at" IS NULL`)).
+		WithArgs(user.Email, expiresAt, token, false, pending, sqlmock.AnyArg(), user.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.UpdateEmail(context.Background(), user))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryPG_UpdateEmail_Taken(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET`)).
+		WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "idx_users_email"})
+
+	err := repo.UpdateEmail(context.Background(), &domain.User{ID: uuid.New(), Email: "taken@example.com"})
+	assert.ErrorIs(t, err, domain.ErrUserAlreadyExists)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryPG_UpdateEmail_NotFound(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.UpdateEmail(context.Background(), &domain.User{ID: uuid.New()})
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func TestUserRepositoryPG_IncrementTokenVersion(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
+
+	userID := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE "users" SET "token_version"=token_version + $1 WHERE id = $2 AND "users"."deleted_at" IS NULL RETURNING "token_version"`)).
+		WithArgs(1, userID).
+		WillReturnRows(sqlmock.NewRows([]string{"token_version"}).AddRow(3))
+
+	version, err := repo.IncrementTokenVersion(context.Background(), userID)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryPG_IncrementTokenVersion_NotFound(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
+
+	userID := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE "users" SET "token_version"=token_version + $1`)).
+		WithArgs(1, userID).
+		WillReturnRows(sqlmock.NewRows([]string{"token_version"}))
+
+	_, err := repo.IncrementTokenVersion(context.Background(), userID)
+	assert.Equal(t, domain.ErrUserNotFound, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryPG_UpdateLastLogin(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
+
+	userID := uuid.New()
+	at := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	// Only last_login_at is written, updated_at is left alone
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "last_login_at"=$1 WHERE id = $2 AND "users"."deleted_at" IS NULL`)).
+		WithArgs(at, userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.UpdateLastLogin(context.Background(), userID, at)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryPG_UpdateLastLogin_NotFound(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "last_login_at"=$1`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.UpdateLastLogin(context.Background(), uuid.New(), time.Now())
+	assert.Equal(t, domain.ErrUserNotFound, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryPG_SetActive(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
+
+	userID := uuid.New()
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "is_active"=$1,"updated_at"=$2 WHERE id = $3 AND "users"."deleted_at" IS NULL`)).
+		WithArgs(false, sqlmock.AnyArg(), userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.SetActive(context.Background(), userID, false)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryPG_SetActive_NotFound(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "is_active"=$1`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.SetActive(context.Background(), uuid.New(), true)
+	assert.Equal(t, domain.ErrUserNotFound, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMapPgError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"unique violation", &pgconn.PgError{Code: "23505"}, dberr.ErrDBDuplicateKey},
+		{"foreign key violation", &pgconn.PgError{Code: "23503"}, dberr.ErrDBConstraint},
+		{"check violation", &pgconn.PgError{Code: "23514"}, dberr.ErrDBConstraint},
+		{"other pg error", &pgconn.PgError{Code: "42P01"}, nil},
+		{"non-pg error", errors.New("boom"), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mapPgError(tt.err)
+			if tt.want == nil {
+				assert.Equal(t, tt.err, got)
+				return
+			}
+			assert.ErrorIs(t, got, tt.want)
+		})
+	}
+}
+
 func TestUserRepositoryPG_FindByID(t *testing.T) {
 	db, mock := setupTestDB(t)
-	repo := NewUserRepositoryPG(db)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
 
 	userID := uuid.New()
 	now := time.Now()
@@ -76,7 +277,7 @@ func TestUserRepositoryPG_FindByID(t *testing.T) {
 
 func TestUserRepositoryPG_FindByID_NotFound(t *testing.T) {
 	db, mock := setupTestDB(t)
-	repo := NewUserRepositoryPG(db)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
 
 	userID := uuid.New()
 
@@ -91,9 +292,132 @@ func TestUserRepositoryPG_FindByID_NotFound(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestUserRepositoryPG_FindByID_QueryTimeout(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewUserRepositoryPG(db, 20*time.Millisecond, noop.NewNoopTracingService())
+
+	userID := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE id = $1`)).
+		WithArgs(userID, 1).
+		WillDelayFor(time.Second).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(userID))
+
+	user, err := repo.FindByID(context.Background(), userID)
+	assert.ErrorIs(t, err, dberr.ErrDBTimeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Nil(t, user)
+}
+
+func TestUserRepositoryPG_Count_CallerDeadlineTakesPrecedence(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewUserRepositoryPG(db, time.Millisecond, noop.NewNoopTracingService())
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "users"`)).
+		WillDelayFor(20 * time.Millisecond).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	count, err := repo.Count(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryPG_FindByID_UnsupportedInclude(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
+
+	user, err := repo.FindByID(context.Background(), uuid.New(), "password_resets")
+	assert.Nil(t, user)
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestApplyPreloads(t *testing.T) {
+	db, _ := setupTestDB(t)
+
+	query, err := applyPreloads(db, []string{"roles"}, map[string]string{"roles": "Roles"})
+	require.NoError(t, err)
+	assert.Contains(t, query.Statement.Preloads, "Roles")
+}
+
+func TestUserRepositoryPG_FindByIDs(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
+
+	foundID := uuid.New()
+	missingID := uuid.New()
+	now := time.Now()
+
+	rows := sqlmock.NewRows([]string{"id", "email", "name", "password", "created_at", "updated_at", "deleted_at"}).
+		AddRow(foundID, "test@example.com", "Test User", "hashedpassword", now, now, nil)
+
+	// Duplicate IDs are collapsed into a single IN clause
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE id IN ($1,$2) AND "users"."deleted_at" IS NULL`)).
+		WithArgs(foundID, missingID).
+		WillReturnRows(rows)
+
+	users, err := repo.FindByIDs(context.Background(), []uuid.UUID{foundID, missingID, foundID})
+	assert.NoError(t, err)
+	assert.Len(t, users, 1)
+	assert.Equal(t, "test@example.com", users[foundID].Email)
+	assert.NotContains(t, users, missingID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryPG_FindByIDs_Empty(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
+
+	users, err := repo.FindByIDs(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Empty(t, users)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryPG_FindByID_ReadsFromPrimaryAfterRevoke(t *testing.T) {
+	db, primary := setupTestDB(t)
+	replicaDB, replica := setupTestDB(t)
+	require.NoError(t, db.Use(dbresolver.Register(dbresolver.Config{Replicas: []gorm.Dialector{replicaDB.Dialector}})))
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
+
+	userID := uuid.New()
+	now := time.Now()
+
+	// The replica still has the version from before the revocation, so it must not be asked
+	primary.ExpectQuery(regexp.QuoteMeta(`UPDATE "users" SET "token_version"=token_version + $1`)).
+		WithArgs(1, userID).
+		WillReturnRows(sqlmock.NewRows([]string{"token_version"}).AddRow(4))
+	primary.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE id = $1`)).
+		WithArgs(userID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "token_version", "is_active", "created_at", "updated_at", "deleted_at"}).
+			AddRow(userID, "test@example.com", 4, true, now, now, nil))
+	primary.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE LOWER(email) = LOWER($1)`)).
+		WithArgs("test@example.com", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "token_version", "is_active", "created_at", "updated_at", "deleted_at"}).
+			AddRow(userID, "test@example.com", 4, true, now, now, nil))
+
+	version, err := repo.IncrementTokenVersion(context.Background(), userID)
+	require.NoError(t, err)
+
+	user, err := repo.FindByID(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, version, user.TokenVersion)
+
+	user, err = repo.FindByEmail(context.Background(), "test@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, version, user.TokenVersion)
+
+	assert.NoError(t, primary.ExpectationsWereMet())
+	assert.NoError(t, replica.ExpectationsWereMet())
+}
+
 func TestUserRepositoryPG_FindByEmail(t *testing.T) {
 	db, mock := setupTestDB(t)
-	repo := NewUserRepositoryPG(db)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
 
 	userID := uuid.New()
 	email := "test@example.com"
@@ -102,7 +426,7 @@ func TestUserRepositoryPG_FindByEmail(t *testing.T) {
 	rows := sqlmock.NewRows([]string{"id", "email", "name", "password", "created_at", "updated_at", "deleted_at"}).
 		AddRow(userID, email, "Test User", "hashedpassword", now, now, nil)
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE email = $1 AND "users"."deleted_at" IS NULL ORDER BY "users"."id" LIMIT`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE LOWER(email) = LOWER($1) AND "users"."deleted_at" IS NULL ORDER BY "users"."id" LIMIT`)).
 		WithArgs(email, 1).
 		WillReturnRows(rows)
 
@@ -115,11 +439,11 @@ func TestUserRepositoryPG_FindByEmail(t *testing.T) {
 
 func TestUserRepositoryPG_FindByEmail_NotFound(t *testing.T) {
 	db, mock := setupTestDB(t)
-	repo := NewUserRepositoryPG(db)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
 
 	email := "notfound@example.com"
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE email = $1 AND "users"."deleted_at" IS NULL ORDER BY "users"."id" LIMIT`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE LOWER(email) = LOWER($1) AND "users"."deleted_at" IS NULL ORDER BY "users"."id" LIMIT`)).
 		WithArgs(email, 1).
 		WillReturnError(gorm.ErrRecordNotFound)
 
@@ -132,7 +456,7 @@ func TestUserRepositoryPG_FindByEmail_NotFound(t *testing.T) {
 
 func TestUserRepositoryPG_Update(t *testing.T) {
 	db, mock := setupTestDB(t)
-	repo := NewUserRepositoryPG(db)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
 
 	user := &domain.User{
 		ID:        uuid.New(),
@@ -151,7 +475,7 @@ func TestUserRepositoryPG_Update(t *testing.T) {
 
 func TestUserRepositoryPG_Update_NotFound(t *testing.T) {
 	db, mock := setupTestDB(t)
-	repo := NewUserRepositoryPG(db)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
 
 	user := &domain.User{
 		ID:        uuid.New(),
@@ -171,7 +495,7 @@ func TestUserRepositoryPG_Update_NotFound(t *testing.T) {
 
 func TestUserRepositoryPG_Delete(t *testing.T) {
 	db, mock := setupTestDB(t)
-	repo := NewUserRepositoryPG(db)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
 
 	userID := uuid.New()
 
@@ -186,7 +510,7 @@ func TestUserRepositoryPG_Delete(t *testing.T) {
 
 func TestUserRepositoryPG_Delete_NotFound(t *testing.T) {
 	db, mock := setupTestDB(t)
-	repo := NewUserRepositoryPG(db)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
 
 	userID := uuid.New()
 
@@ -200,9 +524,78 @@ func TestUserRepositoryPG_Delete_NotFound(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestUserRepositoryPG_DeleteBatch(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
+
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE "users" SET "deleted_at"=$1 WHERE id IN ($2,$3) AND "users"."deleted_at" IS NULL RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), ids[0], ids[1]).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(ids[0]).AddRow(ids[1]))
+	mock.ExpectCommit()
+
+	deleted, notFound, err := repo.DeleteBatch(context.Background(), ids)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+	assert.Empty(t, notFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryPG_DeleteBatch_PartialNotFound(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
+
+	existing := uuid.New()
+	missing := uuid.New()
+	alreadyDeleted := uuid.New()
+
+	// Duplicates are collapsed, already deleted users are excluded by the soft delete scope
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE "users" SET "deleted_at"=$1 WHERE id IN ($2,$3,$4) AND "users"."deleted_at" IS NULL RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), missing, existing, alreadyDeleted).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(existing))
+	mock.ExpectCommit()
+
+	deleted, notFound, err := repo.DeleteBatch(context.Background(), []uuid.UUID{missing, existing, alreadyDeleted, existing})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	assert.Equal(t, []uuid.UUID{missing, alreadyDeleted}, notFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryPG_DeleteBatch_RollsBackOnError(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
+
+	dbErr := errors.New("connection reset")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE "users" SET "deleted_at"`)).
+		WillReturnError(dbErr)
+	mock.ExpectRollback()
+
+	_, _, err := repo.DeleteBatch(context.Background(), []uuid.UUID{uuid.New()})
+	assert.ErrorIs(t, err, dbErr)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryPG_DeleteBatch_Empty(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
+
+	// No IDs means no statement at all
+	deleted, notFound, err := repo.DeleteBatch(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Zero(t, deleted)
+	assert.Empty(t, notFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestUserRepositoryPG_List(t *testing.T) {
 	db, mock := setupTestDB(t)
-	repo := NewUserRepositoryPG(db)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
 
 	now := time.Now()
 	rows := sqlmock.NewRows([]string{"id", "email", "name", "password", "created_at", "updated_at", "deleted_at"}).
@@ -210,19 +603,94 @@ func TestUserRepositoryPG_List(t *testing.T) {
 		AddRow(uuid.New(), "user2@example.com", "User 2", "pass2", now, now, nil)
 
 	// GORM doesn't add OFFSET when it's 0
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE "users"."deleted_at" IS NULL ORDER BY created_at DESC LIMIT $1`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE "users"."deleted_at" IS NULL ORDER BY "created_at" DESC,"id" DESC LIMIT $1`)).
 		WithArgs(10).
 		WillReturnRows(rows)
 
-	users, err := repo.List(context.Background(), 0, 10)
+	users, err := repo.List(context.Background(), 0, 10, domain.DefaultUserSort)
 	assert.NoError(t, err)
 	assert.Len(t, users, 2)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestUserRepositoryPG_List_SortOrder(t *testing.T) {
+	tests := []struct {
+		sort    domain.UserSort
+		orderBy string
+	}{
+		{domain.UserSort{Field: domain.UserSortName}, `ORDER BY "name","id"`},
+		{domain.UserSort{Field: domain.UserSortName, Desc: true}, `ORDER BY "name" DESC,"id" DESC`},
+		{domain.UserSort{Field: domain.UserSortEmail}, `ORDER BY "email","id"`},
+		{domain.UserSort{Field: domain.UserSortCreatedAt}, `ORDER BY "created_at","id"`},
+		{domain.UserSort{Field: domain.UserSortUpdatedAt, Desc: true}, `ORDER BY "updated_at" DESC,"id" DESC`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.orderBy, func(t *testing.T) {
+			db, mock := setupTestDB(t)
+			repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
+
+			rows := sqlmock.NewRows([]string{"id", "email", "name", "password", "created_at", "updated_at", "deleted_at"})
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE "users"."deleted_at" IS NULL `+tt.orderBy+` LIMIT $1 OFFSET $2`)).
+				WithArgs(10, 20).
+				WillReturnRows(rows)
+
+			_, err := repo.List(context.Background(), 20, 10, tt.sort)
+			assert.NoError(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserRepositoryPG_List_RejectsUnknownSortField(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
+
+	users, err := repo.List(context.Background(), 0, 10, domain.UserSort{Field: "password; DROP TABLE users"})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	assert.Nil(t, users)
+	assert.NoError(t, mock.ExpectationsWereMet(), "no query should reach the database")
+}
+
+func TestUserRepositoryPG_ListAfter_FirstPage(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "email", "name", "password", "created_at", "updated_at", "deleted_at"}).
+		AddRow(uuid.New(), "user1@example.com", "User 1", "pass1", now, now, nil)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE "users"."deleted_at" IS NULL ORDER BY created_at ASC, id ASC LIMIT $1`)).
+		WithArgs(100).
+		WillReturnRows(rows)
+
+	users, err := repo.ListAfter(context.Background(), nil, 100)
+	assert.NoError(t, err)
+	assert.Len(t, users, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryPG_ListAfter_FromCursor(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
+
+	cursor := &domain.UserCursor{CreatedAt: time.Now(), ID: uuid.New()}
+	rows := sqlmock.NewRows([]string{"id", "email", "name", "password", "created_at", "updated_at", "deleted_at"})
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE (created_at, id) > ($1, $2) AND "users"."deleted_at" IS NULL ORDER BY created_at ASC, id ASC LIMIT $3`)).
+		WithArgs(cursor.CreatedAt, cursor.ID, 100).
+		WillReturnRows(rows)
+
+	users, err := repo.ListAfter(context.Background(), cursor, 100)
+	assert.NoError(t, err)
+	assert.Empty(t, users)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestUserRepositoryPG_Count(t *testing.T) {
 	db, mock := setupTestDB(t)
-	repo := NewUserRepositoryPG(db)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
 
 	rows := sqlmock.NewRows([]string{"count"}).AddRow(5)
 
@@ -235,14 +703,44 @@ func TestUserRepositoryPG_Count(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestUserRepositoryPG_CountApprox(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT reltuples::bigint FROM pg_class WHERE oid = $1::regclass`)).
+		WithArgs("users").
+		WillReturnRows(sqlmock.NewRows([]string{"reltuples"}).AddRow(1250000))
+
+	count, err := repo.CountApprox(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1250000), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryPG_CountApprox_NeverAnalyzedFallsBackToCount(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
+
+	// reltuples is -1 until the table is first vacuumed or analyzed
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM pg_class`)).
+		WillReturnRows(sqlmock.NewRows([]string{"reltuples"}).AddRow(-1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "users"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+
+	count, err := repo.CountApprox(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestUserRepositoryPG_ExistsByEmail(t *testing.T) {
 	db, mock := setupTestDB(t)
-	repo := NewUserRepositoryPG(db)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
 
 	email := "test@example.com"
 	rows := sqlmock.NewRows([]string{"count"}).AddRow(1)
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "users" WHERE email = $1 AND "users"."deleted_at" IS NULL`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "users" WHERE LOWER(email) = LOWER($1) AND "users"."deleted_at" IS NULL`)).
 		WithArgs(email).
 		WillReturnRows(rows)
 
@@ -254,12 +752,12 @@ func TestUserRepositoryPG_ExistsByEmail(t *testing.T) {
 
 func TestUserRepositoryPG_ExistsByEmail_NotFound(t *testing.T) {
 	db, mock := setupTestDB(t)
-	repo := NewUserRepositoryPG(db)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
 
 	email := "notfound@example.com"
 	rows := sqlmock.NewRows([]string{"count"}).AddRow(0)
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "users" WHERE email = $1 AND "users"."deleted_at" IS NULL`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "users" WHERE LOWER(email) = LOWER($1) AND "users"."deleted_at" IS NULL`)).
 		WithArgs(email).
 		WillReturnRows(rows)
 
@@ -268,3 +766,21 @@ func TestUserRepositoryPG_ExistsByEmail_NotFound(t *testing.T) {
 	assert.False(t, exists)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestUserRepositoryPG_Search(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewUserRepositoryPG(db, 0, noop.NewNoopTracingService())
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "email", "name", "password", "created_at", "updated_at", "deleted_at"}).
+		AddRow(uuid.New(), "john@example.com", "John Doe", "pass1", now, now, nil)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE search_vector @@ plainto_tsquery('simple', $1) AND "users"."deleted_at" IS NULL ORDER BY ts_rank(search_vector, plainto_tsquery('simple', $2)) DESC, created_at DESC LIMIT $3`)).
+		WithArgs("john", "john", 10).
+		WillReturnRows(rows)
+
+	users, err := repo.Search(context.Background(), "john", 10)
+	assert.NoError(t, err)
+	assert.Len(t, users, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,128 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gieart87/gohexaclean/internal/port/outbound/broker"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/telemetry"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// deliveryGetter fetches a single message from a queue (implemented by *amqp.Channel)
+type deliveryGetter interface {
+	Get(queue string, autoAck bool) (amqp.Delivery, bool, error)
+}
+
+// DeadLetterPurger drops dead-lettered messages older than the retention window,
+// optionally archiving them first
+type DeadLetterPurger struct {
+	source    deliveryGetter
+	retention time.Duration
+	archiver  broker.DeadLetterArchiver
+	metrics   telemetry.MetricsService
+	now       func() time.Time
+}
+
+// NewDeadLetterPurger creates a new dead-letter purger
+// archiver and metrics are optional and may be nil
+func NewDeadLetterPurger(
+	source deliveryGetter,
+	retention time.Duration,
+	archiver broker.DeadLetterArchiver,
+	metrics telemetry.MetricsService,
+) *DeadLetterPurger {
+	return &DeadLetterPurger{
+		source:    source,
+		retention: retention,
+		archiver:  archiver,
+		metrics:   metrics,
+		now:       time.Now,
+	}
+}
+
+// Purge removes expired messages from the given dead-letter queue and returns how many were purged.
+// Queues are FIFO, so purging stops at the first message still inside the retention window.
+func (p *DeadLetterPurger) Purge(ctx context.Context, queue string) (int, error) {
+	purged := 0
+	defer func() {
+		if p.metrics != nil && purged > 0 {
+			p.metrics.IncrementCounter("broker.dlq.purged", map[string]string{"queue": queue}, float64(purged))
+		}
+	}()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return purged, err
+		}
+
+		msg, ok, err := p.source.Get(queue, false)
+		if err != nil {
+			return purged, fmt.Errorf("failed to get dead-lettered message: %w", err)
+		}
+		if !ok {
+			return purged, nil // queue is empty
+		}
+
+		if !p.isExpired(msg) {
+			// Put it back and stop, everything behind it is newer
+			_ = msg.Nack(false, true)
+			return purged, nil
+		}
+
+		if p.archiver != nil {
+			if err := p.archiver.Archive(ctx, queue, toBrokerMessage(msg)); err != nil {
+				// Keep the message so it is not lost, retry on the next run
+				_ = msg.Nack(false, true)
+				return purged, fmt.Errorf("failed to archive dead-lettered message: %w", err)
+			}
+			if p.metrics != nil {
+				p.metrics.IncrementCounter("broker.dlq.archived", map[string]string{"queue": queue}, 1)
+			}
+		}
+
+		if err := msg.Ack(false); err != nil {
+			return purged, fmt.Errorf("failed to acknowledge dead-lettered message: %w", err)
+		}
+		purged++
+	}
+}
+
+// isExpired reports whether the message has been dead-lettered for longer than the retention
+func (p *DeadLetterPurger) isExpired(msg amqp.Delivery) bool {
+	deadLetteredAt := deadLetteredAt(msg)
+	if deadLetteredAt.IsZero() {
+		return true // age unknown, treat as expired so it can't block the queue forever
+	}
+	return p.now().Sub(deadLetteredAt) > p.retention
+}
+
+// deadLetteredAt returns when the message was dead-lettered, using the x-death header
+// set by RabbitMQ and falling back to the publish timestamp
+func deadLetteredAt(msg amqp.Delivery) time.Time {
+	if deaths, ok := msg.Headers["x-death"].([]interface{}); ok && len(deaths) > 0 {
+		if death, ok := deaths[0].(amqp.Table); ok {
+			if at, ok := death["time"].(time.Time); ok {
+				return at
+			}
+		}
+	}
+	return msg.Timestamp
+}
+
+// toBrokerMessage converts an AMQP delivery to the broker-agnostic message type
+func toBrokerMessage(msg amqp.Delivery) broker.Message {
+	headers := make(map[string]string, len(msg.Headers))
+	for key, value := range msg.Headers {
+		headers[key] = fmt.Sprint(value)
+	}
+
+	return broker.Message{
+		ID:        msg.MessageId,
+		Topic:     msg.RoutingKey,
+		Body:      msg.Body,
+		Timestamp: msg.Timestamp.Unix(),
+		Headers:   headers,
+	}
+}
@@ -0,0 +1,75 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gieart87/gohexaclean/internal/port/outbound/broker"
+)
+
+// FileDeadLetterArchiver appends dead-lettered messages to a JSON Lines file
+type FileDeadLetterArchiver struct {
+	path string
+	mu   sync.Mutex
+}
+
+// archivedMessage is a single line in the archive file
+type archivedMessage struct {
+	Queue      string            `json:"queue"`
+	ID         string            `json:"id"`
+	Topic      string            `json:"topic"`
+	Body       json.RawMessage   `json:"body"`
+	Timestamp  int64             `json:"timestamp"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	ArchivedAt time.Time         `json:"archived_at"`
+}
+
+// NewFileDeadLetterArchiver creates a new file-based dead-letter archiver
+func NewFileDeadLetterArchiver(path string) broker.DeadLetterArchiver {
+	return &FileDeadLetterArchiver{path: path}
+}
+
+// Archive appends the message to the archive file
+func (a *FileDeadLetterArchiver) Archive(ctx context.Context, queue string, message broker.Message) error {
+	body := json.RawMessage(message.Body)
+	if !json.Valid(body) {
+		// Keep non-JSON payloads as a JSON string
+		encoded, err := json.Marshal(string(message.Body))
+		if err != nil {
+			return fmt.Errorf("failed to encode message body: %w", err)
+		}
+		body = encoded
+	}
+
+	line, err := json.Marshal(archivedMessage{
+		Queue:      queue,
+		ID:         message.ID,
+		Topic:      message.Topic,
+		Body:       body,
+		Timestamp:  message.Timestamp,
+		Headers:    message.Headers,
+		ArchivedAt: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal archived message: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	file, err := os.OpenFile(a.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open archive file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write archive file: %w", err)
+	}
+
+	return nil
+}
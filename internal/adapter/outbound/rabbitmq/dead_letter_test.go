@@ -0,0 +1,229 @@
+package rabbitmq
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gieart87/gohexaclean/internal/port/outbound/broker"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/telemetry"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAcknowledger records acks and nacks per delivery tag
+type fakeAcknowledger struct {
+	acked   []uint64
+	nacked  []uint64
+	requeue []bool
+}
+
+func (a *fakeAcknowledger) Ack(tag uint64, multiple bool) error {
+	a.acked = append(a.acked, tag)
+	return nil
+}
+
+func (a *fakeAcknowledger) Nack(tag uint64, multiple, requeue bool) error {
+	a.nacked = append(a.nacked, tag)
+	a.requeue = append(a.requeue, requeue)
+	return nil
+}
+
+func (a *fakeAcknowledger) Reject(tag uint64, requeue bool) error {
+	return a.Nack(tag, false, requeue)
+}
+
+// fakeQueue serves deliveries in order, like basic.get
+type fakeQueue struct {
+	deliveries []amqp.Delivery
+	err        error
+}
+
+func (q *fakeQueue) Get(queue string, autoAck bool) (amqp.Delivery, bool, error) {
+	if q.err != nil {
+		return amqp.Delivery{}, false, q.err
+	}
+	if len(q.deliveries) == 0 {
+		return amqp.Delivery{}, false, nil
+	}
+	msg := q.deliveries[0]
+	q.deliveries = q.deliveries[1:]
+	return msg, true, nil
+}
+
+// fakeArchiver records archived messages
+type fakeArchiver struct {
+	archived []broker.Message
+	err      error
+}
+
+func (a *fakeArchiver) Archive(ctx context.Context, queue string, message broker.Message) error {
+	if a.err != nil {
+		return a.err
+	}
+	a.archived = append(a.archived, message)
+	return nil
+}
+
+// fakeMetrics records counter increments
+type fakeMetrics struct {
+	counters map[string]float64
+}
+
+func newFakeMetrics() *fakeMetrics {
+	return &fakeMetrics{counters: make(map[string]float64)}
+}
+
+func (m *fakeMetrics) IncrementCounter(name string, tags map[string]string, value float64) {
+	m.counters[name] += value
+}
+func (m *fakeMetrics) SetGauge(name string, tags map[string]string, value float64)           {}
+func (m *fakeMetrics) RecordHistogram(name string, tags map[string]string, value float64)    {}
+func (m *fakeMetrics) RecordDistribution(name string, tags map[string]string, value float64) {}
+func (m *fakeMetrics) RecordTiming(name string, tags map[string]string, d time.Duration)     {}
+func (m *fakeMetrics) Close() error                                                          { return nil }
+
+var purgeNow = time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+
+func deadLetteredDelivery(ack amqp.Acknowledger, tag uint64, at time.Time) amqp.Delivery {
+	return amqp.Delivery{
+		Acknowledger: ack,
+		DeliveryTag:  tag,
+		MessageId:    fmt.Sprintf("msg-%d", tag),
+		RoutingKey:   "user.created",
+		Body:         []byte(`{"id":"1"}`),
+		Headers: amqp.Table{
+			"x-death": []interface{}{
+				amqp.Table{"time": at, "queue": "gohexaclean_user.created"},
+			},
+		},
+	}
+}
+
+func newTestPurger(source deliveryGetter, archiver broker.DeadLetterArchiver, metrics telemetry.MetricsService) *DeadLetterPurger {
+	purger := NewDeadLetterPurger(source, 24*time.Hour, archiver, metrics)
+	purger.now = func() time.Time { return purgeNow }
+	return purger
+}
+
+func TestDeadLetterPurger_Purge_RespectsRetention(t *testing.T) {
+	ack := &fakeAcknowledger{}
+	queue := &fakeQueue{deliveries: []amqp.Delivery{
+		deadLetteredDelivery(ack, 1, purgeNow.Add(-72*time.Hour)),
+		deadLetteredDelivery(ack, 2, purgeNow.Add(-25*time.Hour)),
+		deadLetteredDelivery(ack, 3, purgeNow.Add(-1*time.Hour)),
+		deadLetteredDelivery(ack, 4, purgeNow.Add(-48*time.Hour)),
+	}}
+	metrics := newFakeMetrics()
+
+	purged, err := newTestPurger(queue, nil, metrics).Purge(context.Background(), "gohexaclean_user.created.dlq")
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, purged)
+	assert.Equal(t, []uint64{1, 2}, ack.acked)
+	assert.Equal(t, []uint64{3}, ack.nacked)
+	assert.Equal(t, []bool{true}, ack.requeue)
+	assert.Len(t, queue.deliveries, 1, "purge should stop at the first message inside retention")
+	assert.Equal(t, float64(2), metrics.counters["broker.dlq.purged"])
+	assert.Zero(t, metrics.counters["broker.dlq.archived"])
+}
+
+func TestDeadLetterPurger_Purge_FallsBackToTimestamp(t *testing.T) {
+	ack := &fakeAcknowledger{}
+	queue := &fakeQueue{deliveries: []amqp.Delivery{
+		{Acknowledger: ack, DeliveryTag: 1, Timestamp: purgeNow.Add(-48 * time.Hour)},
+		{Acknowledger: ack, DeliveryTag: 2, Timestamp: purgeNow.Add(-time.Hour)},
+	}}
+
+	purged, err := newTestPurger(queue, nil, nil).Purge(context.Background(), "dlq")
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	assert.Equal(t, []uint64{1}, ack.acked)
+	assert.Equal(t, []uint64{2}, ack.nacked)
+}
+
+func TestDeadLetterPurger_Purge_ArchivesBeforePurge(t *testing.T) {
+	ack := &fakeAcknowledger{}
+	queue := &fakeQueue{deliveries: []amqp.Delivery{
+		deadLetteredDelivery(ack, 1, purgeNow.Add(-72*time.Hour)),
+		deadLetteredDelivery(ack, 2, purgeNow.Add(-48*time.Hour)),
+	}}
+	archiver := &fakeArchiver{}
+	metrics := newFakeMetrics()
+
+	purged, err := newTestPurger(queue, archiver, metrics).Purge(context.Background(), "dlq")
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, purged)
+	require.Len(t, archiver.archived, 2)
+	assert.Equal(t, "user.created", archiver.archived[0].Topic)
+	assert.JSONEq(t, `{"id":"1"}`, string(archiver.archived[0].Body))
+	assert.Equal(t, float64(2), metrics.counters["broker.dlq.archived"])
+	assert.Equal(t, float64(2), metrics.counters["broker.dlq.purged"])
+}
+
+func TestDeadLetterPurger_Purge_ArchiveErrorKeepsMessage(t *testing.T) {
+	ack := &fakeAcknowledger{}
+	queue := &fakeQueue{deliveries: []amqp.Delivery{
+		deadLetteredDelivery(ack, 1, purgeNow.Add(-72*time.Hour)),
+	}}
+	archiver := &fakeArchiver{err: errors.New("disk full")}
+
+	purged, err := newTestPurger(queue, archiver, nil).Purge(context.Background(), "dlq")
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, purged)
+	assert.Empty(t, ack.acked)
+	assert.Equal(t, []uint64{1}, ack.nacked)
+	assert.Equal(t, []bool{true}, ack.requeue)
+}
+
+func TestDeadLetterPurger_Purge_GetError(t *testing.T) {
+	queue := &fakeQueue{err: errors.New("channel closed")}
+
+	purged, err := newTestPurger(queue, nil, nil).Purge(context.Background(), "dlq")
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, purged)
+}
+
+func TestFileDeadLetterArchiver_Archive(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "archive.jsonl")
+	archiver := NewFileDeadLetterArchiver(path)
+
+	require.NoError(t, archiver.Archive(context.Background(), "dlq", broker.Message{
+		ID:    "1",
+		Topic: "user.created",
+		Body:  []byte(`{"id":"1"}`),
+	}))
+	require.NoError(t, archiver.Archive(context.Background(), "dlq", broker.Message{
+		ID:    "2",
+		Topic: "user.deleted",
+		Body:  []byte("not json"),
+	}))
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var lines []archivedMessage
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var line archivedMessage
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+
+	require.Len(t, lines, 2)
+	assert.Equal(t, "dlq", lines[0].Queue)
+	assert.JSONEq(t, `{"id":"1"}`, string(lines[0].Body))
+	assert.JSONEq(t, `"not json"`, string(lines[1].Body))
+}
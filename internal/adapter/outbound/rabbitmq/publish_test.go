@@ -0,0 +1,193 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/gieart87/gohexaclean/internal/infra/config"
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePublisher records publishes, optionally blocking until released
+type fakePublisher struct {
+	mu       sync.Mutex
+	messages []amqp.Publishing
+	started  chan struct{}
+	release  chan struct{}
+}
+
+func (p *fakePublisher) PublishWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+	if p.started != nil {
+		p.started <- struct{}{}
+	}
+	if p.release != nil {
+		select {
+		case <-p.release:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.messages = append(p.messages, msg)
+	return nil
+}
+
+func newConnectedBroker(publisher channelPublisher) *RabbitMQBroker {
+	b := NewRabbitMQBroker(&config.RabbitMQConfig{Exchange: "test"}, nil)
+	b.publisher = publisher
+	b.connected = true
+	return b
+}
+
+func TestRabbitMQBroker_Publish(t *testing.T) {
+	publisher := &fakePublisher{}
+	b := newConnectedBroker(publisher)
+	event := domain.NewUserCreatedEvent(uuid.New(), "test@example.com", "Test")
+
+	err := b.Publish(context.Background(), "user.created", event)
+
+	require.NoError(t, err)
+	require.Len(t, publisher.messages, 1)
+	assert.Equal(t, event.EventID(), publisher.messages[0].MessageId)
+}
+
+func TestRabbitMQBroker_Publish_CancelledContext(t *testing.T) {
+	publisher := &fakePublisher{}
+	b := newConnectedBroker(publisher)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := b.Publish(ctx, "user.created", domain.NewUserDeletedEvent(uuid.New()))
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, publisher.messages)
+}
+
+func TestRabbitMQBroker_Publish_NotConnected(t *testing.T) {
+	b := NewRabbitMQBroker(&config.RabbitMQConfig{}, nil)
+
+	err := b.Publish(context.Background(), "user.created", domain.NewUserDeletedEvent(uuid.New()))
+
+	assert.Error(t, err)
+}
+
+func TestRabbitMQBroker_Publish_DoesNotHoldLock(t *testing.T) {
+	publisher := &fakePublisher{started: make(chan struct{}, 2), release: make(chan struct{})}
+	b := newConnectedBroker(publisher)
+	event := domain.NewUserDeletedEvent(uuid.New())
+
+	errs := make(chan error, 2)
+	go func() { errs <- b.Publish(context.Background(), "user.deleted", event) }()
+	<-publisher.started
+
+	// A reconnect taking the write lock must not wait for the in-flight publish
+	locked := make(chan struct{})
+	go func() {
+		b.mu.Lock()
+		b.mu.Unlock()
+		close(locked)
+	}()
+	select {
+	case <-locked:
+	case <-time.After(time.Second):
+		t.Fatal("write lock blocked behind an in-flight publish")
+	}
+
+	// A second publisher runs while the first is still in flight
+	go func() { errs <- b.Publish(context.Background(), "user.deleted", event) }()
+	select {
+	case <-publisher.started:
+	case <-time.After(time.Second):
+		t.Fatal("second publish blocked behind the first")
+	}
+
+	close(publisher.release)
+	require.NoError(t, <-errs)
+	require.NoError(t, <-errs)
+	assert.Len(t, publisher.messages, 2)
+}
+
+// fakeTxChannel records a transactional batch, failing the publish at failAt (1-based) when set
+type fakeTxChannel struct {
+	fakePublisher
+	failAt     int
+	tx         bool
+	committed  bool
+	rolledBack bool
+	closed     bool
+}
+
+func (c *fakeTxChannel) PublishWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+	if c.failAt > 0 && len(c.messages)+1 == c.failAt {
+		return errors.New("channel closed")
+	}
+	return c.fakePublisher.PublishWithContext(ctx, exchange, key, mandatory, immediate, msg)
+}
+
+func (c *fakeTxChannel) Tx() error         { c.tx = true; return nil }
+func (c *fakeTxChannel) TxCommit() error   { c.committed = true; return nil }
+func (c *fakeTxChannel) TxRollback() error { c.rolledBack = true; return nil }
+func (c *fakeTxChannel) Close() error      { c.closed = true; return nil }
+
+func newBatchBroker(ch *fakeTxChannel) *RabbitMQBroker {
+	b := newConnectedBroker(&fakePublisher{})
+	b.openTxChannel = func() (txChannel, error) { return ch, nil }
+	return b
+}
+
+func TestRabbitMQBroker_PublishBatch(t *testing.T) {
+	ch := &fakeTxChannel{}
+	b := newBatchBroker(ch)
+	events := []domain.Event{
+		domain.NewUserCreatedEvent(uuid.New(), "a@example.com", "A"),
+		domain.NewUserCreatedEvent(uuid.New(), "b@example.com", "B"),
+		domain.NewUserCreatedEvent(uuid.New(), "c@example.com", "C"),
+	}
+
+	err := b.PublishBatch(context.Background(), "user.created", events)
+
+	require.NoError(t, err)
+	require.Len(t, ch.messages, 3)
+	for i, event := range events {
+		assert.Equal(t, event.EventID(), ch.messages[i].MessageId)
+	}
+	assert.True(t, ch.tx)
+	assert.True(t, ch.committed)
+	assert.False(t, ch.rolledBack)
+	assert.True(t, ch.closed)
+}
+
+func TestRabbitMQBroker_PublishBatch_MidBatchFailureRollsBack(t *testing.T) {
+	ch := &fakeTxChannel{failAt: 2}
+	b := newBatchBroker(ch)
+	events := []domain.Event{
+		domain.NewUserCreatedEvent(uuid.New(), "a@example.com", "A"),
+		domain.NewUserCreatedEvent(uuid.New(), "b@example.com", "B"),
+		domain.NewUserCreatedEvent(uuid.New(), "c@example.com", "C"),
+	}
+
+	err := b.PublishBatch(context.Background(), "user.created", events)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "batch message 2 of 3")
+	assert.True(t, ch.rolledBack)
+	assert.False(t, ch.committed)
+	assert.True(t, ch.closed)
+}
+
+func TestRabbitMQBroker_PublishBatch_NotConnected(t *testing.T) {
+	b := NewRabbitMQBroker(&config.RabbitMQConfig{}, nil)
+
+	err := b.PublishBatch(context.Background(), "user.created", []domain.Event{domain.NewUserDeletedEvent(uuid.New())})
+
+	assert.Error(t, err)
+}
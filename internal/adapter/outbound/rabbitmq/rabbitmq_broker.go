@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"sync"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/gieart87/gohexaclean/internal/domain"
 	"github.com/gieart87/gohexaclean/internal/infra/config"
 	"github.com/gieart87/gohexaclean/internal/port/outbound/broker"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/telemetry"
 )
 
 // RabbitMQBroker implements the MessageBroker interface for RabbitMQ
@@ -18,25 +20,46 @@ type RabbitMQBroker struct {
 	config     *config.RabbitMQConfig
 	conn       *amqp.Connection
 	channel    *amqp.Channel
+	publisher  channelPublisher
+	openTxChannel func() (txChannel, error)
 	mu         sync.RWMutex
 	connected  bool
 	reconnecting bool
 	subscriptions map[string]*subscription
 	done       chan struct{}
+	metrics    telemetry.MetricsService
+	purging    bool
+}
+
+// channelPublisher is the part of *amqp.Channel used to publish
+type channelPublisher interface {
+	PublishWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error
+}
+
+// txChannel is the part of *amqp.Channel used to publish a batch inside a transaction
+type txChannel interface {
+	channelPublisher
+	Tx() error
+	TxCommit() error
+	TxRollback() error
+	Close() error
 }
 
 type subscription struct {
-	queue   string
-	handler broker.MessageHandler
-	cancel  context.CancelFunc
+	queue           string
+	deadLetterQueue string
+	handler         broker.MessageHandler
+	cancel          context.CancelFunc
 }
 
 // NewRabbitMQBroker creates a new RabbitMQ message broker
-func NewRabbitMQBroker(cfg *config.RabbitMQConfig) *RabbitMQBroker {
+// metrics is optional and used for dead-letter purge counts
+func NewRabbitMQBroker(cfg *config.RabbitMQConfig, metrics telemetry.MetricsService) *RabbitMQBroker {
 	return &RabbitMQBroker{
 		config:        cfg,
 		subscriptions: make(map[string]*subscription),
 		done:          make(chan struct{}),
+		metrics:       metrics,
 	}
 }
 
@@ -103,11 +126,20 @@ func (r *RabbitMQBroker) Connect(ctx context.Context) error {
 
 	r.conn = conn
 	r.channel = ch
+	r.publisher = ch
+	r.openTxChannel = func() (txChannel, error) { return conn.Channel() }
 	r.connected = true
 
 	// Monitor connection
 	go r.monitorConnection()
 
+	// Start dead-letter purge once, it survives reconnects
+	dlq := r.config.DeadLetter
+	if dlq.Enabled && dlq.Retention > 0 && !r.purging {
+		r.purging = true
+		go r.runDeadLetterPurge()
+	}
+
 	return nil
 }
 
@@ -148,39 +180,28 @@ func (r *RabbitMQBroker) Health() error {
 
 // Publish publishes an event to RabbitMQ
 func (r *RabbitMQBroker) Publish(ctx context.Context, topic string, event domain.Event) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("failed to publish message: %w", err)
+	}
+
+	// Copy the channel under the lock and release it before the network call,
+	// so publishers don't serialize behind a reconnect waiting for the write lock
 	r.mu.RLock()
-	defer r.mu.RUnlock()
+	connected, publisher := r.connected, r.publisher
+	r.mu.RUnlock()
 
-	if !r.connected {
+	if !connected {
 		return fmt.Errorf("not connected to RabbitMQ")
 	}
 
-	body, err := json.Marshal(event)
+	msg, err := r.newPublishing(event)
 	if err != nil {
-		return fmt.Errorf("failed to marshal event: %w", err)
-	}
-
-	exchange := r.config.Exchange
-	if exchange == "" {
-		exchange = "amq.topic"
+		return err
 	}
 
-	msg := amqp.Publishing{
-		DeliveryMode: amqp.Transient,
-		ContentType:  "application/json",
-		Body:         body,
-		Timestamp:    event.OccurredAt(),
-		MessageId:    event.EventID(),
-		Type:         event.EventType(),
-	}
-
-	if r.config.Persistent {
-		msg.DeliveryMode = amqp.Persistent
-	}
-
-	err = r.channel.PublishWithContext(
+	err = publisher.PublishWithContext(
 		ctx,
-		exchange,
+		r.exchange(),
 		topic,
 		false, // mandatory
 		false, // immediate
@@ -194,16 +215,92 @@ func (r *RabbitMQBroker) Publish(ctx context.Context, topic string, event domain
 	return nil
 }
 
-// PublishBatch publishes multiple events in a batch
+// PublishBatch publishes multiple events in a single channel transaction,
+// so the broker routes either all of them or none if any publish or the commit fails
 func (r *RabbitMQBroker) PublishBatch(ctx context.Context, topic string, events []domain.Event) error {
-	for _, event := range events {
-		if err := r.Publish(ctx, topic, event); err != nil {
+	if len(events) == 0 {
+		return nil
+	}
+
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("failed to publish batch: %w", err)
+	}
+
+	r.mu.RLock()
+	connected, openTxChannel := r.connected, r.openTxChannel
+	r.mu.RUnlock()
+
+	if !connected {
+		return fmt.Errorf("not connected to RabbitMQ")
+	}
+
+	// Encode everything up front so a bad event publishes nothing
+	msgs := make([]amqp.Publishing, len(events))
+	for i, event := range events {
+		msg, err := r.newPublishing(event)
+		if err != nil {
 			return err
 		}
+		msgs[i] = msg
+	}
+
+	// Transactions are per channel, use a dedicated one so concurrent publishes stay out of the batch
+	ch, err := openTxChannel()
+	if err != nil {
+		return fmt.Errorf("failed to open batch channel: %w", err)
+	}
+	defer ch.Close()
+
+	if err := ch.Tx(); err != nil {
+		return fmt.Errorf("failed to start batch transaction: %w", err)
+	}
+
+	for i, msg := range msgs {
+		err := ch.PublishWithContext(ctx, r.exchange(), topic, false, false, msg)
+		if err != nil {
+			_ = ch.TxRollback()
+			return fmt.Errorf("failed to publish batch message %d of %d: %w", i+1, len(msgs), err)
+		}
+	}
+
+	if err := ch.TxCommit(); err != nil {
+		return fmt.Errorf("failed to commit batch: %w", err)
 	}
+
 	return nil
 }
 
+// newPublishing encodes an event as an AMQP message
+func (r *RabbitMQBroker) newPublishing(event domain.Event) (amqp.Publishing, error) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return amqp.Publishing{}, fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	msg := amqp.Publishing{
+		DeliveryMode: amqp.Transient,
+		ContentType:  "application/json",
+		Body:         body,
+		Timestamp:    event.OccurredAt(),
+		MessageId:    event.EventID(),
+		Type:         event.EventType(),
+	}
+
+	if r.config.Persistent {
+		msg.DeliveryMode = amqp.Persistent
+	}
+
+	return msg, nil
+}
+
+// exchange returns the configured exchange, falling back to amq.topic
+func (r *RabbitMQBroker) exchange() string {
+	if r.config.Exchange == "" {
+		return "amq.topic"
+	}
+	return r.config.Exchange
+}
+
 // Subscribe subscribes to a topic and handles incoming messages
 func (r *RabbitMQBroker) Subscribe(ctx context.Context, topic string, handler broker.MessageHandler) error {
 	r.mu.Lock()
@@ -224,6 +321,21 @@ func (r *RabbitMQBroker) Subscribe(ctx context.Context, topic string, handler br
 		queueName = topic
 	}
 
+	// Declare dead-letter queue for messages the handler fails to process
+	var queueArgs amqp.Table
+	var deadLetterQueue string
+	if r.config.DeadLetter.Enabled {
+		dlq, err := r.declareDeadLetterQueue(queueName, topic)
+		if err != nil {
+			return err
+		}
+		deadLetterQueue = dlq
+		queueArgs = amqp.Table{
+			"x-dead-letter-exchange":    r.deadLetterExchange(),
+			"x-dead-letter-routing-key": topic,
+		}
+	}
+
 	// Declare queue
 	queue, err := r.channel.QueueDeclare(
 		queueName,
@@ -231,7 +343,7 @@ func (r *RabbitMQBroker) Subscribe(ctx context.Context, topic string, handler br
 		false, // auto-delete
 		false, // exclusive
 		false, // no-wait
-		nil,   // arguments
+		queueArgs,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to declare queue: %w", err)
@@ -273,9 +385,10 @@ func (r *RabbitMQBroker) Subscribe(ctx context.Context, topic string, handler br
 
 	// Store subscription
 	r.subscriptions[topic] = &subscription{
-		queue:   queue.Name,
-		handler: handler,
-		cancel:  cancel,
+		queue:           queue.Name,
+		deadLetterQueue: deadLetterQueue,
+		handler:         handler,
+		cancel:          cancel,
 	}
 
 	// Process messages
@@ -325,8 +438,8 @@ func (r *RabbitMQBroker) processMessages(ctx context.Context, topic string, msgs
 
 			// Handle message
 			if err := sub.handler(ctx, msg.Body); err != nil {
-				// Nack and requeue on error
-				msg.Nack(false, true)
+				// Route to the dead-letter queue if configured, otherwise requeue
+				msg.Nack(false, sub.deadLetterQueue == "")
 			} else {
 				// Ack on success
 				msg.Ack(false)
@@ -335,6 +448,117 @@ func (r *RabbitMQBroker) processMessages(ctx context.Context, topic string, msgs
 	}
 }
 
+// deadLetterExchange returns the dead-letter exchange name
+func (r *RabbitMQBroker) deadLetterExchange() string {
+	if r.config.DeadLetter.Exchange != "" {
+		return r.config.DeadLetter.Exchange
+	}
+	if r.config.Exchange != "" {
+		return r.config.Exchange + ".dlx"
+	}
+	return "amq.topic.dlx"
+}
+
+// declareDeadLetterQueue declares the dead-letter exchange and queue for a source queue
+func (r *RabbitMQBroker) declareDeadLetterQueue(queueName, topic string) (string, error) {
+	exchange := r.deadLetterExchange()
+	if err := r.channel.ExchangeDeclare(
+		exchange,
+		"topic",
+		true,  // durable
+		false, // auto-deleted
+		false, // internal
+		false, // no-wait
+		nil,   // arguments
+	); err != nil {
+		return "", fmt.Errorf("failed to declare dead-letter exchange: %w", err)
+	}
+
+	suffix := r.config.DeadLetter.QueueSuffix
+	if suffix == "" {
+		suffix = ".dlq"
+	}
+
+	var args amqp.Table
+	if r.config.DeadLetter.MaxLength > 0 {
+		args = amqp.Table{"x-max-length": r.config.DeadLetter.MaxLength}
+	}
+
+	queue, err := r.channel.QueueDeclare(
+		queueName+suffix,
+		true,  // durable
+		false, // auto-delete
+		false, // exclusive
+		false, // no-wait
+		args,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to declare dead-letter queue: %w", err)
+	}
+
+	if err := r.channel.QueueBind(queue.Name, topic, exchange, false, nil); err != nil {
+		return "", fmt.Errorf("failed to bind dead-letter queue: %w", err)
+	}
+
+	return queue.Name, nil
+}
+
+// runDeadLetterPurge periodically purges expired messages from all dead-letter queues
+func (r *RabbitMQBroker) runDeadLetterPurge() {
+	interval := r.config.DeadLetter.PurgeInterval
+	if interval == 0 {
+		interval = time.Hour
+	}
+
+	var archiver broker.DeadLetterArchiver
+	if r.config.DeadLetter.ArchivePath != "" {
+		archiver = NewFileDeadLetterArchiver(r.config.DeadLetter.ArchivePath)
+	}
+	purger := NewDeadLetterPurger(channelGetter{broker: r}, r.config.DeadLetter.Retention, archiver, r.metrics)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.done:
+			return
+		case <-ticker.C:
+			r.mu.RLock()
+			queues := make([]string, 0, len(r.subscriptions))
+			for _, sub := range r.subscriptions {
+				if sub.deadLetterQueue != "" {
+					queues = append(queues, sub.deadLetterQueue)
+				}
+			}
+			r.mu.RUnlock()
+
+			for _, queue := range queues {
+				if _, err := purger.Purge(context.Background(), queue); err != nil {
+					log.Printf("failed to purge dead-letter queue %s: %v", queue, err)
+				}
+			}
+		}
+	}
+}
+
+// channelGetter reads from the broker's current channel, which changes on reconnect
+type channelGetter struct {
+	broker *RabbitMQBroker
+}
+
+// Get fetches a single message from the queue
+func (g channelGetter) Get(queue string, autoAck bool) (amqp.Delivery, bool, error) {
+	g.broker.mu.RLock()
+	defer g.broker.mu.RUnlock()
+
+	if !g.broker.connected {
+		return amqp.Delivery{}, false, fmt.Errorf("not connected to RabbitMQ")
+	}
+
+	return g.broker.channel.Get(queue, autoAck)
+}
+
 // monitorConnection monitors the connection and attempts to reconnect
 func (r *RabbitMQBroker) monitorConnection() {
 	closeChan := make(chan *amqp.Error)
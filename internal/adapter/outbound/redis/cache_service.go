@@ -2,10 +2,15 @@ package redis
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
+	cacheerr "github.com/gieart87/gohexaclean/internal/infra/cache"
 	"github.com/gieart87/gohexaclean/internal/port/outbound/service"
 	"github.com/redis/go-redis/v9"
 )
@@ -24,7 +29,7 @@ func NewCacheServiceRedis(client *redis.Client) service.CacheService {
 func (s *CacheServiceRedis) Get(ctx context.Context, key string) (string, error) {
 	val, err := s.client.Get(ctx, key).Result()
 	if err == redis.Nil {
-		return "", fmt.Errorf("key not found")
+		return "", fmt.Errorf("%w: %s", cacheerr.ErrCacheKeyNotFound, key)
 	}
 	if err != nil {
 		return "", fmt.Errorf("failed to get cache: %w", err)
@@ -91,3 +96,130 @@ func (s *CacheServiceRedis) SetNX(ctx context.Context, key string, value interfa
 	}
 	return result, nil
 }
+
+// scanBatchSize is the COUNT hint for each SCAN call in DeleteByPrefix
+const scanBatchSize = 100
+
+// MGet retrieves multiple values in a single round trip, missing keys are omitted from the result
+func (s *CacheServiceRedis) MGet(ctx context.Context, keys ...string) (map[string]string, error) {
+	result := make(map[string]string, len(keys))
+	if len(keys) == 0 {
+		return result, nil
+	}
+
+	vals, err := s.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cache: %w", err)
+	}
+
+	for i, val := range vals {
+		if str, ok := val.(string); ok {
+			result[keys[i]] = str
+		}
+	}
+	return result, nil
+}
+
+// DeleteMany deletes multiple keys using a pipeline
+func (s *CacheServiceRedis) DeleteMany(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	_, err := s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			pipe.Del(ctx, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete cache: %w", err)
+	}
+	return nil
+}
+
+// DeleteByPrefix deletes all keys starting with prefix
+// It walks the keyspace with SCAN rather than KEYS so Redis is never blocked, and only
+// deletes once the scan completes since deleting mid-scan can make some servers skip keys
+func (s *CacheServiceRedis) DeleteByPrefix(ctx context.Context, prefix string) error {
+	if prefix == "" {
+		return fmt.Errorf("prefix must not be empty")
+	}
+
+	var keys []string
+	iter := s.client.Scan(ctx, 0, escapePattern(prefix)+"*", scanBatchSize).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("failed to scan cache: %w", err)
+	}
+
+	for start := 0; start < len(keys); start += scanBatchSize {
+		end := min(start+scanBatchSize, len(keys))
+		if err := s.DeleteMany(ctx, keys[start:end]...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// escapePattern escapes glob metacharacters so the prefix is matched literally
+func escapePattern(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// lockReleaseTimeout bounds the release call, which runs without the caller's context
+const lockReleaseTimeout = 5 * time.Second
+
+// releaseLockScript deletes the lock only if it still holds the caller's token,
+// so a holder whose lock expired can't release a lock taken by someone else
+var releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// AcquireLock takes a lock on key using SET NX with a random token
+func (s *CacheServiceRedis) AcquireLock(ctx context.Context, key string, ttl time.Duration) (func(), bool, error) {
+	token, err := newLockToken()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to generate lock token: %w", err)
+	}
+
+	acquired, err := s.client.SetNX(ctx, key, token, ttl).Result()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to acquire lock: %w", err)
+	}
+	if !acquired {
+		return nil, false, nil
+	}
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), lockReleaseTimeout)
+			defer cancel()
+			_ = releaseLockScript.Run(ctx, s.client, []string{key}, token).Err()
+		})
+	}
+	return release, true, nil
+}
+
+// newLockToken returns a random token identifying one lock holder
+func newLockToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
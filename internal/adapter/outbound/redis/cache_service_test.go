@@ -0,0 +1,247 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	cacheerr "github.com/gieart87/gohexaclean/internal/infra/cache"
+	"github.com/redis/go-redis/v9"
+	"github.com/redis/go-redis/v9/maintnotifications"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// commandRecorder records the commands sent to Redis and how many pipelines were executed
+type commandRecorder struct {
+	mu        sync.Mutex
+	commands  []string
+	pipelines [][]string
+}
+
+func (r *commandRecorder) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+func (r *commandRecorder) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		r.mu.Lock()
+		r.commands = append(r.commands, cmd.Name())
+		r.mu.Unlock()
+		return next(ctx, cmd)
+	}
+}
+
+func (r *commandRecorder) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		names := make([]string, 0, len(cmds))
+		for _, cmd := range cmds {
+			names = append(names, cmd.Name())
+		}
+		r.mu.Lock()
+		r.pipelines = append(r.pipelines, names)
+		r.mu.Unlock()
+		return next(ctx, cmds)
+	}
+}
+
+func setupCache(t *testing.T) (*CacheServiceRedis, *miniredis.Miniredis, *commandRecorder) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{
+		Addr:                     mr.Addr(),
+		MaintNotificationsConfig: &maintnotifications.Config{Mode: maintnotifications.ModeDisabled},
+	})
+	t.Cleanup(func() { _ = client.Close() })
+
+	// Open the connection first so handshake commands aren't recorded
+	require.NoError(t, client.Ping(context.Background()).Err())
+
+	recorder := &commandRecorder{}
+	client.AddHook(recorder)
+
+	return NewCacheServiceRedis(client).(*CacheServiceRedis), mr, recorder
+}
+
+func TestCacheServiceRedis_Get_Miss(t *testing.T) {
+	cache, _, _ := setupCache(t)
+
+	_, err := cache.Get(context.Background(), "missing")
+
+	assert.ErrorIs(t, err, cacheerr.ErrCacheKeyNotFound)
+}
+
+func TestCacheServiceRedis_MGet(t *testing.T) {
+	cache, mr, recorder := setupCache(t)
+	ctx := context.Background()
+	require.NoError(t, mr.Set("user:1", "alice"))
+	require.NoError(t, mr.Set("user:2", "bob"))
+
+	values, err := cache.MGet(ctx, "user:1", "user:missing", "user:2")
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"user:1": "alice", "user:2": "bob"}, values)
+	assert.Equal(t, []string{"mget"}, recorder.commands)
+}
+
+func TestCacheServiceRedis_MGet_NoKeys(t *testing.T) {
+	cache, _, recorder := setupCache(t)
+
+	values, err := cache.MGet(context.Background())
+
+	require.NoError(t, err)
+	assert.Empty(t, values)
+	assert.Empty(t, recorder.commands)
+}
+
+func TestCacheServiceRedis_DeleteMany(t *testing.T) {
+	cache, mr, recorder := setupCache(t)
+	ctx := context.Background()
+	for _, key := range []string{"a", "b", "c", "keep"} {
+		require.NoError(t, mr.Set(key, "1"))
+	}
+
+	err := cache.DeleteMany(ctx, "a", "b", "c", "missing")
+
+	require.NoError(t, err)
+	assert.False(t, mr.Exists("a"))
+	assert.False(t, mr.Exists("b"))
+	assert.False(t, mr.Exists("c"))
+	assert.True(t, mr.Exists("keep"))
+	require.Len(t, recorder.pipelines, 1, "all deletes should go in one pipeline")
+	assert.Equal(t, []string{"del", "del", "del", "del"}, recorder.pipelines[0])
+}
+
+func TestCacheServiceRedis_DeleteMany_NoKeys(t *testing.T) {
+	cache, _, recorder := setupCache(t)
+
+	require.NoError(t, cache.DeleteMany(context.Background()))
+	assert.Empty(t, recorder.pipelines)
+}
+
+func TestCacheServiceRedis_DeleteByPrefix(t *testing.T) {
+	cache, mr, recorder := setupCache(t)
+	ctx := context.Background()
+	for i := 0; i < scanBatchSize+5; i++ {
+		require.NoError(t, mr.Set(fmt.Sprintf("user:%d", i), "1"))
+	}
+	require.NoError(t, mr.Set("session:1", "1"))
+	require.NoError(t, mr.Set("user*literal", "1"))
+
+	err := cache.DeleteByPrefix(ctx, "user:")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"session:1", "user*literal"}, mr.Keys())
+	assert.Contains(t, recorder.commands, "scan")
+	assert.NotContains(t, recorder.commands, "keys")
+	assert.GreaterOrEqual(t, len(recorder.pipelines), 2, "deletes should be flushed in batches")
+}
+
+func TestCacheServiceRedis_DeleteByPrefix_EscapesPattern(t *testing.T) {
+	cache, mr, _ := setupCache(t)
+	require.NoError(t, mr.Set("user*1", "1"))
+	require.NoError(t, mr.Set("user:1", "1"))
+
+	require.NoError(t, cache.DeleteByPrefix(context.Background(), "user*"))
+
+	assert.Equal(t, []string{"user:1"}, mr.Keys())
+}
+
+func TestCacheServiceRedis_DeleteByPrefix_EmptyPrefix(t *testing.T) {
+	cache, mr, _ := setupCache(t)
+	require.NoError(t, mr.Set("user:1", "1"))
+
+	err := cache.DeleteByPrefix(context.Background(), "")
+
+	assert.Error(t, err)
+	assert.True(t, mr.Exists("user:1"))
+}
+
+func TestCacheServiceRedis_DeleteMany_ContextCanceled(t *testing.T) {
+	cache, _, _ := setupCache(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.Error(t, cache.DeleteMany(ctx, "a"))
+}
+
+func TestCacheServiceRedis_AcquireLock_Exclusive(t *testing.T) {
+	cache, mr, _ := setupCache(t)
+	ctx := context.Background()
+
+	release, ok, err := cache.AcquireLock(ctx, "lock:outbox", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	_, ok, err = cache.AcquireLock(ctx, "lock:outbox", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, ok, "second acquirer must not get a held lock")
+	assert.Equal(t, time.Minute, mr.TTL("lock:outbox"))
+
+	release()
+	assert.False(t, mr.Exists("lock:outbox"))
+
+	release2, ok, err := cache.AcquireLock(ctx, "lock:outbox", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, ok, "lock should be free after release")
+	release2()
+}
+
+func TestCacheServiceRedis_AcquireLock_Concurrent(t *testing.T) {
+	cache, _, _ := setupCache(t)
+	ctx := context.Background()
+
+	const acquirers = 20
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		holders int
+	)
+	for i := 0; i < acquirers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, ok, err := cache.AcquireLock(ctx, "lock:job", time.Minute)
+			assert.NoError(t, err)
+			if ok {
+				mu.Lock()
+				holders++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, holders)
+}
+
+func TestCacheServiceRedis_AcquireLock_ReleaseOnlyOwnToken(t *testing.T) {
+	cache, mr, _ := setupCache(t)
+	ctx := context.Background()
+
+	staleRelease, ok, err := cache.AcquireLock(ctx, "lock:job", time.Second)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// The first holder's lock expires and a second holder takes it
+	mr.FastForward(2 * time.Second)
+	release, ok, err := cache.AcquireLock(ctx, "lock:job", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+	owner, err := mr.Get("lock:job")
+	require.NoError(t, err)
+
+	staleRelease()
+
+	current, err := mr.Get("lock:job")
+	require.NoError(t, err, "stale release must not delete the new owner's lock")
+	assert.Equal(t, owner, current)
+
+	release()
+	assert.False(t, mr.Exists("lock:job"))
+}
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/repository"
+)
+
+// userIterator walks all users in creation order, holding at most one batch in memory
+type userIterator struct {
+	repo      repository.UserRepository
+	batchSize int
+
+	batch  []*domain.User
+	pos    int
+	cursor *domain.UserCursor
+	done   bool
+	err    error
+}
+
+func newUserIterator(repo repository.UserRepository, batchSize int) *userIterator {
+	return &userIterator{repo: repo, batchSize: batchSize}
+}
+
+// Next advances to the next user, fetching the following batch when the current one is used up
+// It returns false when all users have been visited or a fetch failed, see Err
+func (it *userIterator) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+
+	if it.pos >= len(it.batch) {
+		if it.done {
+			return false
+		}
+
+		// Stop between batches once the caller has gone away
+		if err := ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+
+		batch, err := it.repo.ListAfter(ctx, it.cursor, it.batchSize)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		// A short batch means the end has been reached, skip the extra empty query
+		it.done = len(batch) < it.batchSize
+		it.batch, it.pos = batch, 0
+		if len(batch) == 0 {
+			return false
+		}
+
+		last := batch[len(batch)-1]
+		it.cursor = &domain.UserCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	it.pos++
+	return true
+}
+
+// User returns the current user, valid after Next returned true
+func (it *userIterator) User() *domain.User {
+	return it.batch[it.pos-1]
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *userIterator) Err() error {
+	return it.err
+}
@@ -2,114 +2,186 @@ package app
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/gieart87/gohexaclean/internal/adapter/outbound/event"
 	"github.com/gieart87/gohexaclean/internal/domain"
 	"github.com/gieart87/gohexaclean/internal/dto/request"
 	"github.com/gieart87/gohexaclean/internal/dto/response"
 	"github.com/gieart87/gohexaclean/internal/infra/config"
-	"github.com/gieart87/gohexaclean/internal/infra/asynq/tasks"
 	"github.com/gieart87/gohexaclean/internal/port/inbound"
 	"github.com/gieart87/gohexaclean/internal/port/outbound/repository"
 	"github.com/gieart87/gohexaclean/internal/port/outbound/service"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/telemetry"
 	"github.com/gieart87/gohexaclean/pkg/auth"
 	"github.com/gieart87/gohexaclean/pkg/crypto"
+	"github.com/gieart87/gohexaclean/pkg/idgen"
+	"github.com/gieart87/gohexaclean/pkg/pagination"
+	"github.com/gieart87/gohexaclean/pkg/requestid"
+	"github.com/gieart87/gohexaclean/pkg/workerpool"
 	"github.com/google/uuid"
-	"github.com/hibiken/asynq"
 )
 
 // UserService implements the UserServicePort interface
+// Caching is left to the repository, see cached.NewCachedUserRepository
 type UserService struct {
 	userRepo       repository.UserRepository
-	cacheService   service.CacheService
+	transactor     repository.Transactor // runs calls spanning several writes as one unit of work
 	jwtConfig      *config.JWTConfig
 	eventPublisher *event.UserEventPublisher
-	taskClient     *asynq.Client
+	taskQueue      service.TaskQueue // set only when welcome emails aren't driven by user.created events
+	emailQueue     service.TaskQueue // mails email verification tokens, emails can't be changed without it
+	bulkConfig     *config.BulkConfig
+	auditRepo      repository.AuditRepository
+	eventStore     repository.EventStore    // keeps emitted events for the user's activity timeline
+	idStrategy     idgen.Strategy           // how new user IDs are generated, empty means UUID v4
+	metrics        telemetry.MetricsService // business counters, nil disables them
 }
 
+// Business metrics emitted by the user service
+const (
+	metricUsersCreated       = "users.created"
+	metricUsersDeleted       = "users.deleted"
+	metricUsersLoginsSuccess = "users.logins.success"
+	metricUsersLoginsFailed  = "users.logins.failed" // tagged with the reason, which clients never see
+)
+
+// emailVerificationTTL bounds how long a mailed token can confirm an email change
+const emailVerificationTTL = 24 * time.Hour
+
+// Reasons a login failed, reported on metricUsersLoginsFailed
+const (
+	loginFailureNotFound      = "not_found"
+	loginFailureLookupError   = "lookup_error"
+	loginFailureWrongPassword = "wrong_password"
+	loginFailureInactive      = "inactive"
+)
+
 // NewUserService creates a new user service
 func NewUserService(
 	userRepo repository.UserRepository,
-	cacheService service.CacheService,
+	transactor repository.Transactor,
 	jwtConfig *config.JWTConfig,
 	eventPublisher *event.UserEventPublisher,
-	taskClient *asynq.Client,
+	taskQueue service.TaskQueue,
+	emailQueue service.TaskQueue,
+	bulkConfig *config.BulkConfig,
+	auditRepo repository.AuditRepository,
+	eventStore repository.EventStore,
+	idStrategy idgen.Strategy,
+	metrics telemetry.MetricsService,
 ) inbound.UserServicePort {
 	return &UserService{
 		userRepo:       userRepo,
-		cacheService:   cacheService,
+		transactor:     transactor,
 		jwtConfig:      jwtConfig,
 		eventPublisher: eventPublisher,
-		taskClient:     taskClient,
+		taskQueue:      taskQueue,
+		emailQueue:     emailQueue,
+		bulkConfig:     bulkConfig,
+		auditRepo:      auditRepo,
+		eventStore:     eventStore,
+		idStrategy:     idStrategy,
+		metrics:        metrics,
 	}
 }
 
 // CreateUser creates a new user and returns a token
 func (s *UserService) CreateUser(ctx context.Context, req *request.CreateUserRequest) (*response.LoginResponse, error) {
-	// Check if user already exists
-	exists, err := s.userRepo.ExistsByEmail(ctx, req.Email)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	user, token, err := s.createUser(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check user existence: %w", err)
+		return nil, err
+	}
+
+	evt := domain.NewUserCreatedEvent(user.ID, user.Email, user.Name)
+	s.storeEvents(ctx, evt)
+
+	// Publish user created event
+	if s.eventPublisher != nil {
+		if err := s.eventPublisher.PublishUserCreated(ctx, evt); err != nil {
+			// Log error but don't fail the operation
+			log.Printf("failed to publish user created event: %v", err)
+		}
+	}
+
+	return &response.LoginResponse{
+		Token: token,
+		User:  response.NewUserResponse(user),
+	}, nil
+}
+
+// createUser persists a new user and issues their token
+// The welcome email is sent by the user.created consumer, or enqueued here when events are disabled
+// Publishing the created event is left to the caller so bulk creation can batch it
+func (s *UserService) createUser(ctx context.Context, req *request.CreateUserRequest) (*domain.User, string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+
+	// Check if user already exists, emails are unique regardless of case
+	exists, err := s.userRepo.ExistsByEmail(ctx, domain.NormalizeEmail(req.Email))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to check user existence: %w", err)
 	}
 	if exists {
-		return nil, domain.ErrUserAlreadyExists
+		return nil, "", domain.ErrUserAlreadyExists
 	}
 
-	// Hash password
+	// Hash password, deliberately slow so it is skipped once the client has gone away
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
 	hashedPassword, err := crypto.HashPassword(req.Password)
 	if err != nil {
-		return nil, fmt.Errorf("failed to hash password: %w", err)
+		return nil, "", fmt.Errorf("failed to hash password: %w", err)
 	}
 
 	// Create domain entity
-	user := domain.NewUser(req.Email, req.Name, hashedPassword)
+	user := domain.NewUser(req.Email, req.Name, hashedPassword, s.idStrategy)
 
 	// Save to repository
 	if err := s.userRepo.Create(ctx, user); err != nil {
-		return nil, fmt.Errorf("failed to create user: %w", err)
+		return nil, "", fmt.Errorf("failed to create user: %w", err)
 	}
 
+	s.recordAudit(ctx, domain.AuditActionUserCreated, user.ID)
+	s.incrementCounter(metricUsersCreated, nil, 1)
+
 	// Generate token for the newly registered user
-	token, err := auth.GenerateJWT(user.ID, user.Email, s.jwtConfig.Secret, s.jwtConfig.Expired)
+	token, err := auth.GenerateJWT(user.ID, user.Email, user.Role, user.TokenVersion, s.jwtConfig.TokenOptions(), s.jwtConfig.Expired)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate token: %w", err)
-	}
-
-	// Publish user created event
-	if s.eventPublisher != nil {
-		event := domain.NewUserCreatedEvent(user.ID, user.Email, user.Name)
-		if err := s.eventPublisher.PublishUserCreated(ctx, event); err != nil {
-			// Log error but don't fail the operation
-			fmt.Printf("failed to publish user created event: %v\n", err)
-		}
+		return nil, "", fmt.Errorf("failed to generate token: %w", err)
 	}
 
-	// Enqueue welcome email task asynchronously
-	if s.taskClient != nil {
-		task, err := tasks.NewEmailWelcomeTask(user.ID.String(), user.Email, user.Name)
-		if err != nil {
-			log.Printf("failed to create welcome email task: %v", err)
-		} else {
-			info, err := s.taskClient.Enqueue(task)
-			if err != nil {
-				log.Printf("failed to enqueue welcome email task: %v", err)
-			} else {
-				log.Printf("enqueued welcome email task: id=%s queue=%s", info.ID, info.Queue)
-			}
+	// Fallback for deployments without a broker, the email must not block registration
+	if s.taskQueue != nil {
+		if err := s.taskQueue.EnqueueWelcomeEmail(ctx, user.ID, user.Email, user.Name); err != nil {
+			log.Printf("welcome email not sent: %v", err)
 		}
 	}
 
-	return &response.LoginResponse{
-		Token: token,
-		User:  response.NewUserResponse(user),
-	}, nil
+	return user, token, nil
 }
 
-// GetUserByID retrieves a user by ID
-func (s *UserService) GetUserByID(ctx context.Context, id uuid.UUID) (*response.UserResponse, error) {
-	user, err := s.userRepo.FindByID(ctx, id)
+// GetUserByID retrieves a user by ID along with any requested related data
+func (s *UserService) GetUserByID(ctx context.Context, id uuid.UUID, includes ...string) (*response.UserResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, id, includes...)
 	if err != nil {
 		return nil, err
 	}
@@ -119,7 +191,11 @@ func (s *UserService) GetUserByID(ctx context.Context, id uuid.UUID) (*response.
 
 // GetUserByEmail retrieves a user by email
 func (s *UserService) GetUserByEmail(ctx context.Context, email string) (*response.UserResponse, error) {
-	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByEmail(ctx, domain.NormalizeEmail(email))
 	if err != nil {
 		return nil, err
 	}
@@ -127,8 +203,32 @@ func (s *UserService) GetUserByEmail(ctx context.Context, email string) (*respon
 	return response.NewUserResponse(user), nil
 }
 
+// GetUsersByIDs retrieves multiple users in one lookup, keyed by ID
+// IDs that don't exist are omitted from the result
+func (s *UserService) GetUsersByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*response.UserResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	users, err := s.userRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	userResponses := make(map[uuid.UUID]*response.UserResponse, len(users))
+	for id, user := range users {
+		userResponses[id] = response.NewUserResponse(user)
+	}
+
+	return userResponses, nil
+}
+
 // UpdateUser updates user information
 func (s *UserService) UpdateUser(ctx context.Context, id uuid.UUID, req *request.UpdateUserRequest) (*response.UserResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	user, err := s.userRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -140,15 +240,15 @@ func (s *UserService) UpdateUser(ctx context.Context, id uuid.UUID, req *request
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
 
-	// Invalidate cache
-	cacheKey := fmt.Sprintf("user:%s", id.String())
-	_ = s.cacheService.Delete(ctx, cacheKey)
+	s.recordAudit(ctx, domain.AuditActionUserUpdated, user.ID)
+
+	evt := domain.NewUserUpdatedEvent(user.ID, user.Name)
+	s.storeEvents(ctx, evt)
 
 	// Publish user updated event
 	if s.eventPublisher != nil {
-		event := domain.NewUserUpdatedEvent(user.ID, user.Name)
-		if err := s.eventPublisher.PublishUserUpdated(ctx, event); err != nil {
-			fmt.Printf("failed to publish user updated event: %v\n", err)
+		if err := s.eventPublisher.PublishUserUpdated(ctx, evt); err != nil {
+			log.Printf("failed to publish user updated event: %v", err)
 		}
 	}
 
@@ -157,48 +257,422 @@ func (s *UserService) UpdateUser(ctx context.Context, id uuid.UUID, req *request
 
 // DeleteUser deletes a user
 func (s *UserService) DeleteUser(ctx context.Context, id uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	if err := s.userRepo.Delete(ctx, id); err != nil {
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
 
-	// Invalidate cache
-	cacheKey := fmt.Sprintf("user:%s", id.String())
-	_ = s.cacheService.Delete(ctx, cacheKey)
+	s.recordAudit(ctx, domain.AuditActionUserDeleted, id)
+	s.incrementCounter(metricUsersDeleted, nil, 1)
+
+	evt := domain.NewUserDeletedEvent(id)
+	s.storeEvents(ctx, evt)
 
 	// Publish user deleted event
 	if s.eventPublisher != nil {
-		event := domain.NewUserDeletedEvent(id)
-		if err := s.eventPublisher.PublishUserDeleted(ctx, event); err != nil {
-			fmt.Printf("failed to publish user deleted event: %v\n", err)
+		if err := s.eventPublisher.PublishUserDeleted(ctx, evt); err != nil {
+			log.Printf("failed to publish user deleted event: %v", err)
 		}
 	}
 
 	return nil
 }
 
+// ChangeEmail starts moving the user to newEmail, mailing a verification token to that address
+// The email only changes once VerifyEmail confirms the token, until then the current one stays in use
+func (s *UserService) ChangeEmail(ctx context.Context, id uuid.UUID, newEmail string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if s.emailQueue == nil {
+		return errors.New("failed to change email: verification emails are not available")
+	}
+
+	newEmail = domain.NormalizeEmail(newEmail)
+
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if user.Email == newEmail {
+		return fmt.Errorf("%w: the new email is the current one", domain.ErrInvalidInput)
+	}
+
+	exists, err := s.userRepo.ExistsByEmail(ctx, newEmail)
+	if err != nil {
+		return fmt.Errorf("failed to check email availability: %w", err)
+	}
+	if exists {
+		return domain.ErrUserAlreadyExists
+	}
+
+	token, err := newVerificationToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate verification token: %w", err)
+	}
+	expiresAt := time.Now().Add(emailVerificationTTL)
+
+	user.RequestEmailChange(newEmail, hashVerificationToken(token), expiresAt)
+	if err := s.userRepo.UpdateEmail(ctx, user); err != nil {
+		return fmt.Errorf("failed to save email change: %w", err)
+	}
+
+	// Requesting the change again issues a new token, so a failed send is safe to retry
+	if err := s.emailQueue.EnqueueEmailVerification(ctx, user.ID, newEmail, token, expiresAt); err != nil {
+		return fmt.Errorf("failed to send verification email: %w", err)
+	}
+
+	s.recordAudit(ctx, domain.AuditActionUserEmailChangeRequested, user.ID)
+
+	return nil
+}
+
+// VerifyEmail completes the pending email change of the user with the token mailed by ChangeEmail
+func (s *UserService) VerifyEmail(ctx context.Context, id uuid.UUID, token string) (*response.UserResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// The pending token is checked and consumed in one transaction, whose reads skip the user cache
+	var user *domain.User
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = s.userRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := user.VerifyEmailChange(hashVerificationToken(token), time.Now()); err != nil {
+			return err
+		}
+
+		// Someone may have registered the address since the change was requested
+		exists, err := s.userRepo.ExistsByEmail(ctx, user.Email)
+		if err != nil {
+			return fmt.Errorf("failed to check email availability: %w", err)
+		}
+		if exists {
+			return domain.ErrUserAlreadyExists
+		}
+
+		if err := s.userRepo.UpdateEmail(ctx, user); err != nil {
+			return fmt.Errorf("failed to change email: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordAudit(ctx, domain.AuditActionUserEmailChanged, user.ID)
+
+	return response.NewUserResponse(user), nil
+}
+
+// newVerificationToken returns a random URL-safe token to mail, only its hash is stored
+func newVerificationToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashVerificationToken returns the SHA-256 hex of token, the form kept in the database
+func hashVerificationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// RevokeAllTokens bumps the user's token version so every previously issued token is rejected
+func (s *UserService) RevokeAllTokens(ctx context.Context, id uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if _, err := s.userRepo.IncrementTokenVersion(ctx, id); err != nil {
+		return fmt.Errorf("failed to revoke tokens: %w", err)
+	}
+
+	s.recordAudit(ctx, domain.AuditActionUserTokensRevoked, id)
+
+	return nil
+}
+
+// SetActive activates or deactivates a user account
+// Deactivating also revokes all tokens so existing sessions end immediately
+func (s *UserService) SetActive(ctx context.Context, id uuid.UUID, active bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := s.userRepo.SetActive(ctx, id, active); err != nil {
+		return fmt.Errorf("failed to update user active status: %w", err)
+	}
+
+	action := domain.AuditActionUserActivated
+	if !active {
+		action = domain.AuditActionUserDeactivated
+	}
+	s.recordAudit(ctx, action, id)
+
+	if !active {
+		return s.RevokeAllTokens(ctx, id)
+	}
+
+	return nil
+}
+
+// GetTokenVersion returns the user's current token version
+// It fails once the user is deleted, which rejects the tokens issued to them
+func (s *UserService) GetTokenVersion(ctx context.Context, id uuid.UUID) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	return user.TokenVersion, nil
+}
+
+// recordAudit appends an audit entry for a mutation, attributed to the authenticated user in ctx
+// Auditing is best-effort: failures are logged and never fail the operation
+func (s *UserService) recordAudit(ctx context.Context, action string, targetID uuid.UUID) {
+	if s.auditRepo == nil {
+		return
+	}
+
+	var actorID *uuid.UUID
+	if id, ok := auth.UserIDFromContext(ctx); ok {
+		actorID = &id
+	}
+
+	entry := domain.NewAuditLog(actorID, action, targetID, requestid.FromContext(ctx))
+	if err := s.auditRepo.Record(ctx, entry); err != nil {
+		log.Printf("failed to record audit log %s for %s: %v", action, targetID, err)
+	}
+}
+
+// incrementCounter bumps a business metric, a no-op without a metrics service
+func (s *UserService) incrementCounter(name string, tags map[string]string, value float64) {
+	if s.metrics == nil {
+		return
+	}
+	s.metrics.IncrementCounter(name, tags, value)
+}
+
+// recordLoginFailure counts a failed login by reason, the client only ever sees invalid credentials
+func (s *UserService) recordLoginFailure(reason string) {
+	s.incrementCounter(metricUsersLoginsFailed, map[string]string{"reason": reason}, 1)
+}
+
+// storeEvents appends events to the activity timeline, best-effort so a failed write never fails the operation
+func (s *UserService) storeEvents(ctx context.Context, events ...domain.Event) {
+	if s.eventStore == nil || len(events) == 0 {
+		return
+	}
+
+	stored := make([]*domain.StoredEvent, 0, len(events))
+	for _, e := range events {
+		se, err := domain.NewStoredEvent(e)
+		if err != nil {
+			log.Printf("failed to store %s event: %v", e.EventType(), err)
+			continue
+		}
+		stored = append(stored, se)
+	}
+	if err := s.eventStore.Append(ctx, stored...); err != nil {
+		log.Printf("failed to store %d events: %v", len(stored), err)
+	}
+}
+
+// ListUserEvents returns a page of the user's stored domain events, newest first
+func (s *UserService) ListUserEvents(ctx context.Context, id uuid.UUID, page, limit int) ([]*response.UserEventResponse, int64, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	if s.eventStore == nil {
+		return []*response.UserEventResponse{}, 0, nil
+	}
+
+	events, total, err := s.eventStore.List(ctx, id, pagination.Offset(page, limit), limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list user events: %w", err)
+	}
+
+	eventResponses := make([]*response.UserEventResponse, len(events))
+	for i, e := range events {
+		eventResponses[i] = response.NewUserEventResponse(e)
+	}
+
+	return eventResponses, total, nil
+}
+
+// CreateUsers creates multiple users concurrently, bounded by the bulk concurrency limit
+// The created events of all successful items are published together in one batch
+func (s *UserService) CreateUsers(ctx context.Context, reqs []*request.CreateUserRequest) ([]*response.BulkItemResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	results := workerpool.Run(ctx, s.bulkConcurrency(), reqs, func(ctx context.Context, req *request.CreateUserRequest) (*response.UserResponse, error) {
+		user, _, err := s.createUser(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return response.NewUserResponse(user), nil
+	})
+
+	events := make([]domain.Event, 0, len(results))
+	for _, result := range results {
+		if result.Err == nil {
+			events = append(events, domain.NewUserCreatedEvent(result.Value.ID, result.Value.Email, result.Value.Name))
+		}
+	}
+	s.storeEvents(ctx, events...)
+
+	if s.eventPublisher != nil {
+		if err := s.eventPublisher.PublishBatch(ctx, "user.created", events); err != nil {
+			log.Printf("failed to publish user created events: %v", err)
+		}
+	}
+
+	return toBulkItemResults(results), ctx.Err()
+}
+
+// DeleteUsers soft-deletes all users in ids at once, IDs matching no user are reported rather than failing the batch
+// Every deleted user gets the same audit entry and user.deleted event as DeleteUser
+func (s *UserService) DeleteUsers(ctx context.Context, ids []uuid.UUID) (*response.BulkDeleteResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%w: no user IDs given", domain.ErrInvalidInput)
+	}
+
+	deleted, notFound, err := s.userRepo.DeleteBatch(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete users: %w", err)
+	}
+	if deleted > 0 {
+		s.incrementCounter(metricUsersDeleted, nil, float64(deleted))
+	}
+
+	missing := make(map[uuid.UUID]struct{}, len(notFound))
+	for _, id := range notFound {
+		missing[id] = struct{}{}
+	}
+
+	for _, id := range ids {
+		if _, ok := missing[id]; ok {
+			continue
+		}
+		// Mark handled so a duplicated ID is only processed once
+		missing[id] = struct{}{}
+
+		s.recordAudit(ctx, domain.AuditActionUserDeleted, id)
+
+		evt := domain.NewUserDeletedEvent(id)
+		s.storeEvents(ctx, evt)
+
+		// Publish user deleted event
+		if s.eventPublisher != nil {
+			if err := s.eventPublisher.PublishUserDeleted(ctx, evt); err != nil {
+				log.Printf("failed to publish user deleted event: %v", err)
+			}
+		}
+	}
+
+	return &response.BulkDeleteResponse{
+		Deleted:  deleted,
+		NotFound: notFound,
+	}, nil
+}
+
+// bulkConcurrency returns the configured bulk concurrency, defaulting to sequential processing
+func (s *UserService) bulkConcurrency() int {
+	if s.bulkConfig == nil || s.bulkConfig.Concurrency <= 0 {
+		return 1
+	}
+	return s.bulkConfig.Concurrency
+}
+
+// toBulkItemResults converts worker pool results into response DTOs
+func toBulkItemResults(results []workerpool.Result[*response.UserResponse]) []*response.BulkItemResult {
+	items := make([]*response.BulkItemResult, len(results))
+	for i, result := range results {
+		items[i] = &response.BulkItemResult{
+			Index:   result.Index,
+			Success: result.Err == nil,
+			User:    result.Value,
+		}
+		if result.Err != nil {
+			items[i].Error = result.Err.Error()
+		}
+	}
+	return items
+}
+
 // Login authenticates a user and returns a token
 func (s *UserService) Login(ctx context.Context, req *request.LoginRequest) (*response.LoginResponse, error) {
-	user, err := s.userRepo.FindByEmail(ctx, req.Email)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByEmail(ctx, domain.NormalizeEmail(req.Email))
 	if err != nil {
+		reason := loginFailureLookupError
+		if errors.Is(err, domain.ErrUserNotFound) {
+			reason = loginFailureNotFound
+		}
+		s.recordLoginFailure(reason)
 		return nil, domain.ErrInvalidCredentials
 	}
 
 	// Check password
 	if !crypto.CheckPasswordHash(req.Password, user.Password) {
+		s.recordLoginFailure(loginFailureWrongPassword)
 		return nil, domain.ErrInvalidCredentials
 	}
 
+	// Checked after the password so the error doesn't reveal which accounts exist
+	if !user.IsActive {
+		s.recordLoginFailure(loginFailureInactive)
+		return nil, domain.ErrAccountInactive
+	}
+
+	// Record the login, best-effort so a failed write never blocks authentication
+	now := time.Now()
+	if err := s.userRepo.UpdateLastLogin(ctx, user.ID, now); err != nil {
+		log.Printf("failed to update last login for user %s: %v", user.ID, err)
+	} else {
+		user.LastLoginAt = &now
+	}
+
 	// Generate token
-	token, err := auth.GenerateJWT(user.ID, user.Email, s.jwtConfig.Secret, s.jwtConfig.Expired)
+	token, err := auth.GenerateJWT(user.ID, user.Email, user.Role, user.TokenVersion, s.jwtConfig.TokenOptions(), s.jwtConfig.Expired)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate token: %w", err)
 	}
 
+	s.incrementCounter(metricUsersLoginsSuccess, nil, 1)
+
+	evt := domain.NewUserLoggedInEvent(user.ID, user.Email)
+	s.storeEvents(ctx, evt)
+
 	// Publish user logged in event
 	if s.eventPublisher != nil {
-		event := domain.NewUserLoggedInEvent(user.ID, user.Email)
-		if err := s.eventPublisher.PublishUserLoggedIn(ctx, event); err != nil {
-			fmt.Printf("failed to publish user logged in event: %v\n", err)
+		if err := s.eventPublisher.PublishUserLoggedIn(ctx, evt); err != nil {
+			log.Printf("failed to publish user logged in event: %v", err)
 		}
 	}
 
@@ -209,15 +683,23 @@ func (s *UserService) Login(ctx context.Context, req *request.LoginRequest) (*re
 }
 
 // ListUsers retrieves a paginated list of users
-func (s *UserService) ListUsers(ctx context.Context, page, limit int) ([]*response.UserResponse, int64, error) {
-	offset := (page - 1) * limit
+// The total is exact unless opts asks for an approximate count
+func (s *UserService) ListUsers(ctx context.Context, page, limit int, sort domain.UserSort, opts domain.ListOptions) ([]*response.UserResponse, int64, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
 
-	users, err := s.userRepo.List(ctx, offset, limit)
+	users, err := s.userRepo.List(ctx, pagination.Offset(page, limit), limit, sort)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to list users: %w", err)
 	}
 
-	total, err := s.userRepo.Count(ctx)
+	// The client may have gone away while the page was loading, skip the count then
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.countUsers(ctx, opts)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to count users: %w", err)
 	}
@@ -229,3 +711,58 @@ func (s *UserService) ListUsers(ctx context.Context, page, limit int) ([]*respon
 
 	return userResponses, total, nil
 }
+
+// countUsers returns the total for a listing, estimated from table statistics when opts.ApproxCount is set
+func (s *UserService) countUsers(ctx context.Context, opts domain.ListOptions) (int64, error) {
+	if opts.ApproxCount {
+		return s.userRepo.CountApprox(ctx)
+	}
+	return s.userRepo.Count(ctx)
+}
+
+// StreamUsers visits every user in creation order without loading them all at once
+func (s *UserService) StreamUsers(ctx context.Context, batchSize int, fn func(*response.UserResponse) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if batchSize < 1 {
+		return fmt.Errorf("%w: batch size must be positive", domain.ErrInvalidInput)
+	}
+
+	it := newUserIterator(s.userRepo, batchSize)
+	for it.Next(ctx) {
+		if err := fn(response.NewUserResponse(it.User())); err != nil {
+			return err
+		}
+	}
+	if err := it.Err(); err != nil {
+		return fmt.Errorf("failed to stream users: %w", err)
+	}
+
+	return nil
+}
+
+// SearchUsers performs a ranked full-text search over user names and emails
+func (s *UserService) SearchUsers(ctx context.Context, query string, limit int) ([]*response.UserResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("%w: search query is empty", domain.ErrInvalidInput)
+	}
+
+	users, err := s.userRepo.Search(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+
+	userResponses := make([]*response.UserResponse, len(users))
+	for i, user := range users {
+		userResponses[i] = response.NewUserResponse(user)
+	}
+
+	return userResponses, nil
+}
@@ -3,43 +3,73 @@ package app
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/gieart87/gohexaclean/internal/adapter/outbound/event"
 	"github.com/gieart87/gohexaclean/internal/domain"
 	"github.com/gieart87/gohexaclean/internal/dto/request"
+	"github.com/gieart87/gohexaclean/internal/dto/response"
 	"github.com/gieart87/gohexaclean/internal/infra/config"
+	brokermock "github.com/gieart87/gohexaclean/internal/port/outbound/broker/mock"
 	"github.com/gieart87/gohexaclean/internal/port/outbound/repository/mock"
 	servicemock "github.com/gieart87/gohexaclean/internal/port/outbound/service/mock"
+	"github.com/gieart87/gohexaclean/pkg/auth"
 	"github.com/gieart87/gohexaclean/pkg/crypto"
+	"github.com/gieart87/gohexaclean/pkg/requestid"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-func setupUserServiceTest(t *testing.T) (*UserService, *mock.MockUserRepository, *servicemock.MockCacheService, *gomock.Controller) {
+func setupUserServiceTest(t *testing.T) (*UserService, *mock.MockUserRepository, *gomock.Controller) {
 	ctrl := gomock.NewController(t)
 	mockRepo := mock.NewMockUserRepository(ctrl)
-	mockCache := servicemock.NewMockCacheService(ctrl)
 
 	jwtConfig := &config.JWTConfig{
 		Secret:  "test-secret",
-		Expired: 24,
+		Expired: 24 * time.Hour,
 	}
 
 	service := &UserService{
 		userRepo:       mockRepo,
-		cacheService:   mockCache,
 		jwtConfig:      jwtConfig,
 		eventPublisher: nil, // No event publisher in tests (gracefully handled)
 	}
 
-	return service, mockRepo, mockCache, ctrl
+	return service, mockRepo, ctrl
+}
+
+func setupUserServiceTestWithBroker(t *testing.T) (*UserService, *mock.MockUserRepository, *brokermock.MockMessageBroker, *gomock.Controller) {
+	service, mockRepo, ctrl := setupUserServiceTest(t)
+	mockBroker := brokermock.NewMockMessageBroker(ctrl)
+	service.eventPublisher = event.NewUserEventPublisher(mockBroker)
+
+	return service, mockRepo, mockBroker, ctrl
+}
+
+func setupUserServiceTestWithEventStore(t *testing.T) (*UserService, *mock.MockUserRepository, *mock.MockEventStore, *gomock.Controller) {
+	service, mockRepo, ctrl := setupUserServiceTest(t)
+	mockStore := mock.NewMockEventStore(ctrl)
+	service.eventStore = mockStore
+
+	return service, mockRepo, mockStore, ctrl
+}
+
+func setupUserServiceTestWithAudit(t *testing.T) (*UserService, *mock.MockUserRepository, *mock.MockAuditRepository, *gomock.Controller) {
+	service, mockRepo, ctrl := setupUserServiceTest(t)
+	mockAudit := mock.NewMockAuditRepository(ctrl)
+	service.auditRepo = mockAudit
+
+	return service, mockRepo, mockAudit, ctrl
 }
 
 func TestUserService_CreateUser(t *testing.T) {
-	service, mockRepo, _, ctrl := setupUserServiceTest(t)
+	service, mockRepo, ctrl := setupUserServiceTest(t)
 	defer ctrl.Finish()
 
 	req := &request.CreateUserRequest{
@@ -71,7 +101,7 @@ func TestUserService_CreateUser(t *testing.T) {
 }
 
 func TestUserService_CreateUser_EmailAlreadyExists(t *testing.T) {
-	service, mockRepo, _, ctrl := setupUserServiceTest(t)
+	service, mockRepo, ctrl := setupUserServiceTest(t)
 	defer ctrl.Finish()
 
 	req := &request.CreateUserRequest{
@@ -91,8 +121,29 @@ func TestUserService_CreateUser_EmailAlreadyExists(t *testing.T) {
 	assert.Nil(t, resp)
 }
 
+func TestUserService_CreateUser_CasedDuplicateRejected(t *testing.T) {
+	service, mockRepo, ctrl := setupUserServiceTest(t)
+	defer ctrl.Finish()
+
+	req := &request.CreateUserRequest{
+		Email:    "  Existing@Example.COM ",
+		Name:     "Test User",
+		Password: "password123",
+	}
+
+	// The lookup sees the normalized address, matching the stored lowercase one
+	mockRepo.EXPECT().
+		ExistsByEmail(gomock.Any(), "existing@example.com").
+		Return(true, nil)
+
+	resp, err := service.CreateUser(context.Background(), req)
+
+	assert.Equal(t, domain.ErrUserAlreadyExists, err)
+	assert.Nil(t, resp)
+}
+
 func TestUserService_CreateUser_ExistsCheckError(t *testing.T) {
-	service, mockRepo, _, ctrl := setupUserServiceTest(t)
+	service, mockRepo, ctrl := setupUserServiceTest(t)
 	defer ctrl.Finish()
 
 	req := &request.CreateUserRequest{
@@ -112,7 +163,7 @@ func TestUserService_CreateUser_ExistsCheckError(t *testing.T) {
 }
 
 func TestUserService_CreateUser_CreateError(t *testing.T) {
-	service, mockRepo, _, ctrl := setupUserServiceTest(t)
+	service, mockRepo, ctrl := setupUserServiceTest(t)
 	defer ctrl.Finish()
 
 	req := &request.CreateUserRequest{
@@ -136,7 +187,7 @@ func TestUserService_CreateUser_CreateError(t *testing.T) {
 }
 
 func TestUserService_Login(t *testing.T) {
-	service, mockRepo, _, ctrl := setupUserServiceTest(t)
+	service, mockRepo, ctrl := setupUserServiceTest(t)
 	defer ctrl.Finish()
 
 	password := "password123"
@@ -148,6 +199,7 @@ func TestUserService_Login(t *testing.T) {
 		Email:    "test@example.com",
 		Name:     "Test User",
 		Password: hashedPassword,
+		IsActive: true,
 	}
 
 	req := &request.LoginRequest{
@@ -158,6 +210,9 @@ func TestUserService_Login(t *testing.T) {
 	mockRepo.EXPECT().
 		FindByEmail(gomock.Any(), req.Email).
 		Return(user, nil)
+	mockRepo.EXPECT().
+		UpdateLastLogin(gomock.Any(), user.ID, gomock.Any()).
+		Return(nil)
 
 	resp, err := service.Login(context.Background(), req)
 
@@ -166,10 +221,68 @@ func TestUserService_Login(t *testing.T) {
 	assert.NotEmpty(t, resp.Token)
 	assert.Equal(t, user.Email, resp.User.Email)
 	assert.Equal(t, user.Name, resp.User.Name)
+	require.NotNil(t, resp.User.LastLoginAt)
+	assert.WithinDuration(t, time.Now(), *resp.User.LastLoginAt, time.Minute)
+}
+
+func TestUserService_Login_EmailCaseInsensitive(t *testing.T) {
+	service, mockRepo, ctrl := setupUserServiceTest(t)
+	defer ctrl.Finish()
+
+	password := "password123"
+	hashedPassword, err := crypto.HashPassword(password)
+	require.NoError(t, err)
+
+	user := &domain.User{
+		ID:       uuid.New(),
+		Email:    "test@example.com",
+		Name:     "Test User",
+		Password: hashedPassword,
+		IsActive: true,
+	}
+
+	mockRepo.EXPECT().
+		FindByEmail(gomock.Any(), user.Email).
+		Return(user, nil)
+	mockRepo.EXPECT().
+		UpdateLastLogin(gomock.Any(), user.ID, gomock.Any()).
+		Return(nil)
+
+	resp, err := service.Login(context.Background(), &request.LoginRequest{
+		Email:    " Test@EXAMPLE.com",
+		Password: password,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, user.Email, resp.User.Email)
+}
+
+func TestUserService_Login_LastLoginUpdateFailureIsIgnored(t *testing.T) {
+	service, mockRepo, ctrl := setupUserServiceTest(t)
+	defer ctrl.Finish()
+
+	password := "password123"
+	hashedPassword, err := crypto.HashPassword(password)
+	require.NoError(t, err)
+
+	user := &domain.User{ID: uuid.New(), Email: "test@example.com", Password: hashedPassword, IsActive: true}
+
+	mockRepo.EXPECT().
+		FindByEmail(gomock.Any(), user.Email).
+		Return(user, nil)
+	mockRepo.EXPECT().
+		UpdateLastLogin(gomock.Any(), user.ID, gomock.Any()).
+		Return(errors.New("connection reset"))
+
+	resp, err := service.Login(context.Background(), &request.LoginRequest{Email: user.Email, Password: password})
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.Token)
+	assert.Nil(t, resp.User.LastLoginAt)
 }
 
 func TestUserService_Login_InvalidCredentials_UserNotFound(t *testing.T) {
-	service, mockRepo, _, ctrl := setupUserServiceTest(t)
+	service, mockRepo, ctrl := setupUserServiceTest(t)
 	defer ctrl.Finish()
 
 	req := &request.LoginRequest{
@@ -189,7 +302,7 @@ func TestUserService_Login_InvalidCredentials_UserNotFound(t *testing.T) {
 }
 
 func TestUserService_Login_InvalidCredentials_WrongPassword(t *testing.T) {
-	service, mockRepo, _, ctrl := setupUserServiceTest(t)
+	service, mockRepo, ctrl := setupUserServiceTest(t)
 	defer ctrl.Finish()
 
 	hashedPassword, err := crypto.HashPassword("correctpassword")
@@ -200,6 +313,7 @@ func TestUserService_Login_InvalidCredentials_WrongPassword(t *testing.T) {
 		Email:    "test@example.com",
 		Name:     "Test User",
 		Password: hashedPassword,
+		IsActive: true,
 	}
 
 	req := &request.LoginRequest{
@@ -207,6 +321,7 @@ func TestUserService_Login_InvalidCredentials_WrongPassword(t *testing.T) {
 		Password: "wrongpassword",
 	}
 
+	// UpdateLastLogin has no expectation, so gomock fails the test if a failed login records it
 	mockRepo.EXPECT().
 		FindByEmail(gomock.Any(), req.Email).
 		Return(user, nil)
@@ -219,7 +334,7 @@ func TestUserService_Login_InvalidCredentials_WrongPassword(t *testing.T) {
 }
 
 func TestUserService_GetUserByID(t *testing.T) {
-	service, mockRepo, _, ctrl := setupUserServiceTest(t)
+	service, mockRepo, ctrl := setupUserServiceTest(t)
 	defer ctrl.Finish()
 
 	user := &domain.User{
@@ -244,7 +359,7 @@ func TestUserService_GetUserByID(t *testing.T) {
 }
 
 func TestUserService_GetUserByID_NotFound(t *testing.T) {
-	service, mockRepo, _, ctrl := setupUserServiceTest(t)
+	service, mockRepo, ctrl := setupUserServiceTest(t)
 	defer ctrl.Finish()
 
 	userID := uuid.New()
@@ -260,8 +375,61 @@ func TestUserService_GetUserByID_NotFound(t *testing.T) {
 	assert.Nil(t, resp)
 }
 
+func TestUserService_GetUserByID_WithIncludes(t *testing.T) {
+	service, mockRepo, ctrl := setupUserServiceTest(t)
+	defer ctrl.Finish()
+
+	user := &domain.User{ID: uuid.New(), Email: "test@example.com", Name: "Test User"}
+
+	mockRepo.EXPECT().
+		FindByID(gomock.Any(), user.ID, "profile").
+		Return(user, nil)
+
+	resp, err := service.GetUserByID(context.Background(), user.ID, "profile")
+
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, resp.ID)
+}
+
+func TestUserService_GetUsersByIDs(t *testing.T) {
+	service, mockRepo, ctrl := setupUserServiceTest(t)
+	defer ctrl.Finish()
+
+	user := &domain.User{
+		ID:    uuid.New(),
+		Email: "test@example.com",
+		Name:  "Test User",
+	}
+	missingID := uuid.New()
+
+	mockRepo.EXPECT().
+		FindByIDs(gomock.Any(), []uuid.UUID{user.ID, missingID}).
+		Return(map[uuid.UUID]*domain.User{user.ID: user}, nil)
+
+	resp, err := service.GetUsersByIDs(context.Background(), []uuid.UUID{user.ID, missingID})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp, 1)
+	assert.Equal(t, user.Email, resp[user.ID].Email)
+	assert.NotContains(t, resp, missingID)
+}
+
+func TestUserService_GetUsersByIDs_RepositoryError(t *testing.T) {
+	service, mockRepo, ctrl := setupUserServiceTest(t)
+	defer ctrl.Finish()
+
+	mockRepo.EXPECT().
+		FindByIDs(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("database error"))
+
+	resp, err := service.GetUsersByIDs(context.Background(), []uuid.UUID{uuid.New()})
+
+	assert.Error(t, err)
+	assert.Nil(t, resp)
+}
+
 func TestUserService_GetUserByEmail(t *testing.T) {
-	service, mockRepo, _, ctrl := setupUserServiceTest(t)
+	service, mockRepo, ctrl := setupUserServiceTest(t)
 	defer ctrl.Finish()
 
 	user := &domain.User{
@@ -285,7 +453,7 @@ func TestUserService_GetUserByEmail(t *testing.T) {
 }
 
 func TestUserService_GetUserByEmail_NotFound(t *testing.T) {
-	service, mockRepo, _, ctrl := setupUserServiceTest(t)
+	service, mockRepo, ctrl := setupUserServiceTest(t)
 	defer ctrl.Finish()
 
 	email := "notfound@example.com"
@@ -302,7 +470,7 @@ func TestUserService_GetUserByEmail_NotFound(t *testing.T) {
 }
 
 func TestUserService_UpdateUser(t *testing.T) {
-	service, mockRepo, mockCache, ctrl := setupUserServiceTest(t)
+	service, mockRepo, ctrl := setupUserServiceTest(t)
 	defer ctrl.Finish()
 
 	userID := uuid.New()
@@ -329,10 +497,6 @@ func TestUserService_UpdateUser(t *testing.T) {
 			return nil
 		})
 
-	mockCache.EXPECT().
-		Delete(gomock.Any(), gomock.Any()).
-		Return(nil)
-
 	resp, err := service.UpdateUser(context.Background(), userID, req)
 
 	assert.NoError(t, err)
@@ -341,7 +505,7 @@ func TestUserService_UpdateUser(t *testing.T) {
 }
 
 func TestUserService_UpdateUser_NotFound(t *testing.T) {
-	service, mockRepo, _, ctrl := setupUserServiceTest(t)
+	service, mockRepo, ctrl := setupUserServiceTest(t)
 	defer ctrl.Finish()
 
 	userID := uuid.New()
@@ -361,7 +525,7 @@ func TestUserService_UpdateUser_NotFound(t *testing.T) {
 }
 
 func TestUserService_UpdateUser_UpdateError(t *testing.T) {
-	service, mockRepo, _, ctrl := setupUserServiceTest(t)
+	service, mockRepo, ctrl := setupUserServiceTest(t)
 	defer ctrl.Finish()
 
 	userID := uuid.New()
@@ -392,7 +556,7 @@ func TestUserService_UpdateUser_UpdateError(t *testing.T) {
 }
 
 func TestUserService_DeleteUser(t *testing.T) {
-	service, mockRepo, mockCache, ctrl := setupUserServiceTest(t)
+	service, mockRepo, ctrl := setupUserServiceTest(t)
 	defer ctrl.Finish()
 
 	userID := uuid.New()
@@ -401,17 +565,111 @@ func TestUserService_DeleteUser(t *testing.T) {
 		Delete(gomock.Any(), userID).
 		Return(nil)
 
-	mockCache.EXPECT().
-		Delete(gomock.Any(), gomock.Any()).
-		Return(nil)
+	err := service.DeleteUser(context.Background(), userID)
+
+	assert.NoError(t, err)
+}
+
+func TestUserService_UpdateUser_RecordsAudit(t *testing.T) {
+	service, mockRepo, mockAudit, ctrl := setupUserServiceTestWithAudit(t)
+	defer ctrl.Finish()
+
+	actorID := uuid.New()
+	userID := uuid.New()
+	ctx := requestid.NewContext(auth.WithUserID(context.Background(), actorID), "req-123")
+
+	mockRepo.EXPECT().FindByID(gomock.Any(), userID).Return(&domain.User{ID: userID, Name: "Old Name"}, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+	mockAudit.EXPECT().
+		Record(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, entry *domain.AuditLog) error {
+			require.NotNil(t, entry.ActorID)
+			assert.Equal(t, actorID, *entry.ActorID)
+			assert.Equal(t, domain.AuditActionUserUpdated, entry.Action)
+			assert.Equal(t, userID, entry.TargetID)
+			assert.Equal(t, "req-123", entry.RequestID)
+			assert.False(t, entry.CreatedAt.IsZero())
+			return nil
+		})
+
+	_, err := service.UpdateUser(ctx, userID, &request.UpdateUserRequest{Name: "New Name"})
+
+	assert.NoError(t, err)
+}
+
+func TestUserService_DeleteUser_RecordsAudit(t *testing.T) {
+	service, mockRepo, mockAudit, ctrl := setupUserServiceTestWithAudit(t)
+	defer ctrl.Finish()
+
+	actorID := uuid.New()
+	userID := uuid.New()
+	ctx := auth.WithUserID(context.Background(), actorID)
+
+	mockRepo.EXPECT().Delete(gomock.Any(), userID).Return(nil)
+	mockAudit.EXPECT().
+		Record(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, entry *domain.AuditLog) error {
+			require.NotNil(t, entry.ActorID)
+			assert.Equal(t, actorID, *entry.ActorID)
+			assert.Equal(t, domain.AuditActionUserDeleted, entry.Action)
+			assert.Equal(t, userID, entry.TargetID)
+			return nil
+		})
+
+	err := service.DeleteUser(ctx, userID)
+
+	assert.NoError(t, err)
+}
+
+func TestUserService_DeleteUser_AuditFailureDoesNotFail(t *testing.T) {
+	service, mockRepo, mockAudit, ctrl := setupUserServiceTestWithAudit(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+
+	mockRepo.EXPECT().Delete(gomock.Any(), userID).Return(nil)
+	mockAudit.EXPECT().Record(gomock.Any(), gomock.Any()).Return(errors.New("database error"))
+
+	err := service.DeleteUser(context.Background(), userID)
+
+	assert.NoError(t, err)
+}
+
+func TestUserService_DeleteUser_NotFound_NoAudit(t *testing.T) {
+	service, mockRepo, _, ctrl := setupUserServiceTestWithAudit(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	mockRepo.EXPECT().Delete(gomock.Any(), userID).Return(domain.ErrUserNotFound)
 
 	err := service.DeleteUser(context.Background(), userID)
 
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func TestUserService_CreateUser_AuditWithoutActor(t *testing.T) {
+	service, mockRepo, mockAudit, ctrl := setupUserServiceTestWithAudit(t)
+	defer ctrl.Finish()
+
+	mockRepo.EXPECT().ExistsByEmail(gomock.Any(), "new@example.com").Return(false, nil)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	mockAudit.EXPECT().
+		Record(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, entry *domain.AuditLog) error {
+			assert.Nil(t, entry.ActorID, "self-registration has no authenticated actor")
+			assert.Equal(t, domain.AuditActionUserCreated, entry.Action)
+			return nil
+		})
+
+	_, err := service.CreateUser(context.Background(), &request.CreateUserRequest{
+		Email: "new@example.com", Name: "New", Password: "password123",
+	})
+
 	assert.NoError(t, err)
 }
 
 func TestUserService_DeleteUser_NotFound(t *testing.T) {
-	service, mockRepo, _, ctrl := setupUserServiceTest(t)
+	service, mockRepo, ctrl := setupUserServiceTest(t)
 	defer ctrl.Finish()
 
 	userID := uuid.New()
@@ -426,7 +684,7 @@ func TestUserService_DeleteUser_NotFound(t *testing.T) {
 }
 
 func TestUserService_ListUsers(t *testing.T) {
-	service, mockRepo, _, ctrl := setupUserServiceTest(t)
+	service, mockRepo, ctrl := setupUserServiceTest(t)
 	defer ctrl.Finish()
 
 	users := []*domain.User{
@@ -452,14 +710,14 @@ func TestUserService_ListUsers(t *testing.T) {
 	total := int64(2)
 
 	mockRepo.EXPECT().
-		List(gomock.Any(), offset, limit).
+		List(gomock.Any(), offset, limit, domain.DefaultUserSort).
 		Return(users, nil)
 
 	mockRepo.EXPECT().
 		Count(gomock.Any()).
 		Return(total, nil)
 
-	resp, totalCount, err := service.ListUsers(context.Background(), page, limit)
+	resp, totalCount, err := service.ListUsers(context.Background(), page, limit, domain.DefaultUserSort, domain.ListOptions{})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
@@ -470,7 +728,7 @@ func TestUserService_ListUsers(t *testing.T) {
 }
 
 func TestUserService_ListUsers_ListError(t *testing.T) {
-	service, mockRepo, _, ctrl := setupUserServiceTest(t)
+	service, mockRepo, ctrl := setupUserServiceTest(t)
 	defer ctrl.Finish()
 
 	page := 1
@@ -478,18 +736,115 @@ func TestUserService_ListUsers_ListError(t *testing.T) {
 	offset := 0
 
 	mockRepo.EXPECT().
-		List(gomock.Any(), offset, limit).
+		List(gomock.Any(), offset, limit, domain.DefaultUserSort).
 		Return(nil, errors.New("database error"))
 
-	resp, totalCount, err := service.ListUsers(context.Background(), page, limit)
+	resp, totalCount, err := service.ListUsers(context.Background(), page, limit, domain.DefaultUserSort, domain.ListOptions{})
 
 	assert.Error(t, err)
 	assert.Nil(t, resp)
 	assert.Equal(t, int64(0), totalCount)
 }
 
+func TestUserService_StreamUsers_WalksAllBatches(t *testing.T) {
+	service, mockRepo, ctrl := setupUserServiceTest(t)
+	defer ctrl.Finish()
+
+	base := time.Now()
+	users := make([]*domain.User, 5)
+	for i := range users {
+		users[i] = &domain.User{
+			ID:        uuid.New(),
+			Email:     fmt.Sprintf("user%d@example.com", i),
+			Name:      fmt.Sprintf("User %d", i),
+			CreatedAt: base.Add(time.Duration(i) * time.Second),
+		}
+	}
+
+	gomock.InOrder(
+		mockRepo.EXPECT().ListAfter(gomock.Any(), nil, 2).Return(users[0:2], nil),
+		mockRepo.EXPECT().
+			ListAfter(gomock.Any(), &domain.UserCursor{CreatedAt: users[1].CreatedAt, ID: users[1].ID}, 2).
+			Return(users[2:4], nil),
+		mockRepo.EXPECT().
+			ListAfter(gomock.Any(), &domain.UserCursor{CreatedAt: users[3].CreatedAt, ID: users[3].ID}, 2).
+			Return(users[4:], nil),
+	)
+
+	var got []string
+	err := service.StreamUsers(context.Background(), 2, func(u *response.UserResponse) error {
+		got = append(got, u.Email)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"user0@example.com", "user1@example.com", "user2@example.com", "user3@example.com", "user4@example.com",
+	}, got)
+}
+
+func TestUserService_StreamUsers_StopsOnCallbackError(t *testing.T) {
+	service, mockRepo, ctrl := setupUserServiceTest(t)
+	defer ctrl.Finish()
+
+	users := []*domain.User{{ID: uuid.New(), Email: "a@example.com"}, {ID: uuid.New(), Email: "b@example.com"}}
+	mockRepo.EXPECT().ListAfter(gomock.Any(), nil, 10).Return(users, nil)
+
+	sendErr := errors.New("client went away")
+	calls := 0
+	err := service.StreamUsers(context.Background(), 10, func(*response.UserResponse) error {
+		calls++
+		return sendErr
+	})
+
+	assert.ErrorIs(t, err, sendErr)
+	assert.Equal(t, 1, calls)
+}
+
+func TestUserService_StreamUsers_RepositoryError(t *testing.T) {
+	service, mockRepo, ctrl := setupUserServiceTest(t)
+	defer ctrl.Finish()
+
+	mockRepo.EXPECT().ListAfter(gomock.Any(), nil, 10).Return(nil, errors.New("database error"))
+
+	err := service.StreamUsers(context.Background(), 10, func(*response.UserResponse) error { return nil })
+
+	assert.Error(t, err)
+}
+
+func TestUserService_StreamUsers_StopsBetweenBatchesOnCancel(t *testing.T) {
+	service, mockRepo, ctrl := setupUserServiceTest(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	users := []*domain.User{{ID: uuid.New(), Email: "a@example.com"}, {ID: uuid.New(), Email: "b@example.com"}}
+	// Only the first batch is fetched, the cancellation stops the walk before the next one
+	mockRepo.EXPECT().ListAfter(gomock.Any(), nil, 2).Return(users, nil)
+
+	calls := 0
+	err := service.StreamUsers(ctx, 2, func(*response.UserResponse) error {
+		calls++
+		cancel()
+		return nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 2, calls)
+}
+
+func TestUserService_StreamUsers_InvalidBatchSize(t *testing.T) {
+	service, _, ctrl := setupUserServiceTest(t)
+	defer ctrl.Finish()
+
+	err := service.StreamUsers(context.Background(), 0, func(*response.UserResponse) error { return nil })
+
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+}
+
 func TestUserService_ListUsers_CountError(t *testing.T) {
-	service, mockRepo, _, ctrl := setupUserServiceTest(t)
+	service, mockRepo, ctrl := setupUserServiceTest(t)
 	defer ctrl.Finish()
 
 	users := []*domain.User{
@@ -507,16 +862,1020 @@ func TestUserService_ListUsers_CountError(t *testing.T) {
 	offset := 0
 
 	mockRepo.EXPECT().
-		List(gomock.Any(), offset, limit).
+		List(gomock.Any(), offset, limit, domain.DefaultUserSort).
 		Return(users, nil)
 
 	mockRepo.EXPECT().
 		Count(gomock.Any()).
 		Return(int64(0), errors.New("database error"))
 
-	resp, totalCount, err := service.ListUsers(context.Background(), page, limit)
+	resp, totalCount, err := service.ListUsers(context.Background(), page, limit, domain.DefaultUserSort, domain.ListOptions{})
 
 	assert.Error(t, err)
 	assert.Nil(t, resp)
 	assert.Equal(t, int64(0), totalCount)
 }
+
+func TestUserService_ListUsers_ApproxCount(t *testing.T) {
+	service, mockRepo, ctrl := setupUserServiceTest(t)
+	defer ctrl.Finish()
+
+	mockRepo.EXPECT().List(gomock.Any(), 0, 10, domain.DefaultUserSort).Return([]*domain.User{}, nil)
+	// The estimate replaces COUNT(*)
+	mockRepo.EXPECT().CountApprox(gomock.Any()).Return(int64(1250000), nil)
+
+	_, total, err := service.ListUsers(context.Background(), 1, 10, domain.DefaultUserSort, domain.ListOptions{ApproxCount: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1250000), total)
+}
+
+func TestUserService_CreateUser_PublishesUserCreatedEvent(t *testing.T) {
+	service, mockRepo, mockBroker, ctrl := setupUserServiceTestWithBroker(t)
+	defer ctrl.Finish()
+
+	req := &request.CreateUserRequest{
+		Email:    "test@example.com",
+		Name:     "Test User",
+		Password: "password123",
+	}
+
+	var createdID uuid.UUID
+	mockRepo.EXPECT().
+		ExistsByEmail(gomock.Any(), req.Email).
+		Return(false, nil)
+
+	mockRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, user *domain.User) error {
+			createdID = user.ID
+			return nil
+		})
+
+	mockBroker.EXPECT().
+		Publish(gomock.Any(), "user.created", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, topic string, evt domain.Event) error {
+			created, ok := evt.(*domain.UserCreatedEvent)
+			require.True(t, ok)
+			assert.Equal(t, "user.created", created.EventType())
+			assert.Equal(t, createdID.String(), created.AggregateID())
+			assert.Equal(t, req.Email, created.Email)
+			assert.Equal(t, req.Name, created.Name)
+			return nil
+		})
+
+	_, err := service.CreateUser(context.Background(), req)
+
+	assert.NoError(t, err)
+}
+
+func TestUserService_CreateUsers_PublishesCreatedEventsInOneBatch(t *testing.T) {
+	service, mockRepo, mockBroker, ctrl := setupUserServiceTestWithBroker(t)
+	defer ctrl.Finish()
+
+	reqs := []*request.CreateUserRequest{
+		{Email: "a@example.com", Name: "User A", Password: "password123"},
+		{Email: "taken@example.com", Name: "Taken", Password: "password123"},
+		{Email: "b@example.com", Name: "User B", Password: "password123"},
+	}
+
+	mockRepo.EXPECT().ExistsByEmail(gomock.Any(), "a@example.com").Return(false, nil)
+	mockRepo.EXPECT().ExistsByEmail(gomock.Any(), "taken@example.com").Return(true, nil)
+	mockRepo.EXPECT().ExistsByEmail(gomock.Any(), "b@example.com").Return(false, nil)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	// Only the created users are published, in a single call rather than one per user
+	mockBroker.EXPECT().
+		PublishBatch(gomock.Any(), "user.created", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, topic string, events []domain.Event) error {
+			require.Len(t, events, 2)
+			emails := make([]string, len(events))
+			for i, evt := range events {
+				created, ok := evt.(*domain.UserCreatedEvent)
+				require.True(t, ok)
+				emails[i] = created.Email
+			}
+			assert.ElementsMatch(t, []string{"a@example.com", "b@example.com"}, emails)
+			return nil
+		})
+
+	results, err := service.CreateUsers(context.Background(), reqs)
+
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.True(t, results[0].Success)
+	assert.False(t, results[1].Success)
+	assert.True(t, results[2].Success)
+}
+
+func TestUserService_CreateUsers_BatchPublishErrorDoesNotFail(t *testing.T) {
+	service, mockRepo, mockBroker, ctrl := setupUserServiceTestWithBroker(t)
+	defer ctrl.Finish()
+
+	reqs := []*request.CreateUserRequest{
+		{Email: "a@example.com", Name: "User A", Password: "password123"},
+	}
+
+	mockRepo.EXPECT().ExistsByEmail(gomock.Any(), "a@example.com").Return(false, nil)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	mockBroker.EXPECT().
+		PublishBatch(gomock.Any(), "user.created", gomock.Len(1)).
+		Return(errors.New("failed to publish batch message 1 of 1"))
+
+	results, err := service.CreateUsers(context.Background(), reqs)
+
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Success)
+}
+
+func TestUserService_CreateUser_PublishErrorDoesNotFail(t *testing.T) {
+	service, mockRepo, mockBroker, ctrl := setupUserServiceTestWithBroker(t)
+	defer ctrl.Finish()
+
+	req := &request.CreateUserRequest{
+		Email:    "test@example.com",
+		Name:     "Test User",
+		Password: "password123",
+	}
+
+	mockRepo.EXPECT().ExistsByEmail(gomock.Any(), req.Email).Return(false, nil)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	mockBroker.EXPECT().
+		Publish(gomock.Any(), "user.created", gomock.Any()).
+		Return(errors.New("broker unavailable"))
+
+	resp, err := service.CreateUser(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+}
+
+func TestUserService_CreateUser_EnqueuesWelcomeEmailWithoutBroker(t *testing.T) {
+	service, mockRepo, ctrl := setupUserServiceTest(t)
+	defer ctrl.Finish()
+
+	mockTasks := servicemock.NewMockTaskQueue(ctrl)
+	service.taskQueue = mockTasks
+
+	req := &request.CreateUserRequest{
+		Email:    "test@example.com",
+		Name:     "Test User",
+		Password: "password123",
+	}
+
+	var created *domain.User
+	mockRepo.EXPECT().ExistsByEmail(gomock.Any(), req.Email).Return(false, nil)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, user *domain.User) error {
+		created = user
+		return nil
+	})
+	mockTasks.EXPECT().
+		EnqueueWelcomeEmail(gomock.Any(), gomock.Any(), req.Email, req.Name).
+		DoAndReturn(func(ctx context.Context, userID uuid.UUID, email, name string) error {
+			assert.Equal(t, created.ID, userID)
+			return errors.New("redis unavailable")
+		})
+
+	// A failed enqueue must not fail registration
+	resp, err := service.CreateUser(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+}
+
+func TestUserService_UpdateUser_PublishesUserUpdatedEvent(t *testing.T) {
+	service, mockRepo, mockBroker, ctrl := setupUserServiceTestWithBroker(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	user := &domain.User{
+		ID:    userID,
+		Email: "test@example.com",
+		Name:  "Old Name",
+	}
+
+	req := &request.UpdateUserRequest{
+		Name: "New Name",
+	}
+
+	mockRepo.EXPECT().FindByID(gomock.Any(), userID).Return(user, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+	mockBroker.EXPECT().
+		Publish(gomock.Any(), "user.updated", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, topic string, evt domain.Event) error {
+			updated, ok := evt.(*domain.UserUpdatedEvent)
+			require.True(t, ok)
+			assert.Equal(t, userID.String(), updated.AggregateID())
+			assert.Equal(t, req.Name, updated.Name)
+			return nil
+		})
+
+	_, err := service.UpdateUser(context.Background(), userID, req)
+
+	assert.NoError(t, err)
+}
+
+func TestUserService_DeleteUser_PublishesUserDeletedEvent(t *testing.T) {
+	service, mockRepo, mockBroker, ctrl := setupUserServiceTestWithBroker(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+
+	mockRepo.EXPECT().Delete(gomock.Any(), userID).Return(nil)
+
+	mockBroker.EXPECT().
+		Publish(gomock.Any(), "user.deleted", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, topic string, evt domain.Event) error {
+			deleted, ok := evt.(*domain.UserDeletedEvent)
+			require.True(t, ok)
+			assert.Equal(t, userID.String(), deleted.AggregateID())
+			return nil
+		})
+
+	err := service.DeleteUser(context.Background(), userID)
+
+	assert.NoError(t, err)
+}
+
+func TestUserService_DeleteUser_NotFound_DoesNotPublish(t *testing.T) {
+	service, mockRepo, _, ctrl := setupUserServiceTestWithBroker(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+
+	// No Publish expectation: gomock fails the test on any unexpected call
+	mockRepo.EXPECT().Delete(gomock.Any(), userID).Return(domain.ErrUserNotFound)
+
+	err := service.DeleteUser(context.Background(), userID)
+
+	assert.Error(t, err)
+}
+
+func TestUserService_Login_PublishesUserLoggedInEvent(t *testing.T) {
+	service, mockRepo, mockBroker, ctrl := setupUserServiceTestWithBroker(t)
+	defer ctrl.Finish()
+
+	password := "password123"
+	hashedPassword, err := crypto.HashPassword(password)
+	require.NoError(t, err)
+
+	user := &domain.User{
+		ID:       uuid.New(),
+		Email:    "test@example.com",
+		Name:     "Test User",
+		Password: hashedPassword,
+		IsActive: true,
+	}
+
+	mockRepo.EXPECT().FindByEmail(gomock.Any(), user.Email).Return(user, nil)
+	mockRepo.EXPECT().UpdateLastLogin(gomock.Any(), user.ID, gomock.Any()).Return(nil)
+
+	mockBroker.EXPECT().
+		Publish(gomock.Any(), "user.logged_in", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, topic string, evt domain.Event) error {
+			loggedIn, ok := evt.(*domain.UserLoggedInEvent)
+			require.True(t, ok)
+			assert.Equal(t, user.ID.String(), loggedIn.AggregateID())
+			assert.Equal(t, user.Email, loggedIn.Email)
+			return nil
+		})
+
+	_, err = service.Login(context.Background(), &request.LoginRequest{
+		Email:    user.Email,
+		Password: password,
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestUserService_Login_AccountInactive(t *testing.T) {
+	service, mockRepo, ctrl := setupUserServiceTest(t)
+	defer ctrl.Finish()
+
+	password := "password123"
+	hashedPassword, err := crypto.HashPassword(password)
+	require.NoError(t, err)
+
+	user := &domain.User{ID: uuid.New(), Email: "test@example.com", Password: hashedPassword, IsActive: false}
+
+	mockRepo.EXPECT().
+		FindByEmail(gomock.Any(), user.Email).
+		Return(user, nil)
+
+	resp, err := service.Login(context.Background(), &request.LoginRequest{Email: user.Email, Password: password})
+
+	assert.ErrorIs(t, err, domain.ErrAccountInactive)
+	assert.Nil(t, resp)
+}
+
+func TestUserService_SetActive_Deactivate(t *testing.T) {
+	service, mockRepo, ctrl := setupUserServiceTest(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+
+	gomock.InOrder(
+		mockRepo.EXPECT().SetActive(gomock.Any(), userID, false).Return(nil),
+		mockRepo.EXPECT().IncrementTokenVersion(gomock.Any(), userID).Return(1, nil),
+	)
+
+	err := service.SetActive(context.Background(), userID, false)
+
+	assert.NoError(t, err)
+}
+
+func TestUserService_SetActive_ReactivateAllowsLogin(t *testing.T) {
+	service, mockRepo, ctrl := setupUserServiceTest(t)
+	defer ctrl.Finish()
+
+	password := "password123"
+	hashedPassword, err := crypto.HashPassword(password)
+	require.NoError(t, err)
+
+	user := &domain.User{ID: uuid.New(), Email: "test@example.com", Password: hashedPassword, IsActive: false}
+
+	// Reactivation flips the flag without revoking tokens again
+	mockRepo.EXPECT().
+		SetActive(gomock.Any(), user.ID, true).
+		DoAndReturn(func(_ context.Context, _ uuid.UUID, active bool) error {
+			user.IsActive = active
+			return nil
+		})
+
+	require.NoError(t, service.SetActive(context.Background(), user.ID, true))
+
+	mockRepo.EXPECT().FindByEmail(gomock.Any(), user.Email).Return(user, nil)
+	mockRepo.EXPECT().UpdateLastLogin(gomock.Any(), user.ID, gomock.Any()).Return(nil)
+
+	resp, err := service.Login(context.Background(), &request.LoginRequest{Email: user.Email, Password: password})
+
+	require.NoError(t, err)
+	assert.True(t, resp.User.IsActive)
+}
+
+func TestUserService_SetActive_NotFound(t *testing.T) {
+	service, mockRepo, ctrl := setupUserServiceTest(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+
+	mockRepo.EXPECT().
+		SetActive(gomock.Any(), userID, false).
+		Return(domain.ErrUserNotFound)
+
+	err := service.SetActive(context.Background(), userID, false)
+
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func TestUserService_RevokeAllTokens(t *testing.T) {
+	service, mockRepo, ctrl := setupUserServiceTest(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+
+	mockRepo.EXPECT().
+		IncrementTokenVersion(gomock.Any(), userID).
+		Return(2, nil)
+
+	err := service.RevokeAllTokens(context.Background(), userID)
+
+	assert.NoError(t, err)
+}
+
+func TestUserService_RevokeAllTokens_NotFound(t *testing.T) {
+	service, mockRepo, ctrl := setupUserServiceTest(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+
+	mockRepo.EXPECT().
+		IncrementTokenVersion(gomock.Any(), userID).
+		Return(0, domain.ErrUserNotFound)
+
+	err := service.RevokeAllTokens(context.Background(), userID)
+
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func TestUserService_GetTokenVersion(t *testing.T) {
+	service, mockRepo, ctrl := setupUserServiceTest(t)
+	defer ctrl.Finish()
+
+	user := &domain.User{ID: uuid.New(), TokenVersion: 1}
+
+	mockRepo.EXPECT().
+		FindByID(gomock.Any(), user.ID).
+		Return(user, nil)
+
+	version, err := service.GetTokenVersion(context.Background(), user.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, version)
+}
+
+func TestUserService_DeleteUsers(t *testing.T) {
+	service, mockRepo, ctrl := setupUserServiceTest(t)
+	defer ctrl.Finish()
+
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+
+	mockRepo.EXPECT().DeleteBatch(gomock.Any(), ids).Return(int64(2), []uuid.UUID{}, nil)
+
+	result, err := service.DeleteUsers(context.Background(), ids)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.Deleted)
+	assert.Empty(t, result.NotFound)
+}
+
+func TestUserService_DeleteUsers_PartialNotFound(t *testing.T) {
+	service, mockRepo, mockBroker, ctrl := setupUserServiceTestWithBroker(t)
+	defer ctrl.Finish()
+
+	deletedID := uuid.New()
+	missingID := uuid.New()
+	ids := []uuid.UUID{deletedID, missingID, deletedID}
+
+	mockRepo.EXPECT().DeleteBatch(gomock.Any(), ids).Return(int64(1), []uuid.UUID{missingID}, nil)
+
+	// Only the deleted user is invalidated and announced, once despite the duplicate
+	mockBroker.EXPECT().
+		Publish(gomock.Any(), "user.deleted", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, message interface{}) error {
+			event, ok := message.(*domain.UserDeletedEvent)
+			require.True(t, ok)
+			assert.Equal(t, deletedID.String(), event.AggregateID())
+			return nil
+		})
+
+	result, err := service.DeleteUsers(context.Background(), ids)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.Deleted)
+	assert.Equal(t, []uuid.UUID{missingID}, result.NotFound)
+}
+
+func TestUserService_DeleteUsers_RepositoryError(t *testing.T) {
+	service, mockRepo, ctrl := setupUserServiceTest(t)
+	defer ctrl.Finish()
+
+	dbErr := errors.New("connection reset")
+	mockRepo.EXPECT().DeleteBatch(gomock.Any(), gomock.Any()).Return(int64(0), nil, dbErr)
+
+	// Nothing was deleted, so nothing is invalidated or announced
+	result, err := service.DeleteUsers(context.Background(), []uuid.UUID{uuid.New()})
+
+	assert.ErrorIs(t, err, dbErr)
+	assert.Nil(t, result)
+}
+
+func TestUserService_DeleteUsers_NoIDs(t *testing.T) {
+	service, _, ctrl := setupUserServiceTest(t)
+	defer ctrl.Finish()
+
+	result, err := service.DeleteUsers(context.Background(), nil)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	assert.Nil(t, result)
+}
+
+func TestUserService_SearchUsers(t *testing.T) {
+	service, mockRepo, ctrl := setupUserServiceTest(t)
+	defer ctrl.Finish()
+
+	users := []*domain.User{
+		{ID: uuid.New(), Email: "john@example.com", Name: "John Doe", IsActive: true},
+	}
+
+	mockRepo.EXPECT().
+		Search(gomock.Any(), "john doe", 20).
+		Return(users, nil)
+
+	result, err := service.SearchUsers(context.Background(), "  john doe ", 20)
+
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, users[0].ID, result[0].ID)
+	assert.Equal(t, "john@example.com", result[0].Email)
+}
+
+func TestUserService_SearchUsers_EmptyQuery(t *testing.T) {
+	service, _, ctrl := setupUserServiceTest(t)
+	defer ctrl.Finish()
+
+	result, err := service.SearchUsers(context.Background(), "   ", 20)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	assert.Nil(t, result)
+}
+
+func TestUserService_SearchUsers_RepositoryError(t *testing.T) {
+	service, mockRepo, ctrl := setupUserServiceTest(t)
+	defer ctrl.Finish()
+
+	mockRepo.EXPECT().
+		Search(gomock.Any(), "john", 20).
+		Return(nil, errors.New("database error"))
+
+	result, err := service.SearchUsers(context.Background(), "john", 20)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to search users")
+	assert.Nil(t, result)
+}
+
+func TestUserService_Login_StoresLoggedInEvent(t *testing.T) {
+	service, mockRepo, mockStore, ctrl := setupUserServiceTestWithEventStore(t)
+	defer ctrl.Finish()
+
+	hashedPassword, err := crypto.HashPassword("password123")
+	require.NoError(t, err)
+	user := &domain.User{ID: uuid.New(), Email: "test@example.com", Password: hashedPassword, IsActive: true}
+
+	mockRepo.EXPECT().FindByEmail(gomock.Any(), user.Email).Return(user, nil)
+	mockRepo.EXPECT().UpdateLastLogin(gomock.Any(), user.ID, gomock.Any()).Return(nil)
+	mockStore.EXPECT().
+		Append(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, events ...*domain.StoredEvent) error {
+			require.Len(t, events, 1)
+			assert.Equal(t, "user.logged_in", events[0].Type)
+			assert.Equal(t, user.ID, events[0].AggregateID)
+			assert.Contains(t, string(events[0].Payload), `"email":"test@example.com"`)
+			return nil
+		})
+
+	_, err = service.Login(context.Background(), &request.LoginRequest{Email: user.Email, Password: "password123"})
+	assert.NoError(t, err)
+}
+
+func TestUserService_DeleteUser_EventStoreErrorDoesNotFail(t *testing.T) {
+	service, mockRepo, mockStore, ctrl := setupUserServiceTestWithEventStore(t)
+	defer ctrl.Finish()
+
+	id := uuid.New()
+	mockRepo.EXPECT().Delete(gomock.Any(), id).Return(nil)
+	mockStore.EXPECT().Append(gomock.Any(), gomock.Any()).Return(errors.New("database error"))
+
+	assert.NoError(t, service.DeleteUser(context.Background(), id))
+}
+
+func TestUserService_ListUserEvents(t *testing.T) {
+	service, _, mockStore, ctrl := setupUserServiceTestWithEventStore(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	stored, err := domain.NewStoredEvent(domain.NewUserUpdatedEvent(userID, "Jane"))
+	require.NoError(t, err)
+
+	// Page 3 of 5 skips the first 10 events
+	mockStore.EXPECT().
+		List(gomock.Any(), userID, 10, 5).
+		Return([]*domain.StoredEvent{stored}, int64(11), nil)
+
+	events, total, err := service.ListUserEvents(context.Background(), userID, 3, 5)
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), total)
+	require.Len(t, events, 1)
+	assert.Equal(t, stored.ID, events[0].ID)
+	assert.Equal(t, "user.updated", events[0].Type)
+	assert.JSONEq(t, string(stored.Payload), string(events[0].Data))
+}
+
+func TestUserService_CancelledContextSkipsRepository(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name string
+		call func(ctx context.Context, s *UserService) error
+	}{
+		{"CreateUser", func(ctx context.Context, s *UserService) error {
+			_, err := s.CreateUser(ctx, &request.CreateUserRequest{Email: "jane@example.com", Name: "Jane", Password: "password123"})
+			return err
+		}},
+		{"CreateUsers", func(ctx context.Context, s *UserService) error {
+			_, err := s.CreateUsers(ctx, []*request.CreateUserRequest{{Email: "jane@example.com", Name: "Jane", Password: "password123"}})
+			return err
+		}},
+		{"GetUserByID", func(ctx context.Context, s *UserService) error {
+			_, err := s.GetUserByID(ctx, id)
+			return err
+		}},
+		{"GetUserByEmail", func(ctx context.Context, s *UserService) error {
+			_, err := s.GetUserByEmail(ctx, "jane@example.com")
+			return err
+		}},
+		{"GetUsersByIDs", func(ctx context.Context, s *UserService) error {
+			_, err := s.GetUsersByIDs(ctx, []uuid.UUID{id})
+			return err
+		}},
+		{"UpdateUser", func(ctx context.Context, s *UserService) error {
+			_, err := s.UpdateUser(ctx, id, &request.UpdateUserRequest{Name: "Jane"})
+			return err
+		}},
+		{"DeleteUser", func(ctx context.Context, s *UserService) error {
+			return s.DeleteUser(ctx, id)
+		}},
+		{"DeleteUsers", func(ctx context.Context, s *UserService) error {
+			_, err := s.DeleteUsers(ctx, []uuid.UUID{id})
+			return err
+		}},
+		{"RevokeAllTokens", func(ctx context.Context, s *UserService) error {
+			return s.RevokeAllTokens(ctx, id)
+		}},
+		{"SetActive", func(ctx context.Context, s *UserService) error {
+			return s.SetActive(ctx, id, false)
+		}},
+		{"GetTokenVersion", func(ctx context.Context, s *UserService) error {
+			_, err := s.GetTokenVersion(ctx, id)
+			return err
+		}},
+		{"Login", func(ctx context.Context, s *UserService) error {
+			_, err := s.Login(ctx, &request.LoginRequest{Email: "jane@example.com", Password: "password123"})
+			return err
+		}},
+		{"ListUsers", func(ctx context.Context, s *UserService) error {
+			_, _, err := s.ListUsers(ctx, 1, 10, domain.DefaultUserSort, domain.ListOptions{})
+			return err
+		}},
+		{"ListUserEvents", func(ctx context.Context, s *UserService) error {
+			_, _, err := s.ListUserEvents(ctx, id, 1, 10)
+			return err
+		}},
+		{"StreamUsers", func(ctx context.Context, s *UserService) error {
+			return s.StreamUsers(ctx, 10, func(*response.UserResponse) error { return nil })
+		}},
+		{"SearchUsers", func(ctx context.Context, s *UserService) error {
+			_, err := s.SearchUsers(ctx, "jane", 10)
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The mocks have no expectations, any repository or event store call fails the test
+			service, _, _, ctrl := setupUserServiceTestWithEventStore(t)
+			defer ctrl.Finish()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			assert.ErrorIs(t, tt.call(ctx, service), context.Canceled)
+		})
+	}
+}
+
+// fakeMetrics records counter increments by name and tags
+type fakeMetrics struct {
+	mu       sync.Mutex
+	counters map[string]float64
+}
+
+func newFakeMetrics() *fakeMetrics {
+	return &fakeMetrics{counters: make(map[string]float64)}
+}
+
+// counter returns the total of name, with tags written as k=v pairs in the key
+func (m *fakeMetrics) counter(name string, tags ...string) float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.counters[strings.Join(append([]string{name}, tags...), ",")]
+}
+
+func (m *fakeMetrics) IncrementCounter(name string, tags map[string]string, value float64) {
+	key := []string{name}
+	for k, v := range tags {
+		key = append(key, k+"="+v)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.counters[strings.Join(key, ",")] += value
+}
+func (m *fakeMetrics) SetGauge(name string, tags map[string]string, value float64)           {}
+func (m *fakeMetrics) RecordHistogram(name string, tags map[string]string, value float64)    {}
+func (m *fakeMetrics) RecordDistribution(name string, tags map[string]string, value float64) {}
+func (m *fakeMetrics) RecordTiming(name string, tags map[string]string, d time.Duration)     {}
+func (m *fakeMetrics) Close() error                                                          { return nil }
+
+func setupUserServiceTestWithMetrics(t *testing.T) (*UserService, *mock.MockUserRepository, *fakeMetrics, *gomock.Controller) {
+	service, mockRepo, ctrl := setupUserServiceTest(t)
+	metrics := newFakeMetrics()
+	service.metrics = metrics
+
+	return service, mockRepo, metrics, ctrl
+}
+
+func TestUserService_CreateUser_CountsCreatedUser(t *testing.T) {
+	service, mockRepo, metrics, ctrl := setupUserServiceTestWithMetrics(t)
+	defer ctrl.Finish()
+
+	mockRepo.EXPECT().ExistsByEmail(gomock.Any(), "jane@example.com").Return(false, nil)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	_, err := service.CreateUser(context.Background(), &request.CreateUserRequest{
+		Email: "jane@example.com", Name: "Jane", Password: "password123",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, float64(1), metrics.counter(metricUsersCreated))
+}
+
+func TestUserService_CreateUser_FailureNotCounted(t *testing.T) {
+	service, mockRepo, metrics, ctrl := setupUserServiceTestWithMetrics(t)
+	defer ctrl.Finish()
+
+	mockRepo.EXPECT().ExistsByEmail(gomock.Any(), "jane@example.com").Return(true, nil)
+
+	_, err := service.CreateUser(context.Background(), &request.CreateUserRequest{
+		Email: "jane@example.com", Name: "Jane", Password: "password123",
+	})
+
+	assert.ErrorIs(t, err, domain.ErrUserAlreadyExists)
+	assert.Zero(t, metrics.counter(metricUsersCreated))
+}
+
+func TestUserService_Login_CountsSuccess(t *testing.T) {
+	service, mockRepo, metrics, ctrl := setupUserServiceTestWithMetrics(t)
+	defer ctrl.Finish()
+
+	hashedPassword, err := crypto.HashPassword("password123")
+	require.NoError(t, err)
+	user := &domain.User{ID: uuid.New(), Email: "jane@example.com", Password: hashedPassword, IsActive: true}
+
+	mockRepo.EXPECT().FindByEmail(gomock.Any(), user.Email).Return(user, nil)
+	mockRepo.EXPECT().UpdateLastLogin(gomock.Any(), user.ID, gomock.Any()).Return(nil)
+
+	_, err = service.Login(context.Background(), &request.LoginRequest{Email: user.Email, Password: "password123"})
+
+	require.NoError(t, err)
+	assert.Equal(t, float64(1), metrics.counter(metricUsersLoginsSuccess))
+	assert.Zero(t, metrics.counter(metricUsersLoginsFailed, "reason="+loginFailureWrongPassword))
+}
+
+func TestUserService_Login_CountsFailureByReason(t *testing.T) {
+	hashedPassword, err := crypto.HashPassword("password123")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		user     *domain.User
+		findErr  error
+		password string
+		wantErr  error
+		reason   string
+	}{
+		{
+			name:     "unknown email",
+			findErr:  domain.ErrUserNotFound,
+			password: "password123",
+			wantErr:  domain.ErrInvalidCredentials,
+			reason:   loginFailureNotFound,
+		},
+		{
+			name:     "lookup error",
+			findErr:  errors.New("connection refused"),
+			password: "password123",
+			wantErr:  domain.ErrInvalidCredentials,
+			reason:   loginFailureLookupError,
+		},
+		{
+			name:     "wrong password",
+			user:     &domain.User{ID: uuid.New(), Email: "jane@example.com", Password: hashedPassword, IsActive: true},
+			password: "wrongpassword",
+			wantErr:  domain.ErrInvalidCredentials,
+			reason:   loginFailureWrongPassword,
+		},
+		{
+			name:     "inactive account",
+			user:     &domain.User{ID: uuid.New(), Email: "jane@example.com", Password: hashedPassword},
+			password: "password123",
+			wantErr:  domain.ErrAccountInactive,
+			reason:   loginFailureInactive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo, metrics, ctrl := setupUserServiceTestWithMetrics(t)
+			defer ctrl.Finish()
+
+			mockRepo.EXPECT().FindByEmail(gomock.Any(), "jane@example.com").Return(tt.user, tt.findErr)
+
+			_, err := service.Login(context.Background(), &request.LoginRequest{Email: "jane@example.com", Password: tt.password})
+
+			// The reason only shows up in the metric, not found and wrong password look the same to the client
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, float64(1), metrics.counter(metricUsersLoginsFailed, "reason="+tt.reason))
+			assert.Zero(t, metrics.counter(metricUsersLoginsSuccess))
+		})
+	}
+}
+
+func TestUserService_DeleteUsers_CountsDeletedUsers(t *testing.T) {
+	service, mockRepo, metrics, ctrl := setupUserServiceTestWithMetrics(t)
+	defer ctrl.Finish()
+
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	mockRepo.EXPECT().DeleteBatch(gomock.Any(), ids).Return(int64(2), []uuid.UUID{ids[2]}, nil)
+
+	_, err := service.DeleteUsers(context.Background(), ids)
+
+	require.NoError(t, err)
+	assert.Equal(t, float64(2), metrics.counter(metricUsersDeleted))
+}
+
+func setupUserServiceTestWithEmailQueue(t *testing.T) (*UserService, *mock.MockUserRepository, *servicemock.MockTaskQueue, *gomock.Controller) {
+	service, mockRepo, ctrl := setupUserServiceTest(t)
+	mockQueue := servicemock.NewMockTaskQueue(ctrl)
+	service.emailQueue = mockQueue
+
+	return service, mockRepo, mockQueue, ctrl
+}
+
+func TestUserService_ChangeEmail_MailsTokenAndKeepsCurrentEmail(t *testing.T) {
+	service, mockRepo, mockQueue, ctrl := setupUserServiceTestWithEmailQueue(t)
+	defer ctrl.Finish()
+
+	user := &domain.User{ID: uuid.New(), Email: "jane@example.com", EmailVerified: true}
+
+	var mailedToken string
+	mockRepo.EXPECT().FindByID(gomock.Any(), user.ID).Return(user, nil)
+	mockRepo.EXPECT().ExistsByEmail(gomock.Any(), "jane@new.example.com").Return(false, nil)
+	mockRepo.EXPECT().
+		UpdateEmail(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, saved *domain.User) error {
+			assert.Equal(t, "jane@example.com", saved.Email, "the email only changes once verified")
+			assert.False(t, saved.EmailVerified)
+			require.NotNil(t, saved.PendingEmail)
+			assert.Equal(t, "jane@new.example.com", *saved.PendingEmail)
+			require.NotNil(t, saved.EmailVerificationExpiresAt)
+			assert.WithinDuration(t, time.Now().Add(emailVerificationTTL), *saved.EmailVerificationExpiresAt, time.Minute)
+			return nil
+		})
+	mockQueue.EXPECT().
+		EnqueueEmailVerification(gomock.Any(), user.ID, "jane@new.example.com", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id uuid.UUID, email, token string, expiresAt time.Time) error {
+			mailedToken = token
+			return nil
+		})
+
+	require.NoError(t, service.ChangeEmail(context.Background(), user.ID, " Jane@New.Example.com "))
+
+	// Only the hash of the mailed token is stored
+	require.NotEmpty(t, mailedToken)
+	require.NotNil(t, user.EmailVerificationToken)
+	assert.NotEqual(t, mailedToken, *user.EmailVerificationToken)
+	assert.Equal(t, hashVerificationToken(mailedToken), *user.EmailVerificationToken)
+}
+
+func TestUserService_ChangeEmail_Taken(t *testing.T) {
+	service, mockRepo, _, ctrl := setupUserServiceTestWithEmailQueue(t)
+	defer ctrl.Finish()
+
+	user := &domain.User{ID: uuid.New(), Email: "jane@example.com"}
+	mockRepo.EXPECT().FindByID(gomock.Any(), user.ID).Return(user, nil)
+	mockRepo.EXPECT().ExistsByEmail(gomock.Any(), "john@example.com").Return(true, nil)
+
+	// Neither UpdateEmail nor the queue has an expectation, a taken email changes nothing
+	err := service.ChangeEmail(context.Background(), user.ID, "john@example.com")
+
+	assert.ErrorIs(t, err, domain.ErrUserAlreadyExists)
+	assert.Nil(t, user.PendingEmail)
+}
+
+func TestUserService_ChangeEmail_SameEmail(t *testing.T) {
+	service, mockRepo, _, ctrl := setupUserServiceTestWithEmailQueue(t)
+	defer ctrl.Finish()
+
+	user := &domain.User{ID: uuid.New(), Email: "jane@example.com"}
+	mockRepo.EXPECT().FindByID(gomock.Any(), user.ID).Return(user, nil)
+
+	err := service.ChangeEmail(context.Background(), user.ID, "JANE@example.com")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+}
+
+func TestUserService_ChangeEmail_UnavailableWithoutQueue(t *testing.T) {
+	service, _, ctrl := setupUserServiceTest(t)
+	defer ctrl.Finish()
+
+	assert.Error(t, service.ChangeEmail(context.Background(), uuid.New(), "jane@new.example.com"))
+}
+
+// pendingEmailChange returns a user waiting to switch to jane@new.example.com, verified with the returned token
+func pendingEmailChange(expiresAt time.Time) (*domain.User, string) {
+	user := &domain.User{ID: uuid.New(), Email: "jane@example.com", Name: "Jane"}
+	token := "mailed-token"
+	user.RequestEmailChange("jane@new.example.com", hashVerificationToken(token), expiresAt)
+	return user, token
+}
+
+// expectTx gives service a transactor expecting one transaction, whose function it runs right away
+func expectTx(service *UserService, ctrl *gomock.Controller) {
+	transactor := mock.NewMockTransactor(ctrl)
+	transactor.EXPECT().
+		WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+	service.transactor = transactor
+}
+
+func TestUserService_VerifyEmail_PendingBecomesVerified(t *testing.T) {
+	service, mockRepo, auditRepo, ctrl := setupUserServiceTestWithAudit(t)
+	defer ctrl.Finish()
+
+	user, token := pendingEmailChange(time.Now().Add(time.Hour))
+	assert.False(t, user.EmailVerified)
+
+	expectTx(service, ctrl)
+	mockRepo.EXPECT().FindByID(gomock.Any(), user.ID).Return(user, nil)
+	mockRepo.EXPECT().ExistsByEmail(gomock.Any(), "jane@new.example.com").Return(false, nil)
+	mockRepo.EXPECT().
+		UpdateEmail(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, saved *domain.User) error {
+			assert.Equal(t, "jane@new.example.com", saved.Email)
+			assert.True(t, saved.EmailVerified)
+			assert.Nil(t, saved.PendingEmail)
+			assert.Nil(t, saved.EmailVerificationToken)
+			assert.Nil(t, saved.EmailVerificationExpiresAt)
+			return nil
+		})
+	auditRepo.EXPECT().
+		Record(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, entry *domain.AuditLog) error {
+			assert.Equal(t, domain.AuditActionUserEmailChanged, entry.Action)
+			return nil
+		})
+
+	resp, err := service.VerifyEmail(context.Background(), user.ID, token)
+
+	require.NoError(t, err)
+	assert.Equal(t, "jane@new.example.com", resp.Email)
+	assert.True(t, resp.EmailVerified)
+	assert.Nil(t, resp.PendingEmail)
+}
+
+func TestUserService_VerifyEmail_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		user    func() (*domain.User, string)
+		wantErr error
+	}{
+		{
+			name: "wrong token",
+			user: func() (*domain.User, string) {
+				user, _ := pendingEmailChange(time.Now().Add(time.Hour))
+				return user, "guessed-token"
+			},
+			wantErr: domain.ErrInvalidVerificationToken,
+		},
+		{
+			name: "expired token",
+			user: func() (*domain.User, string) {
+				return pendingEmailChange(time.Now().Add(-time.Minute))
+			},
+			wantErr: domain.ErrInvalidVerificationToken,
+		},
+		{
+			name: "nothing pending",
+			user: func() (*domain.User, string) {
+				return &domain.User{ID: uuid.New(), Email: "jane@example.com"}, "mailed-token"
+			},
+			wantErr: domain.ErrNoPendingEmailChange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo, ctrl := setupUserServiceTest(t)
+			defer ctrl.Finish()
+
+			user, token := tt.user()
+			expectTx(service, ctrl)
+			mockRepo.EXPECT().FindByID(gomock.Any(), user.ID).Return(user, nil)
+
+			// UpdateEmail has no expectation, a rejected token leaves the email alone
+			_, err := service.VerifyEmail(context.Background(), user.ID, token)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, "jane@example.com", user.Email)
+		})
+	}
+}
+
+func TestUserService_VerifyEmail_TakenMeanwhile(t *testing.T) {
+	service, mockRepo, ctrl := setupUserServiceTest(t)
+	defer ctrl.Finish()
+
+	user, token := pendingEmailChange(time.Now().Add(time.Hour))
+	expectTx(service, ctrl)
+	mockRepo.EXPECT().FindByID(gomock.Any(), user.ID).Return(user, nil)
+	mockRepo.EXPECT().ExistsByEmail(gomock.Any(), "jane@new.example.com").Return(true, nil)
+
+	_, err := service.VerifyEmail(context.Background(), user.ID, token)
+
+	assert.ErrorIs(t, err, domain.ErrUserAlreadyExists)
+}
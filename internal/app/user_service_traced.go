@@ -0,0 +1,218 @@
+package app
+
+import (
+	"context"
+
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/gieart87/gohexaclean/internal/dto/request"
+	"github.com/gieart87/gohexaclean/internal/dto/response"
+	"github.com/gieart87/gohexaclean/internal/port/inbound"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/telemetry"
+	"github.com/google/uuid"
+)
+
+// TracedUserService decorates a UserServicePort with a child span per call
+// so traces show the time spent in the service apart from the transport and the queries below it
+// Spans are tagged with IDs and sizes only, emails, names and passwords stay out of traces
+type TracedUserService struct {
+	inner   inbound.UserServicePort
+	tracing telemetry.TracingService
+}
+
+// NewTracedUserService wraps inner, starting a UserService.<method> span around every call
+func NewTracedUserService(inner inbound.UserServicePort, tracing telemetry.TracingService) inbound.UserServicePort {
+	return &TracedUserService{
+		inner:   inner,
+		tracing: tracing,
+	}
+}
+
+// startSpan starts the child span of method
+func (s *TracedUserService) startSpan(ctx context.Context, method string) (telemetry.Span, context.Context) {
+	span, ctx := s.tracing.StartChildSpan(ctx, "UserService."+method)
+	span.SetTag("service.name", "UserService")
+	span.SetTag("service.method", method)
+	return span, ctx
+}
+
+// finishSpan records err on span, if any, and finishes it
+func finishSpan(span telemetry.Span, err error) {
+	if err != nil {
+		span.SetError(err)
+	}
+	span.Finish()
+}
+
+// CreateUser traces registration, tagging the new user's ID once it exists
+func (s *TracedUserService) CreateUser(ctx context.Context, req *request.CreateUserRequest) (resp *response.LoginResponse, err error) {
+	span, ctx := s.startSpan(ctx, "CreateUser")
+	defer func() { finishSpan(span, err) }()
+
+	resp, err = s.inner.CreateUser(ctx, req)
+	if err == nil && resp != nil && resp.User != nil {
+		span.SetTag("user.id", resp.User.ID.String())
+	}
+	return resp, err
+}
+
+// GetUserByID traces the lookup tagged with the user ID
+func (s *TracedUserService) GetUserByID(ctx context.Context, id uuid.UUID, includes ...string) (resp *response.UserResponse, err error) {
+	span, ctx := s.startSpan(ctx, "GetUserByID")
+	defer func() { finishSpan(span, err) }()
+	span.SetTag("user.id", id.String())
+
+	return s.inner.GetUserByID(ctx, id, includes...)
+}
+
+// GetUserByEmail traces the lookup, the email is left untagged
+func (s *TracedUserService) GetUserByEmail(ctx context.Context, email string) (resp *response.UserResponse, err error) {
+	span, ctx := s.startSpan(ctx, "GetUserByEmail")
+	defer func() { finishSpan(span, err) }()
+
+	return s.inner.GetUserByEmail(ctx, email)
+}
+
+// GetUsersByIDs traces the batch lookup tagged with the number of IDs
+func (s *TracedUserService) GetUsersByIDs(ctx context.Context, ids []uuid.UUID) (users map[uuid.UUID]*response.UserResponse, err error) {
+	span, ctx := s.startSpan(ctx, "GetUsersByIDs")
+	defer func() { finishSpan(span, err) }()
+	span.SetTag("users.count", len(ids))
+
+	return s.inner.GetUsersByIDs(ctx, ids)
+}
+
+// UpdateUser traces the update tagged with the user ID
+func (s *TracedUserService) UpdateUser(ctx context.Context, id uuid.UUID, req *request.UpdateUserRequest) (resp *response.UserResponse, err error) {
+	span, ctx := s.startSpan(ctx, "UpdateUser")
+	defer func() { finishSpan(span, err) }()
+	span.SetTag("user.id", id.String())
+
+	return s.inner.UpdateUser(ctx, id, req)
+}
+
+// DeleteUser traces the delete tagged with the user ID
+func (s *TracedUserService) DeleteUser(ctx context.Context, id uuid.UUID) (err error) {
+	span, ctx := s.startSpan(ctx, "DeleteUser")
+	defer func() { finishSpan(span, err) }()
+	span.SetTag("user.id", id.String())
+
+	return s.inner.DeleteUser(ctx, id)
+}
+
+// CreateUsers traces the bulk create tagged with the number of requests
+func (s *TracedUserService) CreateUsers(ctx context.Context, reqs []*request.CreateUserRequest) (results []*response.BulkItemResult, err error) {
+	span, ctx := s.startSpan(ctx, "CreateUsers")
+	defer func() { finishSpan(span, err) }()
+	span.SetTag("users.count", len(reqs))
+
+	return s.inner.CreateUsers(ctx, reqs)
+}
+
+// DeleteUsers traces the bulk delete tagged with the number of IDs
+func (s *TracedUserService) DeleteUsers(ctx context.Context, ids []uuid.UUID) (resp *response.BulkDeleteResponse, err error) {
+	span, ctx := s.startSpan(ctx, "DeleteUsers")
+	defer func() { finishSpan(span, err) }()
+	span.SetTag("users.count", len(ids))
+
+	return s.inner.DeleteUsers(ctx, ids)
+}
+
+// Login traces the login, tagging the user ID only when it succeeds
+func (s *TracedUserService) Login(ctx context.Context, req *request.LoginRequest) (resp *response.LoginResponse, err error) {
+	span, ctx := s.startSpan(ctx, "Login")
+	defer func() { finishSpan(span, err) }()
+
+	resp, err = s.inner.Login(ctx, req)
+	if err == nil && resp != nil && resp.User != nil {
+		span.SetTag("user.id", resp.User.ID.String())
+	}
+	return resp, err
+}
+
+// ListUsers traces the listing tagged with the requested page and limit
+func (s *TracedUserService) ListUsers(ctx context.Context, page, limit int, sort domain.UserSort, opts domain.ListOptions) (users []*response.UserResponse, total int64, err error) {
+	span, ctx := s.startSpan(ctx, "ListUsers")
+	defer func() { finishSpan(span, err) }()
+	span.SetTag("page", page)
+	span.SetTag("limit", limit)
+
+	return s.inner.ListUsers(ctx, page, limit, sort, opts)
+}
+
+// RevokeAllTokens traces the revocation tagged with the user ID
+func (s *TracedUserService) RevokeAllTokens(ctx context.Context, id uuid.UUID) (err error) {
+	span, ctx := s.startSpan(ctx, "RevokeAllTokens")
+	defer func() { finishSpan(span, err) }()
+	span.SetTag("user.id", id.String())
+
+	return s.inner.RevokeAllTokens(ctx, id)
+}
+
+// GetTokenVersion traces the version lookup tagged with the user ID
+func (s *TracedUserService) GetTokenVersion(ctx context.Context, id uuid.UUID) (version int, err error) {
+	span, ctx := s.startSpan(ctx, "GetTokenVersion")
+	defer func() { finishSpan(span, err) }()
+	span.SetTag("user.id", id.String())
+
+	return s.inner.GetTokenVersion(ctx, id)
+}
+
+// SetActive traces the status change tagged with the user ID and the new flag
+func (s *TracedUserService) SetActive(ctx context.Context, id uuid.UUID, active bool) (err error) {
+	span, ctx := s.startSpan(ctx, "SetActive")
+	defer func() { finishSpan(span, err) }()
+	span.SetTag("user.id", id.String())
+	span.SetTag("user.active", active)
+
+	return s.inner.SetActive(ctx, id, active)
+}
+
+// SearchUsers traces the search tagged with its limit, the query is left untagged
+func (s *TracedUserService) SearchUsers(ctx context.Context, query string, limit int) (users []*response.UserResponse, err error) {
+	span, ctx := s.startSpan(ctx, "SearchUsers")
+	defer func() { finishSpan(span, err) }()
+	span.SetTag("limit", limit)
+
+	return s.inner.SearchUsers(ctx, query, limit)
+}
+
+// StreamUsers traces the whole stream tagged with its batch size
+func (s *TracedUserService) StreamUsers(ctx context.Context, batchSize int, fn func(*response.UserResponse) error) (err error) {
+	span, ctx := s.startSpan(ctx, "StreamUsers")
+	defer func() { finishSpan(span, err) }()
+	span.SetTag("batch_size", batchSize)
+
+	return s.inner.StreamUsers(ctx, batchSize, fn)
+}
+
+// ListUserEvents traces the timeline listing tagged with the user ID, page and limit
+func (s *TracedUserService) ListUserEvents(ctx context.Context, id uuid.UUID, page, limit int) (events []*response.UserEventResponse, total int64, err error) {
+	span, ctx := s.startSpan(ctx, "ListUserEvents")
+	defer func() { finishSpan(span, err) }()
+	span.SetTag("user.id", id.String())
+	span.SetTag("page", page)
+	span.SetTag("limit", limit)
+
+	return s.inner.ListUserEvents(ctx, id, page, limit)
+}
+
+// ChangeEmail traces the change request tagged with the user ID, the new email is left untagged
+func (s *TracedUserService) ChangeEmail(ctx context.Context, id uuid.UUID, newEmail string) (err error) {
+	span, ctx := s.startSpan(ctx, "ChangeEmail")
+	defer func() { finishSpan(span, err) }()
+	span.SetTag("user.id", id.String())
+
+	return s.inner.ChangeEmail(ctx, id, newEmail)
+}
+
+// VerifyEmail traces the verification tagged with the user ID
+func (s *TracedUserService) VerifyEmail(ctx context.Context, id uuid.UUID, token string) (resp *response.UserResponse, err error) {
+	span, ctx := s.startSpan(ctx, "VerifyEmail")
+	defer func() { finishSpan(span, err) }()
+	span.SetTag("user.id", id.String())
+
+	return s.inner.VerifyEmail(ctx, id, token)
+}
+
+// Ensure TracedUserService implements UserServicePort at compile time
+var _ inbound.UserServicePort = (*TracedUserService)(nil)
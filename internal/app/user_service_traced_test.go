@@ -0,0 +1,132 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/gieart87/gohexaclean/internal/dto/request"
+	"github.com/gieart87/gohexaclean/internal/dto/response"
+	inboundmock "github.com/gieart87/gohexaclean/internal/port/inbound/mock"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/telemetry"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// spanCtxKey marks the context handed out with a span, so tests can check it reaches the inner service
+type spanCtxKey struct{}
+
+// fakeSpan records what was set on it
+type fakeSpan struct {
+	name     string
+	tags     map[string]interface{}
+	err      error
+	finished bool
+}
+
+func (s *fakeSpan) SetTag(key string, value interface{}) { s.tags[key] = value }
+func (s *fakeSpan) SetName(name string)                  { s.name = name }
+func (s *fakeSpan) SetError(err error)                   { s.err = err }
+func (s *fakeSpan) Finish()                              { s.finished = true }
+
+// fakeTracing keeps every span it starts
+type fakeTracing struct {
+	spans []*fakeSpan
+}
+
+func (t *fakeTracing) StartSpan(ctx context.Context, name string, _ ...interface{}) (telemetry.Span, context.Context) {
+	span := &fakeSpan{name: name, tags: make(map[string]interface{})}
+	t.spans = append(t.spans, span)
+	return span, context.WithValue(ctx, spanCtxKey{}, span)
+}
+
+func (t *fakeTracing) StartChildSpan(ctx context.Context, name string) (telemetry.Span, context.Context) {
+	return t.StartSpan(ctx, name)
+}
+
+func (t *fakeTracing) Close() error { return nil }
+
+// spanContextMatcher matches a context carrying a span started by fakeTracing
+type spanContextMatcher struct{}
+
+func (spanContextMatcher) Matches(x interface{}) bool {
+	ctx, ok := x.(context.Context)
+	return ok && ctx.Value(spanCtxKey{}) != nil
+}
+
+func (spanContextMatcher) String() string { return "is a context carrying a span" }
+
+var spanContext = spanContextMatcher{}
+
+func TestTracedUserService_SpanPerCall(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inner := inboundmock.NewMockUserServicePort(ctrl)
+	tracing := &fakeTracing{}
+	service := NewTracedUserService(inner, tracing)
+
+	id := uuid.New()
+	inner.EXPECT().GetUserByID(spanContext, id).Return(&response.UserResponse{ID: id}, nil)
+	inner.EXPECT().DeleteUser(spanContext, id).Return(nil)
+	inner.EXPECT().ListUsers(spanContext, 2, 10, domain.DefaultUserSort, domain.ListOptions{}).Return(nil, int64(0), nil)
+
+	_, err := service.GetUserByID(context.Background(), id)
+	require.NoError(t, err)
+	require.NoError(t, service.DeleteUser(context.Background(), id))
+	_, _, err = service.ListUsers(context.Background(), 2, 10, domain.DefaultUserSort, domain.ListOptions{})
+	require.NoError(t, err)
+
+	require.Len(t, tracing.spans, 3)
+	for i, name := range []string{"UserService.GetUserByID", "UserService.DeleteUser", "UserService.ListUsers"} {
+		assert.Equal(t, name, tracing.spans[i].name)
+		assert.True(t, tracing.spans[i].finished, "%s span not finished", name)
+		assert.NoError(t, tracing.spans[i].err)
+	}
+	assert.Equal(t, "GetUserByID", tracing.spans[0].tags["service.method"])
+	assert.Equal(t, id.String(), tracing.spans[0].tags["user.id"])
+	assert.Equal(t, 2, tracing.spans[2].tags["page"])
+	assert.Equal(t, 10, tracing.spans[2].tags["limit"])
+}
+
+func TestTracedUserService_RecordsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inner := inboundmock.NewMockUserServicePort(ctrl)
+	tracing := &fakeTracing{}
+	service := NewTracedUserService(inner, tracing)
+
+	inner.EXPECT().Login(spanContext, gomock.Any()).Return(nil, domain.ErrInvalidCredentials)
+
+	_, err := service.Login(context.Background(), &request.LoginRequest{Email: "jane@example.com", Password: "wrong"})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	require.Len(t, tracing.spans, 1)
+	assert.True(t, errors.Is(tracing.spans[0].err, domain.ErrInvalidCredentials))
+	assert.True(t, tracing.spans[0].finished)
+	assert.NotContains(t, tracing.spans[0].tags, "user.email", "emails stay out of traces")
+}
+
+func TestTracedUserService_TagsCreatedUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inner := inboundmock.NewMockUserServicePort(ctrl)
+	tracing := &fakeTracing{}
+	service := NewTracedUserService(inner, tracing)
+
+	id := uuid.New()
+	inner.EXPECT().CreateUser(spanContext, gomock.Any()).Return(&response.LoginResponse{User: &response.UserResponse{ID: id}}, nil)
+
+	_, err := service.CreateUser(context.Background(), &request.CreateUserRequest{Email: "jane@example.com", Name: "Jane", Password: "password123"})
+
+	require.NoError(t, err)
+	require.Len(t, tracing.spans, 1)
+	assert.Equal(t, "UserService.CreateUser", tracing.spans[0].name)
+	assert.Equal(t, id.String(), tracing.spans[0].tags["user.id"])
+	assert.True(t, tracing.spans[0].finished)
+}
@@ -6,25 +6,34 @@ import (
 
 	"github.com/gieart87/gohexaclean/internal/adapter/inbound/consumer"
 	"github.com/gieart87/gohexaclean/internal/adapter/inbound/grpc/handler"
+	asynqAdapter "github.com/gieart87/gohexaclean/internal/adapter/outbound/asynq"
+	"github.com/gieart87/gohexaclean/internal/adapter/outbound/cached"
 	"github.com/gieart87/gohexaclean/internal/adapter/outbound/datadog"
 	"github.com/gieart87/gohexaclean/internal/adapter/outbound/event"
+	"github.com/gieart87/gohexaclean/internal/adapter/outbound/noop"
 	"github.com/gieart87/gohexaclean/internal/adapter/outbound/otel"
 	"github.com/gieart87/gohexaclean/internal/adapter/outbound/pgsql"
-	"github.com/gieart87/gohexaclean/internal/adapter/outbound/redis"
+	"github.com/gieart87/gohexaclean/internal/adapter/outbound/rabbitmq"
 	"github.com/gieart87/gohexaclean/internal/app"
 	brokerFactory "github.com/gieart87/gohexaclean/internal/infra/broker"
 	"github.com/gieart87/gohexaclean/internal/infra/cache"
 	"github.com/gieart87/gohexaclean/internal/infra/config"
 	"github.com/gieart87/gohexaclean/internal/infra/db"
+	"github.com/gieart87/gohexaclean/internal/infra/db/migrate"
 	"github.com/gieart87/gohexaclean/internal/infra/logger"
+	"github.com/gieart87/gohexaclean/internal/infra/metrics"
 	asynqInfra "github.com/gieart87/gohexaclean/internal/infra/asynq"
 	"github.com/gieart87/gohexaclean/internal/port/inbound"
 	"github.com/gieart87/gohexaclean/internal/port/outbound/broker"
 	"github.com/gieart87/gohexaclean/internal/port/outbound/repository"
 	"github.com/gieart87/gohexaclean/internal/port/outbound/service"
 	"github.com/gieart87/gohexaclean/internal/port/outbound/telemetry"
+	"github.com/gieart87/gohexaclean/pkg/idgen"
+	"github.com/gieart87/gohexaclean/pkg/pagination"
+	"github.com/gieart87/gohexaclean/pkg/validation"
 	"github.com/hibiken/asynq"
 	redisClient "github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
@@ -34,14 +43,19 @@ type Container struct {
 	Logger *logger.Logger
 
 	// Database
-	DB          *gorm.DB
-	RedisClient *redisClient.Client
+	DB                *gorm.DB
+	PoolStatsExporter *db.PoolStatsExporter
+	RedisClient       *redisClient.Client
 
 	// Repositories
-	UserRepository repository.UserRepository
+	UserRepository  repository.UserRepository
+	AuditRepository repository.AuditRepository
+	EventStore      repository.EventStore
+	Transactor      repository.Transactor
 
 	// Services
-	CacheService service.CacheService
+	CacheService     service.CacheService
+	CacheReconnector *ReconnectingCacheService
 
 	// Message Broker
 	MessageBroker   broker.MessageBroker
@@ -50,11 +64,16 @@ type Container struct {
 
 	// Background Jobs
 	TaskClient *asynq.Client
+	TaskQueue  service.TaskQueue
 
 	// Telemetry
 	MetricsService telemetry.MetricsService
 	TracingService telemetry.TracingService
 
+	// Validation
+	PasswordPolicy validation.PasswordPolicy
+	PageBounds     pagination.Bounds
+
 	// Use Cases / Application Services
 	UserService inbound.UserServicePort
 
@@ -72,6 +91,8 @@ func NewContainer(configPath string) (*Container, error) {
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 	container.Config = cfg
+	container.PasswordPolicy = newPasswordPolicy(&cfg.Security.PasswordPolicy)
+	container.PageBounds = cfg.Pagination.Bounds()
 
 	// Initialize logger
 	log, err := logger.NewLogger(&cfg.Logger)
@@ -81,44 +102,47 @@ func NewContainer(configPath string) (*Container, error) {
 	container.Logger = log
 
 	// Initialize database with GORM
-	database, err := db.NewGormConnection(&cfg.Database)
+	database, err := db.ConnectWithRetry(context.Background(), &cfg.Database, log.Logger, cfg.App.Env == "production")
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 	container.DB = database
 	log.Info("Database connection established")
 
+	// Apply pending migrations when enabled, the migrate command covers manual runs
+	if cfg.Database.AutoMigrate {
+		applied, err := migrate.RunEmbedded(context.Background(), database)
+		if err != nil {
+			return nil, fmt.Errorf("failed to run migrations: %w", err)
+		}
+		log.Info("Database migrations applied", zap.Int("count", applied))
+	}
+
 	// Initialize Redis
 	redisConn, err := cache.NewRedisClient(&cfg.Redis)
 	if err != nil {
-		log.Warn("Failed to connect to Redis, cache will be disabled")
-		// Continue without Redis - cache is optional
+		log.Warn("Failed to connect to Redis", zap.Error(err))
+		// Continue without Redis - cache is optional and reconnects in the background
 	} else {
 		container.RedisClient = redisConn
 		log.Info("Redis connection established")
 	}
 
-	// Initialize repositories
-	container.UserRepository = pgsql.NewUserRepositoryPG(database)
-
 	// Initialize telemetry services
 	ctx := context.Background()
 
-	// Priority: Datadog > OpenTelemetry
-	if cfg.Datadog.Enabled {
-		// Initialize Datadog metrics
-		metricsService, err := datadog.NewMetricsServiceDatadog(
-			cfg.Datadog.AgentHost+":"+cfg.Datadog.AgentPort,
-			cfg.Datadog.Namespace,
-			cfg.Datadog.Tags,
-		)
-		if err != nil {
-			log.Warn("Failed to initialize Datadog metrics, continuing without metrics")
-		} else {
-			container.MetricsService = metricsService
-			log.Info("Datadog metrics initialized")
+	container.MetricsService = metrics.NewMetricsService(ctx, cfg, log)
+
+	// Export connection pool stats when metrics are available
+	if container.MetricsService != nil {
+		if sqlDB, err := database.DB(); err == nil {
+			container.PoolStatsExporter = db.NewPoolStatsExporter(sqlDB, container.MetricsService, cfg.Database.PoolStatsInterval)
+			container.PoolStatsExporter.Start()
 		}
+	}
 
+	// Priority: Datadog > OpenTelemetry
+	if cfg.Datadog.Enabled {
 		// Initialize Datadog APM tracing
 		if cfg.Datadog.APMEnabled {
 			container.TracingService = datadog.NewTracingServiceDatadog(
@@ -130,23 +154,7 @@ func NewContainer(configPath string) (*Container, error) {
 			log.Info("Datadog APM tracing initialized")
 		}
 	} else if cfg.Telemetry.Enabled {
-		// Initialize OpenTelemetry as fallback
-		log.Info("Initializing OpenTelemetry telemetry")
-
-		// Initialize OTEL metrics
-		metricsService, err := otel.NewMetricsServiceOTEL(
-			ctx,
-			cfg.Telemetry.ServiceName,
-			cfg.Telemetry.CollectorEndpoint,
-		)
-		if err != nil {
-			log.Warn("Failed to initialize OpenTelemetry metrics, continuing without metrics")
-		} else {
-			container.MetricsService = metricsService
-			log.Info("OpenTelemetry metrics initialized")
-		}
-
-		// Initialize OTEL tracing
+		// Initialize OpenTelemetry tracing as fallback
 		tracingService, err := otel.NewTracingServiceOTEL(
 			ctx,
 			cfg.Telemetry.ServiceName,
@@ -160,18 +168,48 @@ func NewContainer(configPath string) (*Container, error) {
 		}
 	}
 
-	// Initialize services
-	if container.RedisClient != nil {
-		container.CacheService = redis.NewCacheServiceRedis(container.RedisClient)
-	} else {
-		// Use a no-op cache service if Redis is not available
-		container.CacheService = &NoOpCacheService{}
+	// Remembered before the noop fallback, service spans are only worth creating for a real tracer
+	tracingEnabled := container.TracingService != nil
+
+	// Disabled telemetry falls back to noop implementations so consumers never need nil checks
+	if container.MetricsService == nil {
+		container.MetricsService = noop.NewNoopMetricsService()
+	}
+	if container.TracingService == nil {
+		container.TracingService = noop.NewNoopTracingService()
 	}
 
+	// Initialize repositories, after telemetry so queries can be traced
+	container.UserRepository = pgsql.NewUserRepositoryPG(database, cfg.Database.QueryTimeout, container.TracingService)
+	container.AuditRepository = pgsql.NewAuditRepositoryPG(database, cfg.Database.QueryTimeout)
+	container.EventStore = pgsql.NewEventStorePG(database, cfg.Database.QueryTimeout)
+	container.Transactor = pgsql.NewTransactorPG(database)
+
+	// Initialize services
+	// Serve from the no-op cache while Redis is unavailable and switch back once it is reachable
+	container.CacheReconnector = NewReconnectingCacheService(
+		container.RedisClient,
+		func(ctx context.Context) (*redisClient.Client, error) {
+			return cache.NewRedisClient(&cfg.Redis)
+		},
+		cached.KeyPrefixes,
+		container.MetricsService,
+		log,
+		cfg.Redis.ReconnectInterval,
+	)
+	container.CacheReconnector.Start()
+	container.CacheService = container.CacheReconnector
+
+	// Users and their count are read through the cache, writes through the repository invalidate them
+	// and transactions defer that until they commit
+	container.UserRepository = cached.NewCachedUserRepository(container.UserRepository, container.CacheService, cfg.Cache.UserTTL, cfg.Cache.UserCountTTL)
+	container.Transactor = cached.NewTransactor(container.Transactor)
+
 	// Initialize Asynq task client for background jobs
 	if container.RedisClient != nil {
 		redisAddr := fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port)
 		container.TaskClient = asynqInfra.NewClient(redisAddr)
+		container.TaskQueue = asynqAdapter.NewTaskQueueAsynq(container.TaskClient)
 		log.Info("Asynq task client initialized")
 	} else {
 		log.Warn("Redis not available, background jobs will be disabled")
@@ -179,7 +217,7 @@ func NewContainer(configPath string) (*Container, error) {
 
 	// Initialize message broker
 	if cfg.Broker.Enabled {
-		messageBroker, err := brokerFactory.NewMessageBroker(&cfg.Broker)
+		messageBroker, err := brokerFactory.NewMessageBroker(&cfg.Broker, container.MetricsService)
 		if err != nil {
 			log.Warn("Failed to create message broker, events will be disabled: " + err.Error())
 		} else {
@@ -192,8 +230,19 @@ func NewContainer(configPath string) (*Container, error) {
 				// Initialize event publisher
 				container.EventPublisher = event.NewUserEventPublisher(messageBroker)
 
+				// Webhook failures are archived apart from the broker's dead letters, so replaying those never re-sends webhooks
+				var webhookDeadLetters broker.DeadLetterArchiver
+				if cfg.Webhook.DeadLetterPath != "" {
+					webhookDeadLetters = rabbitmq.NewFileDeadLetterArchiver(cfg.Webhook.DeadLetterPath)
+				}
+
 				// Initialize event consumer
-				container.EventConsumer = consumer.NewUserEventConsumer(messageBroker)
+				container.EventConsumer = consumer.NewUserEventConsumer(messageBroker, container.TaskQueue, consumer.RetryPolicy{
+					MaxRetries: cfg.Broker.ConsumerRetry.MaxRetries,
+					BaseDelay:  cfg.Broker.ConsumerRetry.BaseDelay,
+					// Only RabbitMQ with a DLQ can keep messages that exhausted their retries
+					DeadLetter: cfg.Broker.Type == "rabbitmq" && cfg.Broker.RabbitMQ.DeadLetter.Enabled,
+				}, consumer.NewWebhookSender(&cfg.Webhook, webhookDeadLetters), consumer.NewDeduplicator(container.CacheService, cfg.Broker.DedupTTL, container.MetricsService))
 				if err := container.EventConsumer.Start(ctx); err != nil {
 					log.Warn("Failed to start event consumer: " + err.Error())
 				} else {
@@ -205,17 +254,33 @@ func NewContainer(configPath string) (*Container, error) {
 		log.Info("Message broker is disabled")
 	}
 
+	// The user.created consumer sends welcome emails, the service only enqueues them itself without a broker
+	var directTaskQueue service.TaskQueue
+	if container.EventPublisher == nil && cfg.Jobs.WelcomeEmailFallback {
+		directTaskQueue = container.TaskQueue
+	}
+
 	// Initialize use cases / application services
 	container.UserService = app.NewUserService(
 		container.UserRepository,
-		container.CacheService,
+		container.Transactor,
 		&cfg.JWT,
 		container.EventPublisher,
-		container.TaskClient,
+		directTaskQueue,
+		container.TaskQueue,
+		&cfg.Bulk,
+		container.AuditRepository,
+		container.EventStore,
+		idgen.Strategy(cfg.Database.IDStrategy),
+		container.MetricsService,
 	)
+	if tracingEnabled {
+		// A span per service call separates service time from the transport above and the queries below
+		container.UserService = app.NewTracedUserService(container.UserService, container.TracingService)
+	}
 
 	// Initialize gRPC handlers
-	container.UserGRPCHandler = handler.NewUserHandlerGRPC(container.UserService)
+	container.UserGRPCHandler = handler.NewUserHandlerGRPC(container.UserService, container.PasswordPolicy, container.PageBounds)
 
 	log.Info("Container initialized successfully")
 
@@ -228,12 +293,22 @@ func (c *Container) Close() error {
 		c.Logger.Info("Shutting down application...")
 	}
 
+	// Stop exporting pool stats before the database and metrics are closed
+	if c.PoolStatsExporter != nil {
+		c.PoolStatsExporter.Stop()
+	}
+
 	if c.DB != nil {
 		if err := db.Close(c.DB); err != nil {
 			c.Logger.Error("Failed to close database connection")
 		}
 	}
 
+	// Stop reconnecting before Redis and metrics are closed
+	if c.CacheReconnector != nil {
+		c.CacheReconnector.Stop()
+	}
+
 	if c.RedisClient != nil {
 		if err := cache.Close(c.RedisClient); err != nil {
 			c.Logger.Error("Failed to close Redis connection")
@@ -279,3 +354,15 @@ func (c *Container) Close() error {
 
 	return nil
 }
+
+// newPasswordPolicy builds the password strength rule from config
+func newPasswordPolicy(cfg *config.PasswordPolicyConfig) validation.PasswordPolicy {
+	return validation.PasswordPolicy{
+		MinLength:     cfg.MinLength,
+		RequireUpper:  cfg.RequireUpper,
+		RequireLower:  cfg.RequireLower,
+		RequireDigit:  cfg.RequireDigit,
+		RequireSymbol: cfg.RequireSymbol,
+		RejectCommon:  cfg.RejectCommon,
+	}
+}
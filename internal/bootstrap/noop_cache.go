@@ -28,3 +28,21 @@ func (n *NoOpCacheService) Exists(ctx context.Context, key string) (bool, error)
 func (n *NoOpCacheService) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
 	return true, nil
 }
+
+func (n *NoOpCacheService) MGet(ctx context.Context, keys ...string) (map[string]string, error) {
+	return map[string]string{}, nil // always a cache miss
+}
+
+func (n *NoOpCacheService) DeleteMany(ctx context.Context, keys ...string) error {
+	return nil // no-op
+}
+
+func (n *NoOpCacheService) DeleteByPrefix(ctx context.Context, prefix string) error {
+	return nil // no-op
+}
+
+// AcquireLock always fails, without Redis there is no shared state to lock across replicas
+// and granting the lock would let concurrent holders run
+func (n *NoOpCacheService) AcquireLock(ctx context.Context, key string, ttl time.Duration) (func(), bool, error) {
+	return func() {}, false, fmt.Errorf("cache not available")
+}
@@ -0,0 +1,26 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gieart87/gohexaclean/internal/infra/db"
+)
+
+// CheckReadiness reports whether the dependencies required to serve traffic are reachable
+// Redis is optional and left out, the service degrades to the noop cache without it
+func (c *Container) CheckReadiness(ctx context.Context) error {
+	if c.DB != nil {
+		if err := db.Ping(ctx, c.DB); err != nil {
+			return fmt.Errorf("database not ready: %w", err)
+		}
+	}
+
+	if c.MessageBroker != nil {
+		if err := c.MessageBroker.Health(); err != nil {
+			return fmt.Errorf("message broker not ready: %w", err)
+		}
+	}
+
+	return nil
+}
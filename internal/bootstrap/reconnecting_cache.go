@@ -0,0 +1,253 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gieart87/gohexaclean/internal/adapter/outbound/redis"
+	"github.com/gieart87/gohexaclean/internal/infra/logger"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/service"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/telemetry"
+	redisClient "github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultCacheReconnectInterval = 10 * time.Second
+	cacheProbeTimeout             = 2 * time.Second
+	// cachePurgeTimeout bounds the scan for stale keys when Redis comes back
+	cachePurgeTimeout = 30 * time.Second
+)
+
+// RedisDialer opens a Redis connection, returning an error while Redis is unreachable
+type RedisDialer func(ctx context.Context) (*redisClient.Client, error)
+
+// ReconnectingCacheService serves from Redis while it is reachable and from the no-op cache otherwise
+// A background loop redials an unavailable Redis and health-checks a live one, swapping between the two
+// Availability is reported as the cache.available gauge (1 = Redis, 0 = no-op)
+// Deletes sent to the no-op cache are lost, so keys under stalePrefixes are purged before Redis serves again
+type ReconnectingCacheService struct {
+	dial          RedisDialer
+	stalePrefixes []string
+	metrics       telemetry.MetricsService
+	log           *logger.Logger
+	interval      time.Duration
+
+	mu      sync.RWMutex
+	current service.CacheService
+	client  *redisClient.Client
+	dialed  bool // client was opened by the wrapper and is closed on Stop
+	live    bool
+
+	done     chan struct{}
+	wg       sync.WaitGroup
+	stopOnce sync.Once
+}
+
+// NewReconnectingCacheService creates a cache that starts on client, or on the no-op cache if client is nil
+// stalePrefixes are the keys whose invalidation matters, they are purged whenever Redis is restored
+// interval defaults to 10s
+func NewReconnectingCacheService(client *redisClient.Client, dial RedisDialer, stalePrefixes []string, metrics telemetry.MetricsService, log *logger.Logger, interval time.Duration) *ReconnectingCacheService {
+	if interval <= 0 {
+		interval = defaultCacheReconnectInterval
+	}
+
+	c := &ReconnectingCacheService{
+		dial:          dial,
+		stalePrefixes: stalePrefixes,
+		metrics:       metrics,
+		log:           log,
+		interval:      interval,
+		client:        client,
+		done:          make(chan struct{}),
+	}
+
+	if client != nil {
+		c.markAvailable()
+	} else {
+		c.markUnavailable(nil)
+	}
+
+	return c
+}
+
+// Start probes the cache on every interval until Stop is called
+func (c *ReconnectingCacheService) Start() {
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-c.done:
+				return
+			case <-ticker.C:
+				c.probe()
+			}
+		}
+	}()
+}
+
+// Stop stops the probe loop and closes a connection opened by a reconnect
+func (c *ReconnectingCacheService) Stop() {
+	c.stopOnce.Do(func() {
+		close(c.done)
+	})
+	c.wg.Wait()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.dialed && c.client != nil {
+		_ = c.client.Close()
+		c.client = nil
+	}
+}
+
+// Available reports whether requests are currently served by Redis
+func (c *ReconnectingCacheService) Available() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.live
+}
+
+// probe dials Redis if there is no connection yet, otherwise pings the existing one
+func (c *ReconnectingCacheService) probe() {
+	ctx, cancel := context.WithTimeout(context.Background(), cacheProbeTimeout)
+	defer cancel()
+
+	c.mu.RLock()
+	client := c.client
+	c.mu.RUnlock()
+
+	if client == nil {
+		dialed, err := c.dial(ctx)
+		if err != nil {
+			c.markUnavailable(err)
+			return
+		}
+		if err := c.purgeStale(dialed); err != nil {
+			_ = dialed.Close()
+			c.markUnavailable(err)
+			return
+		}
+
+		c.mu.Lock()
+		c.client = dialed
+		c.dialed = true
+		c.mu.Unlock()
+		c.markAvailable()
+		return
+	}
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		c.markUnavailable(err)
+		return
+	}
+	if !c.Available() {
+		if err := c.purgeStale(client); err != nil {
+			c.markUnavailable(err)
+			return
+		}
+	}
+	c.markAvailable()
+}
+
+// purgeStale drops the keys under stalePrefixes from client, requests keep using the no-op cache meanwhile
+func (c *ReconnectingCacheService) purgeStale(client *redisClient.Client) error {
+	ctx, cancel := context.WithTimeout(context.Background(), cachePurgeTimeout)
+	defer cancel()
+
+	cache := redis.NewCacheServiceRedis(client)
+	for _, prefix := range c.stalePrefixes {
+		if err := cache.DeleteByPrefix(ctx, prefix); err != nil {
+			return fmt.Errorf("failed to purge stale cache entries: %w", err)
+		}
+	}
+	return nil
+}
+
+// markAvailable switches to Redis
+func (c *ReconnectingCacheService) markAvailable() {
+	c.mu.Lock()
+	restored := !c.live
+	if restored {
+		c.current = redis.NewCacheServiceRedis(c.client)
+		c.live = true
+	}
+	c.mu.Unlock()
+
+	if restored && c.log != nil {
+		c.log.Info("Cache connection available, serving from Redis")
+	}
+	c.metrics.SetGauge("cache.available", nil, 1)
+}
+
+// markUnavailable falls back to the no-op cache, warning once per fallback
+func (c *ReconnectingCacheService) markUnavailable(err error) {
+	c.mu.Lock()
+	fellBack := c.live || c.current == nil
+	if fellBack {
+		c.current = &NoOpCacheService{}
+		c.live = false
+	}
+	c.mu.Unlock()
+
+	if fellBack && c.log != nil {
+		c.log.Warn("Cache unavailable, falling back to no-op cache",
+			zap.Error(err),
+			zap.Duration("retry_interval", c.interval),
+		)
+	}
+	c.metrics.SetGauge("cache.available", nil, 0)
+}
+
+// cache returns the implementation currently serving requests
+func (c *ReconnectingCacheService) cache() service.CacheService {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.current
+}
+
+func (c *ReconnectingCacheService) Get(ctx context.Context, key string) (string, error) {
+	return c.cache().Get(ctx, key)
+}
+
+func (c *ReconnectingCacheService) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	return c.cache().Set(ctx, key, value, expiration)
+}
+
+func (c *ReconnectingCacheService) Delete(ctx context.Context, key string) error {
+	return c.cache().Delete(ctx, key)
+}
+
+func (c *ReconnectingCacheService) Exists(ctx context.Context, key string) (bool, error) {
+	return c.cache().Exists(ctx, key)
+}
+
+func (c *ReconnectingCacheService) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	return c.cache().SetNX(ctx, key, value, expiration)
+}
+
+func (c *ReconnectingCacheService) MGet(ctx context.Context, keys ...string) (map[string]string, error) {
+	return c.cache().MGet(ctx, keys...)
+}
+
+func (c *ReconnectingCacheService) DeleteMany(ctx context.Context, keys ...string) error {
+	return c.cache().DeleteMany(ctx, keys...)
+}
+
+func (c *ReconnectingCacheService) DeleteByPrefix(ctx context.Context, prefix string) error {
+	return c.cache().DeleteByPrefix(ctx, prefix)
+}
+
+func (c *ReconnectingCacheService) AcquireLock(ctx context.Context, key string, ttl time.Duration) (func(), bool, error) {
+	return c.cache().AcquireLock(ctx, key, ttl)
+}
+
+// Ensure ReconnectingCacheService implements CacheService at compile time
+var _ service.CacheService = (*ReconnectingCacheService)(nil)
@@ -0,0 +1,156 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	telemetrymock "github.com/gieart87/gohexaclean/internal/port/outbound/telemetry/mock"
+	"github.com/golang/mock/gomock"
+	redisClient "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// availabilityRecorder keeps the latest cache.available gauge value
+type availabilityRecorder struct {
+	mu     sync.Mutex
+	latest float64
+}
+
+func (r *availabilityRecorder) set(_ string, _ map[string]string, value float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.latest = value
+}
+
+func (r *availabilityRecorder) value() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.latest
+}
+
+// newFlakyRedis returns a dialer that fails until reachable is set, then connects to miniredis
+func newFlakyRedis(t *testing.T, reachable *atomic.Bool) (*miniredis.Miniredis, RedisDialer) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	dial := func(ctx context.Context) (*redisClient.Client, error) {
+		if !reachable.Load() {
+			return nil, errors.New("connection refused")
+		}
+		client := redisClient.NewClient(&redisClient.Options{Addr: mr.Addr()})
+		if err := client.Ping(ctx).Err(); err != nil {
+			_ = client.Close()
+			return nil, err
+		}
+		return client, nil
+	}
+	return mr, dial
+}
+
+func TestReconnectingCacheService_FlipsToLiveWhenBackendReachable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gauge := &availabilityRecorder{latest: -1}
+	metrics := telemetrymock.NewMockMetricsService(ctrl)
+	metrics.EXPECT().SetGauge("cache.available", gomock.Any(), gomock.Any()).Do(gauge.set).AnyTimes()
+
+	var reachable atomic.Bool
+	mr, dial := newFlakyRedis(t, &reachable)
+
+	cache := NewReconnectingCacheService(nil, dial, nil, metrics, nil, 10*time.Millisecond)
+	cache.Start()
+	defer cache.Stop()
+
+	ctx := context.Background()
+	assert.False(t, cache.Available())
+	assert.Equal(t, 0.0, gauge.value())
+
+	// Writes are dropped by the no-op cache while Redis is down
+	require.NoError(t, cache.Set(ctx, "greeting", "hello", time.Minute))
+	_, err := cache.Get(ctx, "greeting")
+	assert.Error(t, err)
+
+	reachable.Store(true)
+	require.Eventually(t, cache.Available, time.Second, 5*time.Millisecond, "cache should reconnect once Redis is reachable")
+	assert.Equal(t, 1.0, gauge.value())
+
+	require.NoError(t, cache.Set(ctx, "greeting", "hello", time.Minute))
+	value, err := cache.Get(ctx, "greeting")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", value)
+
+	stored, err := mr.Get("greeting")
+	require.NoError(t, err)
+	assert.Contains(t, stored, "hello")
+}
+
+func TestReconnectingCacheService_FallsBackWhenLiveBackendGoesDown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gauge := &availabilityRecorder{latest: -1}
+	metrics := telemetrymock.NewMockMetricsService(ctrl)
+	metrics.EXPECT().SetGauge("cache.available", gomock.Any(), gomock.Any()).Do(gauge.set).AnyTimes()
+
+	mr := miniredis.RunT(t)
+	client := redisClient.NewClient(&redisClient.Options{Addr: mr.Addr(), MaxRetries: -1})
+	defer client.Close()
+
+	cache := NewReconnectingCacheService(client, nil, nil, metrics, nil, time.Minute)
+	assert.True(t, cache.Available())
+	assert.Equal(t, 1.0, gauge.value())
+
+	mr.Close()
+	cache.probe()
+	assert.False(t, cache.Available())
+	assert.Equal(t, 0.0, gauge.value())
+
+	// The existing client is pinged again rather than redialed
+	require.NoError(t, mr.Restart())
+	cache.probe()
+	assert.True(t, cache.Available())
+	assert.Equal(t, 1.0, gauge.value())
+}
+
+func TestReconnectingCacheService_PurgesStaleKeysOnRestore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	metrics := telemetrymock.NewMockMetricsService(ctrl)
+	metrics.EXPECT().SetGauge("cache.available", gomock.Any(), gomock.Any()).AnyTimes()
+
+	mr := miniredis.RunT(t)
+	client := redisClient.NewClient(&redisClient.Options{Addr: mr.Addr(), MaxRetries: -1})
+	defer client.Close()
+
+	cache := NewReconnectingCacheService(client, nil, []string{"user:", "users:count"}, metrics, nil, time.Minute)
+	ctx := context.Background()
+	require.NoError(t, cache.Set(ctx, "user:id:1", "token_version=1", time.Minute))
+	require.NoError(t, cache.Set(ctx, "users:count", 1, time.Minute))
+	require.NoError(t, cache.Set(ctx, "idempotency:key-1", "response", time.Minute))
+
+	mr.Close()
+	cache.probe()
+	require.False(t, cache.Available())
+
+	// The invalidation after a token revocation is swallowed by the no-op cache
+	require.NoError(t, cache.Delete(ctx, "user:id:1"))
+
+	require.NoError(t, mr.Restart())
+	assert.True(t, mr.Exists("user:id:1"), "Redis still holds the entry the outage kept from being deleted")
+
+	cache.probe()
+	require.True(t, cache.Available())
+
+	_, err := cache.Get(ctx, "user:id:1")
+	assert.Error(t, err, "the stale user must not be served after the restore")
+	assert.False(t, mr.Exists("users:count"))
+	assert.True(t, mr.Exists("idempotency:key-1"), "keys outside the stale prefixes are kept")
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audit actions recorded for user mutations
+const (
+	AuditActionUserCreated       = "user.created"
+	AuditActionUserUpdated       = "user.updated"
+	AuditActionUserDeleted       = "user.deleted"
+	AuditActionUserActivated     = "user.activated"
+	AuditActionUserDeactivated   = "user.deactivated"
+	AuditActionUserTokensRevoked = "user.tokens_revoked"
+	// Email changes are audited when requested and again when verified
+	AuditActionUserEmailChangeRequested = "user.email_change_requested"
+	AuditActionUserEmailChanged         = "user.email_changed"
+)
+
+// AuditLog is an append-only record of who changed what
+type AuditLog struct {
+	ID uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	// ActorID is nil when the change wasn't made by an authenticated user, e.g. self-registration
+	ActorID   *uuid.UUID `gorm:"type:uuid;index"`
+	Action    string     `gorm:"type:varchar(64);not null"`
+	TargetID  uuid.UUID  `gorm:"type:uuid;not null;index"`
+	RequestID string     `gorm:"type:varchar(128)"`
+	CreatedAt time.Time  `gorm:"not null;index"`
+}
+
+// NewAuditLog creates an audit entry for action on target, stamped with the current time
+func NewAuditLog(actorID *uuid.UUID, action string, targetID uuid.UUID, requestID string) *AuditLog {
+	return &AuditLog{
+		ActorID:   actorID,
+		Action:    action,
+		TargetID:  targetID,
+		RequestID: requestID,
+		CreatedAt: time.Now(),
+	}
+}
+
+// TableName overrides the default table name
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
@@ -8,6 +8,11 @@ var (
 	ErrUserNotFound       = errors.New("user not found")
 	ErrUserAlreadyExists  = errors.New("user already exists")
 	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrAccountInactive    = errors.New("account is inactive")
+
+	// Email change errors
+	ErrNoPendingEmailChange     = errors.New("no pending email change")
+	ErrInvalidVerificationToken = errors.New("invalid or expired verification token")
 
 	// Generic errors
 	ErrInvalidInput   = errors.New("invalid input")
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -111,3 +113,41 @@ func NewUserLoggedInEvent(userID uuid.UUID, email string) *UserLoggedInEvent {
 		Email: email,
 	}
 }
+
+// StoredEvent is a domain event persisted for an aggregate's activity timeline
+type StoredEvent struct {
+	ID          uuid.UUID       `gorm:"type:uuid;primaryKey"`
+	Type        string          `gorm:"type:varchar(64);not null"`
+	AggregateID uuid.UUID       `gorm:"type:uuid;not null"`
+	Payload     json.RawMessage `gorm:"type:jsonb;not null"`
+	OccurredAt  time.Time       `gorm:"not null"`
+}
+
+// NewStoredEvent captures e with its full JSON encoding as payload
+func NewStoredEvent(e Event) (*StoredEvent, error) {
+	id, err := uuid.Parse(e.EventID())
+	if err != nil {
+		return nil, fmt.Errorf("invalid event id %q: %w", e.EventID(), err)
+	}
+	aggregateID, err := uuid.Parse(e.AggregateID())
+	if err != nil {
+		return nil, fmt.Errorf("invalid aggregate id %q: %w", e.AggregateID(), err)
+	}
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s event: %w", e.EventType(), err)
+	}
+
+	return &StoredEvent{
+		ID:          id,
+		Type:        e.EventType(),
+		AggregateID: aggregateID,
+		Payload:     payload,
+		OccurredAt:  e.OccurredAt(),
+	}, nil
+}
+
+// TableName overrides the default table name
+func (StoredEvent) TableName() string {
+	return "events"
+}
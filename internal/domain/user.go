@@ -1,21 +1,73 @@
 package domain
 
 import (
+	"crypto/subtle"
+	"strings"
 	"time"
 
+	"github.com/gieart87/gohexaclean/pkg/idgen"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// User roles
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
 // User represents the user domain model (entity)
+// Password and EmailVerificationToken are left out of its JSON, so cached users never hold credentials
 type User struct {
-	ID        uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
-	Email     string         `gorm:"uniqueIndex;not null;size:255"`
-	Name      string         `gorm:"not null;size:255"`
-	Password  string         `gorm:"not null;size:255"`
-	CreatedAt time.Time      `gorm:"autoCreateTime"`
-	UpdatedAt time.Time      `gorm:"autoUpdateTime"`
-	DeletedAt gorm.DeletedAt `gorm:"index"`
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Email    string    `gorm:"uniqueIndex;not null;size:255"`
+	Name     string    `gorm:"not null;size:255"`
+	Password string    `gorm:"not null;size:255" json:"-"`
+	Role     string    `gorm:"not null;size:50;default:user"`
+	// TokenVersion is embedded in issued JWTs; incrementing it revokes all outstanding tokens
+	TokenVersion int `gorm:"not null;default:0"`
+	// IsActive is false while the account is deactivated; inactive users can't log in
+	IsActive bool `gorm:"not null;default:true"`
+	// EmailVerified is false until the user confirms their address, and again while an email change is pending
+	EmailVerified bool `gorm:"not null;default:false"`
+	// PendingEmail replaces Email only once the user verifies it
+	PendingEmail *string `gorm:"size:255"`
+	// EmailVerificationToken is the SHA-256 hex of the token mailed to PendingEmail, the token itself is never stored
+	EmailVerificationToken     *string `gorm:"size:64" json:"-"`
+	EmailVerificationExpiresAt *time.Time
+	LastLoginAt                *time.Time     // nil until the user's first successful login
+	CreatedAt                  time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt                  time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt                  gorm.DeletedAt `gorm:"index"`
+}
+
+// Sortable user fields
+const (
+	UserSortName      = "name"
+	UserSortEmail     = "email"
+	UserSortCreatedAt = "created_at"
+	UserSortUpdatedAt = "updated_at"
+)
+
+// UserSort orders a user listing by one of the sortable fields
+type UserSort struct {
+	Field string
+	Desc  bool
+}
+
+// DefaultUserSort lists the newest users first
+var DefaultUserSort = UserSort{Field: UserSortCreatedAt, Desc: true}
+
+// ListOptions tunes how a user listing is counted
+type ListOptions struct {
+	// ApproxCount estimates the total from table statistics instead of an exact COUNT(*)
+	ApproxCount bool
+}
+
+// UserCursor marks a position in creation order for keyset pagination
+type UserCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
 }
 
 // TableName overrides the default table name
@@ -23,13 +75,20 @@ func (User) TableName() string {
 	return "users"
 }
 
-// NewUser creates a new user entity
-func NewUser(email, name, password string) *User {
+// NormalizeEmail trims and lowercases email so addresses differing only in case or padding match
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// NewUser creates a new user entity with a normalized email and an ID generated by idStrategy
+func NewUser(email, name, password string, idStrategy idgen.Strategy) *User {
 	return &User{
-		ID:       uuid.New(),
-		Email:    email,
+		ID:       idStrategy.New(),
+		Email:    NormalizeEmail(email),
 		Name:     name,
 		Password: password,
+		Role:     RoleUser,
+		IsActive: true,
 	}
 }
 
@@ -37,3 +96,33 @@ func NewUser(email, name, password string) *User {
 func (u *User) UpdateProfile(name string) {
 	u.Name = name
 }
+
+// RequestEmailChange parks newEmail until it is verified with the token hashed to tokenHash before expiresAt
+// Email stays unchanged meanwhile, a new request replaces any earlier one
+func (u *User) RequestEmailChange(newEmail, tokenHash string, expiresAt time.Time) {
+	pending := NormalizeEmail(newEmail)
+	u.PendingEmail = &pending
+	u.EmailVerificationToken = &tokenHash
+	u.EmailVerificationExpiresAt = &expiresAt
+	u.EmailVerified = false
+}
+
+// VerifyEmailChange moves the pending email into Email when tokenHash matches and hasn't expired at now
+func (u *User) VerifyEmailChange(tokenHash string, now time.Time) error {
+	if u.PendingEmail == nil || u.EmailVerificationToken == nil {
+		return ErrNoPendingEmailChange
+	}
+	if subtle.ConstantTimeCompare([]byte(*u.EmailVerificationToken), []byte(tokenHash)) != 1 {
+		return ErrInvalidVerificationToken
+	}
+	if u.EmailVerificationExpiresAt == nil || !now.Before(*u.EmailVerificationExpiresAt) {
+		return ErrInvalidVerificationToken
+	}
+
+	u.Email = *u.PendingEmail
+	u.EmailVerified = true
+	u.PendingEmail = nil
+	u.EmailVerificationToken = nil
+	u.EmailVerificationExpiresAt = nil
+	return nil
+}
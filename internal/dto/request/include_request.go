@@ -0,0 +1,47 @@
+package request
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gieart87/gohexaclean/internal/domain"
+)
+
+// UserIncludes is the allowlist of related resources that can be requested
+// for a user via the "include" query parameter (e.g. ?include=roles).
+// Add entries here as new associations are introduced on the user entity.
+var UserIncludes = []string{}
+
+// ParseIncludes parses a comma-separated include parameter and validates each
+// entry against the allowlist to prevent clients from over-fetching.
+// Duplicates and empty entries are ignored.
+func ParseIncludes(raw string, allowed []string) ([]string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+
+	allowedSet := make(map[string]struct{}, len(allowed))
+	for _, name := range allowed {
+		allowedSet[name] = struct{}{}
+	}
+
+	seen := make(map[string]struct{})
+	includes := make([]string, 0)
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if _, ok := allowedSet[name]; !ok {
+			return nil, fmt.Errorf("%w: unsupported include %q", domain.ErrInvalidInput, name)
+		}
+		if _, dup := seen[name]; dup {
+			continue
+		}
+		seen[name] = struct{}{}
+		includes = append(includes, name)
+	}
+
+	return includes, nil
+}
@@ -0,0 +1,33 @@
+package request
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIncludes(t *testing.T) {
+	includes, err := ParseIncludes(" roles, Profile ,roles,", []string{"roles", "profile"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"roles", "profile"}, includes)
+}
+
+func TestParseIncludes_Empty(t *testing.T) {
+	includes, err := ParseIncludes("", []string{"roles"})
+
+	require.NoError(t, err)
+	assert.Empty(t, includes)
+}
+
+func TestParseIncludes_NotAllowed(t *testing.T) {
+	includes, err := ParseIncludes("roles,password_resets", []string{"roles"})
+
+	assert.Nil(t, includes)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+	assert.Contains(t, err.Error(), "password_resets")
+}
@@ -0,0 +1,39 @@
+package request
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gieart87/gohexaclean/internal/domain"
+)
+
+// UserSortFields is the allowlist of fields users can be ordered by via the "sort" query parameter
+var UserSortFields = []string{
+	domain.UserSortName,
+	domain.UserSortEmail,
+	domain.UserSortCreatedAt,
+	domain.UserSortUpdatedAt,
+}
+
+// ParseUserSort parses a sort parameter such as "name" or "-created_at", a leading "-" sorts descending.
+// An empty value yields domain.DefaultUserSort, fields outside UserSortFields are rejected.
+func ParseUserSort(raw string) (domain.UserSort, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return domain.DefaultUserSort, nil
+	}
+
+	sort := domain.UserSort{Field: strings.ToLower(raw)}
+	if strings.HasPrefix(sort.Field, "-") {
+		sort.Field = strings.TrimPrefix(sort.Field, "-")
+		sort.Desc = true
+	}
+
+	for _, field := range UserSortFields {
+		if field == sort.Field {
+			return sort, nil
+		}
+	}
+
+	return domain.UserSort{}, fmt.Errorf("%w: unsupported sort %q", domain.ErrInvalidInput, raw)
+}
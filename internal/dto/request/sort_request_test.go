@@ -0,0 +1,38 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUserSort(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want domain.UserSort
+	}{
+		{"", domain.DefaultUserSort},
+		{"name", domain.UserSort{Field: domain.UserSortName}},
+		{"-name", domain.UserSort{Field: domain.UserSortName, Desc: true}},
+		{" Email ", domain.UserSort{Field: domain.UserSortEmail}},
+		{"created_at", domain.UserSort{Field: domain.UserSortCreatedAt}},
+		{"-updated_at", domain.UserSort{Field: domain.UserSortUpdatedAt, Desc: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			sort, err := ParseUserSort(tt.raw)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, sort)
+		})
+	}
+}
+
+func TestParseUserSort_RejectsUnknownField(t *testing.T) {
+	for _, raw := range []string{"password", "-password", "name desc", "--name", "name;drop"} {
+		_, err := ParseUserSort(raw)
+		assert.ErrorIs(t, err, domain.ErrInvalidInput, raw)
+	}
+}
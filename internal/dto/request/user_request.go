@@ -1,8 +1,13 @@
 package request
 
 import (
-	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"fmt"
+
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/gieart87/gohexaclean/pkg/validation"
+	ozzo "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/google/uuid"
 )
 
 // CreateUserRequest represents the request to create a new user
@@ -12,20 +17,30 @@ type CreateUserRequest struct {
 	Password string `json:"password"`
 }
 
-// Validate validates CreateUserRequest
+// Normalize trims and lowercases the email so validation and lookups see the stored form
+func (r *CreateUserRequest) Normalize() {
+	r.Email = domain.NormalizeEmail(r.Email)
+}
+
+// Validate validates CreateUserRequest with the default password policy
 func (r CreateUserRequest) Validate() error {
-	return validation.ValidateStruct(&r,
-		validation.Field(&r.Email,
-			validation.Required.Error("email is required"),
+	return r.ValidateWithPolicy(validation.DefaultPasswordPolicy())
+}
+
+// ValidateWithPolicy validates CreateUserRequest, checking the password against policy
+func (r CreateUserRequest) ValidateWithPolicy(policy validation.PasswordPolicy) error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.Email,
+			ozzo.Required.Error("email is required"),
 			is.Email.Error("email must be a valid email address"),
 		),
-		validation.Field(&r.Name,
-			validation.Required.Error("name is required"),
-			validation.Length(3, 100).Error("name must be between 3 and 100 characters"),
+		ozzo.Field(&r.Name,
+			ozzo.Required.Error("name is required"),
+			ozzo.Length(3, 100).Error("name must be between 3 and 100 characters"),
 		),
-		validation.Field(&r.Password,
-			validation.Required.Error("password is required"),
-			validation.Length(6, 0).Error("password must be at least 6 characters"),
+		ozzo.Field(&r.Password,
+			ozzo.Required.Error("password is required"),
+			policy,
 		),
 	)
 }
@@ -37,10 +52,93 @@ type UpdateUserRequest struct {
 
 // Validate validates UpdateUserRequest
 func (r UpdateUserRequest) Validate() error {
-	return validation.ValidateStruct(&r,
-		validation.Field(&r.Name,
-			validation.Required.Error("name is required"),
-			validation.Length(3, 100).Error("name must be between 3 and 100 characters"),
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.Name,
+			ozzo.Required.Error("name is required"),
+			ozzo.Length(3, 100).Error("name must be between 3 and 100 characters"),
+		),
+	)
+}
+
+// ChangeEmailRequest represents the request to move a user to a new email address
+type ChangeEmailRequest struct {
+	Email string `json:"email"`
+}
+
+// Normalize trims and lowercases the email so validation and lookups see the stored form
+func (r *ChangeEmailRequest) Normalize() {
+	r.Email = domain.NormalizeEmail(r.Email)
+}
+
+// Validate validates ChangeEmailRequest
+func (r ChangeEmailRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.Email,
+			ozzo.Required.Error("email is required"),
+			is.Email.Error("email must be a valid email address"),
+		),
+	)
+}
+
+// VerifyEmailRequest represents the request confirming an email change with the mailed token
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+// Validate validates VerifyEmailRequest
+func (r VerifyEmailRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.Token,
+			ozzo.Required.Error("token is required"),
+		),
+	)
+}
+
+// MaxBulkCreateUsers caps how many users one bulk create may add, each one hashes a password
+const MaxBulkCreateUsers = 100
+
+// BulkCreateUsersRequest represents the request to create many users at once
+type BulkCreateUsersRequest struct {
+	Users []CreateUserRequest `json:"users"`
+}
+
+// Normalize normalizes every user in the request
+func (r *BulkCreateUsersRequest) Normalize() {
+	for i := range r.Users {
+		r.Users[i].Normalize()
+	}
+}
+
+// ValidateWithPolicy validates BulkCreateUsersRequest, checking every password against policy
+// Skip keeps ozzo from also running each user's Validate, which checks the default policy
+func (r BulkCreateUsersRequest) ValidateWithPolicy(policy validation.PasswordPolicy) error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.Users,
+			ozzo.Required.Error("users is required"),
+			ozzo.Length(1, MaxBulkCreateUsers).Error(fmt.Sprintf("users must contain between 1 and %d entries", MaxBulkCreateUsers)),
+			ozzo.Each(ozzo.By(func(value interface{}) error {
+				user, _ := value.(CreateUserRequest)
+				return user.ValidateWithPolicy(policy)
+			}), ozzo.Skip),
+			ozzo.Skip,
+		),
+	)
+}
+
+// MaxBulkDeleteIDs caps how many users one bulk delete may remove
+const MaxBulkDeleteIDs = 1000
+
+// BulkDeleteUsersRequest represents the request to delete many users at once
+type BulkDeleteUsersRequest struct {
+	IDs []uuid.UUID `json:"ids"`
+}
+
+// Validate validates BulkDeleteUsersRequest
+func (r BulkDeleteUsersRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.IDs,
+			ozzo.Required.Error("ids is required"),
+			ozzo.Length(1, MaxBulkDeleteIDs).Error(fmt.Sprintf("ids must contain between 1 and %d entries", MaxBulkDeleteIDs)),
 		),
 	)
 }
@@ -51,15 +149,20 @@ type LoginRequest struct {
 	Password string `json:"password"`
 }
 
+// Normalize trims and lowercases the email so validation and lookups see the stored form
+func (r *LoginRequest) Normalize() {
+	r.Email = domain.NormalizeEmail(r.Email)
+}
+
 // Validate validates LoginRequest
 func (r LoginRequest) Validate() error {
-	return validation.ValidateStruct(&r,
-		validation.Field(&r.Email,
-			validation.Required.Error("email is required"),
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.Email,
+			ozzo.Required.Error("email is required"),
 			is.Email.Error("email must be a valid email address"),
 		),
-		validation.Field(&r.Password,
-			validation.Required.Error("password is required"),
+		ozzo.Field(&r.Password,
+			ozzo.Required.Error("password is required"),
 		),
 	)
 }
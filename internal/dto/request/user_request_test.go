@@ -0,0 +1,23 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateUserRequest_NormalizeEmail(t *testing.T) {
+	req := &CreateUserRequest{Email: "  Jane.Doe@Example.COM ", Name: "Jane Doe", Password: "password123"}
+	req.Normalize()
+
+	assert.Equal(t, "jane.doe@example.com", req.Email)
+	assert.NoError(t, req.Validate())
+}
+
+func TestLoginRequest_NormalizeEmail(t *testing.T) {
+	req := &LoginRequest{Email: "Jane.Doe@EXAMPLE.com\t", Password: "password123"}
+	req.Normalize()
+
+	assert.Equal(t, "jane.doe@example.com", req.Email)
+	assert.NoError(t, req.Validate())
+}
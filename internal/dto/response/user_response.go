@@ -1,6 +1,7 @@
 package response
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/gieart87/gohexaclean/internal/domain"
@@ -12,23 +13,65 @@ type UserResponse struct {
 	ID        uuid.UUID `json:"id"`
 	Email     string    `json:"email"`
 	Name      string    `json:"name"`
+	IsActive  bool      `json:"is_active"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	// LastLoginAt is omitted until the user first logs in
+	LastLoginAt   *time.Time `json:"last_login_at,omitempty"`
+	EmailVerified bool       `json:"email_verified"`
+	// PendingEmail is the address waiting for verification, omitted when no email change is pending
+	PendingEmail *string `json:"pending_email,omitempty"`
 }
 
 // NewUserResponse creates a new user response from domain model
 func NewUserResponse(user *domain.User) *UserResponse {
 	return &UserResponse{
-		ID:        user.ID,
-		Email:     user.Email,
-		Name:      user.Name,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:            user.ID,
+		Email:         user.Email,
+		Name:          user.Name,
+		IsActive:      user.IsActive,
+		CreatedAt:     user.CreatedAt,
+		UpdatedAt:     user.UpdatedAt,
+		LastLoginAt:   user.LastLoginAt,
+		EmailVerified: user.EmailVerified,
+		PendingEmail:  user.PendingEmail,
 	}
 }
 
+// BulkItemResult represents the outcome of one item in a bulk operation
+type BulkItemResult struct {
+	Index   int           `json:"index"`
+	Success bool          `json:"success"`
+	User    *UserResponse `json:"user,omitempty"`
+	Error   string        `json:"error,omitempty"`
+}
+
+// BulkDeleteResponse represents the outcome of a bulk delete
+type BulkDeleteResponse struct {
+	Deleted  int64       `json:"deleted"`
+	NotFound []uuid.UUID `json:"not_found"`
+}
+
 // LoginResponse represents the login response
 type LoginResponse struct {
 	Token string        `json:"token"`
 	User  *UserResponse `json:"user"`
 }
+
+// UserEventResponse represents one entry of a user's activity timeline
+type UserEventResponse struct {
+	ID         uuid.UUID       `json:"id"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"` // the event as it was published
+}
+
+// NewUserEventResponse creates a user event response from a stored event
+func NewUserEventResponse(e *domain.StoredEvent) *UserEventResponse {
+	return &UserEventResponse{
+		ID:         e.ID,
+		Type:       e.Type,
+		OccurredAt: e.OccurredAt,
+		Data:       e.Payload,
+	}
+}
@@ -0,0 +1,36 @@
+package asynq
+
+import (
+	"context"
+	"time"
+
+	"github.com/gieart87/gohexaclean/internal/port/outbound/telemetry"
+	"github.com/hibiken/asynq"
+)
+
+// MetricsMiddleware records processed/failed counts and duration for each task, tagged by task type
+func MetricsMiddleware(metrics telemetry.MetricsService) asynq.MiddlewareFunc {
+	return func(next asynq.Handler) asynq.Handler {
+		return asynq.HandlerFunc(func(ctx context.Context, t *asynq.Task) error {
+			if metrics == nil {
+				return next.ProcessTask(ctx, t)
+			}
+
+			start := time.Now()
+			err := next.ProcessTask(ctx, t)
+
+			tags := map[string]string{
+				"type": t.Type(),
+			}
+
+			metrics.IncrementCounter("tasks.processed.total", tags, 1)
+			metrics.RecordTiming("tasks.duration", tags, time.Since(start))
+
+			if err != nil {
+				metrics.IncrementCounter("tasks.failed.total", tags, 1)
+			}
+
+			return err
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package asynq
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gieart87/gohexaclean/internal/port/outbound/telemetry/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsMiddleware_RecordsDuration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	metrics := mock.NewMockMetricsService(ctrl)
+	tags := map[string]string{"type": "email:welcome"}
+
+	metrics.EXPECT().IncrementCounter("tasks.processed.total", tags, float64(1))
+	metrics.EXPECT().RecordTiming("tasks.duration", tags, gomock.Any())
+
+	handler := MetricsMiddleware(metrics)(asynq.HandlerFunc(func(ctx context.Context, t *asynq.Task) error {
+		return nil
+	}))
+
+	err := handler.ProcessTask(context.Background(), asynq.NewTask("email:welcome", nil))
+	assert.NoError(t, err)
+}
+
+func TestMetricsMiddleware_RecordsFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	metrics := mock.NewMockMetricsService(ctrl)
+	tags := map[string]string{"type": "email:welcome"}
+	taskErr := errors.New("smtp unavailable")
+
+	metrics.EXPECT().IncrementCounter("tasks.processed.total", tags, float64(1))
+	metrics.EXPECT().RecordTiming("tasks.duration", tags, gomock.Any())
+	metrics.EXPECT().IncrementCounter("tasks.failed.total", tags, float64(1))
+
+	handler := MetricsMiddleware(metrics)(asynq.HandlerFunc(func(ctx context.Context, t *asynq.Task) error {
+		return taskErr
+	}))
+
+	err := handler.ProcessTask(context.Background(), asynq.NewTask("email:welcome", nil))
+	assert.ErrorIs(t, err, taskErr)
+}
+
+func TestMetricsMiddleware_NilMetrics(t *testing.T) {
+	called := false
+	handler := MetricsMiddleware(nil)(asynq.HandlerFunc(func(ctx context.Context, t *asynq.Task) error {
+		called = true
+		return nil
+	}))
+
+	err := handler.ProcessTask(context.Background(), asynq.NewTask("email:welcome", nil))
+	assert.NoError(t, err)
+	assert.True(t, called)
+}
@@ -0,0 +1,64 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gieart87/gohexaclean/internal/port/outbound/service"
+	"github.com/hibiken/asynq"
+)
+
+const (
+	TypeEmailVerification = "email:verification"
+)
+
+// EmailVerificationPayload represents the payload for the email verification task
+// Token is the only copy of the verification token outside the user's mailbox, the database keeps its hash
+type EmailVerificationPayload struct {
+	UserID    string    `json:"user_id"`
+	Email     string    `json:"email"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// NewEmailVerificationTask creates a new task to send the verification token to a new email address
+func NewEmailVerificationTask(userID, email, token string, expiresAt time.Time) (*asynq.Task, error) {
+	payload, err := json.Marshal(EmailVerificationPayload{
+		UserID:    userID,
+		Email:     email,
+		Token:     token,
+		ExpiresAt: expiresAt.UTC(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	return asynq.NewTask(TypeEmailVerification, payload), nil
+}
+
+// NewEmailVerificationHandler returns the handler that emails verification tokens through notifier
+func NewEmailVerificationHandler(notifier service.Notifier) asynq.HandlerFunc {
+	return func(ctx context.Context, t *asynq.Task) error {
+		var payload EmailVerificationPayload
+		if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal payload: %w", err)
+		}
+
+		if err := notifier.SendEmail(ctx, payload.Email, "Verify your new email address", FormatEmailVerification(payload)); err != nil {
+			return fmt.Errorf("failed to email verification token: %w", err)
+		}
+
+		return nil
+	}
+}
+
+// FormatEmailVerification renders the email verification message
+func FormatEmailVerification(payload EmailVerificationPayload) string {
+	return fmt.Sprintf(
+		"Confirm %s as the new email address of your account with the code %s before %s. Until then your current address stays in use.",
+		payload.Email,
+		payload.Token,
+		payload.ExpiresAt.UTC().Format("2006-01-02 15:04 MST"),
+	)
+}
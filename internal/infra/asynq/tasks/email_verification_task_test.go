@@ -0,0 +1,64 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gieart87/gohexaclean/internal/port/outbound/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEmailVerificationTask_Payload(t *testing.T) {
+	userID := uuid.New().String()
+	expiresAt := time.Date(2024, 5, 2, 9, 30, 0, 0, time.UTC)
+
+	task, err := NewEmailVerificationTask(userID, "jane@new.example.com", "token-123", expiresAt)
+	require.NoError(t, err)
+	assert.Equal(t, TypeEmailVerification, task.Type())
+
+	var payload EmailVerificationPayload
+	require.NoError(t, json.Unmarshal(task.Payload(), &payload))
+	assert.Equal(t, userID, payload.UserID)
+	assert.Equal(t, "jane@new.example.com", payload.Email)
+	assert.Equal(t, "token-123", payload.Token)
+	assert.True(t, payload.ExpiresAt.Equal(expiresAt))
+}
+
+func TestEmailVerificationHandler_EmailsToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	task, err := NewEmailVerificationTask(uuid.New().String(), "jane@new.example.com", "token-123", time.Date(2024, 5, 2, 9, 30, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	notifier := mock.NewMockNotifier(ctrl)
+	notifier.EXPECT().
+		SendEmail(gomock.Any(), "jane@new.example.com", "Verify your new email address", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, body string) error {
+			assert.Contains(t, body, "token-123")
+			assert.Contains(t, body, "2024-05-02 09:30 UTC")
+			return nil
+		})
+
+	assert.NoError(t, NewEmailVerificationHandler(notifier)(context.Background(), task))
+}
+
+func TestEmailVerificationHandler_ReturnsNotifierError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	task, err := NewEmailVerificationTask(uuid.New().String(), "jane@new.example.com", "token-123", time.Now())
+	require.NoError(t, err)
+
+	sendErr := errors.New("smtp unavailable")
+	notifier := mock.NewMockNotifier(ctrl)
+	notifier.EXPECT().SendEmail(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(sendErr)
+
+	assert.ErrorIs(t, NewEmailVerificationHandler(notifier)(context.Background(), task), sendErr)
+}
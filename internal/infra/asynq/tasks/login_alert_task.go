@@ -0,0 +1,69 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gieart87/gohexaclean/internal/port/outbound/service"
+	"github.com/hibiken/asynq"
+)
+
+const (
+	TypeLoginAlert = "notification:login_alert"
+)
+
+// LoginAlertPayload represents the payload for login alert task
+type LoginAlertPayload struct {
+	UserID     string    `json:"user_id"`
+	Email      string    `json:"email"`
+	Phone      string    `json:"phone,omitempty"`
+	LoggedInAt time.Time `json:"logged_in_at"`
+}
+
+// NewLoginAlertTask creates a new task to alert a user about a sign-in to their account
+func NewLoginAlertTask(userID, email string, loggedInAt time.Time) (*asynq.Task, error) {
+	payload, err := json.Marshal(LoginAlertPayload{
+		UserID:     userID,
+		Email:      email,
+		LoggedInAt: loggedInAt.UTC(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	return asynq.NewTask(TypeLoginAlert, payload), nil
+}
+
+// NewLoginAlertHandler returns the handler that sends login alerts through notifier
+// The alert is always emailed, and also texted when the payload carries a phone number
+func NewLoginAlertHandler(notifier service.Notifier) asynq.HandlerFunc {
+	return func(ctx context.Context, t *asynq.Task) error {
+		var payload LoginAlertPayload
+		if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal payload: %w", err)
+		}
+
+		message := FormatLoginAlert(payload)
+		if err := notifier.SendEmail(ctx, payload.Email, "New sign-in to your account", message); err != nil {
+			return fmt.Errorf("failed to email login alert: %w", err)
+		}
+
+		if payload.Phone != "" {
+			if err := notifier.SendSMS(ctx, payload.Phone, message); err != nil {
+				return fmt.Errorf("failed to text login alert: %w", err)
+			}
+		}
+
+		return nil
+	}
+}
+
+// FormatLoginAlert renders the login alert message
+func FormatLoginAlert(payload LoginAlertPayload) string {
+	return fmt.Sprintf(
+		"We noticed a new sign-in to your account %s at %s. If this wasn't you, reset your password now.",
+		payload.Email,
+		payload.LoggedInAt.UTC().Format("2006-01-02 15:04 MST"),
+	)
+}
@@ -0,0 +1,85 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gieart87/gohexaclean/internal/port/outbound/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLoginAlertTask_Payload(t *testing.T) {
+	userID := uuid.New().String()
+	loggedInAt := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+
+	task, err := NewLoginAlertTask(userID, "jane@example.com", loggedInAt)
+	require.NoError(t, err)
+	assert.Equal(t, TypeLoginAlert, task.Type())
+
+	var payload LoginAlertPayload
+	require.NoError(t, json.Unmarshal(task.Payload(), &payload))
+	assert.Equal(t, userID, payload.UserID)
+	assert.Equal(t, "jane@example.com", payload.Email)
+	assert.True(t, payload.LoggedInAt.Equal(loggedInAt))
+	assert.Empty(t, payload.Phone)
+}
+
+func TestLoginAlertHandler_EmailsUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	task, err := NewLoginAlertTask(uuid.New().String(), "jane@example.com", time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	notifier := mock.NewMockNotifier(ctrl)
+	notifier.EXPECT().
+		SendEmail(gomock.Any(), "jane@example.com", "New sign-in to your account", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, body string) error {
+			assert.Contains(t, body, "jane@example.com")
+			assert.Contains(t, body, "2024-05-01 09:30 UTC")
+			return nil
+		})
+
+	assert.NoError(t, NewLoginAlertHandler(notifier)(context.Background(), task))
+}
+
+func TestLoginAlertHandler_TextsUserWithPhone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	payload, err := json.Marshal(LoginAlertPayload{
+		UserID:     uuid.New().String(),
+		Email:      "jane@example.com",
+		Phone:      "+6281234567890",
+		LoggedInAt: time.Now(),
+	})
+	require.NoError(t, err)
+
+	notifier := mock.NewMockNotifier(ctrl)
+	notifier.EXPECT().SendEmail(gomock.Any(), "jane@example.com", gomock.Any(), gomock.Any()).Return(nil)
+	notifier.EXPECT().SendSMS(gomock.Any(), "+6281234567890", gomock.Any()).Return(nil)
+
+	assert.NoError(t, NewLoginAlertHandler(notifier)(context.Background(), asynq.NewTask(TypeLoginAlert, payload)))
+}
+
+func TestLoginAlertHandler_ReturnsNotifierError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	task, err := NewLoginAlertTask(uuid.New().String(), "jane@example.com", time.Now())
+	require.NoError(t, err)
+
+	sendErr := errors.New("smtp unavailable")
+	notifier := mock.NewMockNotifier(ctrl)
+	notifier.EXPECT().SendEmail(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(sendErr)
+
+	// Returning the error lets asynq retry the task
+	assert.ErrorIs(t, NewLoginAlertHandler(notifier)(context.Background(), task), sendErr)
+}
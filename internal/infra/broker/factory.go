@@ -3,20 +3,26 @@ package broker
 import (
 	"fmt"
 
+	"github.com/gieart87/gohexaclean/internal/adapter/outbound/inmem"
 	"github.com/gieart87/gohexaclean/internal/adapter/outbound/rabbitmq"
 	"github.com/gieart87/gohexaclean/internal/infra/config"
 	"github.com/gieart87/gohexaclean/internal/port/outbound/broker"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/telemetry"
 )
 
 // NewMessageBroker creates a new message broker based on configuration
-func NewMessageBroker(cfg *config.BrokerConfig) (broker.MessageBroker, error) {
+// metrics is optional and may be nil
+func NewMessageBroker(cfg *config.BrokerConfig, metrics telemetry.MetricsService) (broker.MessageBroker, error) {
 	if !cfg.Enabled {
 		return nil, fmt.Errorf("message broker is disabled")
 	}
 
 	switch cfg.Type {
 	case "rabbitmq":
-		return rabbitmq.NewRabbitMQBroker(&cfg.RabbitMQ), nil
+		return rabbitmq.NewRabbitMQBroker(&cfg.RabbitMQ, metrics), nil
+	case "inmem":
+		// Single-process pub/sub for tests and local development, nothing leaves the process
+		return inmem.NewBroker(), nil
 	// Future broker implementations can be added here
 	// case "kafka":
 	//     return kafka.NewKafkaBroker(&cfg.Kafka), nil
@@ -0,0 +1,23 @@
+package broker
+
+import (
+	"testing"
+
+	"github.com/gieart87/gohexaclean/internal/adapter/outbound/inmem"
+	"github.com/gieart87/gohexaclean/internal/infra/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMessageBroker_InMemory(t *testing.T) {
+	b, err := NewMessageBroker(&config.BrokerConfig{Enabled: true, Type: "inmem"}, nil)
+
+	require.NoError(t, err)
+	assert.IsType(t, &inmem.Broker{}, b)
+}
+
+func TestNewMessageBroker_UnsupportedType(t *testing.T) {
+	_, err := NewMessageBroker(&config.BrokerConfig{Enabled: true, Type: "carrier-pigeon"}, nil)
+
+	assert.Error(t, err)
+}
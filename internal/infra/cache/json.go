@@ -0,0 +1,36 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gieart87/gohexaclean/internal/port/outbound/service"
+)
+
+// GetJSON reads key from the cache and unmarshals it into T
+// A miss returns the cache's error (ErrCacheKeyNotFound for Redis), bad data returns ErrCacheUnmarshal
+func GetJSON[T any](ctx context.Context, cache service.CacheService, key string) (T, error) {
+	var value T
+
+	raw, err := cache.Get(ctx, key)
+	if err != nil {
+		return value, err
+	}
+
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		return value, fmt.Errorf("%w: %s: %v", ErrCacheUnmarshal, key, err)
+	}
+	return value, nil
+}
+
+// SetJSON marshals v and stores it under key, marshal failures return ErrCacheMarshal
+func SetJSON(ctx context.Context, cache service.CacheService, key string, v interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrCacheMarshal, key, err)
+	}
+
+	return cache.Set(ctx, key, string(data), ttl)
+}
@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gieart87/gohexaclean/internal/port/outbound/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cachedUser struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestGetJSON_Hit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cache := mock.NewMockCacheService(ctrl)
+
+	cache.EXPECT().Get(gomock.Any(), "user:1").Return(`{"id":"1","name":"Alice"}`, nil)
+
+	user, err := GetJSON[cachedUser](context.Background(), cache, "user:1")
+
+	require.NoError(t, err)
+	assert.Equal(t, cachedUser{ID: "1", Name: "Alice"}, user)
+}
+
+func TestGetJSON_Miss(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cache := mock.NewMockCacheService(ctrl)
+
+	cache.EXPECT().Get(gomock.Any(), "user:1").Return("", fmt.Errorf("%w: user:1", ErrCacheKeyNotFound))
+
+	user, err := GetJSON[cachedUser](context.Background(), cache, "user:1")
+
+	assert.ErrorIs(t, err, ErrCacheKeyNotFound)
+	assert.Zero(t, user)
+}
+
+func TestGetJSON_MalformedJSON(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cache := mock.NewMockCacheService(ctrl)
+
+	cache.EXPECT().Get(gomock.Any(), "user:1").Return(`{"id":`, nil)
+
+	_, err := GetJSON[cachedUser](context.Background(), cache, "user:1")
+
+	assert.ErrorIs(t, err, ErrCacheUnmarshal)
+}
+
+func TestSetJSON(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cache := mock.NewMockCacheService(ctrl)
+
+	cache.EXPECT().Set(gomock.Any(), "user:1", `{"id":"1","name":"Alice"}`, time.Minute).Return(nil)
+
+	err := SetJSON(context.Background(), cache, "user:1", cachedUser{ID: "1", Name: "Alice"}, time.Minute)
+
+	assert.NoError(t, err)
+}
+
+func TestSetJSON_MarshalError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cache := mock.NewMockCacheService(ctrl)
+
+	err := SetJSON(context.Background(), cache, "user:1", make(chan int), time.Minute)
+
+	assert.ErrorIs(t, err, ErrCacheMarshal)
+}
@@ -1,28 +1,40 @@
 package config
 
 import (
+	"crypto/rsa"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/gieart87/gohexaclean/pkg/auth"
+	"github.com/gieart87/gohexaclean/pkg/idgen"
+	"github.com/gieart87/gohexaclean/pkg/jsoncodec"
+	"github.com/gieart87/gohexaclean/pkg/pagination"
 	"github.com/joho/godotenv"
 	"gopkg.in/yaml.v3"
 )
 
 // Config holds all configuration for the application
 type Config struct {
-	App       AppConfig       `yaml:"app"`
-	Server    ServerConfig    `yaml:"server"`
-	Database  DatabaseConfig  `yaml:"database"`
-	Redis     RedisConfig     `yaml:"redis"`
-	Logger    LoggerConfig    `yaml:"logger"`
-	JWT       JWTConfig       `yaml:"jwt"`
-	CORS      CORSConfig      `yaml:"cors"`
-	RateLimit RateLimitConfig `yaml:"rate_limit"`
-	Telemetry TelemetryConfig `yaml:"telemetry"`
-	Metrics   MetricsConfig   `yaml:"metrics"`
-	Datadog   DatadogConfig   `yaml:"datadog"`
-	Broker    BrokerConfig    `yaml:"broker"`
+	App        AppConfig        `yaml:"app"`
+	Server     ServerConfig     `yaml:"server"`
+	Database   DatabaseConfig   `yaml:"database"`
+	Redis      RedisConfig      `yaml:"redis"`
+	Logger     LoggerConfig     `yaml:"logger"`
+	JWT        JWTConfig        `yaml:"jwt"`
+	Security   SecurityConfig   `yaml:"security"`
+	CORS       CORSConfig       `yaml:"cors"`
+	RateLimit  RateLimitConfig  `yaml:"rate_limit"`
+	Telemetry  TelemetryConfig  `yaml:"telemetry"`
+	Metrics    MetricsConfig    `yaml:"metrics"`
+	Datadog    DatadogConfig    `yaml:"datadog"`
+	Broker     BrokerConfig     `yaml:"broker"`
+	Bulk       BulkConfig       `yaml:"bulk"`
+	Pagination PaginationConfig `yaml:"pagination"`
+	Cache      CacheConfig      `yaml:"cache"`
+	Jobs       JobsConfig       `yaml:"jobs"`
+	Webhook    WebhookConfig    `yaml:"webhook"`
 }
 
 type AppConfig struct {
@@ -32,21 +44,59 @@ type AppConfig struct {
 }
 
 type ServerConfig struct {
-	HTTP HTTPConfig `yaml:"http"`
-	GRPC GRPCConfig `yaml:"grpc"`
+	HTTP    HTTPConfig    `yaml:"http"`
+	GRPC    GRPCConfig    `yaml:"grpc"`
+	Gateway GatewayConfig `yaml:"gateway"`
 }
 
 type HTTPConfig struct {
-	Port         int           `yaml:"port"`
-	ReadTimeout  time.Duration `yaml:"read_timeout"`
-	WriteTimeout time.Duration `yaml:"write_timeout"`
-	IdleTimeout  time.Duration `yaml:"idle_timeout"`
+	Port           int               `yaml:"port"`
+	ReadTimeout    time.Duration     `yaml:"read_timeout"`
+	WriteTimeout   time.Duration     `yaml:"write_timeout"`
+	IdleTimeout    time.Duration     `yaml:"idle_timeout"`
+	RequestTimeout time.Duration     `yaml:"request_timeout"` // per-request context deadline, 0 = disabled
+	// How long shutdown waits for in-flight requests, 0 = write_timeout
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	// How long responses to requests with an Idempotency-Key are replayed, 0 = 24h
+	IdempotencyTTL time.Duration `yaml:"idempotency_ttl"`
+	Compression    CompressionConfig `yaml:"compression"`
+	// Respond with the bare data payload instead of the {success,message,data,meta} envelope, errors stay enveloped
+	// Requests override this with ?envelope=true or ?envelope=false
+	BareResponses bool `yaml:"bare_responses"`
+	// JSON implementation for response bodies and BodyParser: std (encoding/json) or jsoniter, same output either way
+	JSONCodec string `yaml:"json_codec"`
+	// Request body limits in bytes, larger bodies are rejected with 413, 0 = default
+	MaxBodySize     int `yaml:"max_body_size"`      // every API route without its own limit, default 1MB
+	AuthMaxBodySize int `yaml:"auth_max_body_size"` // /auth routes, default 16KB
+	BulkMaxBodySize int `yaml:"bulk_max_body_size"` // bulk endpoints, default 10MB
+
+	// DisabledMethods and DisabledRoutes respond 405 instead of dispatching, e.g. for read-only deployments
+	DisabledMethods []string `yaml:"disabled_methods"` // e.g. [POST, PUT, DELETE]
+	DisabledRoutes  []string `yaml:"disabled_routes"`  // e.g. ["DELETE /api/v1/admin/users/{id}"]
+}
+
+type CompressionConfig struct {
+	Enabled bool `yaml:"enabled"`
+	Level   int  `yaml:"level"` // -1 disabled, 0 default, 1 best speed, 2 best compression
 }
 
 type GRPCConfig struct {
-	Port              int           `yaml:"port"`
-	MaxConnectionIdle time.Duration `yaml:"max_connection_idle"`
-	MaxConnectionAge  time.Duration `yaml:"max_connection_age"`
+	Port                  int           `yaml:"port"`
+	MaxConnectionIdle     time.Duration `yaml:"max_connection_idle"`
+	MaxConnectionAge      time.Duration `yaml:"max_connection_age"`
+	MaxConnectionAgeGrace time.Duration `yaml:"max_connection_age_grace"` // time for in-flight RPCs after max_connection_age
+	KeepaliveTime         time.Duration `yaml:"keepalive_time"`           // ping idle clients after this long
+	KeepaliveTimeout      time.Duration `yaml:"keepalive_timeout"`        // close the connection if a ping is not acked in time
+	// Clients pinging more often than keepalive_min_time are disconnected, 0 = 5m
+	KeepaliveMinTime  time.Duration `yaml:"keepalive_min_time"`
+	MaxRecvMsgSize    int           `yaml:"max_recv_msg_size"` // bytes, 0 = 10MB
+	MaxSendMsgSize    int           `yaml:"max_send_msg_size"` // bytes, 0 = 10MB
+}
+
+// GatewayConfig configures the REST gateway that proxies to the gRPC server
+type GatewayConfig struct {
+	Port     int    `yaml:"port"`
+	GRPCAddr string `yaml:"grpc_addr"` // gRPC server to dial, empty = localhost on server.grpc.port
 }
 
 type DatabaseConfig struct {
@@ -59,6 +109,22 @@ type DatabaseConfig struct {
 	MaxOpenConns int           `yaml:"max_open_conns"`
 	MaxIdleConns int           `yaml:"max_idle_conns"`
 	MaxLifetime  time.Duration `yaml:"max_lifetime"`
+	QueryTimeout time.Duration `yaml:"query_timeout"` // per-query deadline when the caller sets none, 0 = disabled
+	AutoMigrate  bool          `yaml:"auto_migrate"`  // apply pending migrations on startup
+	// How often connection pool stats are reported as metrics, 0 = 15s
+	PoolStatsInterval time.Duration `yaml:"pool_stats_interval"`
+	// GORM log level: silent, error, warn or info
+	LogLevel string `yaml:"log_level"`
+	// Queries slower than this are logged at warn when LogLevel is warn or info, 0 = disabled
+	SlowThreshold time.Duration `yaml:"slow_threshold"`
+	// How often connecting on startup is attempted before giving up, 0 = once
+	ConnectAttempts int `yaml:"connect_attempts"`
+	// Delay before the first reconnect, doubled after every failed attempt
+	ConnectRetryDelay time.Duration `yaml:"connect_retry_delay"`
+	// How new user IDs are generated: uuidv4, or the time-ordered uuidv7 or ulid
+	IDStrategy string `yaml:"id_strategy"`
+	// DSNs of read replicas, queries outside transactions go to one of them at random, empty = primary only
+	ReplicaDSNs []string `yaml:"replica_dsns"`
 }
 
 type RedisConfig struct {
@@ -68,6 +134,8 @@ type RedisConfig struct {
 	DB           int    `yaml:"db"`
 	PoolSize     int    `yaml:"pool_size"`
 	MinIdleConns int    `yaml:"min_idle_conns"`
+	// How often an unavailable Redis is retried (and a live one health-checked), 0 = 10s
+	ReconnectInterval time.Duration `yaml:"reconnect_interval"`
 }
 
 type LoggerConfig struct {
@@ -77,14 +145,49 @@ type LoggerConfig struct {
 }
 
 type JWTConfig struct {
-	Secret  string        `yaml:"secret"`
+	// Signing algorithm: HS256 (default, signs and verifies with secret) or RS256 (key pair)
+	Algorithm string `yaml:"algorithm"`
+	Secret    string `yaml:"secret"`
+	// PEM files for RS256, the private key may be omitted by services that only verify tokens
+	PrivateKeyPath string `yaml:"private_key_path"`
+	PublicKeyPath  string `yaml:"public_key_path"`
+	// Public keys of rotated-out signing keys, still accepted and published until their tokens expire
+	RetiredPublicKeyPaths []string `yaml:"retired_public_key_paths"`
+	// Token lifetime as a duration string, e.g. 24h or 90m
 	Expired time.Duration `yaml:"expired"`
+	// Issuer and Audience are written to iss/aud and required on incoming tokens, empty disables the check
+	Issuer   string `yaml:"issuer"`
+	Audience string `yaml:"audience"`
+	// Clock skew tolerated when checking token expiry and issue time
+	Leeway time.Duration `yaml:"leeway"`
+
+	// RS256 keys read from the paths above by LoadKeys
+	privateKey        *rsa.PrivateKey
+	publicKey         *rsa.PublicKey
+	retiredPublicKeys []*rsa.PublicKey
+}
+
+type SecurityConfig struct {
+	PasswordPolicy PasswordPolicyConfig `yaml:"password_policy"`
+}
+
+// PasswordPolicyConfig sets the rules new passwords must satisfy
+type PasswordPolicyConfig struct {
+	MinLength     int  `yaml:"min_length"`
+	RequireUpper  bool `yaml:"require_upper"`
+	RequireLower  bool `yaml:"require_lower"`
+	RequireDigit  bool `yaml:"require_digit"`
+	RequireSymbol bool `yaml:"require_symbol"`
+	RejectCommon  bool `yaml:"reject_common"` // reject passwords from the embedded common-passwords list
 }
 
 type CORSConfig struct {
-	AllowOrigins []string `yaml:"allow_origins"`
-	AllowMethods []string `yaml:"allow_methods"`
-	AllowHeaders []string `yaml:"allow_headers"`
+	AllowOrigins     []string `yaml:"allow_origins"`
+	AllowMethods     []string `yaml:"allow_methods"`
+	AllowHeaders     []string `yaml:"allow_headers"`
+	AllowCredentials bool     `yaml:"allow_credentials"` // requires explicit origins, "*" is rejected
+	ExposeHeaders    []string `yaml:"expose_headers"`
+	MaxAge           int      `yaml:"max_age"` // seconds browsers may cache a preflight, 0 = no caching
 }
 
 type RateLimitConfig struct {
@@ -93,10 +196,50 @@ type RateLimitConfig struct {
 	Window  time.Duration `yaml:"window"`
 }
 
+// BulkConfig bounds how many items of a bulk operation are processed in parallel
+type BulkConfig struct {
+	Concurrency int `yaml:"concurrency"`
+}
+
+// PaginationConfig bounds the page size clients may request from the user listings
+type PaginationConfig struct {
+	DefaultLimit int `yaml:"default_limit"` // used when no or a non-positive limit is requested, 0 = 10
+	MaxLimit     int `yaml:"max_limit"`     // larger limits are capped to it, 0 = 100
+}
+
+// CacheConfig configures how long derived values are cached
+type CacheConfig struct {
+	// UserTTL bounds how long a cached user may be served, 0 = 5m
+	UserTTL time.Duration `yaml:"user_ttl"`
+	// UserCountTTL bounds how long the total user count shown in paginated lists may be stale
+	UserCountTTL time.Duration `yaml:"user_count_ttl"`
+}
+
+// JobsConfig configures how background jobs are triggered
+type JobsConfig struct {
+	// Welcome emails are enqueued by the user.created consumer, this enqueues them directly when the broker is disabled
+	WelcomeEmailFallback bool `yaml:"welcome_email_fallback"`
+}
+
+// WebhookConfig configures the HTTP callbacks sent to partners for every consumed user event
+type WebhookConfig struct {
+	URLs       []string      `yaml:"urls"`        // empty disables webhooks
+	Secret     string        `yaml:"secret"`      // HMAC-SHA256 key signing every delivery, required with urls
+	Timeout    time.Duration `yaml:"timeout"`     // per delivery attempt, 0 = 5s
+	MaxRetries int           `yaml:"max_retries"` // retries after the first attempt, 0 disables retries
+	RetryDelay time.Duration `yaml:"retry_delay"` // before the first retry, doubled after every failed attempt, 0 = 1s
+	// DeadLetterPath is a JSON Lines file keeping the deliveries that still failed after their retries,
+	// one line per URL, empty only logs them
+	DeadLetterPath string `yaml:"dead_letter_path"`
+}
+
 type TelemetryConfig struct {
 	Enabled           bool   `yaml:"enabled"`
 	ServiceName       string `yaml:"service_name"`
 	CollectorEndpoint string `yaml:"collector_endpoint"`
+	// Exporter selects how metrics leave the process: "otlp" (default) pushes to the collector,
+	// "prometheus" exposes them on /metrics for scraping
+	Exporter string `yaml:"exporter"`
 }
 
 type MetricsConfig struct {
@@ -114,10 +257,19 @@ type DatadogConfig struct {
 }
 
 type BrokerConfig struct {
-	Type     string        `yaml:"type"` // rabbitmq, kafka, pubsub, nats
+	Type     string        `yaml:"type"` // rabbitmq, inmem, kafka, pubsub, nats
 	Enabled  bool          `yaml:"enabled"`
 	RabbitMQ RabbitMQConfig `yaml:"rabbitmq"`
 	// Future: Kafka, PubSub, NATS configs can be added here
+	ConsumerRetry ConsumerRetryConfig `yaml:"consumer_retry"`
+	// DedupTTL is how long consumed event IDs are remembered so redelivered events are skipped, 0 = 24h
+	DedupTTL time.Duration `yaml:"dedup_ttl"`
+}
+
+// ConsumerRetryConfig bounds how often a failing event handler is retried before the message is given up on
+type ConsumerRetryConfig struct {
+	MaxRetries int           `yaml:"max_retries"` // 0 disables retries
+	BaseDelay  time.Duration `yaml:"base_delay"`  // doubled after every failed attempt
 }
 
 type RabbitMQConfig struct {
@@ -135,6 +287,20 @@ type RabbitMQConfig struct {
 	MaxReconnect     int           `yaml:"max_reconnect"`
 	Persistent       bool          `yaml:"persistent"`
 	ConnectionName   string        `yaml:"connection_name"`
+	DeadLetter       DeadLetterConfig `yaml:"dead_letter"`
+}
+
+// DeadLetterConfig configures dead-letter queues (DLQ) for failed messages
+// Note: enabling it on existing queues requires recreating them, since RabbitMQ
+// rejects redeclaring a queue with different arguments
+type DeadLetterConfig struct {
+	Enabled       bool          `yaml:"enabled"`
+	Exchange      string        `yaml:"exchange"`       // dead-letter exchange name
+	QueueSuffix   string        `yaml:"queue_suffix"`   // appended to the source queue name
+	MaxLength     int           `yaml:"max_length"`     // x-max-length for the DLQ, 0 = unlimited
+	Retention     time.Duration `yaml:"retention"`      // messages older than this are purged, 0 = keep forever
+	PurgeInterval time.Duration `yaml:"purge_interval"` // how often the purge runs
+	ArchivePath   string        `yaml:"archive_path"`   // optional JSON Lines file to archive messages before purge
 }
 
 // GetAMQPURL returns the RabbitMQ connection URL
@@ -167,13 +333,35 @@ func Load(configPath string) (*Config, error) {
 	}
 
 	// Override with environment variables
-	overrideFromEnv(&cfg)
+	if err := overrideFromEnv(&cfg); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	if err := cfg.JWT.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	if err := idgen.Strategy(cfg.Database.IDStrategy).Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: database.id_strategy: %w", err)
+	}
+	if err := jsoncodec.Codec(cfg.Server.HTTP.JSONCodec).Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: server.http.json_codec: %w", err)
+	}
+	if err := cfg.Webhook.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	if err := cfg.Pagination.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	if err := cfg.JWT.LoadKeys(); err != nil {
+		return nil, fmt.Errorf("failed to load JWT keys: %w", err)
+	}
 
 	return &cfg, nil
 }
 
 // overrideFromEnv overrides config values with environment variables if they exist
-func overrideFromEnv(cfg *Config) {
+// A JWT expiry that doesn't parse is an error rather than silently keeping the file's value
+func overrideFromEnv(cfg *Config) error {
 	if v := os.Getenv("APP_NAME"); v != "" {
 		cfg.App.Name = v
 	}
@@ -187,9 +375,120 @@ func overrideFromEnv(cfg *Config) {
 	if v := os.Getenv("HTTP_PORT"); v != "" {
 		fmt.Sscanf(v, "%d", &cfg.Server.HTTP.Port)
 	}
+	if v := os.Getenv("HTTP_REQUEST_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Server.HTTP.RequestTimeout = d
+		}
+	}
+	if v := os.Getenv("HTTP_SHUTDOWN_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Server.HTTP.ShutdownTimeout = d
+		}
+	}
+	if v := os.Getenv("HTTP_IDEMPOTENCY_TTL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Server.HTTP.IdempotencyTTL = d
+		}
+	}
+	if v := os.Getenv("HTTP_DISABLED_METHODS"); v != "" {
+		cfg.Server.HTTP.DisabledMethods = strings.Split(v, ",")
+	}
+	if v := os.Getenv("HTTP_COMPRESSION_ENABLED"); v != "" {
+		cfg.Server.HTTP.Compression.Enabled = v == "true"
+	}
+	if v := os.Getenv("HTTP_BARE_RESPONSES"); v != "" {
+		cfg.Server.HTTP.BareResponses = v == "true"
+	}
+	if v := os.Getenv("HTTP_JSON_CODEC"); v != "" {
+		cfg.Server.HTTP.JSONCodec = v
+	}
+	if v := os.Getenv("HTTP_MAX_BODY_SIZE"); v != "" {
+		fmt.Sscanf(v, "%d", &cfg.Server.HTTP.MaxBodySize)
+	}
+	if v := os.Getenv("HTTP_AUTH_MAX_BODY_SIZE"); v != "" {
+		fmt.Sscanf(v, "%d", &cfg.Server.HTTP.AuthMaxBodySize)
+	}
+	if v := os.Getenv("HTTP_BULK_MAX_BODY_SIZE"); v != "" {
+		fmt.Sscanf(v, "%d", &cfg.Server.HTTP.BulkMaxBodySize)
+	}
+	if v := os.Getenv("CORS_ALLOW_CREDENTIALS"); v != "" {
+		cfg.CORS.AllowCredentials = v == "true"
+	}
+	if v := os.Getenv("CORS_EXPOSE_HEADERS"); v != "" {
+		cfg.CORS.ExposeHeaders = strings.Split(v, ",")
+	}
+	if v := os.Getenv("CORS_MAX_AGE"); v != "" {
+		fmt.Sscanf(v, "%d", &cfg.CORS.MaxAge)
+	}
+	if v := os.Getenv("BULK_CONCURRENCY"); v != "" {
+		fmt.Sscanf(v, "%d", &cfg.Bulk.Concurrency)
+	}
+	if v := os.Getenv("PAGINATION_DEFAULT_LIMIT"); v != "" {
+		fmt.Sscanf(v, "%d", &cfg.Pagination.DefaultLimit)
+	}
+	if v := os.Getenv("PAGINATION_MAX_LIMIT"); v != "" {
+		fmt.Sscanf(v, "%d", &cfg.Pagination.MaxLimit)
+	}
+	if v := os.Getenv("CACHE_USER_TTL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Cache.UserTTL = d
+		}
+	}
+	if v := os.Getenv("CACHE_USER_COUNT_TTL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Cache.UserCountTTL = d
+		}
+	}
+	if v := os.Getenv("JOBS_WELCOME_EMAIL_FALLBACK"); v != "" {
+		cfg.Jobs.WelcomeEmailFallback = v == "true"
+	}
+	if v := os.Getenv("WEBHOOK_URLS"); v != "" {
+		cfg.Webhook.URLs = strings.Split(v, ",")
+	}
+	if v := os.Getenv("WEBHOOK_SECRET"); v != "" {
+		cfg.Webhook.Secret = v
+	}
+	if v := os.Getenv("WEBHOOK_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Webhook.Timeout = d
+		}
+	}
+	if v := os.Getenv("WEBHOOK_MAX_RETRIES"); v != "" {
+		fmt.Sscanf(v, "%d", &cfg.Webhook.MaxRetries)
+	}
+	if v := os.Getenv("WEBHOOK_RETRY_DELAY"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Webhook.RetryDelay = d
+		}
+	}
+	if v := os.Getenv("WEBHOOK_DEAD_LETTER_PATH"); v != "" {
+		cfg.Webhook.DeadLetterPath = v
+	}
 	if v := os.Getenv("GRPC_PORT"); v != "" {
 		fmt.Sscanf(v, "%d", &cfg.Server.GRPC.Port)
 	}
+	if v := os.Getenv("GATEWAY_PORT"); v != "" {
+		fmt.Sscanf(v, "%d", &cfg.Server.Gateway.Port)
+	}
+	if v := os.Getenv("GATEWAY_GRPC_ADDR"); v != "" {
+		cfg.Server.Gateway.GRPCAddr = v
+	}
+	if v := os.Getenv("GRPC_MAX_CONNECTION_IDLE"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Server.GRPC.MaxConnectionIdle = d
+		}
+	}
+	if v := os.Getenv("GRPC_MAX_CONNECTION_AGE"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Server.GRPC.MaxConnectionAge = d
+		}
+	}
+	if v := os.Getenv("GRPC_MAX_RECV_MSG_SIZE"); v != "" {
+		fmt.Sscanf(v, "%d", &cfg.Server.GRPC.MaxRecvMsgSize)
+	}
+	if v := os.Getenv("GRPC_MAX_SEND_MSG_SIZE"); v != "" {
+		fmt.Sscanf(v, "%d", &cfg.Server.GRPC.MaxSendMsgSize)
+	}
 
 	if v := os.Getenv("DB_HOST"); v != "" {
 		cfg.Database.Host = v
@@ -206,6 +505,41 @@ func overrideFromEnv(cfg *Config) {
 	if v := os.Getenv("DB_NAME"); v != "" {
 		cfg.Database.Name = v
 	}
+	if v := os.Getenv("DB_QUERY_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Database.QueryTimeout = d
+		}
+	}
+	if v := os.Getenv("DB_POOL_STATS_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Database.PoolStatsInterval = d
+		}
+	}
+	if v := os.Getenv("DB_AUTO_MIGRATE"); v != "" {
+		cfg.Database.AutoMigrate = v == "true"
+	}
+	if v := os.Getenv("DB_LOG_LEVEL"); v != "" {
+		cfg.Database.LogLevel = v
+	}
+	if v := os.Getenv("DB_SLOW_THRESHOLD"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Database.SlowThreshold = d
+		}
+	}
+	if v := os.Getenv("DB_CONNECT_ATTEMPTS"); v != "" {
+		fmt.Sscanf(v, "%d", &cfg.Database.ConnectAttempts)
+	}
+	if v := os.Getenv("DB_CONNECT_RETRY_DELAY"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Database.ConnectRetryDelay = d
+		}
+	}
+	if v := os.Getenv("DB_ID_STRATEGY"); v != "" {
+		cfg.Database.IDStrategy = v
+	}
+	if v := os.Getenv("DB_REPLICA_DSNS"); v != "" {
+		cfg.Database.ReplicaDSNs = strings.Split(v, ",")
+	}
 
 	if v := os.Getenv("REDIS_HOST"); v != "" {
 		cfg.Redis.Host = v
@@ -216,10 +550,64 @@ func overrideFromEnv(cfg *Config) {
 	if v := os.Getenv("REDIS_PASSWORD"); v != "" {
 		cfg.Redis.Password = v
 	}
+	if v := os.Getenv("REDIS_RECONNECT_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Redis.ReconnectInterval = d
+		}
+	}
 
 	if v := os.Getenv("JWT_SECRET"); v != "" {
 		cfg.JWT.Secret = v
 	}
+	if v := os.Getenv("JWT_EXPIRED"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("JWT_EXPIRED must be a duration such as 24h: %w", err)
+		}
+		cfg.JWT.Expired = d
+	}
+	if v := os.Getenv("JWT_ALGORITHM"); v != "" {
+		cfg.JWT.Algorithm = v
+	}
+	if v := os.Getenv("JWT_PRIVATE_KEY_PATH"); v != "" {
+		cfg.JWT.PrivateKeyPath = v
+	}
+	if v := os.Getenv("JWT_PUBLIC_KEY_PATH"); v != "" {
+		cfg.JWT.PublicKeyPath = v
+	}
+	if v := os.Getenv("JWT_RETIRED_PUBLIC_KEY_PATHS"); v != "" {
+		cfg.JWT.RetiredPublicKeyPaths = strings.Split(v, ",")
+	}
+	if v := os.Getenv("JWT_ISSUER"); v != "" {
+		cfg.JWT.Issuer = v
+	}
+	if v := os.Getenv("JWT_AUDIENCE"); v != "" {
+		cfg.JWT.Audience = v
+	}
+	if v := os.Getenv("JWT_LEEWAY"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.JWT.Leeway = d
+		}
+	}
+
+	if v := os.Getenv("PASSWORD_MIN_LENGTH"); v != "" {
+		fmt.Sscanf(v, "%d", &cfg.Security.PasswordPolicy.MinLength)
+	}
+	if v := os.Getenv("PASSWORD_REQUIRE_UPPER"); v != "" {
+		cfg.Security.PasswordPolicy.RequireUpper = v == "true"
+	}
+	if v := os.Getenv("PASSWORD_REQUIRE_LOWER"); v != "" {
+		cfg.Security.PasswordPolicy.RequireLower = v == "true"
+	}
+	if v := os.Getenv("PASSWORD_REQUIRE_DIGIT"); v != "" {
+		cfg.Security.PasswordPolicy.RequireDigit = v == "true"
+	}
+	if v := os.Getenv("PASSWORD_REQUIRE_SYMBOL"); v != "" {
+		cfg.Security.PasswordPolicy.RequireSymbol = v == "true"
+	}
+	if v := os.Getenv("PASSWORD_REJECT_COMMON"); v != "" {
+		cfg.Security.PasswordPolicy.RejectCommon = v == "true"
+	}
 
 	if v := os.Getenv("LOG_LEVEL"); v != "" {
 		cfg.Logger.Level = v
@@ -249,6 +637,9 @@ func overrideFromEnv(cfg *Config) {
 	if v := os.Getenv("OTEL_COLLECTOR_ENDPOINT"); v != "" {
 		cfg.Telemetry.CollectorEndpoint = v
 	}
+	if v := os.Getenv("OTEL_EXPORTER"); v != "" {
+		cfg.Telemetry.Exporter = v
+	}
 
 	// Message Broker configuration
 	if v := os.Getenv("BROKER_TYPE"); v != "" {
@@ -257,6 +648,19 @@ func overrideFromEnv(cfg *Config) {
 	if v := os.Getenv("BROKER_ENABLED"); v == "true" {
 		cfg.Broker.Enabled = true
 	}
+	if v := os.Getenv("BROKER_CONSUMER_MAX_RETRIES"); v != "" {
+		fmt.Sscanf(v, "%d", &cfg.Broker.ConsumerRetry.MaxRetries)
+	}
+	if v := os.Getenv("BROKER_CONSUMER_RETRY_BASE_DELAY"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Broker.ConsumerRetry.BaseDelay = d
+		}
+	}
+	if v := os.Getenv("BROKER_CONSUMER_DEDUP_TTL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Broker.DedupTTL = d
+		}
+	}
 	if v := os.Getenv("RABBITMQ_URL"); v != "" {
 		cfg.Broker.RabbitMQ.URL = v
 	}
@@ -269,6 +673,183 @@ func overrideFromEnv(cfg *Config) {
 	if v := os.Getenv("RABBITMQ_PASSWORD"); v != "" {
 		cfg.Broker.RabbitMQ.Password = v
 	}
+	if v := os.Getenv("RABBITMQ_DLQ_ENABLED"); v == "true" {
+		cfg.Broker.RabbitMQ.DeadLetter.Enabled = true
+	}
+	if v := os.Getenv("RABBITMQ_DLQ_RETENTION"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Broker.RabbitMQ.DeadLetter.Retention = d
+		}
+	}
+	if v := os.Getenv("RABBITMQ_DLQ_ARCHIVE_PATH"); v != "" {
+		cfg.Broker.RabbitMQ.DeadLetter.ArchivePath = v
+	}
+
+	return nil
+}
+
+// GetShutdownTimeout returns how long shutdown waits for in-flight requests
+// It falls back to the write timeout, then to 30s
+func (c *HTTPConfig) GetShutdownTimeout() time.Duration {
+	if c.ShutdownTimeout > 0 {
+		return c.ShutdownTimeout
+	}
+	if c.WriteTimeout > 0 {
+		return c.WriteTimeout
+	}
+	return 30 * time.Second
+}
+
+// GetIdempotencyTTL returns how long idempotent responses are kept, defaulting to 24h
+func (c *HTTPConfig) GetIdempotencyTTL() time.Duration {
+	if c.IdempotencyTTL > 0 {
+		return c.IdempotencyTTL
+	}
+	return 24 * time.Hour
+}
+
+// GetMaxBodySize returns the default request body limit, defaulting to 1MB
+func (c *HTTPConfig) GetMaxBodySize() int {
+	if c.MaxBodySize > 0 {
+		return c.MaxBodySize
+	}
+	return 1 << 20
+}
+
+// GetAuthMaxBodySize returns the request body limit of the /auth routes, defaulting to 16KB
+func (c *HTTPConfig) GetAuthMaxBodySize() int {
+	if c.AuthMaxBodySize > 0 {
+		return c.AuthMaxBodySize
+	}
+	return 16 << 10
+}
+
+// GetBulkMaxBodySize returns the request body limit of the bulk endpoints, defaulting to 10MB
+func (c *HTTPConfig) GetBulkMaxBodySize() int {
+	if c.BulkMaxBodySize > 0 {
+		return c.BulkMaxBodySize
+	}
+	return 10 << 20
+}
+
+// BodyLimit returns the largest configured request body limit, the server-wide cap that
+// per-route limits are enforced under
+func (c *HTTPConfig) BodyLimit() int {
+	limit := c.GetMaxBodySize()
+	for _, l := range []int{c.GetAuthMaxBodySize(), c.GetBulkMaxBodySize()} {
+		if l > limit {
+			limit = l
+		}
+	}
+	return limit
+}
+
+// Validate checks that tokens get a positive lifetime and the algorithm has the keys it needs
+func (c *JWTConfig) Validate() error {
+	switch c.Algorithm {
+	case "", auth.AlgorithmHS256:
+	case auth.AlgorithmRS256:
+		if c.PublicKeyPath == "" {
+			return fmt.Errorf("jwt.public_key_path is required for %s", auth.AlgorithmRS256)
+		}
+	default:
+		return fmt.Errorf("jwt.algorithm must be %s or %s, got %q", auth.AlgorithmHS256, auth.AlgorithmRS256, c.Algorithm)
+	}
+	if c.Expired <= 0 {
+		return fmt.Errorf("jwt.expired must be a positive duration such as 24h, got %s", c.Expired)
+	}
+	if c.Leeway < 0 {
+		return fmt.Errorf("jwt.leeway must not be negative, got %s", c.Leeway)
+	}
+	return nil
+}
+
+// Enabled reports whether any webhook URL is configured
+func (c *WebhookConfig) Enabled() bool {
+	return len(c.URLs) > 0
+}
+
+// Validate checks that deliveries can be signed and retried sensibly
+func (c *WebhookConfig) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if c.Secret == "" {
+		return fmt.Errorf("webhook.secret is required when webhook.urls is set")
+	}
+	if c.MaxRetries < 0 {
+		return fmt.Errorf("webhook.max_retries must not be negative, got %d", c.MaxRetries)
+	}
+	return nil
+}
+
+// Validate rejects negative limits and a default larger than the maximum
+func (c *PaginationConfig) Validate() error {
+	if c.DefaultLimit < 0 || c.MaxLimit < 0 {
+		return fmt.Errorf("pagination limits must not be negative, got default_limit %d and max_limit %d", c.DefaultLimit, c.MaxLimit)
+	}
+	if bounds := c.Bounds(); bounds.DefaultLimit > bounds.MaxLimit {
+		return fmt.Errorf("pagination.default_limit %d exceeds pagination.max_limit %d", bounds.DefaultLimit, bounds.MaxLimit)
+	}
+	return nil
+}
+
+// Bounds returns the configured page size bounds, unset limits fall back to the package defaults
+func (c *PaginationConfig) Bounds() pagination.Bounds {
+	bounds := pagination.Default
+	if c.DefaultLimit > 0 {
+		bounds.DefaultLimit = c.DefaultLimit
+	}
+	if c.MaxLimit > 0 {
+		bounds.MaxLimit = c.MaxLimit
+	}
+	return bounds
+}
+
+// LoadKeys reads the RS256 key pair, it does nothing for HS256
+func (c *JWTConfig) LoadKeys() error {
+	if c.Algorithm != auth.AlgorithmRS256 {
+		return nil
+	}
+
+	publicKey, err := auth.LoadRSAPublicKey(c.PublicKeyPath)
+	if err != nil {
+		return err
+	}
+	c.publicKey = publicKey
+
+	if c.PrivateKeyPath != "" {
+		privateKey, err := auth.LoadRSAPrivateKey(c.PrivateKeyPath)
+		if err != nil {
+			return err
+		}
+		c.privateKey = privateKey
+	}
+
+	c.retiredPublicKeys = nil
+	for _, path := range c.RetiredPublicKeyPaths {
+		key, err := auth.LoadRSAPublicKey(strings.TrimSpace(path))
+		if err != nil {
+			return err
+		}
+		c.retiredPublicKeys = append(c.retiredPublicKeys, key)
+	}
+	return nil
+}
+
+// TokenOptions returns the signing and validation settings for JWTs
+func (c *JWTConfig) TokenOptions() auth.TokenOptions {
+	return auth.TokenOptions{
+		Algorithm:  c.Algorithm,
+		Secret:     c.Secret,
+		PrivateKey: c.privateKey,
+		PublicKey:  c.publicKey,
+		Issuer:     c.Issuer,
+		Audience:   c.Audience,
+		Leeway:     c.Leeway,
+
+		RetiredPublicKeys: c.retiredPublicKeys,
+	}
 }
 
 // GetDSN returns the database connection string
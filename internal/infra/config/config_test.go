@@ -0,0 +1,234 @@
+package config
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeConfig writes a minimal config file with the given JWT expiry and returns its path
+func writeConfig(t *testing.T, expired string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	content := "jwt:\n  secret: test-secret\n  expired: " + expired + "\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad_JWTExpiredDuration(t *testing.T) {
+	tests := []struct {
+		expired string
+		want    time.Duration
+	}{
+		{"24h", 24 * time.Hour},
+		{"90m", 90 * time.Minute},
+		{"1h30m", 90 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expired, func(t *testing.T) {
+			t.Setenv("JWT_EXPIRED", "")
+
+			cfg, err := Load(writeConfig(t, tt.expired))
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, cfg.JWT.Expired)
+		})
+	}
+}
+
+func TestLoad_JWTExpiredInvalid(t *testing.T) {
+	tests := []string{"tomorrow", "0s", "-1h"}
+
+	for _, expired := range tests {
+		t.Run(expired, func(t *testing.T) {
+			t.Setenv("JWT_EXPIRED", "")
+
+			cfg, err := Load(writeConfig(t, expired))
+
+			assert.Error(t, err)
+			assert.Nil(t, cfg)
+		})
+	}
+}
+
+func TestLoad_JWTExpiredFromEnv(t *testing.T) {
+	t.Setenv("JWT_EXPIRED", "90m")
+
+	cfg, err := Load(writeConfig(t, "24h"))
+
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, cfg.JWT.Expired)
+}
+
+func TestLoad_JWTExpiredFromEnvInvalid(t *testing.T) {
+	for _, expired := range []string{"tomorrow", "24"} {
+		t.Run(expired, func(t *testing.T) {
+			t.Setenv("JWT_EXPIRED", expired)
+
+			cfg, err := Load(writeConfig(t, "24h"))
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "JWT_EXPIRED")
+			assert.Nil(t, cfg)
+		})
+	}
+}
+
+func TestLoad_JWTBindingFromEnv(t *testing.T) {
+	t.Setenv("JWT_EXPIRED", "")
+	t.Setenv("JWT_ISSUER", "gohexaclean")
+	t.Setenv("JWT_AUDIENCE", "gohexaclean-api")
+	t.Setenv("JWT_LEEWAY", "45s")
+
+	cfg, err := Load(writeConfig(t, "24h"))
+	require.NoError(t, err)
+
+	opts := cfg.JWT.TokenOptions()
+	assert.Equal(t, "test-secret", opts.Secret)
+	assert.Equal(t, "gohexaclean", opts.Issuer)
+	assert.Equal(t, "gohexaclean-api", opts.Audience)
+	assert.Equal(t, 45*time.Second, opts.Leeway)
+}
+
+func TestLoad_JWTNegativeLeeway(t *testing.T) {
+	t.Setenv("JWT_EXPIRED", "")
+	t.Setenv("JWT_LEEWAY", "-5s")
+
+	cfg, err := Load(writeConfig(t, "24h"))
+
+	assert.Error(t, err)
+	assert.Nil(t, cfg)
+}
+
+// writeRSAKeyPair writes a PEM encoded RSA key pair and returns their paths
+func writeRSAKeyPair(t *testing.T) (privatePath, publicPath string) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	publicDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	privatePath = filepath.Join(dir, "jwt_private.pem")
+	publicPath = filepath.Join(dir, "jwt_public.pem")
+	require.NoError(t, os.WriteFile(privatePath, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0o600))
+	require.NoError(t, os.WriteFile(publicPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}), 0o600))
+	return privatePath, publicPath
+}
+
+func TestLoad_JWTRS256LoadsKeys(t *testing.T) {
+	privatePath, publicPath := writeRSAKeyPair(t)
+	t.Setenv("JWT_EXPIRED", "")
+	t.Setenv("JWT_ALGORITHM", "RS256")
+	t.Setenv("JWT_PRIVATE_KEY_PATH", privatePath)
+	t.Setenv("JWT_PUBLIC_KEY_PATH", publicPath)
+
+	cfg, err := Load(writeConfig(t, "24h"))
+	require.NoError(t, err)
+
+	opts := cfg.JWT.TokenOptions()
+	assert.Equal(t, "RS256", opts.Algorithm)
+	require.NotNil(t, opts.PrivateKey)
+	require.NotNil(t, opts.PublicKey)
+	assert.True(t, opts.PrivateKey.PublicKey.Equal(opts.PublicKey))
+}
+
+func TestLoad_JWTAlgorithmInvalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		algorithm string
+		publicKey string
+	}{
+		{name: "unsupported algorithm", algorithm: "HS512"},
+		{name: "RS256 without public key", algorithm: "RS256"},
+		{name: "RS256 with missing key file", algorithm: "RS256", publicKey: "/nonexistent/jwt_public.pem"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("JWT_EXPIRED", "")
+			t.Setenv("JWT_ALGORITHM", tt.algorithm)
+			t.Setenv("JWT_PUBLIC_KEY_PATH", tt.publicKey)
+
+			cfg, err := Load(writeConfig(t, "24h"))
+
+			assert.Error(t, err)
+			assert.Nil(t, cfg)
+		})
+	}
+}
+
+func TestLoad_JWTRetiredPublicKeys(t *testing.T) {
+	privatePath, publicPath := writeRSAKeyPair(t)
+	_, retiredPath := writeRSAKeyPair(t)
+	t.Setenv("JWT_EXPIRED", "")
+	t.Setenv("JWT_ALGORITHM", "RS256")
+	t.Setenv("JWT_PRIVATE_KEY_PATH", privatePath)
+	t.Setenv("JWT_PUBLIC_KEY_PATH", publicPath)
+	t.Setenv("JWT_RETIRED_PUBLIC_KEY_PATHS", retiredPath)
+
+	cfg, err := Load(writeConfig(t, "24h"))
+	require.NoError(t, err)
+
+	opts := cfg.JWT.TokenOptions()
+	require.Len(t, opts.RetiredPublicKeys, 1)
+	assert.False(t, opts.RetiredPublicKeys[0].Equal(opts.PublicKey))
+}
+
+func TestLoad_PaginationFromEnv(t *testing.T) {
+	t.Setenv("JWT_EXPIRED", "")
+	t.Setenv("PAGINATION_DEFAULT_LIMIT", "25")
+	t.Setenv("PAGINATION_MAX_LIMIT", "250")
+
+	cfg, err := Load(writeConfig(t, "24h"))
+
+	require.NoError(t, err)
+	assert.Equal(t, 25, cfg.Pagination.Bounds().DefaultLimit)
+	assert.Equal(t, 250, cfg.Pagination.Bounds().MaxLimit)
+}
+
+func TestLoad_PaginationDefaultAboveMax(t *testing.T) {
+	t.Setenv("JWT_EXPIRED", "")
+	t.Setenv("PAGINATION_DEFAULT_LIMIT", "50")
+	t.Setenv("PAGINATION_MAX_LIMIT", "20")
+
+	cfg, err := Load(writeConfig(t, "24h"))
+
+	assert.ErrorContains(t, err, "pagination.default_limit")
+	assert.Nil(t, cfg)
+}
+
+func TestPaginationConfig_Bounds(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         PaginationConfig
+		wantDefault int
+		wantMax     int
+		wantErr     bool
+	}{
+		{name: "unset falls back to the package defaults", wantDefault: 10, wantMax: 100},
+		{name: "configured", cfg: PaginationConfig{DefaultLimit: 20, MaxLimit: 500}, wantDefault: 20, wantMax: 500},
+		{name: "max below the fallback default", cfg: PaginationConfig{MaxLimit: 5}, wantDefault: 10, wantMax: 5, wantErr: true},
+		{name: "negative", cfg: PaginationConfig{DefaultLimit: -1}, wantDefault: 10, wantMax: 100, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bounds := tt.cfg.Bounds()
+			assert.Equal(t, tt.wantDefault, bounds.DefaultLimit)
+			assert.Equal(t, tt.wantMax, bounds.MaxLimit)
+			assert.Equal(t, tt.wantErr, tt.cfg.Validate() != nil)
+		})
+	}
+}
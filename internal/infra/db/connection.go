@@ -1,19 +1,22 @@
 package db
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/gieart87/gohexaclean/internal/infra/config"
+	"go.uber.org/zap"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
+	"gorm.io/plugin/dbresolver"
 )
 
-// NewGormConnection creates a new GORM database connection
-func NewGormConnection(cfg *config.DatabaseConfig) (*gorm.DB, error) {
-	// Configure GORM logger (silent by default)
-	gormLogger := logger.Default.LogMode(logger.Silent)
+// NewGormConnection creates a new GORM database connection logging through log
+// redactParams keeps bound values out of logged queries
+func NewGormConnection(cfg *config.DatabaseConfig, log *zap.Logger, redactParams bool) (*gorm.DB, error) {
+	// Configure GORM logger (silent unless a log level is set)
+	gormLogger := NewGormLogger(log, ParseGormLogLevel(cfg.LogLevel), cfg.SlowThreshold, redactParams)
 
 	// Open GORM connection
 	db, err := gorm.Open(postgres.Open(cfg.GetDSN()), &gorm.Config{
@@ -38,12 +41,89 @@ func NewGormConnection(cfg *config.DatabaseConfig) (*gorm.DB, error) {
 
 	// Test connection
 	if err := sqlDB.Ping(); err != nil {
+		_ = sqlDB.Close()
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
+	// Route reads to the replicas, without any the primary serves everything
+	replicas := make([]gorm.Dialector, 0, len(cfg.ReplicaDSNs))
+	for _, dsn := range cfg.ReplicaDSNs {
+		replicas = append(replicas, postgres.Open(dsn))
+	}
+	if err := registerReplicas(db, replicas, cfg); err != nil {
+		_ = sqlDB.Close()
+		return nil, err
+	}
+
 	return db, nil
 }
 
+// registerReplicas sends queries to replicas through the dbresolver plugin
+// Writes, raw statements other than SELECT and everything inside a transaction stay on the primary,
+// as do queries marked with dbresolver.Write such as the user lookups by ID and email
+// Replica pools use the primary's pool settings, db is left untouched when there are no replicas
+func registerReplicas(db *gorm.DB, replicas []gorm.Dialector, cfg *config.DatabaseConfig) error {
+	if len(replicas) == 0 {
+		return nil
+	}
+
+	resolver := dbresolver.Register(dbresolver.Config{
+		Replicas: replicas,
+		Policy:   dbresolver.RandomPolicy{},
+	}).
+		SetMaxOpenConns(cfg.MaxOpenConns).
+		SetMaxIdleConns(cfg.MaxIdleConns).
+		SetConnMaxLifetime(cfg.MaxLifetime * time.Minute)
+
+	if err := db.Use(resolver); err != nil {
+		return fmt.Errorf("failed to register read replicas: %w", err)
+	}
+	return nil
+}
+
+// dialFunc opens a database connection, failing while the database is unreachable
+type dialFunc func() (*gorm.DB, error)
+
+// ConnectWithRetry opens the connection like NewGormConnection, retrying up to cfg.ConnectAttempts times
+// so a database that starts alongside the app doesn't crash it
+// The delay between attempts starts at cfg.ConnectRetryDelay and doubles after every failure
+func ConnectWithRetry(ctx context.Context, cfg *config.DatabaseConfig, log *zap.Logger, redactParams bool) (*gorm.DB, error) {
+	return connectWithRetry(ctx, cfg.ConnectAttempts, cfg.ConnectRetryDelay, log, func() (*gorm.DB, error) {
+		return NewGormConnection(cfg, log, redactParams)
+	})
+}
+
+// connectWithRetry calls dial until it succeeds, attempts are used up or ctx is done
+func connectWithRetry(ctx context.Context, attempts int, delay time.Duration, log *zap.Logger, dial dialFunc) (*gorm.DB, error) {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		db, err := dial()
+		if err == nil {
+			return db, nil
+		}
+		if attempt >= attempts {
+			return nil, fmt.Errorf("database unreachable after %d attempts: %w", attempts, err)
+		}
+
+		log.Warn("Database connection failed, retrying",
+			zap.Int("attempt", attempt),
+			zap.Int("max_attempts", attempts),
+			zap.Duration("retry_in", delay),
+			zap.Error(err),
+		)
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		delay *= 2
+	}
+}
+
 // Close closes the GORM database connection
 func Close(db *gorm.DB) error {
 	if db != nil {
@@ -55,3 +135,12 @@ func Close(db *gorm.DB) error {
 	}
 	return nil
 }
+
+// Ping verifies the database is still reachable
+func Ping(ctx context.Context, db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
@@ -0,0 +1,134 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gieart87/gohexaclean/internal/infra/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// flakyDial fails the first failures calls, then returns conn
+func flakyDial(conn *gorm.DB, failures int, calls *int) dialFunc {
+	return func() (*gorm.DB, error) {
+		*calls++
+		if *calls <= failures {
+			return nil, errors.New("connection refused")
+		}
+		return conn, nil
+	}
+}
+
+func TestConnectWithRetry_SucceedsAfterTransientFailures(t *testing.T) {
+	core, logs := observer.New(zapcore.WarnLevel)
+	conn := &gorm.DB{}
+
+	var calls int
+	got, err := connectWithRetry(context.Background(), 5, time.Millisecond, zap.New(core), flakyDial(conn, 2, &calls))
+
+	require.NoError(t, err)
+	assert.Same(t, conn, got)
+	assert.Equal(t, 3, calls)
+
+	// Every failed attempt is logged
+	entries := logs.FilterMessage("Database connection failed, retrying").All()
+	require.Len(t, entries, 2)
+	assert.Equal(t, int64(1), entries[0].ContextMap()["attempt"])
+	assert.Equal(t, int64(2), entries[1].ContextMap()["attempt"])
+}
+
+func TestConnectWithRetry_GivesUpAfterAttempts(t *testing.T) {
+	var calls int
+	_, err := connectWithRetry(context.Background(), 3, time.Millisecond, zap.NewNop(), flakyDial(&gorm.DB{}, 10, &calls))
+
+	assert.ErrorContains(t, err, "after 3 attempts")
+	assert.Equal(t, 3, calls)
+}
+
+func TestConnectWithRetry_StopsWhenContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var calls int
+	_, err := connectWithRetry(ctx, 5, time.Hour, zap.NewNop(), flakyDial(&gorm.DB{}, 10, &calls))
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+}
+
+// newMockDialector returns a postgres dialector backed by sqlmock
+func newMockDialector(t *testing.T) (gorm.Dialector, sqlmock.Sqlmock) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	return postgres.New(postgres.Config{Conn: sqlDB, DriverName: "postgres"}), mock
+}
+
+type replicaTestUser struct {
+	ID   int
+	Name string
+}
+
+func (replicaTestUser) TableName() string { return "users" }
+
+func TestRegisterReplicas_RoutesReadsToReplica(t *testing.T) {
+	primaryDialector, primary := newMockDialector(t)
+	replicaDialector, replica := newMockDialector(t)
+
+	database, err := gorm.Open(primaryDialector, &gorm.Config{SkipDefaultTransaction: true})
+	require.NoError(t, err)
+	require.NoError(t, registerReplicas(database, []gorm.Dialector{replicaDialector}, &config.DatabaseConfig{MaxOpenConns: 5, MaxIdleConns: 5}))
+
+	replica.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE id = $1`)).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Jane"))
+	primary.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "users"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+	primary.ExpectBegin()
+	primary.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "users"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	primary.ExpectCommit()
+
+	var user replicaTestUser
+	require.NoError(t, database.Where("id = ?", 1).First(&user).Error)
+	assert.Equal(t, "Jane", user.Name)
+
+	require.NoError(t, database.Create(&replicaTestUser{Name: "John"}).Error)
+
+	// Reads inside a transaction see its writes, they stay on the primary
+	err = database.Transaction(func(tx *gorm.DB) error {
+		var total int64
+		return tx.Model(&replicaTestUser{}).Count(&total).Error
+	})
+	require.NoError(t, err)
+
+	assert.NoError(t, primary.ExpectationsWereMet())
+	assert.NoError(t, replica.ExpectationsWereMet())
+}
+
+func TestRegisterReplicas_WithoutReplicasUsesPrimary(t *testing.T) {
+	primaryDialector, primary := newMockDialector(t)
+
+	database, err := gorm.Open(primaryDialector, &gorm.Config{SkipDefaultTransaction: true})
+	require.NoError(t, err)
+	require.NoError(t, registerReplicas(database, nil, &config.DatabaseConfig{}))
+
+	primary.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "users"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	var total int64
+	require.NoError(t, database.Model(&replicaTestUser{}).Count(&total).Error)
+	assert.Equal(t, int64(1), total)
+	assert.NoError(t, primary.ExpectationsWereMet())
+}
@@ -0,0 +1,128 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// redactedParam is logged in place of each bound value when parameters are redacted
+const redactedParam = "[redacted]"
+
+// GormLogger routes GORM logs through zap
+// Failed queries are logged at error, queries slower than the threshold at warn and, in info mode, every query at info
+type GormLogger struct {
+	log           *zap.Logger
+	level         gormlogger.LogLevel
+	slowThreshold time.Duration // 0 disables slow query logging
+	redactParams  bool          // log SQL without the bound values
+}
+
+// NewGormLogger creates a GORM logger writing to log
+func NewGormLogger(log *zap.Logger, level gormlogger.LogLevel, slowThreshold time.Duration, redactParams bool) *GormLogger {
+	return &GormLogger{
+		log:           log,
+		level:         level,
+		slowThreshold: slowThreshold,
+		redactParams:  redactParams,
+	}
+}
+
+// ParseGormLogLevel maps silent, error, warn or info to a GORM log level, anything else is silent
+func ParseGormLogLevel(level string) gormlogger.LogLevel {
+	switch strings.ToLower(level) {
+	case "error":
+		return gormlogger.Error
+	case "warn":
+		return gormlogger.Warn
+	case "info":
+		return gormlogger.Info
+	default:
+		return gormlogger.Silent
+	}
+}
+
+// LogMode returns a copy of the logger at level
+func (l *GormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	clone := *l
+	clone.level = level
+	return &clone
+}
+
+func (l *GormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Info {
+		l.log.Info(fmt.Sprintf(msg, args...))
+	}
+}
+
+func (l *GormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		l.log.Warn(fmt.Sprintf(msg, args...))
+	}
+}
+
+func (l *GormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Error {
+		l.log.Error(fmt.Sprintf(msg, args...))
+	}
+}
+
+// Trace logs a finished query according to its outcome and duration
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	switch {
+	case err != nil && l.level >= gormlogger.Error && !errors.Is(err, gorm.ErrRecordNotFound):
+		sql, rows := fc()
+		l.log.Error("Database query failed",
+			zap.String("sql", sql),
+			zap.Int64("rows", rows),
+			zap.Duration("duration", elapsed),
+			zap.Error(err),
+		)
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold && l.level >= gormlogger.Warn:
+		sql, rows := fc()
+		l.log.Warn("Slow database query",
+			zap.String("sql", sql),
+			zap.Int64("rows", rows),
+			zap.Duration("duration", elapsed),
+			zap.Duration("threshold", l.slowThreshold),
+		)
+	case l.level >= gormlogger.Info:
+		sql, rows := fc()
+		l.log.Info("Database query",
+			zap.String("sql", sql),
+			zap.Int64("rows", rows),
+			zap.Duration("duration", elapsed),
+		)
+	}
+}
+
+// ParamsFilter replaces the bound values with a marker when parameters are redacted
+// Dropping them instead would leave GORM's internal placeholder markers in the logged SQL
+func (l *GormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	if !l.redactParams {
+		return sql, params
+	}
+
+	redacted := make([]interface{}, len(params))
+	for i := range redacted {
+		redacted[i] = redactedParam
+	}
+	return sql, redacted
+}
+
+// Ensure GormLogger implements GORM's logger and params filter at compile time
+var (
+	_ gormlogger.Interface = (*GormLogger)(nil)
+	_ gorm.ParamsFilter    = (*GormLogger)(nil)
+)
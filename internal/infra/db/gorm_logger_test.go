@@ -0,0 +1,87 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// openObservedDB opens a GORM connection on sqlmock whose logs are captured by the returned observer
+func openObservedDB(t *testing.T, level gormlogger.LogLevel, slowThreshold time.Duration, redactParams bool) (*gorm.DB, sqlmock.Sqlmock, *observer.ObservedLogs) {
+	t.Helper()
+
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB, DriverName: "postgres"}), &gorm.Config{
+		Logger:                 NewGormLogger(zap.New(core), level, slowThreshold, redactParams),
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+
+	return db, mock, logs
+}
+
+func TestGormLogger_LogsSlowQueryAtWarn(t *testing.T) {
+	tests := []struct {
+		name         string
+		redactParams bool
+		wantSQL      string
+	}{
+		{name: "with parameters", redactParams: false, wantSQL: `SELECT name FROM "users" WHERE email = 'jane@example.com'`},
+		{name: "redacted parameters", redactParams: true, wantSQL: `SELECT name FROM "users" WHERE email = '[redacted]'`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, logs := openObservedDB(t, gormlogger.Warn, 5*time.Millisecond, tt.redactParams)
+
+			mock.ExpectQuery(`SELECT name FROM "users"`).
+				WithArgs("jane@example.com").
+				WillDelayFor(20 * time.Millisecond).
+				WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("Jane"))
+
+			var names []string
+			require.NoError(t, db.Table("users").Select("name").Where("email = ?", "jane@example.com").Find(&names).Error)
+			require.NoError(t, mock.ExpectationsWereMet())
+
+			entries := logs.FilterMessage("Slow database query").All()
+			require.Len(t, entries, 1)
+			assert.Equal(t, zapcore.WarnLevel, entries[0].Level)
+
+			fields := entries[0].ContextMap()
+			assert.Equal(t, tt.wantSQL, fields["sql"])
+			assert.GreaterOrEqual(t, fields["duration"], 20*time.Millisecond)
+		})
+	}
+}
+
+func TestGormLogger_FastQueryNotLogged(t *testing.T) {
+	db, mock, logs := openObservedDB(t, gormlogger.Warn, time.Second, false)
+
+	mock.ExpectQuery("SELECT 1").WillReturnRows(sqlmock.NewRows([]string{"one"}).AddRow(1))
+
+	var ones []int
+	require.NoError(t, db.Raw("SELECT 1").Find(&ones).Error)
+
+	assert.Zero(t, logs.Len())
+}
+
+func TestParseGormLogLevel(t *testing.T) {
+	assert.Equal(t, gormlogger.Silent, ParseGormLogLevel(""))
+	assert.Equal(t, gormlogger.Error, ParseGormLogLevel("error"))
+	assert.Equal(t, gormlogger.Warn, ParseGormLogLevel("WARN"))
+	assert.Equal(t, gormlogger.Info, ParseGormLogLevel("info"))
+	assert.Equal(t, gormlogger.Silent, ParseGormLogLevel("verbose"))
+}
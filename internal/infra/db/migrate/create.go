@@ -0,0 +1,59 @@
+package migrate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// migrationTemplate is the skeleton of a new migration, its placeholder statements are meant to be replaced
+const migrationTemplate = `-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+`
+
+// migrationName matches the name part of a migration file
+var migrationName = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// Create writes a new migration named name to dir, numbered after the highest version already there,
+// and returns its path. Names are lowercased with spaces and dashes turned into underscores
+func Create(dir, name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+	if !migrationName.MatchString(name) {
+		return "", fmt.Errorf("invalid migration name %q, use letters, digits and underscores", name)
+	}
+
+	migrations, err := Load(os.DirFS(dir), ".")
+	if err != nil {
+		return "", err
+	}
+
+	var version int64 = 1
+	if len(migrations) > 0 {
+		version = migrations[len(migrations)-1].Version + 1
+	}
+
+	file := filepath.Join(dir, fmt.Sprintf("%05d_%s.sql", version, name))
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return "", fmt.Errorf("failed to create migration: %w", err)
+	}
+	if _, err := f.WriteString(migrationTemplate); err != nil {
+		_ = f.Close()
+		return "", fmt.Errorf("failed to write migration: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("failed to write migration: %w", err)
+	}
+
+	return file, nil
+}
@@ -0,0 +1,33 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gieart87/gohexaclean/internal/infra/db"
+	"gorm.io/gorm"
+)
+
+// NewFromEmbedded creates a migrator for the migrations embedded in the binary
+func NewFromEmbedded(database *gorm.DB) (*Migrator, error) {
+	migrations, err := Load(db.MigrationsFS, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDBMigration, err)
+	}
+
+	sqlDB, err := database.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get underlying database: %w", err)
+	}
+
+	return New(sqlDB, migrations), nil
+}
+
+// RunEmbedded applies all pending embedded migrations
+func RunEmbedded(ctx context.Context, database *gorm.DB) (int, error) {
+	migrator, err := NewFromEmbedded(database)
+	if err != nil {
+		return 0, err
+	}
+	return migrator.Up(ctx)
+}
@@ -0,0 +1,344 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"testing/fstest"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gieart87/gohexaclean/internal/infra/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testMigrations = []Migration{
+	{Version: 1, Name: "00001_create_things", Up: []string{"CREATE TABLE things (id INT);"}, Down: []string{"DROP TABLE things;"}},
+	{Version: 2, Name: "00002_add_name", Up: []string{"ALTER TABLE things ADD COLUMN name TEXT;"}, Down: []string{"ALTER TABLE things DROP COLUMN name;"}},
+}
+
+func TestLoad_EmbeddedMigrations(t *testing.T) {
+	migrations, err := Load(db.MigrationsFS, "migrations")
+
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(migrations), 2)
+	assert.Equal(t, int64(1), migrations[0].Version)
+	assert.Equal(t, "00001_create_users_table", migrations[0].Name)
+	for i := 1; i < len(migrations); i++ {
+		assert.Less(t, migrations[i-1].Version, migrations[i].Version)
+	}
+	for _, m := range migrations {
+		assert.NotEmpty(t, m.Up, m.Name)
+		assert.NotEmpty(t, m.Down, m.Name)
+	}
+}
+
+func TestLoad_ParsesStatements(t *testing.T) {
+	fsys := fstest.MapFS{
+		"m/00002_second.sql": {Data: []byte(`-- +goose Up
+-- +goose StatementBegin
+CREATE FUNCTION touch() RETURNS trigger AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP FUNCTION touch;
+`)},
+		"m/00001_first.sql": {Data: []byte(`-- +goose Up
+-- creates the table
+CREATE TABLE a (id INT);
+CREATE INDEX idx_a ON a(id);
+
+-- +goose Down
+DROP TABLE a;
+`)},
+	}
+
+	migrations, err := Load(fsys, "m")
+
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+	assert.Equal(t, "00001_first", migrations[0].Name)
+	assert.Equal(t, []string{"CREATE TABLE a (id INT);", "CREATE INDEX idx_a ON a(id);"}, migrations[0].Up)
+	assert.Equal(t, []string{"DROP TABLE a;"}, migrations[0].Down)
+	require.Len(t, migrations[1].Up, 1)
+	assert.Contains(t, migrations[1].Up[0], "RETURN NEW;\nEND;")
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files fstest.MapFS
+	}{
+		{
+			name:  "invalid version",
+			files: fstest.MapFS{"m/abc_bad.sql": {Data: []byte("-- +goose Up\nSELECT 1;\n")}},
+		},
+		{
+			name: "duplicate version",
+			files: fstest.MapFS{
+				"m/00001_a.sql": {Data: []byte("-- +goose Up\nSELECT 1;\n")},
+				"m/1_b.sql":     {Data: []byte("-- +goose Up\nSELECT 1;\n")},
+			},
+		},
+		{
+			name:  "missing up section",
+			files: fstest.MapFS{"m/00001_a.sql": {Data: []byte("SELECT 1;\n")}},
+		},
+		{
+			name:  "unterminated block",
+			files: fstest.MapFS{"m/00001_a.sql": {Data: []byte("-- +goose Up\n-- +goose StatementBegin\nSELECT 1;\n")}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(tt.files, "m")
+			assert.Error(t, err)
+		})
+	}
+}
+
+// expectApplied expects the applied versions to be read, and a lookup of goose_db_version when there are none
+func expectApplied(mock sqlmock.Sqlmock, versions ...int64) {
+	expectVersionTable(mock, versions...)
+	if len(versions) == 0 {
+		expectGooseTable(mock, false)
+	}
+}
+
+func expectVersionTable(mock sqlmock.Sqlmock, versions ...int64) {
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS schema_migrations")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	rows := sqlmock.NewRows([]string{"version"})
+	for _, v := range versions {
+		rows.AddRow(v)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT version FROM schema_migrations")).WillReturnRows(rows)
+}
+
+func expectGooseTable(mock sqlmock.Sqlmock, exists bool) {
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT to_regclass('goose_db_version') IS NOT NULL")).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(exists))
+}
+
+func TestMigrator_Up_AppliesPending(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer sqlDB.Close()
+
+	expectApplied(mock, 1)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE things ADD COLUMN name TEXT;")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations (version, name) VALUES ($1, $2)")).
+		WithArgs(int64(2), "00002_add_name").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	applied, err := New(sqlDB, testMigrations).Up(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, applied)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Up_NothingPending(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer sqlDB.Close()
+
+	expectApplied(mock, 1, 2)
+
+	applied, err := New(sqlDB, testMigrations).Up(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, applied)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Up_FailureRollsBack(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer sqlDB.Close()
+
+	expectApplied(mock)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE things (id INT);")).
+		WillReturnError(errors.New("syntax error"))
+	mock.ExpectRollback()
+
+	applied, err := New(sqlDB, testMigrations).Up(context.Background())
+
+	assert.ErrorIs(t, err, db.ErrDBMigration)
+	assert.Contains(t, err.Error(), "00001_create_things")
+	assert.Equal(t, 0, applied)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Down_RollsBackLatest(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer sqlDB.Close()
+
+	expectApplied(mock, 1, 2)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE things DROP COLUMN name;")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM schema_migrations WHERE version = $1")).
+		WithArgs(int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	name, err := New(sqlDB, testMigrations).Down(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "00002_add_name", name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Down_NothingApplied(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer sqlDB.Close()
+
+	expectApplied(mock)
+
+	name, err := New(sqlDB, testMigrations).Down(context.Background())
+
+	require.NoError(t, err)
+	assert.Empty(t, name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Version(t *testing.T) {
+	tests := []struct {
+		name    string
+		applied []int64
+		want    int64
+	}{
+		{"nothing applied", nil, 0},
+		{"latest applied", []int64{1, 2}, 2},
+		{"out of order rows", []int64{2, 1}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer sqlDB.Close()
+
+			expectApplied(mock, tt.applied...)
+
+			version, err := New(sqlDB, testMigrations).Version(context.Background())
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, version)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMigrator_UpThenDown_RestoresVersion(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer sqlDB.Close()
+
+	migrator := New(sqlDB, testMigrations)
+	ctx := context.Background()
+
+	expectApplied(mock, 1)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE things ADD COLUMN name TEXT;")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations (version, name) VALUES ($1, $2)")).
+		WithArgs(int64(2), "00002_add_name").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+
+	expectApplied(mock, 1, 2)
+	version, err := migrator.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), version)
+
+	expectApplied(mock, 1, 2)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE things DROP COLUMN name;")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM schema_migrations WHERE version = $1")).
+		WithArgs(int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	_, err = migrator.Down(ctx)
+	require.NoError(t, err)
+
+	expectApplied(mock, 1)
+	version, err = migrator.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), version)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Up_ImportsGooseVersions(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer sqlDB.Close()
+
+	// goose applied both migrations and then rolled back the second, version 0 is its own init row
+	expectVersionTable(mock)
+	expectGooseTable(mock, true)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT version_id, is_applied FROM goose_db_version ORDER BY id")).
+		WillReturnRows(sqlmock.NewRows([]string{"version_id", "is_applied"}).
+			AddRow(int64(0), true).
+			AddRow(int64(1), true).
+			AddRow(int64(2), true).
+			AddRow(int64(2), false))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations (version, name) VALUES ($1, $2)")).
+		WithArgs(int64(1), "00001_create_things").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// Only the migration goose no longer has applied runs
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE things ADD COLUMN name TEXT;")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations (version, name) VALUES ($1, $2)")).
+		WithArgs(int64(2), "00002_add_name").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	applied, err := New(sqlDB, testMigrations).Up(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, applied)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreate_NumbersAfterLatestMigration(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "00007_existing.sql"), []byte("-- +goose Up\nSELECT 1;\n"), 0o644))
+
+	path, err := Create(dir, "Add Phone-Number")
+
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "00008_add_phone_number.sql"), path)
+
+	migrations, err := Load(os.DirFS(dir), ".")
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+	assert.Equal(t, "00008_add_phone_number", migrations[1].Name)
+
+	_, err = Create(dir, "../escape")
+	assert.Error(t, err)
+}
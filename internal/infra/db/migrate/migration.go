@@ -0,0 +1,136 @@
+package migrate
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Migration is a single versioned schema change with its up and down statements
+type Migration struct {
+	Version int64
+	Name    string
+	Up      []string
+	Down    []string
+}
+
+// Load reads goose-style SQL migrations (<version>_<name>.sql) from dir, sorted by version
+// Files use "-- +goose Up" / "-- +goose Down" sections, with multi-statement blocks
+// wrapped in "-- +goose StatementBegin" / "-- +goose StatementEnd"
+func Load(fsys fs.FS, dir string) ([]Migration, error) {
+	files, err := fs.Glob(fsys, path.Join(dir, "*.sql"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	migrations := make([]Migration, 0, len(files))
+	seen := make(map[int64]string, len(files))
+	for _, file := range files {
+		base := path.Base(file)
+		prefix, _, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("invalid migration file name %q", base)
+		}
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %q: %w", base, err)
+		}
+		if other, ok := seen[version]; ok {
+			return nil, fmt.Errorf("duplicate migration version %d in %q and %q", version, other, base)
+		}
+		seen[version] = base
+
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %q: %w", base, err)
+		}
+
+		up, down, err := parse(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse migration %q: %w", base, err)
+		}
+
+		migrations = append(migrations, Migration{
+			Version: version,
+			Name:    strings.TrimSuffix(base, ".sql"),
+			Up:      up,
+			Down:    down,
+		})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// parse splits a migration file into up and down statements
+func parse(content string) (up, down []string, err error) {
+	var (
+		current *[]string
+		buf     strings.Builder
+		inBlock bool
+	)
+
+	flush := func() {
+		if stmt := strings.TrimSpace(buf.String()); stmt != "" && current != nil {
+			*current = append(*current, stmt)
+		}
+		buf.Reset()
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		switch trimmed {
+		case "-- +goose Up":
+			flush()
+			current = &up
+			continue
+		case "-- +goose Down":
+			flush()
+			current = &down
+			continue
+		case "-- +goose StatementBegin":
+			flush()
+			inBlock = true
+			continue
+		case "-- +goose StatementEnd":
+			flush()
+			inBlock = false
+			continue
+		}
+
+		if current == nil || trimmed == "" || (strings.HasPrefix(trimmed, "--") && buf.Len() == 0) {
+			continue
+		}
+
+		buf.WriteString(line)
+		buf.WriteString("\n")
+
+		// Outside a block every statement ends at a line-terminating semicolon
+		if !inBlock && strings.HasSuffix(trimmed, ";") {
+			flush()
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+	if inBlock {
+		return nil, nil, fmt.Errorf("missing -- +goose StatementEnd")
+	}
+	flush()
+
+	if len(up) == 0 {
+		return nil, nil, fmt.Errorf("no -- +goose Up statements")
+	}
+
+	return up, down, nil
+}
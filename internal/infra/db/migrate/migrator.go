@@ -0,0 +1,228 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	dberr "github.com/gieart87/gohexaclean/internal/infra/db"
+)
+
+const createVersionTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version BIGINT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`
+
+// Migrator applies and rolls back migrations, tracking applied versions in schema_migrations
+// Databases previously migrated with goose have their versions imported from goose_db_version
+// It works on *sql.DB rather than GORM because migration files may hold multiple statements,
+// which prepared statements can't run
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// New creates a new migrator for the given migrations
+func New(db *sql.DB, migrations []Migration) *Migrator {
+	return &Migrator{db: db, migrations: migrations}
+}
+
+// Up applies all pending migrations in version order and returns how many were applied
+// Running it again once the schema is current is a no-op
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	applied, err := m.Applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, migration := range m.migrations {
+		if applied[migration.Version] {
+			continue
+		}
+
+		err := m.inTx(ctx, migration.Up, func(tx *sql.Tx) error {
+			_, err := tx.ExecContext(ctx,
+				"INSERT INTO schema_migrations (version, name) VALUES ($1, $2)",
+				migration.Version, migration.Name,
+			)
+			return err
+		})
+		if err != nil {
+			return count, fmt.Errorf("%w: %s: %v", dberr.ErrDBMigration, migration.Name, err)
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// Down rolls back the most recently applied migration and returns its name, or "" if none were applied
+func (m *Migrator) Down(ctx context.Context) (string, error) {
+	applied, err := m.Applied(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		if !applied[migration.Version] {
+			continue
+		}
+
+		err := m.inTx(ctx, migration.Down, func(tx *sql.Tx) error {
+			_, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version)
+			return err
+		})
+		if err != nil {
+			return "", fmt.Errorf("%w: %s: %v", dberr.ErrDBMigration, migration.Name, err)
+		}
+		return migration.Name, nil
+	}
+
+	return "", nil
+}
+
+// Applied returns the set of applied migration versions, creating the tracking table if needed
+// An empty table is seeded from goose_db_version when that exists
+func (m *Migrator) Applied(ctx context.Context) (map[int64]bool, error) {
+	if _, err := m.db.ExecContext(ctx, createVersionTable); err != nil {
+		return nil, fmt.Errorf("%w: failed to create schema_migrations: %v", dberr.ErrDBMigration, err)
+	}
+
+	rows, err := m.db.QueryContext(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read schema_migrations: %v", dberr.ErrDBMigration, err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]bool)
+	for rows.Next() {
+		var version int64
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("%w: failed to scan schema_migrations: %v", dberr.ErrDBMigration, err)
+		}
+		applied[version] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: failed to read schema_migrations: %v", dberr.ErrDBMigration, err)
+	}
+
+	if len(applied) == 0 {
+		return m.importGooseVersions(ctx)
+	}
+
+	return applied, nil
+}
+
+// importGooseVersions records the known migrations goose applied in schema_migrations and returns them,
+// so the first run on a goose-managed database doesn't apply them again
+func (m *Migrator) importGooseVersions(ctx context.Context) (map[int64]bool, error) {
+	applied := make(map[int64]bool)
+
+	var exists bool
+	if err := m.db.QueryRowContext(ctx, "SELECT to_regclass('goose_db_version') IS NOT NULL").Scan(&exists); err != nil {
+		return nil, fmt.Errorf("%w: failed to look up goose_db_version: %v", dberr.ErrDBMigration, err)
+	}
+	if !exists {
+		return applied, nil
+	}
+
+	// goose appends a row for every up and down, so the latest row of a version holds its state
+	rows, err := m.db.QueryContext(ctx, "SELECT version_id, is_applied FROM goose_db_version ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read goose_db_version: %v", dberr.ErrDBMigration, err)
+	}
+	defer rows.Close()
+
+	gooseApplied := make(map[int64]bool)
+	for rows.Next() {
+		var (
+			version   int64
+			isApplied bool
+		)
+		if err := rows.Scan(&version, &isApplied); err != nil {
+			return nil, fmt.Errorf("%w: failed to scan goose_db_version: %v", dberr.ErrDBMigration, err)
+		}
+		gooseApplied[version] = isApplied
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: failed to read goose_db_version: %v", dberr.ErrDBMigration, err)
+	}
+
+	var imported []Migration
+	for _, migration := range m.migrations {
+		if gooseApplied[migration.Version] {
+			imported = append(imported, migration)
+		}
+	}
+	if len(imported) == 0 {
+		return applied, nil
+	}
+
+	err = m.inTx(ctx, nil, func(tx *sql.Tx) error {
+		for _, migration := range imported {
+			if _, err := tx.ExecContext(ctx,
+				"INSERT INTO schema_migrations (version, name) VALUES ($1, $2)",
+				migration.Version, migration.Name,
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to import goose_db_version: %v", dberr.ErrDBMigration, err)
+	}
+
+	for _, migration := range imported {
+		applied[migration.Version] = true
+	}
+	return applied, nil
+}
+
+// Version returns the highest applied migration version, or 0 if none were applied
+func (m *Migrator) Version(ctx context.Context) (int64, error) {
+	applied, err := m.Applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var version int64
+	for v := range applied {
+		if v > version {
+			version = v
+		}
+	}
+
+	return version, nil
+}
+
+// Migrations returns the known migrations in version order
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+// inTx runs the statements and then record inside one transaction
+func (m *Migrator) inTx(ctx context.Context, statements []string, record func(tx *sql.Tx) error) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	if err := record(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
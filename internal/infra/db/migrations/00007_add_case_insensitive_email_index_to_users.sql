@@ -0,0 +1,15 @@
+-- +goose Up
+-- Emails are stored lowercased from now on, normalize existing rows and enforce uniqueness regardless of case
+-- Fails if accounts differing only in email case exist, those have to be merged first
+-- +goose StatementBegin
+UPDATE users SET email = LOWER(TRIM(email)) WHERE email <> LOWER(TRIM(email));
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_lower ON users (LOWER(email));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_users_email_lower;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS events (
+    id UUID PRIMARY KEY,
+    type VARCHAR(64) NOT NULL,
+    aggregate_id UUID NOT NULL,
+    payload JSONB NOT NULL,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Serves the newest-first timeline of one aggregate
+CREATE INDEX idx_events_aggregate_id_occurred_at ON events(aggregate_id, occurred_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_events_aggregate_id_occurred_at;
+DROP TABLE IF EXISTS events;
+-- +goose StatementEnd
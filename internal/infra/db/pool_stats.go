@@ -0,0 +1,82 @@
+package db
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/gieart87/gohexaclean/internal/port/outbound/telemetry"
+)
+
+const defaultPoolStatsInterval = 15 * time.Second
+
+// statsSource is the part of *sql.DB the exporter reads from
+type statsSource interface {
+	Stats() sql.DBStats
+}
+
+// PoolStatsExporter periodically reports connection pool stats as gauges
+type PoolStatsExporter struct {
+	source   statsSource
+	metrics  telemetry.MetricsService
+	interval time.Duration
+	done     chan struct{}
+	wg       sync.WaitGroup
+	stopOnce sync.Once
+}
+
+// NewPoolStatsExporter creates a new pool stats exporter, interval defaults to 15s
+func NewPoolStatsExporter(source statsSource, metrics telemetry.MetricsService, interval time.Duration) *PoolStatsExporter {
+	if interval <= 0 {
+		interval = defaultPoolStatsInterval
+	}
+
+	return &PoolStatsExporter{
+		source:   source,
+		metrics:  metrics,
+		interval: interval,
+		done:     make(chan struct{}),
+	}
+}
+
+// Start reports stats once and then on every interval until Stop is called
+func (e *PoolStatsExporter) Start() {
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+
+		ticker := time.NewTicker(e.interval)
+		defer ticker.Stop()
+
+		e.report()
+		for {
+			select {
+			case <-e.done:
+				return
+			case <-ticker.C:
+				e.report()
+			}
+		}
+	}()
+}
+
+// Stop stops the exporter and waits for the reporting goroutine to exit
+func (e *PoolStatsExporter) Stop() {
+	e.stopOnce.Do(func() {
+		close(e.done)
+	})
+	e.wg.Wait()
+}
+
+// report emits the current pool stats
+func (e *PoolStatsExporter) report() {
+	if e.metrics == nil {
+		return
+	}
+
+	stats := e.source.Stats()
+	e.metrics.SetGauge("db.pool.open", nil, float64(stats.OpenConnections))
+	e.metrics.SetGauge("db.pool.in_use", nil, float64(stats.InUse))
+	e.metrics.SetGauge("db.pool.idle", nil, float64(stats.Idle))
+	e.metrics.SetGauge("db.pool.wait_count", nil, float64(stats.WaitCount))
+}
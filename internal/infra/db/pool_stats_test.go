@@ -0,0 +1,66 @@
+package db
+
+import (
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	mock "github.com/gieart87/gohexaclean/internal/port/outbound/telemetry/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeStatsSource struct {
+	stats sql.DBStats
+}
+
+func (s fakeStatsSource) Stats() sql.DBStats {
+	return s.stats
+}
+
+func TestPoolStatsExporter_ReportsGauges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		mu     sync.Mutex
+		gauges = make(map[string]float64)
+	)
+	metrics := mock.NewMockMetricsService(ctrl)
+	metrics.EXPECT().SetGauge(gomock.Any(), gomock.Any(), gomock.Any()).
+		Do(func(name string, tags map[string]string, value float64) {
+			mu.Lock()
+			defer mu.Unlock()
+			gauges[name] = value
+		}).
+		MinTimes(4)
+
+	source := fakeStatsSource{stats: sql.DBStats{OpenConnections: 5, InUse: 3, Idle: 2, WaitCount: 7}}
+	exporter := NewPoolStatsExporter(source, metrics, time.Millisecond)
+	exporter.Start()
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(gauges) == 4
+	}, time.Second, time.Millisecond)
+
+	exporter.Stop()
+	exporter.Stop() // idempotent
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, map[string]float64{
+		"db.pool.open":       5,
+		"db.pool.in_use":     3,
+		"db.pool.idle":       2,
+		"db.pool.wait_count": 7,
+	}, gauges)
+}
+
+func TestPoolStatsExporter_DefaultInterval(t *testing.T) {
+	exporter := NewPoolStatsExporter(fakeStatsSource{}, nil, 0)
+
+	assert.Equal(t, defaultPoolStatsInterval, exporter.interval)
+}
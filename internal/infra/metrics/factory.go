@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gieart87/gohexaclean/internal/adapter/outbound/datadog"
+	"github.com/gieart87/gohexaclean/internal/adapter/outbound/otel"
+	"github.com/gieart87/gohexaclean/internal/infra/config"
+	"github.com/gieart87/gohexaclean/internal/infra/logger"
+	"github.com/gieart87/gohexaclean/internal/port/outbound/telemetry"
+)
+
+// NewMetricsService initializes the metrics service from configuration (Datadog > OpenTelemetry)
+// Returns nil if metrics are disabled or fail to initialize, so callers can run without them
+func NewMetricsService(ctx context.Context, cfg *config.Config, log *logger.Logger) telemetry.MetricsService {
+	if cfg.Datadog.Enabled {
+		metricsService, err := datadog.NewMetricsServiceDatadog(
+			cfg.Datadog.AgentHost+":"+cfg.Datadog.AgentPort,
+			cfg.Datadog.Namespace,
+			cfg.Datadog.Tags,
+		)
+		if err != nil {
+			log.Warn("Failed to initialize Datadog metrics, continuing without metrics")
+			return nil
+		}
+		log.Info("Datadog metrics initialized")
+		return metricsService
+	}
+
+	if cfg.Telemetry.Enabled {
+		log.Info("Initializing OpenTelemetry telemetry")
+
+		if cfg.Telemetry.Exporter == "prometheus" {
+			metricsService, err := otel.NewPrometheusMetricsServiceOTEL(ctx, cfg.Telemetry.ServiceName)
+			if err != nil {
+				log.Warn("Failed to initialize OpenTelemetry Prometheus metrics, continuing without metrics")
+				return nil
+			}
+			log.Info("OpenTelemetry metrics initialized with Prometheus exporter")
+			return metricsService
+		}
+
+		metricsService, err := otel.NewMetricsServiceOTEL(
+			ctx,
+			cfg.Telemetry.ServiceName,
+			cfg.Telemetry.CollectorEndpoint,
+		)
+		if err != nil {
+			log.Warn("Failed to initialize OpenTelemetry metrics, continuing without metrics")
+			return nil
+		}
+		log.Info("OpenTelemetry metrics initialized")
+		return metricsService
+	}
+
+	return nil
+}
+
+// Handler returns the HTTP handler exposing scraped metrics, or nil when the metrics service is push based
+func Handler(metricsService telemetry.MetricsService) http.Handler {
+	if scraped, ok := metricsService.(interface{ Handler() http.Handler }); ok {
+		return scraped.Handler()
+	}
+	return nil
+}
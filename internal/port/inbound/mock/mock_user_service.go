@@ -8,6 +8,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	domain "github.com/gieart87/gohexaclean/internal/domain"
 	request "github.com/gieart87/gohexaclean/internal/dto/request"
 	response "github.com/gieart87/gohexaclean/internal/dto/response"
 	gomock "github.com/golang/mock/gomock"
@@ -37,6 +38,20 @@ func (m *MockUserServicePort) EXPECT() *MockUserServicePortMockRecorder {
 	return m.recorder
 }
 
+// ChangeEmail mocks base method.
+func (m *MockUserServicePort) ChangeEmail(ctx context.Context, id uuid.UUID, newEmail string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeEmail", ctx, id, newEmail)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeEmail indicates an expected call of ChangeEmail.
+func (mr *MockUserServicePortMockRecorder) ChangeEmail(ctx, id, newEmail interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeEmail", reflect.TypeOf((*MockUserServicePort)(nil).ChangeEmail), ctx, id, newEmail)
+}
+
 // CreateUser mocks base method.
 func (m *MockUserServicePort) CreateUser(ctx context.Context, req *request.CreateUserRequest) (*response.LoginResponse, error) {
 	m.ctrl.T.Helper()
@@ -52,6 +67,21 @@ func (mr *MockUserServicePortMockRecorder) CreateUser(ctx, req interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserServicePort)(nil).CreateUser), ctx, req)
 }
 
+// CreateUsers mocks base method.
+func (m *MockUserServicePort) CreateUsers(ctx context.Context, reqs []*request.CreateUserRequest) ([]*response.BulkItemResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUsers", ctx, reqs)
+	ret0, _ := ret[0].([]*response.BulkItemResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUsers indicates an expected call of CreateUsers.
+func (mr *MockUserServicePortMockRecorder) CreateUsers(ctx, reqs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUsers", reflect.TypeOf((*MockUserServicePort)(nil).CreateUsers), ctx, reqs)
+}
+
 // DeleteUser mocks base method.
 func (m *MockUserServicePort) DeleteUser(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -66,6 +96,36 @@ func (mr *MockUserServicePortMockRecorder) DeleteUser(ctx, id interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserServicePort)(nil).DeleteUser), ctx, id)
 }
 
+// DeleteUsers mocks base method.
+func (m *MockUserServicePort) DeleteUsers(ctx context.Context, ids []uuid.UUID) (*response.BulkDeleteResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUsers", ctx, ids)
+	ret0, _ := ret[0].(*response.BulkDeleteResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUsers indicates an expected call of DeleteUsers.
+func (mr *MockUserServicePortMockRecorder) DeleteUsers(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUsers", reflect.TypeOf((*MockUserServicePort)(nil).DeleteUsers), ctx, ids)
+}
+
+// GetTokenVersion mocks base method.
+func (m *MockUserServicePort) GetTokenVersion(ctx context.Context, id uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTokenVersion", ctx, id)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTokenVersion indicates an expected call of GetTokenVersion.
+func (mr *MockUserServicePortMockRecorder) GetTokenVersion(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTokenVersion", reflect.TypeOf((*MockUserServicePort)(nil).GetTokenVersion), ctx, id)
+}
+
 // GetUserByEmail mocks base method.
 func (m *MockUserServicePort) GetUserByEmail(ctx context.Context, email string) (*response.UserResponse, error) {
 	m.ctrl.T.Helper()
@@ -82,24 +142,60 @@ func (mr *MockUserServicePortMockRecorder) GetUserByEmail(ctx, email interface{}
 }
 
 // GetUserByID mocks base method.
-func (m *MockUserServicePort) GetUserByID(ctx context.Context, id uuid.UUID) (*response.UserResponse, error) {
+func (m *MockUserServicePort) GetUserByID(ctx context.Context, id uuid.UUID, includes ...string) (*response.UserResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
+	varargs := []interface{}{ctx, id}
+	for _, a := range includes {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetUserByID", varargs...)
 	ret0, _ := ret[0].(*response.UserResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUserByID indicates an expected call of GetUserByID.
-func (mr *MockUserServicePortMockRecorder) GetUserByID(ctx, id interface{}) *gomock.Call {
+func (mr *MockUserServicePortMockRecorder) GetUserByID(ctx, id interface{}, includes ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUserServicePort)(nil).GetUserByID), ctx, id)
+	varargs := append([]interface{}{ctx, id}, includes...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUserServicePort)(nil).GetUserByID), varargs...)
+}
+
+// GetUsersByIDs mocks base method.
+func (m *MockUserServicePort) GetUsersByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*response.UserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByIDs", ctx, ids)
+	ret0, _ := ret[0].(map[uuid.UUID]*response.UserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersByIDs indicates an expected call of GetUsersByIDs.
+func (mr *MockUserServicePortMockRecorder) GetUsersByIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByIDs", reflect.TypeOf((*MockUserServicePort)(nil).GetUsersByIDs), ctx, ids)
+}
+
+// ListUserEvents mocks base method.
+func (m *MockUserServicePort) ListUserEvents(ctx context.Context, id uuid.UUID, page, limit int) ([]*response.UserEventResponse, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserEvents", ctx, id, page, limit)
+	ret0, _ := ret[0].([]*response.UserEventResponse)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListUserEvents indicates an expected call of ListUserEvents.
+func (mr *MockUserServicePortMockRecorder) ListUserEvents(ctx, id, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserEvents", reflect.TypeOf((*MockUserServicePort)(nil).ListUserEvents), ctx, id, page, limit)
 }
 
 // ListUsers mocks base method.
-func (m *MockUserServicePort) ListUsers(ctx context.Context, page, limit int) ([]*response.UserResponse, int64, error) {
+func (m *MockUserServicePort) ListUsers(ctx context.Context, page, limit int, sort domain.UserSort, opts domain.ListOptions) ([]*response.UserResponse, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUsers", ctx, page, limit)
+	ret := m.ctrl.Call(m, "ListUsers", ctx, page, limit, sort, opts)
 	ret0, _ := ret[0].([]*response.UserResponse)
 	ret1, _ := ret[1].(int64)
 	ret2, _ := ret[2].(error)
@@ -107,9 +203,9 @@ func (m *MockUserServicePort) ListUsers(ctx context.Context, page, limit int) ([
 }
 
 // ListUsers indicates an expected call of ListUsers.
-func (mr *MockUserServicePortMockRecorder) ListUsers(ctx, page, limit interface{}) *gomock.Call {
+func (mr *MockUserServicePortMockRecorder) ListUsers(ctx, page, limit, sort, opts interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserServicePort)(nil).ListUsers), ctx, page, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserServicePort)(nil).ListUsers), ctx, page, limit, sort, opts)
 }
 
 // Login mocks base method.
@@ -127,6 +223,63 @@ func (mr *MockUserServicePortMockRecorder) Login(ctx, req interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUserServicePort)(nil).Login), ctx, req)
 }
 
+// RevokeAllTokens mocks base method.
+func (m *MockUserServicePort) RevokeAllTokens(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAllTokens", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAllTokens indicates an expected call of RevokeAllTokens.
+func (mr *MockUserServicePortMockRecorder) RevokeAllTokens(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllTokens", reflect.TypeOf((*MockUserServicePort)(nil).RevokeAllTokens), ctx, id)
+}
+
+// SearchUsers mocks base method.
+func (m *MockUserServicePort) SearchUsers(ctx context.Context, query string, limit int) ([]*response.UserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchUsers", ctx, query, limit)
+	ret0, _ := ret[0].([]*response.UserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchUsers indicates an expected call of SearchUsers.
+func (mr *MockUserServicePortMockRecorder) SearchUsers(ctx, query, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsers", reflect.TypeOf((*MockUserServicePort)(nil).SearchUsers), ctx, query, limit)
+}
+
+// SetActive mocks base method.
+func (m *MockUserServicePort) SetActive(ctx context.Context, id uuid.UUID, active bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetActive", ctx, id, active)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetActive indicates an expected call of SetActive.
+func (mr *MockUserServicePortMockRecorder) SetActive(ctx, id, active interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetActive", reflect.TypeOf((*MockUserServicePort)(nil).SetActive), ctx, id, active)
+}
+
+// StreamUsers mocks base method.
+func (m *MockUserServicePort) StreamUsers(ctx context.Context, batchSize int, fn func(*response.UserResponse) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamUsers", ctx, batchSize, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamUsers indicates an expected call of StreamUsers.
+func (mr *MockUserServicePortMockRecorder) StreamUsers(ctx, batchSize, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamUsers", reflect.TypeOf((*MockUserServicePort)(nil).StreamUsers), ctx, batchSize, fn)
+}
+
 // UpdateUser mocks base method.
 func (m *MockUserServicePort) UpdateUser(ctx context.Context, id uuid.UUID, req *request.UpdateUserRequest) (*response.UserResponse, error) {
 	m.ctrl.T.Helper()
@@ -141,3 +294,18 @@ func (mr *MockUserServicePortMockRecorder) UpdateUser(ctx, id, req interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserServicePort)(nil).UpdateUser), ctx, id, req)
 }
+
+// VerifyEmail mocks base method.
+func (m *MockUserServicePort) VerifyEmail(ctx context.Context, id uuid.UUID, token string) (*response.UserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, id, token)
+	ret0, _ := ret[0].(*response.UserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockUserServicePortMockRecorder) VerifyEmail(ctx, id, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockUserServicePort)(nil).VerifyEmail), ctx, id, token)
+}
@@ -3,6 +3,7 @@ package inbound
 import (
 	"context"
 
+	"github.com/gieart87/gohexaclean/internal/domain"
 	"github.com/gieart87/gohexaclean/internal/dto/request"
 	"github.com/gieart87/gohexaclean/internal/dto/response"
 	"github.com/google/uuid"
@@ -12,10 +13,33 @@ import (
 // This is what the adapters (HTTP, gRPC) will call
 type UserServicePort interface {
 	CreateUser(ctx context.Context, req *request.CreateUserRequest) (*response.LoginResponse, error)
-	GetUserByID(ctx context.Context, id uuid.UUID) (*response.UserResponse, error)
+	GetUserByID(ctx context.Context, id uuid.UUID, includes ...string) (*response.UserResponse, error)
 	GetUserByEmail(ctx context.Context, email string) (*response.UserResponse, error)
+	GetUsersByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*response.UserResponse, error)
 	UpdateUser(ctx context.Context, id uuid.UUID, req *request.UpdateUserRequest) (*response.UserResponse, error)
 	DeleteUser(ctx context.Context, id uuid.UUID) error
+	// CreateUsers processes items concurrently within the bulk concurrency limit,
+	// returning one result per item in input order; the error is set only if ctx was cancelled
+	CreateUsers(ctx context.Context, reqs []*request.CreateUserRequest) ([]*response.BulkItemResult, error)
+	// DeleteUsers soft-deletes all users in ids in one transaction, reporting the IDs that matched no user
+	DeleteUsers(ctx context.Context, ids []uuid.UUID) (*response.BulkDeleteResponse, error)
 	Login(ctx context.Context, req *request.LoginRequest) (*response.LoginResponse, error)
-	ListUsers(ctx context.Context, page, limit int) ([]*response.UserResponse, int64, error)
+	ListUsers(ctx context.Context, page, limit int, sort domain.UserSort, opts domain.ListOptions) ([]*response.UserResponse, int64, error)
+	// StreamUsers calls fn for every user in creation order, loading batchSize users at a time
+	// Iteration stops at the first error returned by fn
+	StreamUsers(ctx context.Context, batchSize int, fn func(*response.UserResponse) error) error
+	// SearchUsers returns up to limit users matching query by name or email, best matches first
+	SearchUsers(ctx context.Context, query string, limit int) ([]*response.UserResponse, error)
+	// RevokeAllTokens invalidates every token issued to the user so far
+	RevokeAllTokens(ctx context.Context, id uuid.UUID) error
+	// GetTokenVersion returns the user's current token version, served from cache when possible
+	GetTokenVersion(ctx context.Context, id uuid.UUID) (int, error)
+	// SetActive activates or deactivates the user's account; deactivation also revokes their tokens
+	SetActive(ctx context.Context, id uuid.UUID, active bool) error
+	// ChangeEmail mails a verification token to newEmail, the email changes once VerifyEmail confirms it
+	ChangeEmail(ctx context.Context, id uuid.UUID, newEmail string) error
+	// VerifyEmail completes the user's pending email change with the mailed token
+	VerifyEmail(ctx context.Context, id uuid.UUID, token string) (*response.UserResponse, error)
+	// ListUserEvents returns a page of the user's recorded domain events, newest first, with their total
+	ListUserEvents(ctx context.Context, id uuid.UUID, page, limit int) ([]*response.UserEventResponse, int64, error)
 }
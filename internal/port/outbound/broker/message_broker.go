@@ -18,6 +18,7 @@ type MessageBroker interface {
 // Publisher defines the interface for publishing messages
 type Publisher interface {
 	Publish(ctx context.Context, topic string, event domain.Event) error
+	// PublishBatch publishes all events or none of them
 	PublishBatch(ctx context.Context, topic string, events []domain.Event) error
 }
 
@@ -39,6 +40,11 @@ type Message struct {
 	Headers   map[string]string
 }
 
+// DeadLetterArchiver stores dead-lettered messages before they are purged
+type DeadLetterArchiver interface {
+	Archive(ctx context.Context, queue string, message Message) error
+}
+
 // PublishOptions provides options for publishing messages
 type PublishOptions struct {
 	Topic       string
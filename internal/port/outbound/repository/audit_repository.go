@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gieart87/gohexaclean/internal/domain"
+)
+
+// AuditRepository defines the interface for the append-only audit log
+type AuditRepository interface {
+	// Record appends an audit entry, entries are never updated or deleted
+	Record(ctx context.Context, entry *domain.AuditLog) error
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gieart87/gohexaclean/internal/domain"
+	"github.com/google/uuid"
+)
+
+// EventStore defines the interface for persisted domain events
+type EventStore interface {
+	// Append stores events, inside the caller's transaction when there is one
+	Append(ctx context.Context, events ...*domain.StoredEvent) error
+	// List returns a page of the aggregate's events newest first, with the total number of its events
+	List(ctx context.Context, aggregateID uuid.UUID, offset, limit int) ([]*domain.StoredEvent, int64, error)
+}
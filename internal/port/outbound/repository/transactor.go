@@ -0,0 +1,10 @@
+package repository
+
+import "context"
+
+// Transactor runs several repository calls as one unit of work
+type Transactor interface {
+	// WithinTransaction runs fn in a transaction, committed if fn returns nil and rolled back otherwise
+	// Repositories called with the ctx passed to fn take part in the transaction; nested calls join the outer one
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
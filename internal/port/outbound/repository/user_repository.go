@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/gieart87/gohexaclean/internal/domain"
 	"github.com/google/uuid"
@@ -11,11 +12,31 @@ import (
 // This interface will be implemented by the database adapter
 type UserRepository interface {
 	Create(ctx context.Context, user *domain.User) error
-	FindByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
+	FindByID(ctx context.Context, id uuid.UUID, includes ...string) (*domain.User, error)
 	FindByEmail(ctx context.Context, email string) (*domain.User, error)
+	// FindByIDs looks up users in a single query; IDs with no matching user are absent from the map
+	FindByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*domain.User, error)
 	Update(ctx context.Context, user *domain.User) error
+	// UpdateEmail saves only the user's email, pending email and verification state
+	UpdateEmail(ctx context.Context, user *domain.User) error
 	Delete(ctx context.Context, id uuid.UUID) error
-	List(ctx context.Context, offset, limit int) ([]*domain.User, error)
+	// DeleteBatch soft-deletes all users in ids atomically, returning the number deleted
+	// and the IDs that matched no user
+	DeleteBatch(ctx context.Context, ids []uuid.UUID) (int64, []uuid.UUID, error)
+	List(ctx context.Context, offset, limit int, sort domain.UserSort) ([]*domain.User, error)
+	// ListAfter returns up to limit users created after cursor, oldest first; a nil cursor starts at the beginning
+	ListAfter(ctx context.Context, cursor *domain.UserCursor, limit int) ([]*domain.User, error)
 	Count(ctx context.Context) (int64, error)
+	// CountApprox estimates the number of users from the planner statistics, cheap on very large tables
+	// but only as fresh as the last ANALYZE and including soft-deleted rows
+	CountApprox(ctx context.Context) (int64, error)
+	// Search returns up to limit users matching query by name or email, best matches first
+	Search(ctx context.Context, query string, limit int) ([]*domain.User, error)
 	ExistsByEmail(ctx context.Context, email string) (bool, error)
+	// IncrementTokenVersion bumps the user's token version and returns the new value
+	IncrementTokenVersion(ctx context.Context, id uuid.UUID) (int, error)
+	// UpdateLastLogin sets only the user's last login time
+	UpdateLastLogin(ctx context.Context, id uuid.UUID, at time.Time) error
+	// SetActive updates only the user's active flag
+	SetActive(ctx context.Context, id uuid.UUID, active bool) error
 }
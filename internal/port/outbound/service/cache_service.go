@@ -12,4 +12,11 @@ type CacheService interface {
 	Delete(ctx context.Context, key string) error
 	Exists(ctx context.Context, key string) (bool, error)
 	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error)
+	// MGet returns the values of the keys that exist, missing keys are left out of the map
+	MGet(ctx context.Context, keys ...string) (map[string]string, error)
+	DeleteMany(ctx context.Context, keys ...string) error
+	DeleteByPrefix(ctx context.Context, prefix string) error
+	// AcquireLock takes a lock on key for at most ttl, ok is false if another holder has it
+	// release frees the lock only while this caller still owns it
+	AcquireLock(ctx context.Context, key string, ttl time.Duration) (release func(), ok bool, err error)
 }
@@ -35,6 +35,22 @@ func (m *MockCacheService) EXPECT() *MockCacheServiceMockRecorder {
 	return m.recorder
 }
 
+// AcquireLock mocks base method.
+func (m *MockCacheService) AcquireLock(ctx context.Context, key string, ttl time.Duration) (func(), bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireLock", ctx, key, ttl)
+	ret0, _ := ret[0].(func())
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AcquireLock indicates an expected call of AcquireLock.
+func (mr *MockCacheServiceMockRecorder) AcquireLock(ctx, key, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireLock", reflect.TypeOf((*MockCacheService)(nil).AcquireLock), ctx, key, ttl)
+}
+
 // Delete mocks base method.
 func (m *MockCacheService) Delete(ctx context.Context, key string) error {
 	m.ctrl.T.Helper()
@@ -49,6 +65,39 @@ func (mr *MockCacheServiceMockRecorder) Delete(ctx, key interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCacheService)(nil).Delete), ctx, key)
 }
 
+// DeleteByPrefix mocks base method.
+func (m *MockCacheService) DeleteByPrefix(ctx context.Context, prefix string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByPrefix", ctx, prefix)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByPrefix indicates an expected call of DeleteByPrefix.
+func (mr *MockCacheServiceMockRecorder) DeleteByPrefix(ctx, prefix interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByPrefix", reflect.TypeOf((*MockCacheService)(nil).DeleteByPrefix), ctx, prefix)
+}
+
+// DeleteMany mocks base method.
+func (m *MockCacheService) DeleteMany(ctx context.Context, keys ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range keys {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteMany", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMany indicates an expected call of DeleteMany.
+func (mr *MockCacheServiceMockRecorder) DeleteMany(ctx interface{}, keys ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, keys...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMany", reflect.TypeOf((*MockCacheService)(nil).DeleteMany), varargs...)
+}
+
 // Exists mocks base method.
 func (m *MockCacheService) Exists(ctx context.Context, key string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +128,26 @@ func (mr *MockCacheServiceMockRecorder) Get(ctx, key interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCacheService)(nil).Get), ctx, key)
 }
 
+// MGet mocks base method.
+func (m *MockCacheService) MGet(ctx context.Context, keys ...string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range keys {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MGet", varargs...)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MGet indicates an expected call of MGet.
+func (mr *MockCacheServiceMockRecorder) MGet(ctx interface{}, keys ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, keys...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MGet", reflect.TypeOf((*MockCacheService)(nil).MGet), varargs...)
+}
+
 // Set mocks base method.
 func (m *MockCacheService) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/port/outbound/service/task_queue.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockTaskQueue is a mock of TaskQueue interface.
+type MockTaskQueue struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskQueueMockRecorder
+}
+
+// MockTaskQueueMockRecorder is the mock recorder for MockTaskQueue.
+type MockTaskQueueMockRecorder struct {
+	mock *MockTaskQueue
+}
+
+// NewMockTaskQueue creates a new mock instance.
+func NewMockTaskQueue(ctrl *gomock.Controller) *MockTaskQueue {
+	mock := &MockTaskQueue{ctrl: ctrl}
+	mock.recorder = &MockTaskQueueMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskQueue) EXPECT() *MockTaskQueueMockRecorder {
+	return m.recorder
+}
+
+// EnqueueEmailVerification mocks base method.
+func (m *MockTaskQueue) EnqueueEmailVerification(ctx context.Context, userID uuid.UUID, email, token string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueEmailVerification", ctx, userID, email, token, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnqueueEmailVerification indicates an expected call of EnqueueEmailVerification.
+func (mr *MockTaskQueueMockRecorder) EnqueueEmailVerification(ctx, userID, email, token, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueEmailVerification", reflect.TypeOf((*MockTaskQueue)(nil).EnqueueEmailVerification), ctx, userID, email, token, expiresAt)
+}
+
+// EnqueueLoginAlert mocks base method.
+func (m *MockTaskQueue) EnqueueLoginAlert(ctx context.Context, userID uuid.UUID, email string, loggedInAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueLoginAlert", ctx, userID, email, loggedInAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnqueueLoginAlert indicates an expected call of EnqueueLoginAlert.
+func (mr *MockTaskQueueMockRecorder) EnqueueLoginAlert(ctx, userID, email, loggedInAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueLoginAlert", reflect.TypeOf((*MockTaskQueue)(nil).EnqueueLoginAlert), ctx, userID, email, loggedInAt)
+}
+
+// EnqueueWelcomeEmail mocks base method.
+func (m *MockTaskQueue) EnqueueWelcomeEmail(ctx context.Context, userID uuid.UUID, email, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueWelcomeEmail", ctx, userID, email, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnqueueWelcomeEmail indicates an expected call of EnqueueWelcomeEmail.
+func (mr *MockTaskQueueMockRecorder) EnqueueWelcomeEmail(ctx, userID, email, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueWelcomeEmail", reflect.TypeOf((*MockTaskQueue)(nil).EnqueueWelcomeEmail), ctx, userID, email, name)
+}
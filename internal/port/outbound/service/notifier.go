@@ -0,0 +1,9 @@
+package service
+
+import "context"
+
+// Notifier defines the outbound port for sending user notifications
+type Notifier interface {
+	SendEmail(ctx context.Context, to, subject, body string) error
+	SendSMS(ctx context.Context, to, message string) error
+}
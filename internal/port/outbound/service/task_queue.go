@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskQueue defines the outbound port for enqueueing background jobs
+type TaskQueue interface {
+	EnqueueWelcomeEmail(ctx context.Context, userID uuid.UUID, email, name string) error
+	EnqueueLoginAlert(ctx context.Context, userID uuid.UUID, email string, loggedInAt time.Time) error
+	// EnqueueEmailVerification mails token to the address the user wants to switch to
+	EnqueueEmailVerification(ctx context.Context, userID uuid.UUID, email, token string, expiresAt time.Time) error
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/port/outbound/telemetry/metrics.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMetricsService is a mock of MetricsService interface.
+type MockMetricsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricsServiceMockRecorder
+}
+
+// MockMetricsServiceMockRecorder is the mock recorder for MockMetricsService.
+type MockMetricsServiceMockRecorder struct {
+	mock *MockMetricsService
+}
+
+// NewMockMetricsService creates a new mock instance.
+func NewMockMetricsService(ctrl *gomock.Controller) *MockMetricsService {
+	mock := &MockMetricsService{ctrl: ctrl}
+	mock.recorder = &MockMetricsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricsService) EXPECT() *MockMetricsServiceMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockMetricsService) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockMetricsServiceMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockMetricsService)(nil).Close))
+}
+
+// IncrementCounter mocks base method.
+func (m *MockMetricsService) IncrementCounter(name string, tags map[string]string, value float64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "IncrementCounter", name, tags, value)
+}
+
+// IncrementCounter indicates an expected call of IncrementCounter.
+func (mr *MockMetricsServiceMockRecorder) IncrementCounter(name, tags, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementCounter", reflect.TypeOf((*MockMetricsService)(nil).IncrementCounter), name, tags, value)
+}
+
+// RecordDistribution mocks base method.
+func (m *MockMetricsService) RecordDistribution(name string, tags map[string]string, value float64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordDistribution", name, tags, value)
+}
+
+// RecordDistribution indicates an expected call of RecordDistribution.
+func (mr *MockMetricsServiceMockRecorder) RecordDistribution(name, tags, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordDistribution", reflect.TypeOf((*MockMetricsService)(nil).RecordDistribution), name, tags, value)
+}
+
+// RecordHistogram mocks base method.
+func (m *MockMetricsService) RecordHistogram(name string, tags map[string]string, value float64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordHistogram", name, tags, value)
+}
+
+// RecordHistogram indicates an expected call of RecordHistogram.
+func (mr *MockMetricsServiceMockRecorder) RecordHistogram(name, tags, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordHistogram", reflect.TypeOf((*MockMetricsService)(nil).RecordHistogram), name, tags, value)
+}
+
+// RecordTiming mocks base method.
+func (m *MockMetricsService) RecordTiming(name string, tags map[string]string, duration time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordTiming", name, tags, duration)
+}
+
+// RecordTiming indicates an expected call of RecordTiming.
+func (mr *MockMetricsServiceMockRecorder) RecordTiming(name, tags, duration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordTiming", reflect.TypeOf((*MockMetricsService)(nil).RecordTiming), name, tags, duration)
+}
+
+// SetGauge mocks base method.
+func (m *MockMetricsService) SetGauge(name string, tags map[string]string, value float64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetGauge", name, tags, value)
+}
+
+// SetGauge indicates an expected call of SetGauge.
+func (mr *MockMetricsServiceMockRecorder) SetGauge(name, tags, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGauge", reflect.TypeOf((*MockMetricsService)(nil).SetGauge), name, tags, value)
+}
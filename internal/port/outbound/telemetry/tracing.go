@@ -7,6 +7,9 @@ type Span interface {
 	// SetTag sets a tag on the span
 	SetTag(key string, value interface{})
 
+	// SetName renames the span, e.g. once the matched route is known
+	SetName(name string)
+
 	// SetError marks the span as having an error
 	SetError(err error)
 
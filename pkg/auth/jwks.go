@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+)
+
+// JWK is a JSON Web Key describing an RSA public key (RFC 7517)
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	Modulus   string `json:"n"`
+	Exponent  string `json:"e"`
+}
+
+// JWKS is a JSON Web Key Set, the document verifiers fetch public keys from
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// NewJWKS publishes the RS256 verification keys, the active key first and then the retired ones
+// The set is empty for HS256 since its secret must stay private
+func NewJWKS(opts TokenOptions) JWKS {
+	jwks := JWKS{Keys: []JWK{}}
+	if opts.algorithm() != AlgorithmRS256 {
+		return jwks
+	}
+
+	for _, key := range opts.publicKeys() {
+		jwks.Keys = append(jwks.Keys, newJWK(key))
+	}
+	return jwks
+}
+
+// newJWK describes an RS256 verification key
+func newJWK(key *rsa.PublicKey) JWK {
+	return JWK{
+		KeyType:   "RSA",
+		Use:       "sig",
+		Algorithm: AlgorithmRS256,
+		KeyID:     KeyID(key),
+		Modulus:   encodeBigInt(key.N),
+		Exponent:  encodeBigInt(big.NewInt(int64(key.E))),
+	}
+}
+
+// PublicKey rebuilds the RSA public key described by the JWK
+func (k JWK) PublicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.Modulus)
+	if err != nil {
+		return nil, err
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.Exponent)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
+
+// KeyID returns the RFC 7638 thumbprint of key, written to the kid header of RS256 tokens
+func KeyID(key *rsa.PublicKey) string {
+	// Members in lexicographic order, as the thumbprint requires
+	thumbprint, _ := json.Marshal(struct {
+		E   string `json:"e"`
+		Kty string `json:"kty"`
+		N   string `json:"n"`
+	}{
+		E:   encodeBigInt(big.NewInt(int64(key.E))),
+		Kty: "RSA",
+		N:   encodeBigInt(key.N),
+	})
+
+	sum := sha256.Sum256(thumbprint)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func encodeBigInt(v *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(v.Bytes())
+}
@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewJWKS_VerifiesTokenByKeyID(t *testing.T) {
+	opts := newRS256Options(t)
+	userID := uuid.New()
+
+	token, err := GenerateJWT(userID, "test@example.com", "user", 0, opts, time.Hour)
+	require.NoError(t, err)
+
+	// Round trip through JSON like a verifier fetching the document would
+	data, err := json.Marshal(NewJWKS(opts))
+	require.NoError(t, err)
+	var jwks JWKS
+	require.NoError(t, json.Unmarshal(data, &jwks))
+	require.Len(t, jwks.Keys, 1)
+	assert.Equal(t, "RSA", jwks.Keys[0].KeyType)
+	assert.Equal(t, AlgorithmRS256, jwks.Keys[0].Algorithm)
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &JWTClaims{})
+	require.NoError(t, err)
+	assert.Equal(t, jwks.Keys[0].KeyID, parsed.Header["kid"])
+
+	publicKey, err := jwks.Keys[0].PublicKey()
+	require.NoError(t, err)
+	assert.True(t, opts.PublicKey.Equal(publicKey))
+
+	claims, err := ValidateJWT(token, TokenOptions{
+		Algorithm: AlgorithmRS256,
+		PublicKey: publicKey,
+		Issuer:    opts.Issuer,
+		Audience:  opts.Audience,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, userID, claims.UserID)
+}
+
+func TestNewJWKS_EmptyForHS256(t *testing.T) {
+	jwks := NewJWKS(testOpts)
+
+	assert.Empty(t, jwks.Keys)
+}
+
+func TestValidateJWT_AcceptsTokensFromRetiredKey(t *testing.T) {
+	old := newRS256Options(t)
+	oldToken, err := GenerateJWT(uuid.New(), "test@example.com", "user", 0, old, time.Hour)
+	require.NoError(t, err)
+
+	// Rotate: a new key signs, the old public key is retired
+	rotated := newRS256Options(t)
+	rotated.RetiredPublicKeys = []*rsa.PublicKey{old.PublicKey}
+	newToken, err := GenerateJWT(uuid.New(), "test@example.com", "user", 0, rotated, time.Hour)
+	require.NoError(t, err)
+
+	_, err = ValidateJWT(oldToken, rotated)
+	assert.NoError(t, err)
+	_, err = ValidateJWT(newToken, rotated)
+	assert.NoError(t, err)
+
+	jwks := NewJWKS(rotated)
+	require.Len(t, jwks.Keys, 2)
+	assert.Equal(t, KeyID(rotated.PublicKey), jwks.Keys[0].KeyID, "the active key is listed first")
+	assert.Equal(t, KeyID(old.PublicKey), jwks.Keys[1].KeyID)
+}
+
+func TestValidateJWT_RejectsUnknownKeyID(t *testing.T) {
+	other := newRS256Options(t)
+	token, err := GenerateJWT(uuid.New(), "test@example.com", "user", 0, other, time.Hour)
+	require.NoError(t, err)
+
+	_, err = ValidateJWT(token, newRS256Options(t))
+	assert.ErrorContains(t, err, "unknown key id")
+}
@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"crypto/rsa"
+	"errors"
 	"fmt"
 	"time"
 
@@ -10,25 +12,67 @@ import (
 
 // JWTClaims represents JWT claims
 type JWTClaims struct {
-	UserID uuid.UUID `json:"user_id"`
-	Email  string    `json:"email"`
+	UserID       uuid.UUID `json:"user_id"`
+	Email        string    `json:"email"`
+	Role         string    `json:"role,omitempty"`
+	TokenVersion int       `json:"token_version"`
 	jwt.RegisteredClaims
 }
 
+// Supported signing algorithms
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+)
+
+// TokenOptions holds the signing keys and the claims binding tokens to this service
+type TokenOptions struct {
+	// Algorithm is HS256 (default, shared Secret) or RS256 (PrivateKey signs, PublicKey verifies)
+	Algorithm  string
+	Secret     string
+	PrivateKey *rsa.PrivateKey
+	PublicKey  *rsa.PublicKey
+	// RetiredPublicKeys still verify (and are published) after a key rotation, until their tokens expire
+	RetiredPublicKeys []*rsa.PublicKey
+	// Issuer is written to iss and required on validation, empty disables the check
+	Issuer string
+	// Audience is written to aud and required on validation, empty disables the check
+	Audience string
+	// Leeway is the clock skew tolerated when checking exp, nbf and iat
+	Leeway time.Duration
+}
+
 // GenerateJWT generates a JWT token
-func GenerateJWT(userID uuid.UUID, email, secret string, expiration time.Duration) (string, error) {
+// tokenVersion must match the user's current version for the token to be accepted
+func GenerateJWT(userID uuid.UUID, email, role string, tokenVersion int, opts TokenOptions, expiration time.Duration) (string, error) {
+	now := time.Now()
 	claims := JWTClaims{
-		UserID: userID,
-		Email:  email,
+		UserID:       userID,
+		Email:        email,
+		Role:         role,
+		TokenVersion: tokenVersion,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiration)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
+			Issuer:    opts.Issuer,
+			ExpiresAt: jwt.NewNumericDate(now.Add(expiration)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
 		},
 	}
+	if opts.Audience != "" {
+		claims.Audience = jwt.ClaimStrings{opts.Audience}
+	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(secret))
+	method, key, err := opts.signingKey()
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(method, claims)
+	if opts.algorithm() == AlgorithmRS256 {
+		// Lets verifiers pick the matching key from the published JWKS
+		token.Header["kid"] = KeyID(&opts.PrivateKey.PublicKey)
+	}
+	tokenString, err := token.SignedString(key)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign token: %w", err)
 	}
@@ -37,13 +81,36 @@ func GenerateJWT(userID uuid.UUID, email, secret string, expiration time.Duratio
 }
 
 // ValidateJWT validates a JWT token and returns the claims
-func ValidateJWT(tokenString, secret string) (*JWTClaims, error) {
+// Tokens from another issuer or for another audience are rejected when opts sets them
+// The alg header must match opts.Algorithm, so an RS256 public key is never used as an HMAC secret
+func ValidateJWT(tokenString string, opts TokenOptions) (*JWTClaims, error) {
+	key, err := opts.verificationKey()
+	if err != nil {
+		return nil, err
+	}
+
+	parserOpts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{opts.algorithm()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(opts.Leeway),
+	}
+	if opts.Issuer != "" {
+		parserOpts = append(parserOpts, jwt.WithIssuer(opts.Issuer))
+	}
+	if opts.Audience != "" {
+		parserOpts = append(parserOpts, jwt.WithAudience(opts.Audience))
+	}
+
 	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		if token.Method.Alg() != opts.algorithm() {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-		return []byte(secret), nil
-	})
+		if kid, ok := token.Header["kid"].(string); ok && opts.algorithm() == AlgorithmRS256 {
+			return opts.publicKeyByID(kid)
+		}
+		return key, nil
+	}, parserOpts...)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse token: %w", err)
@@ -55,3 +122,60 @@ func ValidateJWT(tokenString, secret string) (*JWTClaims, error) {
 
 	return nil, fmt.Errorf("invalid token")
 }
+
+// algorithm returns the configured algorithm, HS256 when unset
+func (o TokenOptions) algorithm() string {
+	if o.Algorithm == "" {
+		return AlgorithmHS256
+	}
+	return o.Algorithm
+}
+
+// signingKey returns the method and key tokens are signed with
+func (o TokenOptions) signingKey() (jwt.SigningMethod, interface{}, error) {
+	switch o.algorithm() {
+	case AlgorithmHS256:
+		return jwt.SigningMethodHS256, []byte(o.Secret), nil
+	case AlgorithmRS256:
+		if o.PrivateKey == nil {
+			return nil, nil, errors.New("RS256 signing requires a private key")
+		}
+		return jwt.SigningMethodRS256, o.PrivateKey, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported signing algorithm: %s", o.Algorithm)
+	}
+}
+
+// verificationKey returns the key token signatures are checked against
+func (o TokenOptions) verificationKey() (interface{}, error) {
+	switch o.algorithm() {
+	case AlgorithmHS256:
+		return []byte(o.Secret), nil
+	case AlgorithmRS256:
+		if o.PublicKey == nil {
+			return nil, errors.New("RS256 verification requires a public key")
+		}
+		return o.PublicKey, nil
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm: %s", o.Algorithm)
+	}
+}
+
+// publicKeyByID returns the current or retired public key with the given kid
+func (o TokenOptions) publicKeyByID(kid string) (*rsa.PublicKey, error) {
+	for _, key := range o.publicKeys() {
+		if KeyID(key) == kid {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown key id: %s", kid)
+}
+
+// publicKeys returns the current public key followed by the retired ones
+func (o TokenOptions) publicKeys() []*rsa.PublicKey {
+	keys := make([]*rsa.PublicKey, 0, 1+len(o.RetiredPublicKeys))
+	if o.PublicKey != nil {
+		keys = append(keys, o.PublicKey)
+	}
+	return append(keys, o.RetiredPublicKeys...)
+}
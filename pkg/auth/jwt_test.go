@@ -0,0 +1,224 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testOpts = TokenOptions{
+	Secret:   "test-secret",
+	Issuer:   "gohexaclean",
+	Audience: "gohexaclean-api",
+	Leeway:   30 * time.Second,
+}
+
+func TestGenerateJWT_RoundTrip(t *testing.T) {
+	userID := uuid.New()
+
+	token, err := GenerateJWT(userID, "test@example.com", "admin", 3, testOpts, time.Hour)
+	require.NoError(t, err)
+
+	claims, err := ValidateJWT(token, testOpts)
+	require.NoError(t, err)
+	assert.Equal(t, userID, claims.UserID)
+	assert.Equal(t, "admin", claims.Role)
+	assert.Equal(t, 3, claims.TokenVersion)
+	assert.Equal(t, "gohexaclean", claims.Issuer)
+	assert.Equal(t, jwt.ClaimStrings{"gohexaclean-api"}, claims.Audience)
+}
+
+func TestValidateJWT_RejectsWrongIssuer(t *testing.T) {
+	other := testOpts
+	other.Issuer = "other-service"
+
+	token, err := GenerateJWT(uuid.New(), "test@example.com", "user", 0, other, time.Hour)
+	require.NoError(t, err)
+
+	_, err = ValidateJWT(token, testOpts)
+	assert.ErrorIs(t, err, jwt.ErrTokenInvalidIssuer)
+}
+
+func TestValidateJWT_RejectsWrongAudience(t *testing.T) {
+	other := testOpts
+	other.Audience = "billing-api"
+
+	token, err := GenerateJWT(uuid.New(), "test@example.com", "user", 0, other, time.Hour)
+	require.NoError(t, err)
+
+	_, err = ValidateJWT(token, testOpts)
+	assert.ErrorIs(t, err, jwt.ErrTokenInvalidAudience)
+}
+
+func TestValidateJWT_RejectsMissingIssuerAndAudience(t *testing.T) {
+	// Same secret, but minted by a service that doesn't bind its tokens
+	token, err := GenerateJWT(uuid.New(), "test@example.com", "user", 0, TokenOptions{Secret: testOpts.Secret}, time.Hour)
+	require.NoError(t, err)
+
+	_, err = ValidateJWT(token, testOpts)
+	assert.Error(t, err)
+}
+
+func TestValidateJWT_ClockSkew(t *testing.T) {
+	tests := []struct {
+		name    string
+		expired time.Duration
+		wantErr bool
+	}{
+		{name: "expired within leeway", expired: -10 * time.Second, wantErr: false},
+		{name: "expired beyond leeway", expired: -time.Minute, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := GenerateJWT(uuid.New(), "test@example.com", "user", 0, testOpts, tt.expired)
+			require.NoError(t, err)
+
+			_, err = ValidateJWT(token, testOpts)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, jwt.ErrTokenExpired)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateJWT_AcceptsTokenIssuedSlightlyInTheFuture(t *testing.T) {
+	// The issuing host's clock runs ahead of ours
+	issued := time.Now().Add(10 * time.Second)
+	claims := JWTClaims{
+		UserID: uuid.New(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    testOpts.Issuer,
+			Audience:  jwt.ClaimStrings{testOpts.Audience},
+			ExpiresAt: jwt.NewNumericDate(issued.Add(time.Hour)),
+			IssuedAt:  jwt.NewNumericDate(issued),
+			NotBefore: jwt.NewNumericDate(issued),
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testOpts.Secret))
+	require.NoError(t, err)
+
+	_, err = ValidateJWT(token, testOpts)
+	assert.NoError(t, err)
+
+	noLeeway := testOpts
+	noLeeway.Leeway = 0
+	_, err = ValidateJWT(token, noLeeway)
+	assert.Error(t, err)
+}
+
+// newRS256Options returns options holding a freshly generated RSA key pair
+func newRS256Options(t *testing.T) TokenOptions {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	opts := testOpts
+	opts.Algorithm = AlgorithmRS256
+	opts.Secret = ""
+	opts.PrivateKey = key
+	opts.PublicKey = &key.PublicKey
+	return opts
+}
+
+func TestGenerateJWT_HS256IsDefault(t *testing.T) {
+	token, err := GenerateJWT(uuid.New(), "test@example.com", "user", 0, testOpts, time.Hour)
+	require.NoError(t, err)
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &JWTClaims{})
+	require.NoError(t, err)
+	assert.Equal(t, AlgorithmHS256, parsed.Method.Alg())
+}
+
+func TestGenerateJWT_RS256RoundTrip(t *testing.T) {
+	opts := newRS256Options(t)
+	userID := uuid.New()
+
+	token, err := GenerateJWT(userID, "test@example.com", "user", 0, opts, time.Hour)
+	require.NoError(t, err)
+
+	// Verifiers only need the public key
+	verifier := opts
+	verifier.PrivateKey = nil
+	claims, err := ValidateJWT(token, verifier)
+	require.NoError(t, err)
+	assert.Equal(t, userID, claims.UserID)
+}
+
+func TestGenerateJWT_RS256RequiresPrivateKey(t *testing.T) {
+	opts := newRS256Options(t)
+	opts.PrivateKey = nil
+
+	_, err := GenerateJWT(uuid.New(), "test@example.com", "user", 0, opts, time.Hour)
+	assert.Error(t, err)
+}
+
+func TestValidateJWT_RejectsAlgorithmSwap(t *testing.T) {
+	opts := newRS256Options(t)
+
+	// Classic alg confusion: HMAC-sign a forged token with the (public) RSA key as the secret
+	publicDER, err := x509.MarshalPKIXPublicKey(opts.PublicKey)
+	require.NoError(t, err)
+	forged := JWTClaims{
+		UserID: uuid.New(),
+		Role:   "admin",
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    opts.Issuer,
+			Audience:  jwt.ClaimStrings{opts.Audience},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+	forgedToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, forged).SignedString(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+	require.NoError(t, err)
+
+	_, err = ValidateJWT(forgedToken, opts)
+	assert.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
+
+	// And the reverse: an RS256 token is not accepted by an HS256 validator
+	rsToken, err := GenerateJWT(uuid.New(), "test@example.com", "user", 0, opts, time.Hour)
+	require.NoError(t, err)
+
+	_, err = ValidateJWT(rsToken, testOpts)
+	assert.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
+}
+
+func TestValidateJWT_RejectsUnsupportedAlgorithm(t *testing.T) {
+	opts := testOpts
+	opts.Algorithm = "none"
+
+	_, err := ValidateJWT("a.b.c", opts)
+	assert.Error(t, err)
+}
+
+func TestValidateJWT_RejectsTamperedRS256Token(t *testing.T) {
+	opts := newRS256Options(t)
+
+	token, err := GenerateJWT(uuid.New(), "user@example.com", "user", 0, opts, time.Hour)
+	require.NoError(t, err)
+
+	// Swap in a payload claiming the admin role while keeping the original signature
+	parts := strings.Split(token, ".")
+	require.Len(t, parts, 3)
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	tampered := strings.Replace(string(payload), `"role":"user"`, `"role":"admin"`, 1)
+	require.NotEqual(t, string(payload), tampered)
+	parts[1] = base64.RawURLEncoding.EncodeToString([]byte(tampered))
+
+	_, err = ValidateJWT(strings.Join(parts, "."), opts)
+	assert.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
+}
@@ -23,12 +23,18 @@ func MapDomainError(err error) *AppError {
 		return Conflict("User already exists", err)
 	case stderrors.Is(err, domain.ErrInvalidCredentials):
 		return Unauthorized("Invalid credentials", err)
+	case stderrors.Is(err, domain.ErrAccountInactive):
+		return Forbidden("Account is inactive", err)
 	case stderrors.Is(err, domain.ErrUnauthorized):
 		return Unauthorized("Unauthorized access", err)
 	case stderrors.Is(err, domain.ErrForbidden):
 		return Forbidden("Access forbidden", err)
 	case stderrors.Is(err, domain.ErrInvalidInput):
 		return BadRequest("Invalid input provided", err)
+	case stderrors.Is(err, domain.ErrInvalidVerificationToken):
+		return BadRequest("Invalid or expired verification token", err)
+	case stderrors.Is(err, domain.ErrNoPendingEmailChange):
+		return BadRequest("No email change pending", err)
 
 	// Database Infrastructure Errors
 	case stderrors.Is(err, dberr.ErrDBConnection):
@@ -115,11 +121,15 @@ func GetHTTPStatusFromDomainError(err error) int {
 		return http.StatusConflict
 	case stderrors.Is(err, domain.ErrInvalidCredentials):
 		return http.StatusUnauthorized
+	case stderrors.Is(err, domain.ErrAccountInactive):
+		return http.StatusForbidden
 	case stderrors.Is(err, domain.ErrUnauthorized):
 		return http.StatusUnauthorized
 	case stderrors.Is(err, domain.ErrForbidden):
 		return http.StatusForbidden
-	case stderrors.Is(err, domain.ErrInvalidInput):
+	case stderrors.Is(err, domain.ErrInvalidInput),
+		stderrors.Is(err, domain.ErrInvalidVerificationToken),
+		stderrors.Is(err, domain.ErrNoPendingEmailChange):
 		return http.StatusBadRequest
 	default:
 		return http.StatusInternalServerError
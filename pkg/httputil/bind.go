@@ -0,0 +1,80 @@
+package httputil
+
+import (
+	"errors"
+
+	"github.com/gieart87/gohexaclean/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+// normalizer is implemented by request DTOs that canonicalize their fields before validation
+type normalizer interface {
+	Normalize()
+}
+
+// validator is implemented by request DTOs that can check themselves
+type validator interface {
+	Validate() error
+}
+
+// BindError is returned when a request body can't be bound, Respond writes it to the client
+type BindError struct {
+	Status   int
+	Response *response.ErrorResponse
+	Err      error
+}
+
+func (e *BindError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *BindError) Unwrap() error {
+	return e.Err
+}
+
+// BindAndValidate parses the request body into a T, normalizes it and runs its Validate method
+// Either step is skipped when T doesn't implement it
+// A malformed body fails with 400, a failed validation with 422
+func BindAndValidate[T any](c *fiber.Ctx) (T, error) {
+	return BindAndValidateWith(c, func(req T) error {
+		if v, ok := any(&req).(validator); ok {
+			return v.Validate()
+		}
+		return nil
+	})
+}
+
+// BindAndValidateWith is BindAndValidate with a custom validation, for DTOs whose rules depend on configuration
+func BindAndValidateWith[T any](c *fiber.Ctx, validate func(T) error) (T, error) {
+	var req T
+	if err := c.BodyParser(&req); err != nil {
+		return req, &BindError{
+			Status:   fiber.StatusBadRequest,
+			Response: response.NewErrorResponse("Invalid request body", err),
+			Err:      err,
+		}
+	}
+
+	if n, ok := any(&req).(normalizer); ok {
+		n.Normalize()
+	}
+
+	if err := validate(req); err != nil {
+		return req, &BindError{
+			Status:   fiber.StatusUnprocessableEntity,
+			Response: response.NewValidationErrorResponse("Validation failed", response.ParseValidationErrors(err)),
+			Err:      err,
+		}
+	}
+
+	return req, nil
+}
+
+// Respond writes the error response of a BindError, other errors are left to the Fiber error handler
+func Respond(c *fiber.Ctx, err error) error {
+	var bindErr *BindError
+	if errors.As(err, &bindErr) {
+		return c.Status(bindErr.Status).JSON(bindErr.Response)
+	}
+	return err
+}
@@ -0,0 +1,108 @@
+package httputil
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gieart87/gohexaclean/pkg/response"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type greetRequest struct {
+	Name string `json:"name"`
+}
+
+func (r *greetRequest) Normalize() {
+	r.Name = strings.TrimSpace(r.Name)
+}
+
+func (r greetRequest) Validate() error {
+	if r.Name == "" {
+		return errors.New("name is required")
+	}
+	return nil
+}
+
+// bindApp serves POST /greet, answering with the bound name
+func bindApp() *fiber.App {
+	app := fiber.New()
+	app.Post("/greet", func(c *fiber.Ctx) error {
+		req, err := BindAndValidate[greetRequest](c)
+		if err != nil {
+			return Respond(c, err)
+		}
+		return c.SendString(req.Name)
+	})
+	return app
+}
+
+func postGreet(t *testing.T, app *fiber.App, body string) *http.Response {
+	t.Helper()
+
+	req, _ := http.NewRequest(http.MethodPost, "/greet", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestBindAndValidate_NormalizesAndBinds(t *testing.T) {
+	resp := postGreet(t, bindApp(), `{"name":"  Jane  "}`)
+
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "Jane", string(body))
+}
+
+func TestBindAndValidate_MalformedBody(t *testing.T) {
+	resp := postGreet(t, bindApp(), `{"name":`)
+
+	require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	var body response.ErrorResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "Invalid request body", body.Message)
+}
+
+func TestBindAndValidate_ValidationFailure(t *testing.T) {
+	// Whitespace only is normalized away before validation
+	resp := postGreet(t, bindApp(), `{"name":"   "}`)
+
+	require.Equal(t, fiber.StatusUnprocessableEntity, resp.StatusCode)
+
+	var body response.ErrorResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "VALIDATION_ERROR", body.ErrorCode)
+	assert.Equal(t, []string{"name is required"}, body.Errors["error"])
+}
+
+func TestBindAndValidateWith_CustomValidation(t *testing.T) {
+	app := fiber.New()
+	app.Post("/greet", func(c *fiber.Ctx) error {
+		_, err := BindAndValidateWith(c, func(req greetRequest) error {
+			if req.Name != "Jane" {
+				return errors.New("only Jane is greeted")
+			}
+			return nil
+		})
+		if err != nil {
+			return Respond(c, err)
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	assert.Equal(t, fiber.StatusNoContent, postGreet(t, app, `{"name":"Jane"}`).StatusCode)
+	assert.Equal(t, fiber.StatusUnprocessableEntity, postGreet(t, app, `{"name":"John"}`).StatusCode)
+}
+
+func TestRespond_LeavesOtherErrorsToErrorHandler(t *testing.T) {
+	err := errors.New("boom")
+	assert.Same(t, err, Respond(nil, err))
+}
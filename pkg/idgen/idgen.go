@@ -0,0 +1,101 @@
+package idgen
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Strategy selects how new primary keys are generated
+type Strategy string
+
+// Supported strategies, all of them fit a UUID column
+const (
+	// StrategyUUIDv4 generates random UUIDs, the default
+	StrategyUUIDv4 Strategy = "uuidv4"
+	// StrategyUUIDv7 generates UUIDs prefixed with the millisecond timestamp
+	StrategyUUIDv7 Strategy = "uuidv7"
+	// StrategyULID generates ULIDs stored in their 128-bit UUID form
+	StrategyULID Strategy = "ulid"
+)
+
+// Validate reports whether s is a supported strategy, empty means the default
+func (s Strategy) Validate() error {
+	switch s {
+	case "", StrategyUUIDv4, StrategyUUIDv7, StrategyULID:
+		return nil
+	default:
+		return fmt.Errorf("unsupported id strategy %q, want %s, %s or %s", string(s), StrategyUUIDv4, StrategyUUIDv7, StrategyULID)
+	}
+}
+
+// New generates an ID with the strategy, unknown strategies fall back to UUID v4
+// UUID v7 and ULID IDs sort in creation order, which keeps inserts at the end of the primary key index
+func (s Strategy) New() uuid.UUID {
+	switch s {
+	case StrategyUUIDv7:
+		return uuid.Must(uuid.NewV7())
+	case StrategyULID:
+		return ulids.next(time.Now())
+	default:
+		return uuid.New()
+	}
+}
+
+// ulidSource generates monotonic ULIDs: 48 bits of Unix milliseconds followed by 80 random bits
+// Within the same millisecond the random part of the previous ULID is incremented instead
+type ulidSource struct {
+	mu   sync.Mutex
+	last uuid.UUID
+	ms   uint64
+}
+
+var ulids ulidSource
+
+func (g *ulidSource) next(now time.Time) uuid.UUID {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ms := uint64(now.UnixMilli())
+	if ms <= g.ms {
+		// Same millisecond or a clock that went backwards, stay after the previous ULID
+		if g.incrementRandom() {
+			return g.last
+		}
+		ms = g.ms + 1
+	}
+
+	var id uuid.UUID
+	var stamp [8]byte
+	binary.BigEndian.PutUint64(stamp[:], ms)
+	copy(id[:6], stamp[2:])
+	if _, err := rand.Read(id[6:]); err != nil {
+		panic(fmt.Sprintf("idgen: failed to read random bytes: %v", err))
+	}
+
+	g.ms = ms
+	g.last = id
+	return id
+}
+
+// incrementRandom adds one to the random part of the last ULID, it reports false on overflow
+func (g *ulidSource) incrementRandom() bool {
+	for i := len(g.last) - 1; i >= 6; i-- {
+		g.last[i]++
+		if g.last[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Time returns the creation time encoded in a UUID v7 or ULID id
+func Time(id uuid.UUID) time.Time {
+	var stamp [8]byte
+	copy(stamp[2:], id[:6])
+	return time.UnixMilli(int64(binary.BigEndian.Uint64(stamp[:])))
+}
@@ -0,0 +1,88 @@
+package idgen
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrategy_Validate(t *testing.T) {
+	for _, s := range []Strategy{"", StrategyUUIDv4, StrategyUUIDv7, StrategyULID} {
+		assert.NoError(t, s.Validate(), s)
+	}
+	assert.ErrorContains(t, Strategy("snowflake").Validate(), `unsupported id strategy "snowflake"`)
+}
+
+func TestStrategy_New_DefaultsToRandomUUID(t *testing.T) {
+	for _, s := range []Strategy{"", StrategyUUIDv4} {
+		assert.Equal(t, uuid.Version(4), s.New().Version())
+	}
+}
+
+func TestStrategy_New_TimeOrdered(t *testing.T) {
+	for _, s := range []Strategy{StrategyUUIDv7, StrategyULID} {
+		t.Run(string(s), func(t *testing.T) {
+			before := time.Now().Truncate(time.Millisecond)
+
+			ids := make([]uuid.UUID, 1000)
+			for i := range ids {
+				ids[i] = s.New()
+			}
+
+			for i := 1; i < len(ids); i++ {
+				require.Equal(t, -1, bytes.Compare(ids[i-1][:], ids[i][:]), "id %d should sort after id %d", i, i-1)
+			}
+
+			// The string form sorts the same way, so does the uuid column
+			assert.Less(t, ids[0].String(), ids[len(ids)-1].String())
+
+			// IDs carry their creation time
+			created := Time(ids[0])
+			assert.False(t, created.Before(before))
+			assert.WithinDuration(t, time.Now(), created, time.Second)
+		})
+	}
+}
+
+func TestStrategy_New_ParsesBack(t *testing.T) {
+	for _, s := range []Strategy{StrategyUUIDv4, StrategyUUIDv7, StrategyULID} {
+		id := s.New()
+
+		parsed, err := uuid.Parse(id.String())
+		require.NoError(t, err, s)
+		assert.Equal(t, id, parsed, s)
+	}
+
+	assert.Equal(t, uuid.Version(7), StrategyUUIDv7.New().Version())
+}
+
+func TestULIDSource_MonotonicWithinMillisecondAndBackwardClock(t *testing.T) {
+	var src ulidSource
+	now := time.UnixMilli(1_700_000_000_000)
+
+	first := src.next(now)
+	second := src.next(now)
+	earlier := src.next(now.Add(-time.Second))
+
+	assert.Equal(t, -1, bytes.Compare(first[:], second[:]))
+	assert.Equal(t, -1, bytes.Compare(second[:], earlier[:]))
+	assert.Equal(t, now, Time(first))
+}
+
+func TestULIDSource_OverflowMovesToNextMillisecond(t *testing.T) {
+	var src ulidSource
+	now := time.UnixMilli(1_700_000_000_000)
+
+	first := src.next(now)
+	for i := 6; i < len(src.last); i++ {
+		src.last[i] = 0xff
+	}
+	next := src.next(now)
+
+	assert.Equal(t, now.Add(time.Millisecond), Time(next))
+	assert.Equal(t, -1, bytes.Compare(first[:], next[:]))
+}
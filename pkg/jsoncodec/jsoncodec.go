@@ -0,0 +1,49 @@
+package jsoncodec
+
+import (
+	"encoding/json"
+	"fmt"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Codec selects the JSON implementation used to encode responses and decode request bodies
+type Codec string
+
+// Supported codecs, both produce the same bytes for the same value
+const (
+	// CodecStd uses encoding/json, the default
+	CodecStd Codec = "std"
+	// CodecJSONIter uses json-iterator in its standard library compatible mode, faster on large lists
+	CodecJSONIter Codec = "jsoniter"
+)
+
+// jsonIter matches encoding/json: sorted map keys, HTML escaping and Marshaler/TextMarshaler support,
+// so time.Time and uuid.UUID encode exactly as with the stdlib
+var jsonIter = jsoniter.ConfigCompatibleWithStandardLibrary
+
+// Validate reports whether c is a supported codec, empty means the default
+func (c Codec) Validate() error {
+	switch c {
+	case "", CodecStd, CodecJSONIter:
+		return nil
+	default:
+		return fmt.Errorf("unsupported json codec %q, want %s or %s", string(c), CodecStd, CodecJSONIter)
+	}
+}
+
+// Marshal returns the encoder of the codec, unknown codecs fall back to encoding/json
+func (c Codec) Marshal() func(v interface{}) ([]byte, error) {
+	if c == CodecJSONIter {
+		return jsonIter.Marshal
+	}
+	return json.Marshal
+}
+
+// Unmarshal returns the decoder of the codec, unknown codecs fall back to encoding/json
+func (c Codec) Unmarshal() func(data []byte, v interface{}) error {
+	if c == CodecJSONIter {
+		return jsonIter.Unmarshal
+	}
+	return json.Unmarshal
+}
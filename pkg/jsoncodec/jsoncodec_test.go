@@ -0,0 +1,100 @@
+package jsoncodec
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	dto "github.com/gieart87/gohexaclean/internal/dto/response"
+	"github.com/gieart87/gohexaclean/pkg/response"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// userPage builds a page of n users like the one served by GET /users
+func userPage(n int) *response.PaginatedResponse {
+	createdAt := time.Date(2024, 3, 1, 12, 30, 45, 123456789, time.FixedZone("WIB", 7*60*60))
+	lastLogin := createdAt.Add(time.Hour)
+
+	users := make([]*dto.UserResponse, n)
+	for i := range users {
+		users[i] = &dto.UserResponse{
+			ID:        uuid.New(),
+			Email:     fmt.Sprintf("user%d@example.com", i),
+			Name:      fmt.Sprintf("User <%d> & co", i),
+			IsActive:  i%2 == 0,
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		}
+		if i%3 == 0 {
+			users[i].LastLoginAt = &lastLogin
+		}
+	}
+
+	return response.NewPaginatedResponse("Users retrieved successfully", users, 1, n, int64(n*10))
+}
+
+func TestCodec_Validate(t *testing.T) {
+	for _, c := range []Codec{"", CodecStd, CodecJSONIter} {
+		assert.NoError(t, c.Validate(), c)
+	}
+	assert.ErrorContains(t, Codec("sonic").Validate(), `unsupported json codec "sonic"`)
+}
+
+func TestCodec_MatchesStdlib(t *testing.T) {
+	page := userPage(5)
+
+	want, err := CodecStd.Marshal()(page)
+	require.NoError(t, err)
+
+	got, err := CodecJSONIter.Marshal()(page)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got))
+}
+
+func TestCodec_RoundTripsPaginatedResponse(t *testing.T) {
+	page := userPage(5)
+
+	for _, c := range []Codec{CodecStd, CodecJSONIter} {
+		t.Run(string(c), func(t *testing.T) {
+			body, err := c.Marshal()(page)
+			require.NoError(t, err)
+
+			decoded := &response.PaginatedResponse{Data: &[]*dto.UserResponse{}}
+			require.NoError(t, c.Unmarshal()(body, decoded))
+
+			users := *decoded.Data.(*[]*dto.UserResponse)
+			want := page.Data.([]*dto.UserResponse)
+			require.Len(t, users, len(want))
+			for i := range want {
+				assert.Equal(t, want[i].ID, users[i].ID)
+				assert.True(t, want[i].CreatedAt.Equal(users[i].CreatedAt))
+				assert.Equal(t, want[i].LastLoginAt == nil, users[i].LastLoginAt == nil)
+			}
+			assert.True(t, page.Meta.Timestamp.Equal(decoded.Meta.Timestamp))
+			assert.Equal(t, page.Meta.Pagination, decoded.Meta.Pagination)
+
+			// Encoding the decoded page again yields the original bytes
+			again, err := c.Marshal()(decoded)
+			require.NoError(t, err)
+			assert.Equal(t, string(body), string(again))
+		})
+	}
+}
+
+func BenchmarkCodec_MarshalUserList(b *testing.B) {
+	page := userPage(100)
+
+	for _, c := range []Codec{CodecStd, CodecJSONIter} {
+		marshal := c.Marshal()
+		b.Run(string(c), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := marshal(page); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package pagination
+
+import "math"
+
+const (
+	// DefaultLimit is the page size used when none or an invalid one is requested
+	DefaultLimit = 10
+	// MaxLimit is the largest page size a client gets, larger requests are capped to it
+	MaxLimit = 100
+)
+
+// Bounds configures the default and maximum page size
+type Bounds struct {
+	DefaultLimit int
+	MaxLimit     int
+}
+
+// Default is the page size policy used when none is configured
+var Default = Bounds{DefaultLimit: DefaultLimit, MaxLimit: MaxLimit}
+
+// Normalize normalizes page and limit with the Default bounds
+func Normalize(page, limit int) (int, int) {
+	return Default.Normalize(page, limit)
+}
+
+// Normalize returns page raised to 1 and limit checked against b
+func (b Bounds) Normalize(page, limit int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	return page, b.Limit(limit)
+}
+
+// Limit returns limit, the default limit when it is below 1, or the maximum when it is above it
+func (b Bounds) Limit(limit int) int {
+	switch {
+	case limit < 1:
+		return b.DefaultLimit
+	case limit > b.MaxLimit:
+		return b.MaxLimit
+	default:
+		return limit
+	}
+}
+
+// Offset returns how many items precede page, pages below 1 count as the first page
+func Offset(page, limit int) int {
+	if page < 1 || limit < 1 {
+		return 0
+	}
+	return (page - 1) * limit
+}
+
+// TotalPages returns how many pages of perPage items hold total items, 0 when perPage is not positive
+// The count is computed in int64 and capped at the largest int, so huge totals can't wrap on 32-bit platforms
+func TotalPages(total int64, perPage int) int {
+	if total <= 0 || perPage <= 0 {
+		return 0
+	}
+
+	// Rounds up without total+perPage-1, which overflows for totals near the int64 maximum
+	pages := total / int64(perPage)
+	if total%int64(perPage) != 0 {
+		pages++
+	}
+	if pages > math.MaxInt {
+		return math.MaxInt
+	}
+	return int(pages)
+}
+
+// HasNext reports whether another page of perPage items follows page
+func HasNext(page, perPage int, total int64) bool {
+	return page >= 1 && page < TotalPages(total, perPage)
+}
@@ -0,0 +1,118 @@
+package pagination
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name                string
+		page, limit         int
+		wantPage, wantLimit int
+	}{
+		{name: "zero values", page: 0, limit: 0, wantPage: 1, wantLimit: DefaultLimit},
+		{name: "negative values", page: -3, limit: -1, wantPage: 1, wantLimit: DefaultLimit},
+		{name: "valid values", page: 4, limit: 25, wantPage: 4, wantLimit: 25},
+		{name: "limit of one", page: 1, limit: 1, wantPage: 1, wantLimit: 1},
+		{name: "exact max limit", page: 2, limit: MaxLimit, wantPage: 2, wantLimit: MaxLimit},
+		{name: "over max limit", page: 2, limit: MaxLimit + 1, wantPage: 2, wantLimit: MaxLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, limit := Normalize(tt.page, tt.limit)
+			assert.Equal(t, tt.wantPage, page)
+			assert.Equal(t, tt.wantLimit, limit)
+		})
+	}
+}
+
+func TestBounds_Limit(t *testing.T) {
+	b := Bounds{DefaultLimit: 20, MaxLimit: 50}
+
+	tests := []struct {
+		name  string
+		limit int
+		want  int
+	}{
+		{name: "negative clamps to default", limit: -5, want: 20},
+		{name: "zero clamps to default", limit: 0, want: 20},
+		{name: "valid", limit: 7, want: 7},
+		{name: "exact max", limit: 50, want: 50},
+		{name: "over max clamps to max", limit: 51, want: 50},
+		{name: "far over max clamps to max", limit: 10000, want: 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, b.Limit(tt.limit))
+		})
+	}
+}
+
+func TestBounds_Normalize(t *testing.T) {
+	b := Bounds{DefaultLimit: 25, MaxLimit: 200}
+
+	page, limit := b.Normalize(-1, 500)
+	assert.Equal(t, 1, page)
+	assert.Equal(t, 200, limit)
+
+	page, limit = b.Normalize(3, 0)
+	assert.Equal(t, 3, page)
+	assert.Equal(t, 25, limit)
+}
+
+func TestOffset(t *testing.T) {
+	assert.Equal(t, 0, Offset(1, 10))
+	assert.Equal(t, 40, Offset(5, 10))
+	assert.Equal(t, 0, Offset(0, 10))
+	assert.Equal(t, 0, Offset(-2, 10))
+	assert.Equal(t, 0, Offset(3, 0))
+}
+
+func TestTotalPages(t *testing.T) {
+	tests := []struct {
+		name    string
+		total   int64
+		perPage int
+		want    int
+	}{
+		{name: "empty", total: 0, perPage: 10, want: 0},
+		{name: "exact pages", total: 30, perPage: 10, want: 3},
+		{name: "partial last page", total: 31, perPage: 10, want: 4},
+		{name: "fewer than a page", total: 3, perPage: 10, want: 1},
+		{name: "zero per page", total: 30, perPage: 0, want: 0},
+		{name: "negative per page", total: 30, perPage: -1, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotPanics(t, func() {
+				assert.Equal(t, tt.want, TotalPages(tt.total, tt.perPage))
+			})
+		})
+	}
+}
+
+func TestTotalPages_HugeTotal(t *testing.T) {
+	// Computed in int64, the expected count only fits an int on 64-bit platforms
+	want := int64(math.MaxInt64/(1<<20)) + 1
+	if want > math.MaxInt {
+		want = math.MaxInt
+	}
+	assert.Equal(t, want, int64(TotalPages(math.MaxInt64, 1<<20)))
+
+	assert.Equal(t, math.MaxInt, TotalPages(math.MaxInt64, 1))
+}
+
+func TestHasNext(t *testing.T) {
+	assert.True(t, HasNext(1, 10, 31))
+	assert.True(t, HasNext(3, 10, 31))
+	assert.False(t, HasNext(4, 10, 31), "the partial last page has no next page")
+	assert.False(t, HasNext(3, 10, 30))
+	assert.False(t, HasNext(1, 10, 0))
+	assert.False(t, HasNext(1, 0, 31))
+}
@@ -0,0 +1,14 @@
+package response
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// WeakETag builds a weak entity tag (W/"...") from the values that identify a representation
+// Parts are hashed so the tag doesn't leak them and stays a fixed length
+func WeakETag(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`
+}
@@ -0,0 +1,16 @@
+package response
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeakETag(t *testing.T) {
+	etag := WeakETag("id", "1")
+
+	assert.Regexp(t, `^W/"[0-9a-f]{32}"$`, etag)
+	assert.Equal(t, etag, WeakETag("id", "1"))
+	assert.NotEqual(t, etag, WeakETag("id", "2"))
+	assert.NotEqual(t, WeakETag("a", "bc"), WeakETag("ab", "c"))
+}
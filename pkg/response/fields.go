@@ -0,0 +1,108 @@
+package response
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrUnknownField is returned when a requested field doesn't exist on the response
+var ErrUnknownField = errors.New("unknown field")
+
+// ParseFields parses a comma-separated fields parameter (e.g. ?fields=id,name)
+// Entries are trimmed, empty entries and duplicates are ignored
+func ParseFields(raw string) []string {
+	seen := make(map[string]struct{})
+	fields := make([]string, 0)
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, dup := seen[name]; dup {
+			continue
+		}
+		seen[name] = struct{}{}
+		fields = append(fields, name)
+	}
+	return fields
+}
+
+// ValidateFields checks that every field is a JSON field of v, which must be a struct or pointer to one
+func ValidateFields(v interface{}, fields []string) error {
+	_, err := fieldIndexes(reflect.TypeOf(v), fields)
+	return err
+}
+
+// SelectFields returns a map holding only the requested JSON fields of v, keyed by their JSON names
+// v must be a struct or pointer to one, a nil pointer yields nil
+func SelectFields(v interface{}, fields []string) (map[string]interface{}, error) {
+	rv := reflect.ValueOf(v)
+	indexes, err := fieldIndexes(rv.Type(), fields)
+	if err != nil {
+		return nil, err
+	}
+
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, nil
+		}
+		rv = rv.Elem()
+	}
+
+	selected := make(map[string]interface{}, len(indexes))
+	for name, index := range indexes {
+		selected[name] = rv.Field(index).Interface()
+	}
+	return selected, nil
+}
+
+// SelectFieldsEach applies SelectFields to every item of a slice
+func SelectFieldsEach[T any](items []T, fields []string) ([]map[string]interface{}, error) {
+	selected := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		m, err := SelectFields(item, fields)
+		if err != nil {
+			return nil, err
+		}
+		selected = append(selected, m)
+	}
+	return selected, nil
+}
+
+// fieldIndexes maps each requested JSON field name to its struct field index
+func fieldIndexes(t reflect.Type, fields []string) (map[string]int, error) {
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("field selection requires a struct, got %v", t)
+	}
+
+	known := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		known[name] = i
+	}
+
+	indexes := make(map[string]int, len(fields))
+	for _, name := range fields {
+		index, ok := known[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownField, name)
+		}
+		indexes[name] = index
+	}
+	return indexes, nil
+}
@@ -0,0 +1,66 @@
+package response
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testUser struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email,omitempty"`
+	Password  string    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func TestParseFields(t *testing.T) {
+	assert.Equal(t, []string{"id", "name"}, ParseFields(" id, name ,id,,"))
+	assert.Empty(t, ParseFields(""))
+}
+
+func TestSelectFields_Subset(t *testing.T) {
+	user := &testUser{ID: "1", Name: "Alice", Email: "alice@example.com", Password: "secret"}
+
+	selected, err := SelectFields(user, []string{"id", "name"})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"id": "1", "name": "Alice"}, selected)
+}
+
+func TestSelectFields_UnknownField(t *testing.T) {
+	_, err := SelectFields(testUser{}, []string{"id", "nickname"})
+
+	assert.ErrorIs(t, err, ErrUnknownField)
+	assert.Contains(t, err.Error(), "nickname")
+}
+
+func TestSelectFields_IgnoredFieldIsUnknown(t *testing.T) {
+	err := ValidateFields(testUser{}, []string{"Password"})
+
+	assert.ErrorIs(t, err, ErrUnknownField)
+}
+
+func TestSelectFields_NilPointer(t *testing.T) {
+	selected, err := SelectFields((*testUser)(nil), []string{"id"})
+
+	require.NoError(t, err)
+	assert.Nil(t, selected)
+}
+
+func TestSelectFields_NotStruct(t *testing.T) {
+	_, err := SelectFields("id", []string{"id"})
+
+	assert.Error(t, err)
+}
+
+func TestSelectFieldsEach(t *testing.T) {
+	users := []*testUser{{ID: "1", Name: "Alice"}, {ID: "2", Name: "Bob"}}
+
+	selected, err := SelectFieldsEach(users, []string{"name"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{{"name": "Alice"}, {"name": "Bob"}}, selected)
+}
@@ -3,6 +3,7 @@ package response
 import (
 	"time"
 
+	"github.com/gieart87/gohexaclean/pkg/pagination"
 	"github.com/google/uuid"
 )
 
@@ -18,6 +19,9 @@ type PaginationMeta struct {
 	PerPage    int   `json:"per_page"`
 	Total      int64 `json:"total"`
 	TotalPages int   `json:"total_pages"`
+	HasNext    bool  `json:"has_next"`
+	// Approximate is set when Total is estimated rather than counted
+	Approximate bool `json:"approximate,omitempty"`
 }
 
 // MetaWithPagination represents metadata with pagination
@@ -122,13 +126,8 @@ func NewErrorResponseWithCode(message string, errorCode string, err error) *Erro
 	return resp
 }
 
-// NewPaginatedResponse creates a new paginated response
+// NewPaginatedResponse creates a new paginated response, a non-positive perPage reports no pages
 func NewPaginatedResponse(message string, data interface{}, page, perPage int, total int64) *PaginatedResponse {
-	totalPages := int(total) / perPage
-	if int(total)%perPage != 0 {
-		totalPages++
-	}
-
 	return &PaginatedResponse{
 		Success: true,
 		Message: message,
@@ -140,7 +139,8 @@ func NewPaginatedResponse(message string, data interface{}, page, perPage int, t
 				Page:       page,
 				PerPage:    perPage,
 				Total:      total,
-				TotalPages: totalPages,
+				TotalPages: pagination.TotalPages(total, perPage),
+				HasNext:    pagination.HasNext(page, perPage, total),
 			},
 		},
 	}
@@ -0,0 +1,52 @@
+package response
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// EnvelopeQueryParam lets a request override whether its success response is enveloped, e.g. ?envelope=false
+const EnvelopeQueryParam = "envelope"
+
+// HeaderTotalCount carries the total of a paginated listing sent without the envelope
+const HeaderTotalCount = "X-Total-Count"
+
+// WantsEnvelope reports whether the success response to c is wrapped in the standard envelope
+// The envelope query parameter takes precedence over defaultEnvelope
+func WantsEnvelope(c *fiber.Ctx, defaultEnvelope bool) bool {
+	if raw := c.Query(EnvelopeQueryParam); raw != "" {
+		if envelope, err := strconv.ParseBool(raw); err == nil {
+			return envelope
+		}
+	}
+	return defaultEnvelope
+}
+
+// Write sends a success response with status, wrapped in the standard envelope unless envelope is false
+// Bare responses carry only data, or no content when there is none
+func Write(c *fiber.Ctx, envelope bool, status int, message string, data interface{}) error {
+	if envelope {
+		return c.Status(status).JSON(NewSuccessResponse(message, data))
+	}
+	if data == nil {
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+	return c.Status(status).JSON(data)
+}
+
+// WriteCreated sends a 201 response for a newly created resource, with Location pointing at it
+// Use it for every create endpoint so clients can follow the header instead of building the URL
+func WriteCreated(c *fiber.Ctx, envelope bool, location, message string, data interface{}) error {
+	c.Location(location)
+	return Write(c, envelope, fiber.StatusCreated, message, data)
+}
+
+// WritePaginated sends a page of results, reporting the total in the X-Total-Count header when not enveloped
+func WritePaginated(c *fiber.Ctx, envelope bool, resp *PaginatedResponse) error {
+	if envelope {
+		return c.JSON(resp)
+	}
+	c.Set(HeaderTotalCount, strconv.FormatInt(resp.Meta.Pagination.Total, 10))
+	return c.JSON(resp.Data)
+}
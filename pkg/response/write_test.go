@@ -0,0 +1,111 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWantsEnvelope(t *testing.T) {
+	tests := []struct {
+		query           string
+		defaultEnvelope bool
+		want            bool
+	}{
+		{query: "", defaultEnvelope: true, want: true},
+		{query: "", defaultEnvelope: false, want: false},
+		{query: "?envelope=false", defaultEnvelope: true, want: false},
+		{query: "?envelope=1", defaultEnvelope: false, want: true},
+		{query: "?envelope=maybe", defaultEnvelope: true, want: true},
+	}
+
+	for _, tt := range tests {
+		app := fiber.New()
+		app.Get("/", func(c *fiber.Ctx) error {
+			assert.Equal(t, tt.want, WantsEnvelope(c, tt.defaultEnvelope), tt.query)
+			return nil
+		})
+
+		_, err := app.Test(httptest.NewRequest("GET", "/"+tt.query, nil))
+		require.NoError(t, err)
+	}
+}
+
+func TestWrite_BareWithoutDataIsNoContent(t *testing.T) {
+	app := fiber.New()
+	app.Delete("/", func(c *fiber.Ctx) error {
+		return Write(c, false, fiber.StatusOK, "User deleted successfully", nil)
+	})
+
+	resp, err := app.Test(httptest.NewRequest("DELETE", "/", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+}
+
+func TestWrite_EnvelopedKeepsStatus(t *testing.T) {
+	app := fiber.New()
+	app.Post("/", func(c *fiber.Ctx) error {
+		return Write(c, true, fiber.StatusCreated, "User registered successfully", map[string]string{"id": "1"})
+	})
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+	var body SuccessResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.True(t, body.Success)
+	assert.Equal(t, "User registered successfully", body.Message)
+}
+
+func TestWriteCreated_SetsLocation(t *testing.T) {
+	for _, envelope := range []bool{true, false} {
+		app := fiber.New()
+		app.Post("/", func(c *fiber.Ctx) error {
+			return WriteCreated(c, envelope, "/api/v1/admin/users/1", "User registered successfully", map[string]string{"id": "1"})
+		})
+
+		resp, err := app.Test(httptest.NewRequest("POST", "/", nil))
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+		assert.Equal(t, "/api/v1/admin/users/1", resp.Header.Get("Location"))
+	}
+}
+
+func TestNewPaginatedResponse_ZeroPerPageReportsNoPages(t *testing.T) {
+	var resp *PaginatedResponse
+	assert.NotPanics(t, func() {
+		resp = NewPaginatedResponse("Users retrieved successfully", []string{}, 1, 0, 42)
+	})
+	assert.Equal(t, 0, resp.Meta.Pagination.TotalPages)
+	assert.Equal(t, int64(42), resp.Meta.Pagination.Total)
+}
+
+func TestNewPaginatedResponse_TotalPages(t *testing.T) {
+	tests := []struct {
+		name    string
+		page    int
+		perPage int
+		total   int64
+		want    int64
+		hasNext bool
+	}{
+		{name: "partial last page", page: 1, perPage: 10, total: 31, want: 4, hasNext: true},
+		{name: "on last page", page: 4, perPage: 10, total: 31, want: 4, hasNext: false},
+		{name: "no results", page: 1, perPage: 10, total: 0, want: 0, hasNext: false},
+		{name: "huge total", page: 1, perPage: 1000, total: 1 << 40, want: (1<<40 + 999) / 1000, hasNext: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := NewPaginatedResponse("Users retrieved successfully", []string{}, tt.page, tt.perPage, tt.total)
+			assert.Equal(t, tt.want, int64(resp.Meta.Pagination.TotalPages))
+			assert.Equal(t, tt.total, resp.Meta.Pagination.Total)
+			assert.Equal(t, tt.hasNext, resp.Meta.Pagination.HasNext)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package validation
+
+import (
+	_ "embed"
+	"strings"
+	"sync"
+	"unicode"
+
+	ozzo "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// defaultMinPasswordLength is used when a policy leaves MinLength unset
+const defaultMinPasswordLength = 6
+
+var (
+	// ErrPasswordTooShort is returned when the password is shorter than the policy minimum
+	ErrPasswordTooShort = ozzo.NewError("validation_password_too_short", "password must be at least {{.min}} characters")
+	// ErrPasswordNoUpper is returned when an uppercase letter is required but missing
+	ErrPasswordNoUpper = ozzo.NewError("validation_password_no_upper", "password must contain an uppercase letter")
+	// ErrPasswordNoLower is returned when a lowercase letter is required but missing
+	ErrPasswordNoLower = ozzo.NewError("validation_password_no_lower", "password must contain a lowercase letter")
+	// ErrPasswordNoDigit is returned when a digit is required but missing
+	ErrPasswordNoDigit = ozzo.NewError("validation_password_no_digit", "password must contain a digit")
+	// ErrPasswordNoSymbol is returned when a symbol is required but missing
+	ErrPasswordNoSymbol = ozzo.NewError("validation_password_no_symbol", "password must contain a symbol")
+	// ErrPasswordCommon is returned when the password is on the common-passwords denylist
+	ErrPasswordCommon = ozzo.NewError("validation_password_common", "password is too common")
+)
+
+//go:embed common_passwords.txt
+var commonPasswordsFile string
+
+// commonPasswords lazily parses the embedded denylist into a lowercase lookup set
+var commonPasswords = sync.OnceValue(func() map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, line := range strings.Split(commonPasswordsFile, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		set[strings.ToLower(line)] = struct{}{}
+	}
+	return set
+})
+
+// PasswordPolicy is an ozzo-validation rule enforcing password strength
+// An empty value is considered valid, combine it with validation.Required
+type PasswordPolicy struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	RejectCommon  bool
+}
+
+// DefaultPasswordPolicy only enforces the minimum length
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{MinLength: defaultMinPasswordLength}
+}
+
+// Validate checks value against the policy and returns the first rule it breaks
+func (p PasswordPolicy) Validate(value interface{}) error {
+	value, isNil := ozzo.Indirect(value)
+	if isNil || ozzo.IsEmpty(value) {
+		return nil
+	}
+
+	password, err := ozzo.EnsureString(value)
+	if err != nil {
+		return err
+	}
+
+	return p.ValidatePassword(password)
+}
+
+// ValidatePassword checks password against the policy and returns the first rule it breaks
+func (p PasswordPolicy) ValidatePassword(password string) error {
+	minLength := p.MinLength
+	if minLength <= 0 {
+		minLength = defaultMinPasswordLength
+	}
+	if len([]rune(password)) < minLength {
+		return ErrPasswordTooShort.SetParams(map[string]interface{}{"min": minLength})
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+
+	switch {
+	case p.RequireUpper && !hasUpper:
+		return ErrPasswordNoUpper
+	case p.RequireLower && !hasLower:
+		return ErrPasswordNoLower
+	case p.RequireDigit && !hasDigit:
+		return ErrPasswordNoDigit
+	case p.RequireSymbol && !hasSymbol:
+		return ErrPasswordNoSymbol
+	}
+
+	if p.RejectCommon {
+		if _, common := commonPasswords()[strings.ToLower(password)]; common {
+			return ErrPasswordCommon
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package validation
+
+import (
+	"testing"
+
+	ozzo "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// errorCode returns the ozzo error code of err, failing the test if err is not an ozzo error
+func errorCode(t *testing.T, err error) string {
+	t.Helper()
+
+	var ozzoErr ozzo.Error
+	require.ErrorAs(t, err, &ozzoErr)
+	return ozzoErr.Code()
+}
+
+func TestPasswordPolicy_MinLength(t *testing.T) {
+	policy := PasswordPolicy{MinLength: 10}
+
+	err := policy.ValidatePassword("short-pw")
+	assert.Equal(t, ErrPasswordTooShort.Code(), errorCode(t, err))
+	assert.Equal(t, "password must be at least 10 characters", err.Error())
+
+	assert.NoError(t, policy.ValidatePassword("long-enough-pw"))
+}
+
+func TestPasswordPolicy_MinLengthDefault(t *testing.T) {
+	err := PasswordPolicy{}.ValidatePassword("abc12")
+	assert.Equal(t, "password must be at least 6 characters", err.Error())
+
+	assert.NoError(t, DefaultPasswordPolicy().ValidatePassword("abc123x"))
+}
+
+func TestPasswordPolicy_CharacterClasses(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   PasswordPolicy
+		password string
+		wantErr  ozzo.Error
+	}{
+		{"missing upper", PasswordPolicy{RequireUpper: true}, "lowercase1!", ErrPasswordNoUpper},
+		{"missing lower", PasswordPolicy{RequireLower: true}, "UPPERCASE1!", ErrPasswordNoLower},
+		{"missing digit", PasswordPolicy{RequireDigit: true}, "NoDigits!!", ErrPasswordNoDigit},
+		{"missing symbol", PasswordPolicy{RequireSymbol: true}, "NoSymbols1", ErrPasswordNoSymbol},
+		{"all classes present", PasswordPolicy{RequireUpper: true, RequireLower: true, RequireDigit: true, RequireSymbol: true}, "Str0ng#Pass", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.ValidatePassword(tt.password)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, tt.wantErr.Code(), errorCode(t, err))
+		})
+	}
+}
+
+func TestPasswordPolicy_RejectCommon(t *testing.T) {
+	policy := PasswordPolicy{RejectCommon: true}
+
+	err := policy.ValidatePassword("Password123")
+	assert.Equal(t, ErrPasswordCommon.Code(), errorCode(t, err))
+
+	assert.NoError(t, policy.ValidatePassword("correct-horse-battery"))
+	assert.NoError(t, PasswordPolicy{}.ValidatePassword("password123"), "denylist only applies when enabled")
+}
+
+func TestPasswordPolicy_AsOzzoRule(t *testing.T) {
+	policy := PasswordPolicy{MinLength: 8, RequireDigit: true}
+
+	assert.NoError(t, ozzo.Validate("", policy), "empty values are left to Required")
+	assert.NoError(t, ozzo.Validate("abcdefg1", policy))
+
+	err := ozzo.Validate("abcdefgh", policy)
+	assert.Equal(t, ErrPasswordNoDigit.Code(), errorCode(t, err))
+}
@@ -0,0 +1,55 @@
+package workerpool
+
+import (
+	"context"
+	"sync"
+)
+
+// Result holds the outcome of processing the item at Index
+type Result[R any] struct {
+	Index int
+	Value R
+	Err   error
+}
+
+// Run processes items with at most limit concurrent calls to fn and returns one result per item, in input order
+// Once ctx is cancelled no further items are started and those items report ctx.Err()
+func Run[T, R any](ctx context.Context, limit int, items []T, fn func(ctx context.Context, item T) (R, error)) []Result[R] {
+	if limit <= 0 {
+		limit = 1
+	}
+
+	results := make([]Result[R], len(items))
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+
+	for i, item := range items {
+		results[i].Index = i
+
+		// Wait for a free slot, giving up if the context is cancelled meanwhile
+		select {
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		case sem <- struct{}{}:
+		}
+
+		// select picks randomly when both cases are ready, so re-check before starting
+		if err := ctx.Err(); err != nil {
+			<-sem
+			results[i].Err = err
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, item T) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			results[i].Value, results[i].Err = fn(ctx, item)
+		}(i, item)
+	}
+
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,93 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun_BoundsConcurrency(t *testing.T) {
+	const limit = 3
+
+	var inFlight, maxInFlight int32
+	items := make([]int, 20)
+	for i := range items {
+		items[i] = i
+	}
+
+	results := Run(context.Background(), limit, items, func(ctx context.Context, item int) (int, error) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		return item * 2, nil
+	})
+
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(limit))
+	assert.Greater(t, atomic.LoadInt32(&maxInFlight), int32(1), "items should run concurrently")
+
+	require.Len(t, results, len(items))
+	for i, result := range results {
+		assert.Equal(t, i, result.Index)
+		assert.Equal(t, i*2, result.Value)
+		assert.NoError(t, result.Err)
+	}
+}
+
+func TestRun_CollectsPerItemErrors(t *testing.T) {
+	failure := errors.New("item failed")
+
+	results := Run(context.Background(), 2, []int{1, 2, 3, 4}, func(ctx context.Context, item int) (int, error) {
+		if item%2 == 0 {
+			return 0, failure
+		}
+		return item, nil
+	})
+
+	require.Len(t, results, 4)
+	assert.NoError(t, results[0].Err)
+	assert.ErrorIs(t, results[1].Err, failure)
+	assert.NoError(t, results[2].Err)
+	assert.ErrorIs(t, results[3].Err, failure)
+}
+
+func TestRun_StopsOnCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var started int32
+	results := Run(ctx, 1, []int{0, 1, 2, 3, 4}, func(ctx context.Context, item int) (int, error) {
+		atomic.AddInt32(&started, 1)
+		if item == 1 {
+			cancel()
+		}
+		return item, nil
+	})
+
+	require.Len(t, results, 5)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&started))
+	assert.NoError(t, results[0].Err)
+	assert.NoError(t, results[1].Err)
+	for _, result := range results[2:] {
+		assert.ErrorIs(t, result.Err, context.Canceled)
+	}
+}
+
+func TestRun_Empty(t *testing.T) {
+	results := Run(context.Background(), 4, nil, func(ctx context.Context, item int) (int, error) {
+		t.Fatal("fn should not be called")
+		return 0, nil
+	})
+
+	assert.Empty(t, results)
+}